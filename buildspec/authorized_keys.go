@@ -3,10 +3,10 @@ package buildspec
 import "encoding/xml"
 
 type AuthorizedKeys struct {
-	AllUsers   bool     `json:"all_users,omitempty" yaml:"all_users,omitempty"`
-	AllSSHKeys bool     `json:"all_ssh_keys,omitempty" yaml:"all_ssh_keys,omitempty"`
-	Users      []*User  `json:"users,omitempty" yaml:"users,omitempty"`
-	SSHKeys    []string `json:"ssh_keys,omitempty" yaml:"ssh_keys,omitempty"`
+	AllUsers   bool     `json:"all_users,omitempty" yaml:"all_users,omitempty" hcl:"all_users,optional" toml:"all_users,omitempty"`
+	AllSSHKeys bool     `json:"all_ssh_keys,omitempty" yaml:"all_ssh_keys,omitempty" hcl:"all_ssh_keys,optional" toml:"all_ssh_keys,omitempty"`
+	Users      []*User  `json:"users,omitempty" yaml:"users,omitempty" hcl:"user,block" toml:"users,omitempty"`
+	SSHKeys    []string `json:"ssh_keys,omitempty" yaml:"ssh_keys,omitempty" hcl:"ssh_keys,optional" toml:"ssh_keys,omitempty"`
 }
 
 func (s *AuthorizedKeys) MarshalXML(
@@ -35,7 +35,10 @@ func (s *AuthorizedKeys) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlAuthorizedKeys{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := AuthorizedKeys{}
 
@@ -1,8 +1,8 @@
 package buildspec
 
 type BackupPolicy struct {
-	Retention int       `xml:",omitempty" json:"retention,omitempty" yaml:"retention,omitempty"`
-	Schedule  *Schedule `xml:",omitempty" json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	Retention int       `xml:",omitempty" json:"retention,omitempty" yaml:"retention,omitempty" hcl:"retention,optional" toml:"retention,omitempty"`
+	Schedule  *Schedule `xml:",omitempty" json:"schedule,omitempty" yaml:"schedule,omitempty" hcl:"schedule,block" toml:"schedule,omitempty"`
 }
 
 type xmlBackupPolicies struct {
@@ -0,0 +1,453 @@
+package buildspec
+
+// VMBuilder builds a VirtualMachineSpec with chained method calls, instead
+// of nesting struct literals. Create one with NewVM.
+//
+// Resources are referenced by permalink, or by name or email address where
+// they have no permalink. Use a VirtualMachineSpec directly to reference them
+// by ID.
+//
+// Each call validates the part of the spec it sets, and problems are
+// collected and returned by Build, so calls can be chained without checking
+// for errors in between.
+type VMBuilder struct {
+	spec *VirtualMachineSpec
+	v    *validator
+}
+
+// NewVM returns a new VMBuilder for an empty spec.
+func NewVM() *VMBuilder {
+	return &VMBuilder{spec: &VirtualMachineSpec{}, v: &validator{}}
+}
+
+// InZone sets the zone to create the virtual machine in. It cannot be
+// combined with InDataCenter.
+func (b *VMBuilder) InZone(permalink string) *VMBuilder {
+	if b.spec.DataCenter != nil {
+		b.v.add("zone", "zone and data_center are mutually exclusive")
+	}
+	b.required("zone.permalink", permalink)
+	b.spec.Zone = &Zone{Permalink: permalink}
+
+	return b
+}
+
+// InDataCenter sets the data center to create the virtual machine in. It
+// cannot be combined with InZone.
+func (b *VMBuilder) InDataCenter(permalink string) *VMBuilder {
+	if b.spec.Zone != nil {
+		b.v.add("data_center", "zone and data_center are mutually exclusive")
+	}
+	b.required("data_center.permalink", permalink)
+	b.spec.DataCenter = &DataCenter{Permalink: permalink}
+
+	return b
+}
+
+// WithPackage sets the package which determines the virtual machine's
+// resources. It cannot be combined with WithResources.
+func (b *VMBuilder) WithPackage(permalink string) *VMBuilder {
+	if r := b.spec.Resources; r != nil && r.Package == nil {
+		b.v.add("resources.package",
+			"package, and memory and cpu_cores are mutually exclusive",
+		)
+	}
+	b.required("resources.package.permalink", permalink)
+	b.spec.Resources = &Resources{Package: &Package{Permalink: permalink}}
+
+	return b
+}
+
+// WithResources sets the memory in GB and number of CPU cores of the virtual
+// machine. It cannot be combined with WithPackage.
+func (b *VMBuilder) WithResources(memory int, cpuCores int) *VMBuilder {
+	if r := b.spec.Resources; r != nil && r.Package != nil {
+		b.v.add("resources",
+			"package, and memory and cpu_cores are mutually exclusive",
+		)
+	}
+	if memory <= 0 {
+		b.v.add("resources.memory", "must be greater than 0")
+	}
+	if cpuCores <= 0 {
+		b.v.add("resources.cpu_cores", "must be greater than 0")
+	}
+	b.spec.Resources = &Resources{Memory: memory, CPUCores: cpuCores}
+
+	return b
+}
+
+// WithDiskTemplate sets the disk template to install on the virtual
+// machine's first system disk.
+func (b *VMBuilder) WithDiskTemplate(permalink string) *VMBuilder {
+	b.required("disk_template.permalink", permalink)
+	b.diskTemplate().Permalink = permalink
+
+	return b
+}
+
+// WithDiskTemplateVersion sets the version of the disk template to install,
+// instead of the latest version.
+func (b *VMBuilder) WithDiskTemplateVersion(version int) *VMBuilder {
+	if version <= 0 {
+		b.v.add("disk_template.version", "must be greater than 0")
+	}
+	b.diskTemplate().Version = version
+
+	return b
+}
+
+// WithDiskTemplateOption sets an option of the disk template, such as a
+// password or timezone.
+func (b *VMBuilder) WithDiskTemplateOption(key, value string) *VMBuilder {
+	dt := b.diskTemplate()
+	field := index("disk_template.options", len(dt.Options))
+	switch {
+	case key == "":
+		b.v.add(field+".key", "is required")
+	case dt.option(key) != nil:
+		b.v.add(field+".key", "duplicate option %q", key)
+	}
+	dt.Options = append(dt.Options, &DiskTemplateOption{Key: key, Value: value})
+
+	return b
+}
+
+// AddSystemDisk adds a system disk built with NewSystemDisk. The first
+// system disk is the boot disk.
+//
+// The disk is copied when it is added, so later calls on d do not change it,
+// and d can be added again to add a similar disk.
+func (b *VMBuilder) AddSystemDisk(d *SystemDiskBuilder) *VMBuilder {
+	field := index("system_disks", len(b.spec.SystemDisks))
+	if d.disk.Name != "" {
+		for _, sd := range b.spec.SystemDisks {
+			if sd.Name == d.disk.Name {
+				b.v.add(field+".name", "duplicate disk name %q", d.disk.Name)
+			}
+		}
+	}
+	disk := d.build()
+	validateSystemDisk(b.v, field, disk)
+	b.spec.SystemDisks = append(b.spec.SystemDisks, disk)
+
+	return b
+}
+
+// AddSharedDisk attaches the existing shared disk with the given name.
+func (b *VMBuilder) AddSharedDisk(name string) *VMBuilder {
+	b.required(
+		index("shared_disks", len(b.spec.SharedDisks))+".name", name,
+	)
+	b.spec.SharedDisks = append(b.spec.SharedDisks, &SharedDisk{Name: name})
+
+	return b
+}
+
+// AddNIC adds a network interface built with NewNIC.
+//
+// The interface is copied when it is added, so later calls on n do not
+// change it, and n can be added again to add a similar interface.
+func (b *VMBuilder) AddNIC(n *NICBuilder) *VMBuilder {
+	field := index("network_interfaces", len(b.spec.NetworkInterfaces))
+	nic := n.build()
+	validateNetworkInterface(b.v, field, nic)
+	b.spec.NetworkInterfaces = append(b.spec.NetworkInterfaces, nic)
+
+	return b
+}
+
+// WithHostname sets the hostname of the virtual machine. A random hostname
+// is used if none is set.
+func (b *VMBuilder) WithHostname(hostname string) *VMBuilder {
+	if !validHostname(hostname) {
+		b.v.add("hostname", "%q is not a valid hostname", hostname)
+	}
+	b.spec.Hostname = hostname
+
+	return b
+}
+
+// WithName sets the name of the virtual machine.
+func (b *VMBuilder) WithName(name string) *VMBuilder {
+	b.spec.Name = name
+
+	return b
+}
+
+// WithDescription sets the description of the virtual machine.
+func (b *VMBuilder) WithDescription(description string) *VMBuilder {
+	b.spec.Description = description
+
+	return b
+}
+
+// InGroup adds the virtual machine to the group with the given name.
+func (b *VMBuilder) InGroup(name string) *VMBuilder {
+	b.required("group.name", name)
+	b.spec.Group = &Group{Name: name}
+
+	return b
+}
+
+// AuthorizeAllUsers authorizes the SSH keys of all users in the
+// organization.
+func (b *VMBuilder) AuthorizeAllUsers() *VMBuilder {
+	b.authorizedKeys().AllUsers = true
+
+	return b
+}
+
+// AuthorizeAllSSHKeys authorizes all SSH keys of the organization.
+func (b *VMBuilder) AuthorizeAllSSHKeys() *VMBuilder {
+	b.authorizedKeys().AllSSHKeys = true
+
+	return b
+}
+
+// AuthorizeUser authorizes the SSH keys of the user with the given email
+// address.
+func (b *VMBuilder) AuthorizeUser(emailAddress string) *VMBuilder {
+	ak := b.authorizedKeys()
+	b.required(
+		index("authorized_keys.users", len(ak.Users))+".email_address",
+		emailAddress,
+	)
+	ak.Users = append(ak.Users, &User{EmailAddress: emailAddress})
+
+	return b
+}
+
+// AuthorizeSSHKey authorizes the SSH key with the given ID.
+func (b *VMBuilder) AuthorizeSSHKey(id string) *VMBuilder {
+	ak := b.authorizedKeys()
+	b.required(index("authorized_keys.ssh_keys", len(ak.SSHKeys)), id)
+	ak.SSHKeys = append(ak.SSHKeys, id)
+
+	return b
+}
+
+// AddBackupPolicy adds a policy for backing up all of the virtual machine's
+// disks, keeping the given number of backups. A zero Schedule adds a policy
+// with only a retention.
+func (b *VMBuilder) AddBackupPolicy(
+	retention int,
+	schedule Schedule,
+) *VMBuilder {
+	bp := newBackupPolicy(retention, schedule)
+	validateBackupPolicy(
+		b.v, index("backup_policies", len(b.spec.BackupPolicies)), bp,
+	)
+	b.spec.BackupPolicies = append(b.spec.BackupPolicies, bp)
+
+	return b
+}
+
+// WithTags adds tags to the virtual machine, skipping those already added.
+func (b *VMBuilder) WithTags(tags ...string) *VMBuilder {
+	for _, t := range tags {
+		if t == "" {
+			b.v.add(index("tags", len(b.spec.Tags)), "is required")
+
+			continue
+		}
+		if !containsString(b.spec.Tags, t) {
+			b.spec.Tags = append(b.spec.Tags, t)
+		}
+	}
+
+	return b
+}
+
+// WithISO sets the ID of an ISO to attach to the virtual machine.
+func (b *VMBuilder) WithISO(id string) *VMBuilder {
+	b.spec.ISO = id
+
+	return b
+}
+
+// Build returns the spec, or a *ValidationError listing every problem found
+// while building it. Once every call has succeeded, the spec is checked as a
+// whole with Validate, to catch missing parts such as a zone or resources.
+//
+// The returned spec is a copy, so the builder can be used again to build
+// similar specs.
+func (b *VMBuilder) Build() (*VirtualMachineSpec, error) {
+	if err := b.v.err(); err != nil {
+		return nil, err
+	}
+	if err := b.spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b.spec.clone()
+}
+
+func (b *VMBuilder) required(field, value string) {
+	if value == "" {
+		b.v.add(field, "is required")
+	}
+}
+
+func (b *VMBuilder) diskTemplate() *DiskTemplate {
+	if b.spec.DiskTemplate == nil {
+		b.spec.DiskTemplate = &DiskTemplate{}
+	}
+
+	return b.spec.DiskTemplate
+}
+
+func (b *VMBuilder) authorizedKeys() *AuthorizedKeys {
+	if b.spec.AuthorizedKeys == nil {
+		b.spec.AuthorizedKeys = &AuthorizedKeys{}
+	}
+
+	return b.spec.AuthorizedKeys
+}
+
+func (s *DiskTemplate) option(key string) *DiskTemplateOption {
+	for _, o := range s.Options {
+		if o.Key == key {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// SystemDiskBuilder builds a system disk for VMBuilder.AddSystemDisk. Create
+// one with NewSystemDisk.
+type SystemDiskBuilder struct {
+	disk *SystemDisk
+}
+
+// NewSystemDisk returns a new SystemDiskBuilder for a disk with the given
+// name and size in GB. The name may be empty.
+func NewSystemDisk(name string, size int) *SystemDiskBuilder {
+	return &SystemDiskBuilder{disk: &SystemDisk{Name: name, Size: size}}
+}
+
+// WithSpeed sets the storage speed of the disk, such as "ssd" or "nvme".
+func (b *SystemDiskBuilder) WithSpeed(speed string) *SystemDiskBuilder {
+	b.disk.Speed = speed
+
+	return b
+}
+
+// WithIOProfile sets the IO profile of the disk.
+func (b *SystemDiskBuilder) WithIOProfile(
+	permalink string,
+) *SystemDiskBuilder {
+	b.disk.IOProfile = &DiskIOProfile{Permalink: permalink}
+
+	return b
+}
+
+// WithFileSystem sets the file system type to format the disk with.
+func (b *SystemDiskBuilder) WithFileSystem(
+	fileSystemType string,
+) *SystemDiskBuilder {
+	b.disk.FileSystemType = fileSystemType
+
+	return b
+}
+
+// AddBackupPolicy adds a policy for backing up the disk, keeping the given
+// number of backups. A zero Schedule adds a policy with only a retention.
+func (b *SystemDiskBuilder) AddBackupPolicy(
+	retention int,
+	schedule Schedule,
+) *SystemDiskBuilder {
+	b.disk.BackupPolicies = append(b.disk.BackupPolicies,
+		newBackupPolicy(retention, schedule),
+	)
+
+	return b
+}
+
+// build returns a copy of the disk. Builder methods only ever replace or
+// append to the disk's fields, so copying its slices is enough to keep
+// later calls from changing the copy.
+func (b *SystemDiskBuilder) build() *SystemDisk {
+	d := *b.disk
+	d.BackupPolicies = append([]*BackupPolicy(nil), d.BackupPolicies...)
+
+	return &d
+}
+
+// NICBuilder builds a network interface for VMBuilder.AddNIC. Create one
+// with NewNIC.
+type NICBuilder struct {
+	nic *NetworkInterface
+}
+
+// NewNIC returns a new NICBuilder for an empty network interface.
+func NewNIC() *NICBuilder {
+	return &NICBuilder{nic: &NetworkInterface{}}
+}
+
+// OnNetwork attaches the interface to a network. It cannot be combined with
+// OnVirtualNetwork.
+func (b *NICBuilder) OnNetwork(permalink string) *NICBuilder {
+	b.nic.Network = &Network{Permalink: permalink}
+
+	return b
+}
+
+// OnVirtualNetwork attaches the interface to the virtual network with the
+// given ID. It cannot be combined with OnNetwork.
+func (b *NICBuilder) OnVirtualNetwork(id string) *NICBuilder {
+	b.nic.VirtualNetwork = &VirtualNetwork{ID: id}
+
+	return b
+}
+
+// WithSpeedProfile sets the speed profile of the interface.
+func (b *NICBuilder) WithSpeedProfile(permalink string) *NICBuilder {
+	b.nic.SpeedProfile = &NetworkSpeedProfile{Permalink: permalink}
+
+	return b
+}
+
+// AllocateIP allocates a new IP address of the given version to the
+// interface.
+func (b *NICBuilder) AllocateIP(version IPVersion) *NICBuilder {
+	b.nic.IPAddressAllocations = append(b.nic.IPAddressAllocations,
+		&IPAddressAllocation{Type: NewIPAddressAllocation, Version: version},
+	)
+
+	return b
+}
+
+// UseIP allocates an existing IP address to the interface.
+func (b *NICBuilder) UseIP(address string) *NICBuilder {
+	b.nic.IPAddressAllocations = append(b.nic.IPAddressAllocations,
+		&IPAddressAllocation{
+			Type:      ExistingIPAddressAllocation,
+			IPAddress: &IPAddress{Address: address},
+		},
+	)
+
+	return b
+}
+
+// build returns a copy of the interface, like SystemDiskBuilder.build.
+func (b *NICBuilder) build() *NetworkInterface {
+	n := *b.nic
+	n.IPAddressAllocations = append(
+		[]*IPAddressAllocation(nil), n.IPAddressAllocations...,
+	)
+
+	return &n
+}
+
+// newBackupPolicy returns a backup policy, without a schedule if schedule is
+// the zero value.
+func newBackupPolicy(retention int, schedule Schedule) *BackupPolicy {
+	bp := &BackupPolicy{Retention: retention}
+	if schedule != (Schedule{}) {
+		bp.Schedule = &schedule
+	}
+
+	return bp
+}
@@ -0,0 +1,79 @@
+package buildspec_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+func ExampleNewVM() {
+	spec, err := buildspec.NewVM().
+		InDataCenter("uk-lon-01").
+		WithPackage("rock-3").
+		WithDiskTemplate("templates/ubuntu-22-04").
+		AddSystemDisk(
+			buildspec.NewSystemDisk("System Disk", 20).WithSpeed("nvme"),
+		).
+		AddNIC(
+			buildspec.NewNIC().OnNetwork("public").AllocateIP(buildspec.IPv4),
+		).
+		WithHostname("web-3").
+		AddBackupPolicy(7, buildspec.Schedule{
+			Interval:  buildspec.ScheduledDaily,
+			Frequency: 1,
+			Time:      3,
+		}).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	_ = spec.WriteYAML(os.Stdout)
+	// Output:
+	// data_center:
+	//   permalink: uk-lon-01
+	// resources:
+	//   package:
+	//     permalink: rock-3
+	// disk_template:
+	//   permalink: templates/ubuntu-22-04
+	// system_disks:
+	//   - name: System Disk
+	//     size: 20
+	//     speed: nvme
+	// network_interfaces:
+	//   - network:
+	//       permalink: public
+	//     ip_address_allocations:
+	//       - type: new
+	//         version: 4
+	// hostname: web-3
+	// backup_policies:
+	//   - retention: 7
+	//     schedule:
+	//       interval: daily
+	//       frequency: 1
+	//       time: 3
+}
+
+func ExampleVMBuilder_Build() {
+	_, err := buildspec.NewVM().
+		InDataCenter("uk-lon-01").
+		WithResources(0, 2).
+		AddSystemDisk(buildspec.NewSystemDisk("System Disk", 0)).
+		WithHostname("web_3").
+		Build()
+
+	var verr *buildspec.ValidationError
+	if errors.As(err, &verr) {
+		for _, fe := range verr.Errors {
+			fmt.Println(fe)
+		}
+	}
+	// Output:
+	// resources.memory: must be greater than 0
+	// system_disks[0].size: must be greater than 0
+	// hostname: "web_3" is not a valid hostname
+}
@@ -0,0 +1,316 @@
+package buildspec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMBuilder_Build(t *testing.T) {
+	daily := Schedule{Interval: ScheduledDaily, Frequency: 1, Time: 3}
+
+	tests := []struct {
+		name    string
+		builder func() *VMBuilder
+		want    *VirtualMachineSpec
+		wantErr []string
+	}{
+		{
+			name: "minimal",
+			builder: func() *VMBuilder {
+				return NewVM().InZone("uk-lon-01a").WithPackage("rock-3")
+			},
+			want: &VirtualMachineSpec{
+				Zone: &Zone{Permalink: "uk-lon-01a"},
+				Resources: &Resources{
+					Package: &Package{Permalink: "rock-3"},
+				},
+			},
+		},
+		{
+			name: "full",
+			builder: func() *VMBuilder {
+				return NewVM().
+					InDataCenter("uk-lon-01").
+					WithResources(8, 4).
+					WithDiskTemplate("templates/ubuntu-22-04").
+					WithDiskTemplateVersion(3).
+					WithDiskTemplateOption("timezone", "Europe/London").
+					AddSystemDisk(
+						NewSystemDisk("Boot", 20).
+							WithSpeed("nvme").
+							WithIOProfile("fast").
+							WithFileSystem("ext4").
+							AddBackupPolicy(4, daily),
+					).
+					AddSystemDisk(NewSystemDisk("", 100)).
+					AddSharedDisk("uploads").
+					AddNIC(
+						NewNIC().
+							OnNetwork("public").
+							WithSpeedProfile("1gbps").
+							AllocateIP(IPv4).
+							UseIP("2001:db8::10"),
+					).
+					AddNIC(NewNIC().OnVirtualNetwork("vnet_1")).
+					WithHostname("web-1").
+					WithName("Web 1").
+					WithDescription("Primary web server").
+					InGroup("web").
+					AuthorizeAllUsers().
+					AuthorizeUser("ops@example.com").
+					AuthorizeSSHKey("key_1").
+					AddBackupPolicy(7, daily).
+					WithTags("web", "production", "web").
+					WithISO("iso_1")
+			},
+			want: &VirtualMachineSpec{
+				DataCenter: &DataCenter{Permalink: "uk-lon-01"},
+				Resources:  &Resources{Memory: 8, CPUCores: 4},
+				DiskTemplate: &DiskTemplate{
+					Permalink: "templates/ubuntu-22-04",
+					Version:   3,
+					Options: []*DiskTemplateOption{
+						{Key: "timezone", Value: "Europe/London"},
+					},
+				},
+				SystemDisks: []*SystemDisk{
+					{
+						Name:           "Boot",
+						Size:           20,
+						Speed:          "nvme",
+						IOProfile:      &DiskIOProfile{Permalink: "fast"},
+						FileSystemType: "ext4",
+						BackupPolicies: []*BackupPolicy{
+							{Retention: 4, Schedule: &daily},
+						},
+					},
+					{Size: 100},
+				},
+				SharedDisks: []*SharedDisk{{Name: "uploads"}},
+				NetworkInterfaces: []*NetworkInterface{
+					{
+						Network: &Network{Permalink: "public"},
+						SpeedProfile: &NetworkSpeedProfile{
+							Permalink: "1gbps",
+						},
+						IPAddressAllocations: []*IPAddressAllocation{
+							{Type: NewIPAddressAllocation, Version: IPv4},
+							{
+								Type: ExistingIPAddressAllocation,
+								IPAddress: &IPAddress{
+									Address: "2001:db8::10",
+								},
+							},
+						},
+					},
+					{VirtualNetwork: &VirtualNetwork{ID: "vnet_1"}},
+				},
+				Hostname:    "web-1",
+				Name:        "Web 1",
+				Description: "Primary web server",
+				Group:       &Group{Name: "web"},
+				AuthorizedKeys: &AuthorizedKeys{
+					AllUsers: true,
+					Users:    []*User{{EmailAddress: "ops@example.com"}},
+					SSHKeys:  []string{"key_1"},
+				},
+				BackupPolicies: []*BackupPolicy{
+					{Retention: 7, Schedule: &daily},
+				},
+				Tags: []string{"web", "production"},
+				ISO:  "iso_1",
+			},
+		},
+		{
+			name: "conflicting calls",
+			builder: func() *VMBuilder {
+				return NewVM().
+					InZone("uk-lon-01a").
+					InDataCenter("uk-lon-01").
+					WithResources(8, 4).
+					WithPackage("rock-3").
+					WithResources(8, 4)
+			},
+			wantErr: []string{
+				"data_center: zone and data_center are mutually exclusive",
+				"resources.package: " +
+					"package, and memory and cpu_cores are mutually exclusive",
+				"resources: " +
+					"package, and memory and cpu_cores are mutually exclusive",
+			},
+		},
+		{
+			name: "invalid arguments",
+			builder: func() *VMBuilder {
+				return NewVM().
+					InZone("").
+					WithResources(0, 2).
+					WithDiskTemplate("templates/ubuntu-22-04").
+					WithDiskTemplateVersion(0).
+					WithDiskTemplateOption("locale", "en_GB").
+					WithDiskTemplateOption("locale", "en_US").
+					WithHostname("web_1").
+					InGroup("").
+					AuthorizeUser("").
+					AddBackupPolicy(0, Schedule{
+						Interval: "fortnightly", Frequency: 1,
+					}).
+					WithTags("web", "")
+			},
+			wantErr: []string{
+				"zone.permalink: is required",
+				"resources.memory: must be greater than 0",
+				"disk_template.version: must be greater than 0",
+				`disk_template.options[1].key: duplicate option "locale"`,
+				`hostname: "web_1" is not a valid hostname`,
+				"group.name: is required",
+				"authorized_keys.users[0].email_address: is required",
+				"backup_policies[0].retention: must be greater than 0",
+				"backup_policies[0].schedule.interval: " +
+					`"fortnightly" is not one of ` +
+					"hourly, daily, weekly or monthly",
+				"tags[1]: is required",
+			},
+		},
+		{
+			name: "invalid disks and network interfaces",
+			builder: func() *VMBuilder {
+				return NewVM().
+					InZone("uk-lon-01a").
+					WithPackage("rock-3").
+					AddSystemDisk(NewSystemDisk("Boot", 20)).
+					AddSystemDisk(
+						NewSystemDisk("Boot", 0).
+							AddBackupPolicy(7, Schedule{Time: 24}),
+					).
+					AddNIC(
+						NewNIC().
+							OnNetwork("public").
+							OnVirtualNetwork("vnet_1").
+							AllocateIP(5),
+					)
+			},
+			wantErr: []string{
+				`system_disks[1].name: duplicate disk name "Boot"`,
+				"system_disks[1].size: must be greater than 0",
+				"system_disks[1].backup_policies[0].schedule.interval: " +
+					"is required",
+				"system_disks[1].backup_policies[0].schedule.frequency: " +
+					"must be at least 1",
+				"system_disks[1].backup_policies[0].schedule.time: " +
+					"must be an hour between 0 and 23",
+				"network_interfaces[0]: " +
+					"network and virtual_network are mutually exclusive",
+				"network_interfaces[0].ip_address_allocations[0].version: " +
+					"must be 4 or 6",
+			},
+		},
+		{
+			name: "incomplete spec",
+			builder: func() *VMBuilder {
+				return NewVM().WithHostname("web-1")
+			},
+			wantErr: []string{
+				"zone: zone or data_center is required",
+				"resources: is required",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder().Build()
+
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+
+				return
+			}
+
+			assert.Nil(t, got)
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			msgs := make([]string, 0, len(verr.Errors))
+			for _, fe := range verr.Errors {
+				msgs = append(msgs, fe.Error())
+			}
+			assert.Equal(t, tt.wantErr, msgs)
+		})
+	}
+}
+
+func TestVMBuilder_Build_reuse(t *testing.T) {
+	b := NewVM().InZone("uk-lon-01a").WithPackage("rock-3")
+
+	web1, err := b.WithHostname("web-1").Build()
+	require.NoError(t, err)
+	web2, err := b.WithHostname("web-2").Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, "web-1", web1.Hostname)
+	assert.Equal(t, "web-2", web2.Hostname)
+	assert.NotSame(t, web1.Zone, web2.Zone)
+}
+
+func TestVMBuilder_AddSystemDisk_copies(t *testing.T) {
+	disk := NewSystemDisk("", 50)
+	b := NewVM().
+		InZone("uk-lon-01a").
+		WithPackage("rock-3").
+		AddSystemDisk(NewSystemDisk("Boot", 20)).
+		AddSystemDisk(disk).
+		AddSystemDisk(disk)
+	disk.WithSpeed("nvme").AddBackupPolicy(7, Schedule{})
+
+	got, err := b.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*SystemDisk{
+		{Name: "Boot", Size: 20},
+		{Size: 50},
+		{Size: 50},
+	}, got.SystemDisks)
+	assert.NotSame(t, got.SystemDisks[1], got.SystemDisks[2])
+
+	_, err = b.AddSystemDisk(NewSystemDisk("Boot", 0)).Build()
+	assert.EqualError(t, err, "validation: "+
+		`system_disks[3].name: duplicate disk name "Boot"; `+
+		"system_disks[3].size: must be greater than 0",
+	)
+}
+
+func TestVMBuilder_AddNIC_copies(t *testing.T) {
+	nic := NewNIC().OnNetwork("public").AllocateIP(IPv4)
+	b := NewVM().InZone("uk-lon-01a").WithPackage("rock-3").AddNIC(nic)
+	nic.AllocateIP(5).OnVirtualNetwork("vnet_1")
+
+	got, err := b.AddNIC(NewNIC().OnVirtualNetwork("vnet_2")).Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*NetworkInterface{
+		{
+			Network: &Network{Permalink: "public"},
+			IPAddressAllocations: []*IPAddressAllocation{
+				{Type: NewIPAddressAllocation, Version: IPv4},
+			},
+		},
+		{VirtualNetwork: &VirtualNetwork{ID: "vnet_2"}},
+	}, got.NetworkInterfaces)
+}
+
+func TestVMBuilder_AddBackupPolicy_retentionOnly(t *testing.T) {
+	got, err := NewVM().
+		InZone("uk-lon-01a").
+		WithPackage("rock-3").
+		AddSystemDisk(NewSystemDisk("Boot", 20).AddBackupPolicy(3, Schedule{})).
+		AddBackupPolicy(7, Schedule{}).
+		Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, []*BackupPolicy{{Retention: 7}}, got.BackupPolicies)
+	assert.Equal(t,
+		[]*BackupPolicy{{Retention: 3}}, got.SystemDisks[0].BackupPolicies,
+	)
+}
@@ -2,7 +2,7 @@
 // document format.
 //
 // It supports both building and parsing buld spec XML documents to/from Go
-// structs, JSON and YAML.
+// structs, JSON, YAML, HCL and TOML.
 package buildspec
 
 import (
@@ -31,6 +31,8 @@ func FromJSON(r io.Reader) (*VirtualMachineSpec, error) {
 }
 
 // FromXML parses a XML build spec document into a *VirtualMachineSpec object.
+// Unknown elements and attributes are ignored, use FromXMLStrict to reject
+// them instead, and to have invalid values reported where they occur.
 func FromXML(r io.Reader) (*VirtualMachineSpec, error) {
 	dec := xml.NewDecoder(r)
 
@@ -50,3 +52,51 @@ func FromYAML(r io.Reader) (*VirtualMachineSpec, error) {
 
 	return spec, err
 }
+
+// FromHCL parses a HCL build spec document into a *VirtualMachineSpec object.
+// Unknown attributes and blocks are rejected with a *HCLError.
+//
+// HCL documents use the same field names as JSON and YAML documents, with
+// objects given as blocks. Lists of objects are given as repeated blocks
+// named after a single item of the list:
+//
+//	data_center {
+//	  permalink = "uk-lon-01"
+//	}
+//
+//	system_disk {
+//	  name = "System Disk"
+//	  size = 20
+//	}
+//
+//	tags = ["web", "production"]
+//
+// Expressions such as heredocs and string templates are supported, but no
+// variables or functions are defined.
+func FromHCL(r io.Reader) (*VirtualMachineSpec, error) {
+	spec := &VirtualMachineSpec{}
+	err := readHCL(r, spec)
+
+	return spec, err
+}
+
+// FromTOML parses a TOML build spec document into a *VirtualMachineSpec
+// object. Unknown keys are rejected with a *TOMLError.
+//
+// TOML documents use the same field names as JSON and YAML documents, with
+// objects given as tables, and lists of objects as arrays of tables:
+//
+//	hostname = "web-3"
+//
+//	[data_center]
+//	permalink = "uk-lon-01"
+//
+//	[[system_disks]]
+//	name = "System Disk"
+//	size = 20
+func FromTOML(r io.Reader) (*VirtualMachineSpec, error) {
+	spec := &VirtualMachineSpec{}
+	err := readTOML(r, spec)
+
+	return spec, err
+}
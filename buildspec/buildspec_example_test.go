@@ -3,6 +3,7 @@ package buildspec_test
 import (
 	"fmt"
 	"strings"
+	"testing/fstest"
 
 	"github.com/krystal/go-katapult/buildspec"
 )
@@ -89,3 +90,88 @@ hostname: web-3`)
 	// templates/ubuntu-18-04
 	// web-3
 }
+
+func ExampleFromHCL() {
+	r := strings.NewReader(`
+data_center {
+  permalink = "london"
+}
+
+resources {
+  package {
+    permalink = "rock-3"
+  }
+}
+
+disk_template {
+  permalink = "templates/ubuntu-18-04"
+}
+
+hostname = "web-3"`)
+
+	spec, _ := buildspec.FromHCL(r)
+
+	fmt.Println(spec.DataCenter.Permalink)
+	fmt.Println(spec.Resources.Package.Permalink)
+	fmt.Println(spec.DiskTemplate.Permalink)
+	fmt.Println(spec.Hostname)
+	// Output:
+	// london
+	// rock-3
+	// templates/ubuntu-18-04
+	// web-3
+}
+
+func ExampleFromTOML() {
+	r := strings.NewReader(`
+hostname = "web-3"
+
+[data_center]
+permalink = "london"
+
+[resources.package]
+permalink = "rock-3"
+
+[disk_template]
+permalink = "templates/ubuntu-18-04"`)
+
+	spec, _ := buildspec.FromTOML(r)
+
+	fmt.Println(spec.DataCenter.Permalink)
+	fmt.Println(spec.Resources.Package.Permalink)
+	fmt.Println(spec.DiskTemplate.Permalink)
+	fmt.Println(spec.Hostname)
+	// Output:
+	// london
+	// rock-3
+	// templates/ubuntu-18-04
+	// web-3
+}
+
+func ExampleTemplate_FromYAML() {
+	tmpl := &buildspec.Template{
+		Vars: map[string]string{"ENV": "staging"},
+		FS: fstest.MapFS{
+			"backups.yaml": {Data: []byte(`
+- retention: ${RETENTION:-7}
+  schedule:
+    interval: daily`)},
+		},
+	}
+	r := strings.NewReader(`
+data_center:
+  permalink: ${DATA_CENTER:-london}
+hostname: web-${ENV}
+backup_policies:
+  ${include:backups.yaml}`)
+
+	spec, _ := tmpl.FromYAML(r)
+
+	fmt.Println(spec.DataCenter.Permalink)
+	fmt.Println(spec.Hostname)
+	fmt.Println(spec.BackupPolicies[0].Retention)
+	// Output:
+	// london
+	// web-staging
+	// 7
+}
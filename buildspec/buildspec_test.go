@@ -245,6 +245,29 @@ func TestFromXML(t *testing.T) {
 			),
 			want: &VirtualMachineSpec{},
 		},
+		{
+			name: "unsupported lookup",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<Zone by="name">London</Zone>
+				</VirtualMachineSpec>`,
+			),
+			want:   &VirtualMachineSpec{},
+			errStr: `parse_xml: Zone by="name" is not supported`,
+		},
+		{
+			name: "invalid value",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<SystemDisks>
+						<Disk><Size>ten</Size></Disk>
+					</SystemDisks>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "xml: (*buildspec.SystemDisk).UnmarshalXML did not " +
+				"consume entire <Disk> element",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -325,3 +348,351 @@ func TestFromYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestFromHCL(t *testing.T) {
+	tests := []struct {
+		name   string
+		hcl    string
+		want   *VirtualMachineSpec
+		errIs  error
+		errStr string
+	}{
+		{
+			name: "empty string",
+			hcl:  ``,
+			want: &VirtualMachineSpec{},
+		},
+		{
+			name: "basic VirtualMachineSpec",
+			hcl: undent.String(`
+				name = "web-3"`,
+			),
+			want: &VirtualMachineSpec{Name: "web-3"},
+		},
+		{
+			name: "blocks",
+			hcl: undent.String(`
+				tags = ["web", "production"]
+
+				zone {
+				  permalink = "uk-lon-01a"
+				}
+
+				system_disk {
+				  name = "Boot"
+				  size = 20
+				}
+
+				system_disk {
+				  name = "Data"
+				  size = 100
+				}`,
+			),
+			want: &VirtualMachineSpec{
+				Zone: &Zone{Permalink: "uk-lon-01a"},
+				SystemDisks: []*SystemDisk{
+					{Name: "Boot", Size: 20},
+					{Name: "Data", Size: 100},
+				},
+				Tags: []string{"web", "production"},
+			},
+		},
+		{
+			name: "heredoc",
+			hcl: undent.String(`
+				description = <<-EOT
+				  Primary web server.
+				  Serves example.com.
+				EOT
+				name = "web-3"`,
+			),
+			want: &VirtualMachineSpec{
+				Name:        "web-3",
+				Description: "Primary web server.\nServes example.com.\n",
+			},
+		},
+		{
+			name: "template",
+			hcl: undent.String(`
+				hostname    = "web-${1 + 2}"
+				description = "Say \"hi\" $${literal}"`,
+			),
+			want: &VirtualMachineSpec{
+				Hostname:    "web-3",
+				Description: `Say "hi" ${literal}`,
+			},
+		},
+		{
+			name: "invalid attribute",
+			hcl: undent.String(`
+				rocket_fuel = "maybe"`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 1, column 1: Unsupported argument; " +
+				`An argument named "rocket_fuel" is not expected here.`,
+		},
+		{
+			name: "invalid nested block",
+			hcl: undent.String(`
+				system_disk {
+				  size = 20
+
+				  encryption {
+				    enabled = true
+				  }
+				}`,
+			),
+			want: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{{Size: 20}},
+			},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 4, column 3: Unsupported block type; " +
+				`Blocks of type "encryption" are not expected here.`,
+		},
+		{
+			name: "plural block name",
+			hcl: undent.String(`
+				system_disks {
+				  size = 20
+				}`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 1, column 1: Unsupported block type; " +
+				`Blocks of type "system_disks" are not expected here. ` +
+				`Did you mean "system_disk"?`,
+		},
+		{
+			name: "object attribute",
+			hcl: undent.String(`
+				zone = { permalink = "uk-lon-01a" }`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 1, column 1: Unsupported argument; " +
+				`An argument named "zone" is not expected here. ` +
+				`Did you mean to define a block of type "zone"?`,
+		},
+		{
+			name: "wrong type",
+			hcl: undent.String(`
+				resources {
+				  memory = "lots"
+				}`,
+			),
+			want:  &VirtualMachineSpec{Resources: &Resources{}},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 2, column 13: Unsuitable value type; " +
+				"Unsuitable value: a number is required",
+		},
+		{
+			name: "duplicate block",
+			hcl: undent.String(`
+				zone {
+				  permalink = "uk-lon-01a"
+				}
+
+				zone {
+				  permalink = "uk-lon-01b"
+				}`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 5, column 1: Duplicate zone block; " +
+				"Only one zone block is allowed. " +
+				"Another was defined at :1,1-5.",
+		},
+		{
+			name: "variable",
+			hcl: undent.String(`
+				hostname = var.hostname`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 1, column 12: Variables not allowed; " +
+				"Variables may not be used here.",
+		},
+		{
+			name: "unclosed block",
+			hcl: undent.String(`
+				zone {
+				  permalink = "uk-lon-01a"`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseHCL,
+			errStr: "parse_hcl: line 1, column 6: Unclosed configuration " +
+				"block; There is no closing brace for this block before " +
+				"the end of the file. This may be caused by incorrect " +
+				"brace nesting elsewhere in this file.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromHCL(strings.NewReader(tt.hcl))
+
+			if tt.errIs != nil {
+				assert.True(t, errors.Is(err, tt.errIs))
+			}
+
+			if tt.errStr != "" {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.errIs == nil && tt.errStr == "" {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromTOML(t *testing.T) {
+	tests := []struct {
+		name   string
+		toml   string
+		want   *VirtualMachineSpec
+		errIs  error
+		errStr string
+	}{
+		{
+			name: "empty string",
+			toml: ``,
+			want: &VirtualMachineSpec{},
+		},
+		{
+			name: "basic VirtualMachineSpec",
+			toml: undent.String(`
+				name = "web-3"`,
+			),
+			want: &VirtualMachineSpec{Name: "web-3"},
+		},
+		{
+			name: "tables",
+			toml: undent.String(`
+				name = 'web-3'
+				zone.permalink = "uk-lon-01a"
+				resources = { memory = 8, cpu_cores = 4 }
+				tags = [
+				  "web",
+				  "production",
+				]
+
+				[[system_disks]]
+				name = "Boot"
+				size = 20
+
+				[[system_disks]]
+				name = "Data"
+				size = 100
+
+				[system_disks.io_profile]
+				permalink = "fast"`,
+			),
+			want: &VirtualMachineSpec{
+				Name:      "web-3",
+				Zone:      &Zone{Permalink: "uk-lon-01a"},
+				Resources: &Resources{Memory: 8, CPUCores: 4},
+				SystemDisks: []*SystemDisk{
+					{Name: "Boot", Size: 20},
+					{
+						Name:      "Data",
+						Size:      100,
+						IOProfile: &DiskIOProfile{Permalink: "fast"},
+					},
+				},
+				Tags: []string{"web", "production"},
+			},
+		},
+		{
+			name: "multi-line strings",
+			toml: undent.String(`
+				description = """
+				Primary web server.
+				Serves "example.com"."""
+				hostname = '''
+				web-3'''`,
+			),
+			want: &VirtualMachineSpec{
+				Description: "Primary web server.\nServes \"example.com\".",
+				Hostname:    "web-3",
+			},
+		},
+		{
+			name: "invalid key",
+			toml: undent.String(`
+				rocket_fuel = "maybe"`,
+			),
+			want:   &VirtualMachineSpec{},
+			errIs:  ErrParseTOML,
+			errStr: `parse_toml: unknown field "rocket_fuel"`,
+		},
+		{
+			name: "invalid nested key",
+			toml: undent.String(`
+				[[system_disks]]
+				size = 20
+				encryption = true`,
+			),
+			want: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{{Size: 20}},
+			},
+			errIs:  ErrParseTOML,
+			errStr: `parse_toml: unknown field "system_disks.encryption"`,
+		},
+		{
+			name: "wrong type",
+			toml: undent.String(`
+				[resources]
+				memory = "lots"`,
+			),
+			want:  &VirtualMachineSpec{Resources: &Resources{}},
+			errIs: ErrParseTOML,
+			errStr: "parse_toml: toml: line 2 " +
+				`(last key "resources.memory"): incompatible types: ` +
+				"TOML value has type string; destination has type integer",
+		},
+		{
+			name: "duplicate key",
+			toml: undent.String(`
+				name = "web-3"
+				name = "web-4"`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseTOML,
+			errStr: "parse_toml: line 2, column 1: " +
+				`Key 'name' has already been defined.`,
+		},
+		{
+			name: "unterminated string",
+			toml: undent.String(`
+				name = "web-3
+				hostname = "web-3"`,
+			),
+			want:  &VirtualMachineSpec{},
+			errIs: ErrParseTOML,
+			errStr: "parse_toml: line 1, column 14: " +
+				"strings cannot contain newlines",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromTOML(strings.NewReader(tt.toml))
+
+			if tt.errIs != nil {
+				assert.True(t, errors.Is(err, tt.errIs))
+			}
+
+			if tt.errStr != "" {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.errIs == nil && tt.errStr == "" {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
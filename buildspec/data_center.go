@@ -6,9 +6,9 @@ import (
 )
 
 type DataCenter struct {
-	ID        string `json:"id,omitempty" yaml:"id,omitempty"`
-	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
-	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty"`
+	ID        string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty" hcl:"name,optional" toml:"name,omitempty"`
+	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty" hcl:"permalink,optional" toml:"permalink,omitempty"`
 }
 
 func (s *DataCenter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -33,7 +33,10 @@ func (s *DataCenter) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlDataCenter{}
-	_ = d.DecodeElement(&x, &start)
+	err := decodeXMLElement(d, &x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := DataCenter{}
 
@@ -0,0 +1,587 @@
+package buildspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ChangeType describes how a value differs between two build specs.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// Change is a single difference between two build specs.
+type Change struct {
+	// Type is whether the value was added, removed or modified.
+	Type ChangeType `json:"type"`
+
+	// Path is the location of the value, using JSON field names. Items of
+	// system disks and disk template options are identified by name and key,
+	// for example `system_disks["Data"].size`, and other list items by
+	// index.
+	Path string `json:"path"`
+
+	// From is the old value, empty when the value was added.
+	From string `json:"from,omitempty"`
+
+	// To is the new value, empty when the value was removed.
+	To string `json:"to,omitempty"`
+
+	// Summary describes the change in plain English, for example "resized
+	// system disk "Data" from 100 GB to 200 GB".
+	Summary string `json:"summary"`
+}
+
+// String returns the change's summary, prefixed with "+", "-" or "~" for
+// added, removed and modified values.
+func (c *Change) String() string {
+	prefix := "~"
+	switch c.Type {
+	case ChangeAdded:
+		prefix = "+"
+	case ChangeRemoved:
+		prefix = "-"
+	}
+
+	return prefix + " " + c.Summary
+}
+
+// SpecDiff is the list of changes between two build specs returned by Diff.
+type SpecDiff struct {
+	Changes []*Change `json:"changes"`
+}
+
+// Empty returns true if there are no changes.
+func (d *SpecDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Text returns the changes as human-readable text, with one change per line.
+func (d *SpecDiff) Text() string {
+	buf := &bytes.Buffer{}
+	_ = d.WriteText(buf)
+
+	return buf.String()
+}
+
+// WriteText writes the changes as human-readable text to given io.Writer.
+func (d *SpecDiff) WriteText(w io.Writer) error {
+	if d.Empty() {
+		_, err := io.WriteString(w, "No changes.\n")
+
+		return err
+	}
+
+	for _, c := range d.Changes {
+		_, err := fmt.Fprintln(w, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// JSON returns the changes in JSON format as a byte slice.
+func (d *SpecDiff) JSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := d.WriteJSON(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteJSON writes the changes in JSON format to given io.Writer.
+func (d *SpecDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	return enc.Encode(d)
+}
+
+// Diff compares two build specs, and returns the changes needed to turn a
+// into b. Either spec may be nil, which is treated as an empty spec.
+//
+// Differences which do not change the meaning of a spec are ignored: system
+// disks are matched by name, disk template options by key, and the order of
+// tags, shared disks, authorized users and SSH keys, backup policies and IP
+// address allocations does not matter. Network interfaces are compared by
+// position.
+func Diff(a, b *VirtualMachineSpec) *SpecDiff {
+	if a == nil {
+		a = &VirtualMachineSpec{}
+	}
+	if b == nil {
+		b = &VirtualMachineSpec{}
+	}
+
+	d := &differ{diff: &SpecDiff{Changes: []*Change{}}}
+
+	d.value("zone", "zone", describeRef(a.Zone), describeRef(b.Zone))
+	d.value("data_center", "data center",
+		describeRef(a.DataCenter), describeRef(b.DataCenter),
+	)
+	d.resources(a.Resources, b.Resources)
+	d.diskTemplate(a.DiskTemplate, b.DiskTemplate)
+	d.systemDisks(a.SystemDisks, b.SystemDisks)
+	d.set("shared_disks", "shared disk", "",
+		describeAll(a.SharedDisks), describeAll(b.SharedDisks),
+	)
+	d.networkInterfaces(a.NetworkInterfaces, b.NetworkInterfaces)
+	d.value("hostname", "hostname", a.Hostname, b.Hostname)
+	d.value("name", "name", a.Name, b.Name)
+	d.value("description", "description", a.Description, b.Description)
+	d.value("group", "group", describeRef(a.Group), describeRef(b.Group))
+	d.authorizedKeys(a.AuthorizedKeys, b.AuthorizedKeys)
+	d.set("backup_policies", "backup policy", "",
+		describeAll(a.BackupPolicies), describeAll(b.BackupPolicies),
+	)
+	d.set("tags", "tag", "", a.Tags, b.Tags)
+	d.value("iso", "ISO", a.ISO, b.ISO)
+
+	return d.diff
+}
+
+type differ struct {
+	diff *SpecDiff
+}
+
+func (d *differ) add(
+	typ ChangeType,
+	path, from, to, format string,
+	a ...any,
+) {
+	d.diff.Changes = append(d.diff.Changes, &Change{
+		Type:    typ,
+		Path:    path,
+		From:    from,
+		To:      to,
+		Summary: fmt.Sprintf(format, a...),
+	})
+}
+
+// value records a change of a single value, described by what.
+func (d *differ) value(path, what, from, to string) {
+	switch {
+	case from == to:
+	case from == "":
+		d.add(ChangeAdded, path, from, to, "set %s to %s", what, to)
+	case to == "":
+		d.add(ChangeRemoved, path, from, to, "removed %s %s", what, from)
+	default:
+		d.add(ChangeModified, path, from, to,
+			"changed %s from %s to %s", what, from, to,
+		)
+	}
+}
+
+// toggle records a change of a boolean option, described by what.
+func (d *differ) toggle(path, what string, from, to bool) {
+	switch {
+	case from == to:
+	case to:
+		d.add(ChangeModified, path, "false", "true", "enabled %s", what)
+	default:
+		d.add(ChangeModified, path, "true", "false", "disabled %s", what)
+	}
+}
+
+// set records the values which were added to and removed from an unordered
+// list, described by what. Lists are compared by the number of times each
+// value occurs, so adding or removing a duplicate is recorded as a change. If
+// the list belongs to an item of another list, owner describes the item.
+func (d *differ) set(path, what, owner string, from, to []string) {
+	removed, added := "", ""
+	if owner != "" {
+		removed, added = " from "+owner, " to "+owner
+	}
+
+	kept := countStrings(to)
+	for _, v := range from {
+		if kept[v] > 0 {
+			kept[v]--
+
+			continue
+		}
+		d.add(ChangeRemoved, path, v, "",
+			"removed %s %s%s", what, v, removed,
+		)
+	}
+
+	kept = countStrings(from)
+	for _, v := range to {
+		if kept[v] > 0 {
+			kept[v]--
+
+			continue
+		}
+		d.add(ChangeAdded, path, "", v, "added %s %s%s", what, v, added)
+	}
+}
+
+// countStrings returns the number of times each value occurs in list.
+func countStrings(list []string) map[string]int {
+	counts := make(map[string]int, len(list))
+	for _, v := range list {
+		counts[v]++
+	}
+
+	return counts
+}
+
+func (d *differ) resources(a, b *Resources) {
+	if a == nil {
+		a = &Resources{}
+	}
+	if b == nil {
+		b = &Resources{}
+	}
+
+	d.value("resources.package", "package",
+		describeRef(a.Package), describeRef(b.Package),
+	)
+	d.value("resources.memory", "memory",
+		formatGB(a.Memory), formatGB(b.Memory),
+	)
+	d.value("resources.cpu_cores", "CPU cores",
+		formatCount(a.CPUCores), formatCount(b.CPUCores),
+	)
+}
+
+func (d *differ) diskTemplate(a, b *DiskTemplate) {
+	if a == nil {
+		a = &DiskTemplate{}
+	}
+	if b == nil {
+		b = &DiskTemplate{}
+	}
+
+	d.value("disk_template", "disk template",
+		describeRef(a), describeRef(b),
+	)
+	d.value("disk_template.version", "disk template version",
+		formatCount(a.Version), formatCount(b.Version),
+	)
+
+	from := map[string]string{}
+	for _, o := range a.Options {
+		from[o.Key] = o.Value
+	}
+	to := map[string]string{}
+	for _, o := range b.Options {
+		to[o.Key] = o.Value
+	}
+
+	for _, o := range a.Options {
+		if _, ok := to[o.Key]; !ok {
+			d.add(ChangeRemoved,
+				fmt.Sprintf("disk_template.options[%q]", o.Key), o.Value, "",
+				"removed disk template option %s", o.Key,
+			)
+		}
+	}
+	for _, o := range b.Options {
+		path := fmt.Sprintf("disk_template.options[%q]", o.Key)
+		old, ok := from[o.Key]
+		switch {
+		case !ok:
+			d.add(ChangeAdded, path, "", o.Value,
+				"added disk template option %s = %q", o.Key, o.Value,
+			)
+		case old != o.Value:
+			d.add(ChangeModified, path, old, o.Value,
+				"changed disk template option %s from %q to %q",
+				o.Key, old, o.Value,
+			)
+		}
+	}
+}
+
+func (d *differ) systemDisks(a, b []*SystemDisk) {
+	from, fromKeys := keyedSystemDisks(a)
+	to, toKeys := keyedSystemDisks(b)
+
+	for _, k := range fromKeys {
+		if _, ok := to[k]; !ok {
+			disk := from[k]
+			d.add(ChangeRemoved, "system_disks"+k, formatGB(disk.Size), "",
+				"removed system disk %s (%s)", diskName(disk, k),
+				formatGB(disk.Size),
+			)
+		}
+	}
+
+	for _, k := range toKeys {
+		disk := to[k]
+		path := "system_disks" + k
+		name := diskName(disk, k)
+
+		old, ok := from[k]
+		if !ok {
+			d.add(ChangeAdded, path, "", formatGB(disk.Size),
+				"added system disk %s (%s)", name, formatGB(disk.Size),
+			)
+
+			continue
+		}
+
+		if old.Size != disk.Size {
+			from, to := formatGB(old.Size), formatGB(disk.Size)
+			d.add(ChangeModified, path+".size", from, to,
+				"resized system disk %s from %s to %s", name, from, to,
+			)
+		}
+		d.value(path+".speed", "speed of system disk "+name,
+			old.Speed, disk.Speed,
+		)
+		d.value(path+".io_profile", "IO profile of system disk "+name,
+			describeRef(old.IOProfile), describeRef(disk.IOProfile),
+		)
+		d.value(path+".file_system_type",
+			"file system type of system disk "+name,
+			old.FileSystemType, disk.FileSystemType,
+		)
+		d.set(path+".backup_policies", "backup policy",
+			"system disk "+name,
+			describeAll(old.BackupPolicies), describeAll(disk.BackupPolicies),
+		)
+	}
+}
+
+// keyedSystemDisks returns disks keyed by their name, or by their index when
+// they have no name, along with the keys in order. Keys are formatted as an
+// index into the list, for example `["Data"]` or `[2]`.
+func keyedSystemDisks(disks []*SystemDisk) (map[string]*SystemDisk, []string) {
+	m := make(map[string]*SystemDisk, len(disks))
+	keys := make([]string, 0, len(disks))
+	for i, disk := range disks {
+		k := fmt.Sprintf("[%d]", i)
+		if disk.Name != "" {
+			k = fmt.Sprintf("[%q]", disk.Name)
+		}
+		if _, ok := m[k]; !ok {
+			m[k] = disk
+			keys = append(keys, k)
+		}
+	}
+
+	return m, keys
+}
+
+func diskName(disk *SystemDisk, key string) string {
+	if disk.Name != "" {
+		return strconv.Quote(disk.Name)
+	}
+
+	return key
+}
+
+func (d *differ) networkInterfaces(a, b []*NetworkInterface) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		path := index("network_interfaces", i)
+
+		switch {
+		case i >= len(b):
+			d.add(ChangeRemoved, path, describeNIC(a[i]), "",
+				"removed network interface %d (%s)", i, describeNIC(a[i]),
+			)
+		case i >= len(a):
+			d.add(ChangeAdded, path, "", describeNIC(b[i]),
+				"added network interface %d (%s)", i, describeNIC(b[i]),
+			)
+		default:
+			what := fmt.Sprintf("of network interface %d", i)
+			d.value(path+".network", "network "+what,
+				describeRef(a[i].Network), describeRef(b[i].Network),
+			)
+			d.value(path+".virtual_network", "virtual network "+what,
+				describeRef(a[i].VirtualNetwork),
+				describeRef(b[i].VirtualNetwork),
+			)
+			d.value(path+".speed_profile", "speed profile "+what,
+				describeRef(a[i].SpeedProfile),
+				describeRef(b[i].SpeedProfile),
+			)
+			d.set(path+".ip_address_allocations", "IP address allocation",
+				fmt.Sprintf("network interface %d", i),
+				describeAll(a[i].IPAddressAllocations),
+				describeAll(b[i].IPAddressAllocations),
+			)
+		}
+	}
+}
+
+func (d *differ) authorizedKeys(a, b *AuthorizedKeys) {
+	if a == nil {
+		a = &AuthorizedKeys{}
+	}
+	if b == nil {
+		b = &AuthorizedKeys{}
+	}
+
+	d.toggle("authorized_keys.all_users", "authorizing all users",
+		a.AllUsers, b.AllUsers,
+	)
+	d.toggle("authorized_keys.all_ssh_keys", "authorizing all SSH keys",
+		a.AllSSHKeys, b.AllSSHKeys,
+	)
+	d.set("authorized_keys.users", "authorized user", "",
+		describeAll(a.Users), describeAll(b.Users),
+	)
+	d.set("authorized_keys.ssh_keys", "authorized SSH key", "",
+		a.SSHKeys, b.SSHKeys,
+	)
+}
+
+// describeRef returns a short description of a reference to another
+// resource, or an empty string if v is nil.
+func describeRef(v any) string {
+	switch r := v.(type) {
+	case *Zone:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink)
+		}
+	case *DataCenter:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink, r.Name)
+		}
+	case *Package:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink)
+		}
+	case *DiskTemplate:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink)
+		}
+	case *DiskIOProfile:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink)
+		}
+	case *Group:
+		if r != nil {
+			return firstSet(r.ID, r.Name)
+		}
+	case *Network:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink)
+		}
+	case *VirtualNetwork:
+		if r != nil {
+			return r.ID
+		}
+	case *NetworkSpeedProfile:
+		if r != nil {
+			return firstSet(r.ID, r.Permalink)
+		}
+	case *Subnet:
+		if r != nil {
+			return firstSet(r.ID, r.Address)
+		}
+	case *IPAddress:
+		if r != nil {
+			return firstSet(r.ID, r.Address)
+		}
+	case *SharedDisk:
+		if r != nil {
+			return firstSet(r.ID, r.Name)
+		}
+	case *User:
+		if r != nil {
+			return firstSet(r.ID, r.EmailAddress)
+		}
+	case *BackupPolicy:
+		if r != nil {
+			return describeBackupPolicy(r)
+		}
+	case *IPAddressAllocation:
+		if r != nil {
+			return describeIPAddressAllocation(r)
+		}
+	}
+
+	return ""
+}
+
+// describeAll returns the descriptions of all items.
+func describeAll[T any](items []*T) []string {
+	s := make([]string, 0, len(items))
+	for _, item := range items {
+		s = append(s, describeRef(item))
+	}
+
+	return s
+}
+
+func describeNIC(nic *NetworkInterface) string {
+	switch {
+	case nic.VirtualNetwork != nil:
+		return "virtual network " + describeRef(nic.VirtualNetwork)
+	case nic.Network != nil:
+		return "network " + describeRef(nic.Network)
+	}
+
+	return "default network"
+}
+
+func describeBackupPolicy(bp *BackupPolicy) string {
+	s := fmt.Sprintf("retention %d", bp.Retention)
+	if sch := bp.Schedule; sch != nil {
+		s += ", " + string(sch.Interval)
+		if sch.Frequency != 0 {
+			s += fmt.Sprintf(" every %d", sch.Frequency)
+		}
+		if sch.Time != 0 {
+			s += fmt.Sprintf(" at %02d:00", sch.Time)
+		}
+	}
+
+	return s
+}
+
+func describeIPAddressAllocation(a *IPAddressAllocation) string {
+	if a.Type == ExistingIPAddressAllocation {
+		return "existing " + describeRef(a.IPAddress)
+	}
+
+	s := string(a.Type)
+	if a.Version != 0 {
+		s += fmt.Sprintf(" IPv%d", a.Version)
+	}
+	if a.Subnet != nil {
+		s += " in subnet " + describeRef(a.Subnet)
+	}
+
+	return s
+}
+
+// firstSet returns the first non-empty string in values.
+func firstSet(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func formatGB(size int) string {
+	if size == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d GB", size)
+}
+
+func formatCount(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
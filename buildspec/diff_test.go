@@ -0,0 +1,440 @@
+package buildspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jimeh/go-golden"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureDiffBase() *VirtualMachineSpec {
+	return &VirtualMachineSpec{
+		Zone:      &Zone{Permalink: "uk-lon-01a"},
+		Resources: &Resources{Package: &Package{Permalink: "rock-3"}},
+		DiskTemplate: &DiskTemplate{
+			Permalink: "templates/ubuntu-22-04",
+			Options: []*DiskTemplateOption{
+				{Key: "timezone", Value: "UTC"},
+				{Key: "locale", Value: "en_GB"},
+			},
+		},
+		SystemDisks: []*SystemDisk{
+			{Name: "System Disk", Size: 20},
+			{Name: "Data", Size: 100, Speed: "ssd"},
+		},
+		NetworkInterfaces: []*NetworkInterface{
+			{
+				Network: &Network{Permalink: "public"},
+				IPAddressAllocations: []*IPAddressAllocation{
+					{Type: NewIPAddressAllocation, Version: IPv4},
+				},
+			},
+		},
+		Hostname: "web",
+		Group:    &Group{Name: "web"},
+		BackupPolicies: []*BackupPolicy{
+			{Retention: 7, Schedule: &Schedule{Interval: ScheduledDaily}},
+		},
+		Tags: []string{"web", "production"},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *VirtualMachineSpec
+		b    *VirtualMachineSpec
+		want []*Change
+	}{
+		{
+			name: "nil specs",
+			want: []*Change{},
+		},
+		{
+			name: "identical specs",
+			a:    fixtureDiffBase(),
+			b:    fixtureDiffBase(),
+			want: []*Change{},
+		},
+		{
+			name: "reordered lists",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.SystemDisks[0], s.SystemDisks[1] = s.SystemDisks[1],
+					s.SystemDisks[0]
+				s.DiskTemplate.Options[0], s.DiskTemplate.Options[1] =
+					s.DiskTemplate.Options[1], s.DiskTemplate.Options[0]
+				s.Tags = []string{"production", "web"}
+			}),
+			want: []*Change{},
+		},
+		{
+			name: "changed package",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.Resources.Package.Permalink = "rock-6"
+			}),
+			want: []*Change{
+				{
+					Type:    ChangeModified,
+					Path:    "resources.package",
+					From:    "rock-3",
+					To:      "rock-6",
+					Summary: "changed package from rock-3 to rock-6",
+				},
+			},
+		},
+		{
+			name: "package replaced by memory and cpu cores",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.Resources = &Resources{Memory: 8, CPUCores: 4}
+			}),
+			want: []*Change{
+				{
+					Type:    ChangeRemoved,
+					Path:    "resources.package",
+					From:    "rock-3",
+					Summary: "removed package rock-3",
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    "resources.memory",
+					To:      "8 GB",
+					Summary: "set memory to 8 GB",
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    "resources.cpu_cores",
+					To:      "4",
+					Summary: "set CPU cores to 4",
+				},
+			},
+		},
+		{
+			name: "system disks",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.SystemDisks = []*SystemDisk{
+					{Name: "Data", Size: 200},
+					{Name: "Logs", Size: 10},
+				}
+			}),
+			want: []*Change{
+				{
+					Type:    ChangeRemoved,
+					Path:    `system_disks["System Disk"]`,
+					From:    "20 GB",
+					Summary: `removed system disk "System Disk" (20 GB)`,
+				},
+				{
+					Type: ChangeModified,
+					Path: `system_disks["Data"].size`,
+					From: "100 GB",
+					To:   "200 GB",
+					Summary: `resized system disk "Data" ` +
+						"from 100 GB to 200 GB",
+				},
+				{
+					Type:    ChangeRemoved,
+					Path:    `system_disks["Data"].speed`,
+					From:    "ssd",
+					Summary: `removed speed of system disk "Data" ssd`,
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    `system_disks["Logs"]`,
+					To:      "10 GB",
+					Summary: `added system disk "Logs" (10 GB)`,
+				},
+			},
+		},
+		{
+			name: "unnamed system disks",
+			a: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{{Size: 10}},
+			},
+			b: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{{Size: 10}, {Size: 20}},
+			},
+			want: []*Change{
+				{
+					Type:    ChangeAdded,
+					Path:    "system_disks[1]",
+					To:      "20 GB",
+					Summary: "added system disk [1] (20 GB)",
+				},
+			},
+		},
+		{
+			name: "disk template options",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.DiskTemplate.Options = []*DiskTemplateOption{
+					{Key: "timezone", Value: "Europe/London"},
+					{Key: "keyboard", Value: "gb"},
+				}
+			}),
+			want: []*Change{
+				{
+					Type:    ChangeRemoved,
+					Path:    `disk_template.options["locale"]`,
+					From:    "en_GB",
+					Summary: "removed disk template option locale",
+				},
+				{
+					Type: ChangeModified,
+					Path: `disk_template.options["timezone"]`,
+					From: "UTC",
+					To:   "Europe/London",
+					Summary: "changed disk template option timezone " +
+						`from "UTC" to "Europe/London"`,
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    `disk_template.options["keyboard"]`,
+					To:      "gb",
+					Summary: `added disk template option keyboard = "gb"`,
+				},
+			},
+		},
+		{
+			name: "network interfaces",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				nic := s.NetworkInterfaces[0]
+				nic.SpeedProfile = &NetworkSpeedProfile{Permalink: "10gbps"}
+				nic.IPAddressAllocations = append(nic.IPAddressAllocations,
+					&IPAddressAllocation{
+						Type:    NewIPAddressAllocation,
+						Version: IPv6,
+					},
+				)
+				s.NetworkInterfaces = append(s.NetworkInterfaces,
+					&NetworkInterface{
+						VirtualNetwork: &VirtualNetwork{ID: "vnet_1"},
+					},
+				)
+			}),
+			want: []*Change{
+				{
+					Type: ChangeAdded,
+					Path: "network_interfaces[0].speed_profile",
+					To:   "10gbps",
+					Summary: "set speed profile of network interface 0 " +
+						"to 10gbps",
+				},
+				{
+					Type: ChangeAdded,
+					Path: "network_interfaces[0].ip_address_allocations",
+					To:   "new IPv6",
+					Summary: "added IP address allocation new IPv6 " +
+						"to network interface 0",
+				},
+				{
+					Type: ChangeAdded,
+					Path: "network_interfaces[1]",
+					To:   "virtual network vnet_1",
+					Summary: "added network interface 1 " +
+						"(virtual network vnet_1)",
+				},
+			},
+		},
+		{
+			name: "authorized keys",
+			a: &VirtualMachineSpec{
+				AuthorizedKeys: &AuthorizedKeys{AllUsers: true},
+			},
+			b: &VirtualMachineSpec{
+				AuthorizedKeys: &AuthorizedKeys{
+					AllSSHKeys: true,
+					Users:      []*User{{EmailAddress: "ops@example.com"}},
+				},
+			},
+			want: []*Change{
+				{
+					Type:    ChangeModified,
+					Path:    "authorized_keys.all_users",
+					From:    "true",
+					To:      "false",
+					Summary: "disabled authorizing all users",
+				},
+				{
+					Type:    ChangeModified,
+					Path:    "authorized_keys.all_ssh_keys",
+					From:    "false",
+					To:      "true",
+					Summary: "enabled authorizing all SSH keys",
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    "authorized_keys.users",
+					To:      "ops@example.com",
+					Summary: "added authorized user ops@example.com",
+				},
+			},
+		},
+		{
+			name: "scalars, backup policies and tags",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.Hostname = "web-2"
+				s.Group = nil
+				s.BackupPolicies[0].Schedule.Time = 3
+				s.Tags = []string{"web", "staging"}
+			}),
+			want: []*Change{
+				{
+					Type:    ChangeModified,
+					Path:    "hostname",
+					From:    "web",
+					To:      "web-2",
+					Summary: "changed hostname from web to web-2",
+				},
+				{
+					Type:    ChangeRemoved,
+					Path:    "group",
+					From:    "web",
+					Summary: "removed group web",
+				},
+				{
+					Type:    ChangeRemoved,
+					Path:    "backup_policies",
+					From:    "retention 7, daily",
+					Summary: "removed backup policy retention 7, daily",
+				},
+				{
+					Type: ChangeAdded,
+					Path: "backup_policies",
+					To:   "retention 7, daily at 03:00",
+					Summary: "added backup policy " +
+						"retention 7, daily at 03:00",
+				},
+				{
+					Type:    ChangeRemoved,
+					Path:    "tags",
+					From:    "production",
+					Summary: "removed tag production",
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    "tags",
+					To:      "staging",
+					Summary: "added tag staging",
+				},
+			},
+		},
+		{
+			name: "added duplicates",
+			a:    fixtureDiffBase(),
+			b: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				nic := s.NetworkInterfaces[0]
+				nic.IPAddressAllocations = append(nic.IPAddressAllocations,
+					&IPAddressAllocation{
+						Type:    NewIPAddressAllocation,
+						Version: IPv4,
+					},
+				)
+				s.Tags = append(s.Tags, "web")
+			}),
+			want: []*Change{
+				{
+					Type: ChangeAdded,
+					Path: "network_interfaces[0].ip_address_allocations",
+					To:   "new IPv4",
+					Summary: "added IP address allocation new IPv4 " +
+						"to network interface 0",
+				},
+				{
+					Type:    ChangeAdded,
+					Path:    "tags",
+					To:      "web",
+					Summary: "added tag web",
+				},
+			},
+		},
+		{
+			name: "removed duplicates",
+			a: mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+				s.Tags = []string{"web", "production", "web"}
+			}),
+			b: fixtureDiffBase(),
+			want: []*Change{
+				{
+					Type:    ChangeRemoved,
+					Path:    "tags",
+					From:    "web",
+					Summary: "removed tag web",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Diff(tt.a, tt.b)
+
+			assert.Equal(t, tt.want, got.Changes)
+		})
+	}
+}
+
+func TestSpecDiff_Rendering(t *testing.T) {
+	b := mutate(fixtureDiffBase(), func(s *VirtualMachineSpec) {
+		s.Resources.Package.Permalink = "rock-6"
+		s.SystemDisks[1].Size = 200
+		s.SystemDisks = append(s.SystemDisks, &SystemDisk{
+			Name: "Logs", Size: 10,
+		})
+		s.NetworkInterfaces[0].IPAddressAllocations = append(
+			s.NetworkInterfaces[0].IPAddressAllocations,
+			&IPAddressAllocation{Type: NewIPAddressAllocation, Version: IPv6},
+		)
+		s.Tags = []string{"web", "staging"}
+	})
+	diff := Diff(fixtureDiffBase(), b)
+
+	t.Run("Text", func(t *testing.T) {
+		got := []byte(diff.Text())
+
+		if golden.Update() {
+			golden.Set(t, got)
+		}
+		assert.Equal(t, string(golden.Get(t)), string(got))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		got, err := diff.JSON()
+		require.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, json.Indent(buf, got, "", "  "))
+		if golden.Update() {
+			golden.Set(t, buf.Bytes())
+		}
+		assert.Equal(t, string(golden.Get(t)), buf.String())
+
+		parsed := &SpecDiff{}
+		require.NoError(t, json.Unmarshal(got, parsed))
+		assert.Equal(t, diff, parsed)
+	})
+
+	t.Run("Text without changes", func(t *testing.T) {
+		diff := Diff(fixtureDiffBase(), fixtureDiffBase())
+
+		assert.True(t, diff.Empty())
+		assert.Equal(t, "No changes.\n", diff.Text())
+	})
+}
+
+// mutate modifies s with f, and returns it.
+func mutate(
+	s *VirtualMachineSpec,
+	f func(s *VirtualMachineSpec),
+) *VirtualMachineSpec {
+	f(s)
+
+	return s
+}
@@ -6,8 +6,8 @@ import (
 )
 
 type DiskIOProfile struct {
-	ID        string `json:"id,omitempty" yaml:"id,omitempty"`
-	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty"`
+	ID        string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty" hcl:"permalink,optional" toml:"permalink,omitempty"`
 }
 
 func (s *DiskIOProfile) MarshalXML(
@@ -32,7 +32,10 @@ func (s *DiskIOProfile) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlDiskIOProfile{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := DiskIOProfile{}
 
@@ -6,10 +6,10 @@ import (
 )
 
 type DiskTemplate struct {
-	ID        string                `json:"id,omitempty" yaml:"id,omitempty"`
-	Permalink string                `json:"permalink,omitempty" yaml:"permalink,omitempty"`
-	Version   int                   `json:"version,omitempty" yaml:"version,omitempty"`
-	Options   []*DiskTemplateOption `json:"options,omitempty" yaml:"options,omitempty"`
+	ID        string                `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Permalink string                `json:"permalink,omitempty" yaml:"permalink,omitempty" hcl:"permalink,optional" toml:"permalink,omitempty"`
+	Version   int                   `json:"version,omitempty" yaml:"version,omitempty" hcl:"version,optional" toml:"version,omitempty"`
+	Options   []*DiskTemplateOption `json:"options,omitempty" yaml:"options,omitempty" hcl:"option,block" toml:"options,omitempty"`
 }
 
 func (s *DiskTemplate) MarshalXML(
@@ -45,7 +45,10 @@ func (s *DiskTemplate) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlDiskTemplate{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := DiskTemplate{}
 
@@ -75,8 +78,8 @@ func (s *DiskTemplate) UnmarshalXML(
 }
 
 type DiskTemplateOption struct {
-	Key   string `xml:"key,attr" json:"key" yaml:"key"`
-	Value string `xml:",chardata" json:"value" yaml:"value"`
+	Key   string `xml:"key,attr" json:"key" yaml:"key" hcl:"key,optional" toml:"key"`
+	Value string `xml:",chardata" json:"value" yaml:"value" hcl:"value,optional" toml:"value"`
 }
 
 type xmlDiskTemplate struct {
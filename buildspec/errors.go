@@ -3,11 +3,174 @@ package buildspec
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
 	Err = errors.New("")
 
-	ErrParse    = fmt.Errorf("%wparse", Err)
-	ErrParseXML = fmt.Errorf("%w_xml", ErrParse)
+	ErrParse     = fmt.Errorf("%wparse", Err)
+	ErrParseXML  = fmt.Errorf("%w_xml", ErrParse)
+	ErrParseHCL  = fmt.Errorf("%w_hcl", ErrParse)
+	ErrParseTOML = fmt.Errorf("%w_toml", ErrParse)
+
+	ErrValidation = fmt.Errorf("%wvalidation", Err)
+
+	ErrTemplate          = fmt.Errorf("%wtemplate", Err)
+	ErrUndefinedVariable = fmt.Errorf("%w: undefined_variable", ErrTemplate)
 )
+
+// FieldError describes a single invalid field of a build spec.
+type FieldError struct {
+	// Field is the path to the invalid field, using JSON field names, for
+	// example "system_disks[1].size".
+	Field string
+
+	// Message describes what is wrong with the field.
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned when a build spec fails validation, and holds
+// every problem found. It matches ErrValidation with errors.Is, and each
+// *FieldError with errors.As.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrValidation, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors)+1)
+	errs = append(errs, ErrValidation)
+	for _, fe := range e.Errors {
+		errs = append(errs, fe)
+	}
+
+	return errs
+}
+
+// XMLError describes a problem found by FromXMLStrict, and where in the XML
+// document it occurred. It matches ErrParseXML with errors.Is.
+type XMLError struct {
+	// Line is the 1-based line of the offending element.
+	Line int
+
+	// Column is the 1-based column of the offending element.
+	Column int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *XMLError) Error() string {
+	msg := strings.TrimPrefix(e.Err.Error(), ErrParseXML.Error()+": ")
+
+	return fmt.Sprintf(
+		"%s: line %d, column %d: %s", ErrParseXML, e.Line, e.Column, msg,
+	)
+}
+
+func (e *XMLError) Unwrap() []error {
+	return []error{ErrParseXML, e.Err}
+}
+
+// HCLError describes a problem found by FromHCL, and where in the HCL
+// document it occurred. It matches ErrParseHCL with errors.Is.
+type HCLError struct {
+	// Line is the 1-based line of the offending attribute, block or value.
+	Line int
+
+	// Column is the 1-based column of the offending attribute, block or
+	// value.
+	Column int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *HCLError) Error() string {
+	return fmt.Sprintf(
+		"%s: line %d, column %d: %s", ErrParseHCL, e.Line, e.Column, e.Err,
+	)
+}
+
+func (e *HCLError) Unwrap() []error {
+	return []error{ErrParseHCL, e.Err}
+}
+
+// TOMLError describes a problem found by FromTOML, and where in the TOML
+// document it occurred when that is known. It matches ErrParseTOML with
+// errors.Is.
+type TOMLError struct {
+	// Line is the 1-based line of the syntax error, or 0 for problems
+	// found while decoding, such as unknown keys, which are described by
+	// Err instead.
+	Line int
+
+	// Column is the 1-based column of the syntax error, or 0.
+	Column int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TOMLError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", ErrParseTOML, e.Err)
+	}
+
+	return fmt.Sprintf(
+		"%s: line %d, column %d: %s", ErrParseTOML, e.Line, e.Column, e.Err,
+	)
+}
+
+func (e *TOMLError) Unwrap() []error {
+	return []error{ErrParseTOML, e.Err}
+}
+
+// TemplateError describes a problem found while rendering a Template, and
+// where in the source document or fragment it occurred. It matches
+// ErrTemplate with errors.Is.
+type TemplateError struct {
+	// Source is the path of the included fragment the problem was found in,
+	// or empty if it was found in the document being rendered.
+	Source string
+
+	// Line is the 1-based line of the offending expression.
+	Line int
+
+	// Column is the 1-based column of the offending expression.
+	Column int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *TemplateError) Error() string {
+	msg := strings.TrimPrefix(e.Err.Error(), ErrTemplate.Error()+": ")
+	if e.Source != "" {
+		return fmt.Sprintf(
+			"%s: %s: line %d, column %d: %s",
+			ErrTemplate, e.Source, e.Line, e.Column, msg,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s: line %d, column %d: %s", ErrTemplate, e.Line, e.Column, msg,
+	)
+}
+
+func (e *TemplateError) Unwrap() []error {
+	return []error{ErrTemplate, e.Err}
+}
@@ -23,3 +23,71 @@ func TestErrParseXML(t *testing.T) {
 	)
 	assert.True(t, errors.Is(ErrParseXML, Err), "ErrParseXML is not a Err")
 }
+
+func TestErrParseHCL(t *testing.T) {
+	assert.EqualError(t, ErrParseHCL, "parse_hcl")
+	assert.True(t, errors.Is(ErrParseHCL, ErrParse),
+		"ErrParseHCL is not a ErrParse",
+	)
+	assert.True(t, errors.Is(ErrParseHCL, Err), "ErrParseHCL is not a Err")
+}
+
+func TestErrParseTOML(t *testing.T) {
+	assert.EqualError(t, ErrParseTOML, "parse_toml")
+	assert.True(t, errors.Is(ErrParseTOML, ErrParse),
+		"ErrParseTOML is not a ErrParse",
+	)
+	assert.True(t, errors.Is(ErrParseTOML, Err), "ErrParseTOML is not a Err")
+}
+
+func TestHCLError(t *testing.T) {
+	cause := errors.New(`unknown field "rocket_fuel"`)
+	err := &HCLError{Line: 3, Column: 1, Err: cause}
+
+	assert.EqualError(t, err,
+		`parse_hcl: line 3, column 1: unknown field "rocket_fuel"`,
+	)
+	assert.ErrorIs(t, err, ErrParseHCL)
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestTOMLError(t *testing.T) {
+	cause := errors.New("strings cannot contain newlines")
+	err := &TOMLError{Line: 3, Column: 8, Err: cause}
+
+	assert.EqualError(t, err,
+		"parse_toml: line 3, column 8: strings cannot contain newlines",
+	)
+	assert.ErrorIs(t, err, ErrParseTOML)
+	assert.ErrorIs(t, err, cause)
+}
+
+func TestTOMLError_noPosition(t *testing.T) {
+	err := &TOMLError{Err: errors.New(`unknown field "rocket_fuel"`)}
+
+	assert.EqualError(t, err, `parse_toml: unknown field "rocket_fuel"`)
+	assert.ErrorIs(t, err, ErrParseTOML)
+}
+
+func TestErrValidation(t *testing.T) {
+	assert.EqualError(t, ErrValidation, "validation")
+	assert.True(t, errors.Is(ErrValidation, Err), "ErrValidation is not a Err")
+}
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{Errors: []*FieldError{
+		{Field: "resources", Message: "is required"},
+		{Field: "system_disks[1].size", Message: "must be greater than 0"},
+	}}
+
+	assert.EqualError(t, err,
+		"validation: resources: is required; "+
+			"system_disks[1].size: must be greater than 0",
+	)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.ErrorIs(t, err, Err)
+
+	var fieldErr *FieldError
+	assert.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "resources", fieldErr.Field)
+}
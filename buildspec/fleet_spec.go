@@ -0,0 +1,631 @@
+package buildspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/krystal/go-katapult/namegenerator"
+	"gopkg.in/yaml.v3"
+)
+
+// maxRandomHostnameAttempts is how many times a hostname pattern containing
+// {random} is expanded before giving up on finding an unused hostname.
+const maxRandomHostnameAttempts = 100
+
+var hostnamePlaceholder = regexp.MustCompile(`\{([a-z]+)(?::(\d+))?\}`)
+
+// FleetSpec describes a fleet of virtual machines built from a single base
+// spec. Like VirtualMachineSpec, it can be created manually, or parsed from
+// XML, JSON, or YAML, and can output itself as XML, JSON, and YAML.
+//
+// Use Expand to get the individual VirtualMachineSpec of every virtual
+// machine in the fleet.
+type FleetSpec struct {
+	// Base is the spec every virtual machine in the fleet is built from.
+	Base *VirtualMachineSpec `json:"base,omitempty" yaml:"base,omitempty"`
+
+	// Count is the number of virtual machines in the fleet. When there are
+	// more Instances than Count, one virtual machine is built per instance.
+	Count int `json:"count,omitempty" yaml:"count,omitempty"`
+
+	// HostnamePattern generates the hostname of each virtual machine which
+	// has no hostname set by its instance. It supports the placeholders:
+	//
+	//   - {index} the 1-based position of the virtual machine in the fleet.
+	//     A width can be given to zero-pad it, for example {index:2}.
+	//   - {random} a random name, such as "brave-otter", which is unique
+	//     within the fleet.
+	//
+	// When empty, the hostname of Base is used, and the API picks a random
+	// hostname if that is empty too.
+	HostnamePattern string `json:"hostname_pattern,omitempty" yaml:"hostname_pattern,omitempty"`
+
+	// Placements spreads virtual machines across zones or data centers in a
+	// round-robin fashion, overriding the zone and data center of Base.
+	Placements []*FleetPlacement `json:"placements,omitempty" yaml:"placements,omitempty"`
+
+	// Instances holds per-instance overrides of Base, applied in order to
+	// the first virtual machines in the fleet.
+	Instances []*FleetInstance `json:"instances,omitempty" yaml:"instances,omitempty"`
+
+	// RandomName returns the value of the {random} hostname placeholder.
+	// When nil, namegenerator.RandomName is used.
+	RandomName func() string `json:"-" yaml:"-"`
+}
+
+// FleetPlacement is a zone or data center virtual machines in a fleet can be
+// placed in.
+type FleetPlacement struct {
+	Zone       *Zone       `xml:",omitempty" json:"zone,omitempty" yaml:"zone,omitempty"`
+	DataCenter *DataCenter `xml:",omitempty" json:"data_center,omitempty" yaml:"data_center,omitempty"`
+}
+
+// FleetInstance overrides parts of a fleet's base spec for a single virtual
+// machine. Fields which are set replace those of the base spec, except for
+// Tags, which are added to the base spec's tags.
+type FleetInstance struct {
+	Hostname    string      `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Name        string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Zone        *Zone       `json:"zone,omitempty" yaml:"zone,omitempty"`
+	DataCenter  *DataCenter `json:"data_center,omitempty" yaml:"data_center,omitempty"`
+	Resources   *Resources  `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Group       *Group      `json:"group,omitempty" yaml:"group,omitempty"`
+	Tags        []string    `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// Size returns the number of virtual machines in the fleet.
+func (f *FleetSpec) Size() int {
+	if len(f.Instances) > f.Count {
+		return len(f.Instances)
+	}
+
+	return f.Count
+}
+
+// Validate checks the fleet for problems which would prevent it from being
+// expanded, and validates its base spec. It returns a *ValidationError
+// listing every problem found, or nil if the fleet is valid.
+func (f *FleetSpec) Validate() error {
+	v := &validator{}
+
+	if f.Count < 0 {
+		v.add("count", "must not be negative")
+	}
+	if f.Base == nil {
+		v.add("base", "is required")
+	}
+	f.validateHostnamePattern(v)
+
+	for i, p := range f.Placements {
+		field := index("placements", i)
+		switch {
+		case p.Zone == nil && p.DataCenter == nil:
+			v.add(field, "zone or data_center is required")
+		case p.Zone != nil && p.DataCenter != nil:
+			v.add(field, "zone and data_center are mutually exclusive")
+		}
+	}
+
+	hostnames := map[string]int{}
+	for i, inst := range f.Instances {
+		if inst.Hostname == "" {
+			continue
+		}
+		field := index("instances", i) + ".hostname"
+		if !validHostname(inst.Hostname) {
+			v.add(field, "%q is not a valid hostname", inst.Hostname)
+		}
+		if j, ok := hostnames[inst.Hostname]; ok {
+			v.add(field, "%q is also used by instances[%d]", inst.Hostname, j)
+		}
+		hostnames[inst.Hostname] = i
+	}
+
+	if v.err() != nil {
+		return v.err()
+	}
+
+	// Validate the spec of each instance, and of the base spec if any virtual
+	// machines have no instance, reporting problems against the fleet's
+	// fields.
+	if f.Size() > len(f.Instances) {
+		spec, err := f.instanceSpec(len(f.Instances), nil, "")
+		if err != nil {
+			return err
+		}
+		err = f.validateSpec(v, "base", spec)
+		if err != nil {
+			return err
+		}
+	}
+	for i, inst := range f.Instances {
+		spec, err := f.instanceSpec(i, inst, inst.Hostname)
+		if err != nil {
+			return err
+		}
+		err = f.validateSpec(v, index("instances", i), spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	return v.err()
+}
+
+func (f *FleetSpec) validateHostnamePattern(v *validator) {
+	if f.HostnamePattern == "" {
+		if f.Base != nil && f.Base.Hostname != "" &&
+			f.Size()-f.namedInstances() > 1 {
+			v.add("hostname_pattern",
+				"is required when base has a hostname and more than one "+
+					"virtual machine has no hostname",
+			)
+		}
+
+		return
+	}
+
+	unique := false
+	for _, m := range hostnamePlaceholder.FindAllStringSubmatch(
+		f.HostnamePattern, -1,
+	) {
+		switch {
+		case m[1] == "index" || m[1] == "random" && m[2] == "":
+			unique = true
+		default:
+			v.add("hostname_pattern", "unknown placeholder %q", m[0])
+
+			return
+		}
+	}
+	if !unique && f.Size()-f.namedInstances() > 1 {
+		v.add("hostname_pattern", "must contain {index} or {random}")
+	}
+
+	sample := expandHostnamePattern(f.HostnamePattern, 1, "brave-otter")
+	if !validHostname(sample) {
+		v.add("hostname_pattern",
+			"does not produce a valid hostname, for example %q", sample,
+		)
+	}
+}
+
+// validateSpec validates spec, adding its problems to v with their field
+// prefixed by prefix.
+func (f *FleetSpec) validateSpec(
+	v *validator,
+	prefix string,
+	spec *VirtualMachineSpec,
+) error {
+	if spec == nil {
+		return nil
+	}
+
+	err := spec.Validate()
+	if err == nil {
+		return nil
+	}
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	for _, fe := range verr.Errors {
+		v.add(prefix+"."+fe.Field, "%s", fe.Message)
+	}
+
+	return nil
+}
+
+func (f *FleetSpec) namedInstances() int {
+	n := 0
+	for _, inst := range f.Instances {
+		if inst.Hostname != "" {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Expand returns the spec of every virtual machine in the fleet, in order.
+// It returns a *ValidationError if the fleet is invalid.
+func (f *FleetSpec) Expand() ([]*VirtualMachineSpec, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	size := f.Size()
+	used := map[string]bool{}
+	for _, inst := range f.Instances {
+		if inst.Hostname != "" {
+			used[inst.Hostname] = true
+		}
+	}
+
+	specs := make([]*VirtualMachineSpec, 0, size)
+	for i := 0; i < size; i++ {
+		var inst *FleetInstance
+		if i < len(f.Instances) {
+			inst = f.Instances[i]
+		}
+
+		hostname := ""
+		switch {
+		case inst != nil && inst.Hostname != "":
+			hostname = inst.Hostname
+		case f.HostnamePattern != "":
+			var err error
+			hostname, err = f.hostname(i, used)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		spec, err := f.instanceSpec(i, inst, hostname)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// hostname expands HostnamePattern for the virtual machine at index i,
+// returning an error if it produces a hostname which is already used.
+func (f *FleetSpec) hostname(i int, used map[string]bool) (string, error) {
+	attempts := 1
+	if strings.Contains(f.HostnamePattern, "{random}") {
+		attempts = maxRandomHostnameAttempts
+	}
+
+	randomName := f.RandomName
+	if randomName == nil {
+		randomName = func() string { return namegenerator.RandomName() }
+	}
+
+	var hostname string
+	for n := 0; n < attempts; n++ {
+		hostname = expandHostnamePattern(f.HostnamePattern, i+1, randomName())
+		if !used[hostname] {
+			used[hostname] = true
+
+			return hostname, nil
+		}
+	}
+
+	return "", &ValidationError{Errors: []*FieldError{{
+		Field: "hostname_pattern",
+		Message: fmt.Sprintf(
+			"produced hostname %q for virtual machine %d, which is already "+
+				"in use", hostname, i+1,
+		),
+	}}}
+}
+
+// instanceSpec returns a copy of Base for the virtual machine at index i,
+// with its placement, hostname and instance overrides applied.
+func (f *FleetSpec) instanceSpec(
+	i int,
+	inst *FleetInstance,
+	hostname string,
+) (*VirtualMachineSpec, error) {
+	if f.Base == nil {
+		return nil, nil
+	}
+	s, err := f.Base.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(f.Placements) > 0 {
+		p := f.Placements[i%len(f.Placements)]
+		s.Zone, s.DataCenter = p.Zone.clone(), p.DataCenter.clone()
+	}
+	if hostname != "" {
+		s.Hostname = hostname
+	}
+	if inst == nil {
+		return s, nil
+	}
+
+	if inst.Name != "" {
+		s.Name = inst.Name
+	}
+	if inst.Description != "" {
+		s.Description = inst.Description
+	}
+	if inst.Zone != nil || inst.DataCenter != nil {
+		s.Zone, s.DataCenter = inst.Zone.clone(), inst.DataCenter.clone()
+	}
+	if inst.Resources != nil {
+		r := *inst.Resources
+		s.Resources = &r
+	}
+	if inst.Group != nil {
+		g := *inst.Group
+		s.Group = &g
+	}
+	for _, tag := range inst.Tags {
+		if !containsString(s.Tags, tag) {
+			s.Tags = append(s.Tags, tag)
+		}
+	}
+
+	return s, nil
+}
+
+// expandHostnamePattern replaces the placeholders in pattern.
+func expandHostnamePattern(pattern string, i int, random string) string {
+	replace := func(m string) string {
+		sub := hostnamePlaceholder.FindStringSubmatch(m)
+		switch sub[1] {
+		case "index":
+			width, _ := strconv.Atoi(sub[2])
+
+			return fmt.Sprintf("%0*d", width, i)
+		case "random":
+			return random
+		}
+
+		return m
+	}
+
+	return hostnamePlaceholder.ReplaceAllStringFunc(pattern, replace)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// clone returns a deep copy of the spec.
+func (s *VirtualMachineSpec) clone() (*VirtualMachineSpec, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone spec: %w", err)
+	}
+
+	c := &VirtualMachineSpec{}
+	err = json.Unmarshal(b, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone spec: %w", err)
+	}
+
+	return c, nil
+}
+
+func (s *Zone) clone() *Zone {
+	if s == nil {
+		return nil
+	}
+	c := *s
+
+	return &c
+}
+
+func (s *DataCenter) clone() *DataCenter {
+	if s == nil {
+		return nil
+	}
+	c := *s
+
+	return &c
+}
+
+// FleetFromJSON parses a JSON fleet spec document into a *FleetSpec object.
+func FleetFromJSON(r io.Reader) (*FleetSpec, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	fleet := &FleetSpec{}
+	err := dec.Decode(fleet)
+
+	return fleet, err
+}
+
+// FleetFromXML parses a XML fleet spec document into a *FleetSpec object.
+func FleetFromXML(r io.Reader) (*FleetSpec, error) {
+	dec := xml.NewDecoder(r)
+
+	fleet := &FleetSpec{}
+	err := dec.Decode(fleet)
+
+	return fleet, err
+}
+
+// FleetFromYAML parses a YAML fleet spec document into a *FleetSpec object.
+func FleetFromYAML(r io.Reader) (*FleetSpec, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	fleet := &FleetSpec{}
+	err := dec.Decode(fleet)
+
+	return fleet, err
+}
+
+// JSON returns the fleet spec in JSON format as a byte slice.
+func (f *FleetSpec) JSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := f.WriteJSON(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteJSON writes the fleet spec in JSON format to given io.Writer.
+func (f *FleetSpec) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	return enc.Encode(f)
+}
+
+// XML returns the fleet spec in XML format as a byte slice.
+func (f *FleetSpec) XML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := f.WriteXML(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteXML writes the fleet spec in XML format to given io.Writer.
+func (f *FleetSpec) WriteXML(w io.Writer) error {
+	_, err := w.Write([]byte(xml.Header))
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+
+	return enc.Encode(f)
+}
+
+// YAML returns the fleet spec in YAML format as a byte slice.
+func (f *FleetSpec) YAML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := f.WriteYAML(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteYAML writes the fleet spec in YAML format to given io.Writer.
+func (f *FleetSpec) WriteYAML(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+
+	return enc.Encode(f)
+}
+
+type xmlFleetSpec struct {
+	Base            *VirtualMachineSpec `xml:",omitempty"`
+	Count           int                 `xml:",omitempty"`
+	HostnamePattern string              `xml:",omitempty"`
+	Placements      *xmlFleetPlacements `xml:",omitempty"`
+	Instances       *xmlFleetInstances  `xml:",omitempty"`
+}
+
+type xmlFleetPlacements struct {
+	Placements []*FleetPlacement `xml:"Placement,omitempty"`
+}
+
+type xmlFleetInstances struct {
+	Instances []*FleetInstance `xml:"Instance,omitempty"`
+}
+
+func (f *FleetSpec) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	x := &xmlFleetSpec{
+		Base:            f.Base,
+		Count:           f.Count,
+		HostnamePattern: f.HostnamePattern,
+	}
+
+	if len(f.Placements) > 0 {
+		x.Placements = &xmlFleetPlacements{Placements: f.Placements}
+	}
+
+	if len(f.Instances) > 0 {
+		x.Instances = &xmlFleetInstances{Instances: f.Instances}
+	}
+
+	return e.EncodeElement(x, start)
+}
+
+func (f *FleetSpec) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	x := &xmlFleetSpec{}
+	err := d.DecodeElement(x, &start)
+	if err != nil {
+		return err
+	}
+
+	v := FleetSpec{
+		Base:            x.Base,
+		Count:           x.Count,
+		HostnamePattern: x.HostnamePattern,
+	}
+
+	if x.Placements != nil {
+		v.Placements = x.Placements.Placements
+	}
+
+	if x.Instances != nil {
+		v.Instances = x.Instances.Instances
+	}
+
+	*f = v
+
+	return nil
+}
+
+type xmlFleetInstance struct {
+	Hostname    string      `xml:",omitempty"`
+	Name        string      `xml:",omitempty"`
+	Description string      `xml:",omitempty"`
+	Zone        *Zone       `xml:",omitempty"`
+	DataCenter  *DataCenter `xml:",omitempty"`
+	Resources   *Resources  `xml:",omitempty"`
+	Group       *Group      `xml:",omitempty"`
+	Tags        *xmlTags    `xml:",omitempty"`
+}
+
+func (s *FleetInstance) MarshalXML(
+	e *xml.Encoder,
+	start xml.StartElement,
+) error {
+	x := &xmlFleetInstance{
+		Hostname:    s.Hostname,
+		Name:        s.Name,
+		Description: s.Description,
+		Zone:        s.Zone,
+		DataCenter:  s.DataCenter,
+		Resources:   s.Resources,
+		Group:       s.Group,
+	}
+
+	if len(s.Tags) > 0 {
+		x.Tags = &xmlTags{Tags: s.Tags}
+	}
+
+	return e.EncodeElement(x, start)
+}
+
+func (s *FleetInstance) UnmarshalXML(
+	d *xml.Decoder,
+	start xml.StartElement,
+) error {
+	x := &xmlFleetInstance{}
+	err := d.DecodeElement(x, &start)
+	if err != nil {
+		return err
+	}
+
+	v := FleetInstance{
+		Hostname:    x.Hostname,
+		Name:        x.Name,
+		Description: x.Description,
+		Zone:        x.Zone,
+		DataCenter:  x.DataCenter,
+		Resources:   x.Resources,
+		Group:       x.Group,
+	}
+
+	if x.Tags != nil {
+		v.Tags = x.Tags.Tags
+	}
+
+	*s = v
+
+	return nil
+}
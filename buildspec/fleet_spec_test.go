@@ -0,0 +1,461 @@
+package buildspec
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rock3 = &Resources{Package: &Package{Permalink: "rock-3"}}
+
+var fixtureFleetSpecFull = &FleetSpec{
+	Base: &VirtualMachineSpec{
+		DataCenter: &DataCenter{Permalink: "uk-lon-01"},
+		Resources: &Resources{
+			Package: &Package{Permalink: "rock-3"},
+		},
+		DiskTemplate: &DiskTemplate{
+			Permalink: "templates/ubuntu-22-04",
+		},
+		Tags: []string{"web"},
+	},
+	Count:           3,
+	HostnamePattern: "web-{index:2}",
+	Placements: []*FleetPlacement{
+		{Zone: &Zone{Permalink: "uk-lon-01a"}},
+		{Zone: &Zone{Permalink: "uk-lon-01b"}},
+	},
+	Instances: []*FleetInstance{
+		{
+			Hostname:    "web-primary",
+			Name:        "Primary",
+			Description: "Primary web server",
+			DataCenter:  &DataCenter{Permalink: "nl-ams-01"},
+			Resources: &Resources{
+				Package: &Package{Permalink: "rock-6"},
+			},
+			Group: &Group{Name: "Primary"},
+			Tags:  []string{"primary"},
+		},
+	},
+}
+
+func TestFleetSpec_Marshaling(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *FleetSpec
+	}{
+		{
+			name: "empty",
+			obj:  &FleetSpec{},
+		},
+		{
+			name: "full",
+			obj:  fixtureFleetSpecFull,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("json_"+tt.name, func(t *testing.T) {
+			testJSONMarshaling(t, tt.obj)
+		})
+		t.Run("xml_"+tt.name, func(t *testing.T) {
+			testXMLMarshaling(t, tt.obj)
+		})
+		t.Run("yaml_"+tt.name, func(t *testing.T) {
+			testYAMLMarshaling(t, tt.obj)
+		})
+	}
+}
+
+func TestFleetSpec_ToFrom(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(f *FleetSpec) ([]byte, error)
+		read  func(b []byte) (*FleetSpec, error)
+	}{
+		{
+			name:  "json",
+			write: (*FleetSpec).JSON,
+			read: func(b []byte) (*FleetSpec, error) {
+				return FleetFromJSON(bytes.NewReader(b))
+			},
+		},
+		{
+			name:  "xml",
+			write: (*FleetSpec).XML,
+			read: func(b []byte) (*FleetSpec, error) {
+				return FleetFromXML(bytes.NewReader(b))
+			},
+		},
+		{
+			name:  "yaml",
+			write: (*FleetSpec).YAML,
+			read: func(b []byte) (*FleetSpec, error) {
+				return FleetFromYAML(bytes.NewReader(b))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.write(fixtureFleetSpecFull)
+			require.NoError(t, err)
+
+			got, err := tt.read(b)
+			require.NoError(t, err)
+
+			assert.Equal(t, fixtureFleetSpecFull, got)
+		})
+	}
+}
+
+func TestFleetSpec_Expand(t *testing.T) {
+	names := []string{"brave-otter", "brave-otter", "calm-heron", "quick-fox"}
+	tests := []struct {
+		name  string
+		fleet *FleetSpec
+		want  []*VirtualMachineSpec
+	}{
+		{
+			name: "count with index pattern and placements",
+			fleet: &FleetSpec{
+				Base: &VirtualMachineSpec{
+					Resources:  rock3,
+					DataCenter: &DataCenter{Permalink: "uk-lon-01"},
+					Tags:       []string{"web"},
+				},
+				Count:           3,
+				HostnamePattern: "web-{index:2}",
+				Placements: []*FleetPlacement{
+					{Zone: &Zone{Permalink: "uk-lon-01a"}},
+					{Zone: &Zone{Permalink: "uk-lon-01b"}},
+				},
+			},
+			want: []*VirtualMachineSpec{
+				{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "web-01",
+					Tags:      []string{"web"},
+				},
+				{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01b"},
+					Hostname:  "web-02",
+					Tags:      []string{"web"},
+				},
+				{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "web-03",
+					Tags:      []string{"web"},
+				},
+			},
+		},
+		{
+			name: "instances override base",
+			fleet: &FleetSpec{
+				Base: &VirtualMachineSpec{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "web",
+					Tags:      []string{"web"},
+				},
+				Instances: []*FleetInstance{
+					{
+						Name:       "Amsterdam",
+						DataCenter: &DataCenter{Permalink: "nl-ams-01"},
+						Resources: &Resources{
+							Package: &Package{Permalink: "rock-6"},
+						},
+						Group: &Group{Name: "Primary"},
+						Tags:  []string{"web", "primary"},
+					},
+				},
+			},
+			want: []*VirtualMachineSpec{
+				{
+					DataCenter: &DataCenter{Permalink: "nl-ams-01"},
+					Resources: &Resources{
+						Package: &Package{Permalink: "rock-6"},
+					},
+					Hostname: "web",
+					Name:     "Amsterdam",
+					Group:    &Group{Name: "Primary"},
+					Tags:     []string{"web", "primary"},
+				},
+			},
+		},
+		{
+			name: "random pattern skips used hostnames",
+			fleet: &FleetSpec{
+				Base: &VirtualMachineSpec{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+				},
+				Count:           3,
+				HostnamePattern: "app-{random}",
+				Instances: []*FleetInstance{
+					{Hostname: "app-calm-heron"},
+				},
+			},
+			want: []*VirtualMachineSpec{
+				{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "app-calm-heron",
+				},
+				{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "app-brave-otter",
+				},
+				{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "app-quick-fox",
+				},
+			},
+		},
+		{
+			name: "no hostnames",
+			fleet: &FleetSpec{
+				Base: &VirtualMachineSpec{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+				},
+				Count: 2,
+			},
+			want: []*VirtualMachineSpec{
+				{Zone: &Zone{Permalink: "uk-lon-01a"}, Resources: rock3},
+				{Zone: &Zone{Permalink: "uk-lon-01a"}, Resources: rock3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := 0
+			tt.fleet.RandomName = func() string {
+				name := names[i%len(names)]
+				i++
+
+				return name
+			}
+
+			got, err := tt.fleet.Expand()
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFleetSpec_Expand_doesNotModifyBase(t *testing.T) {
+	fleet := &FleetSpec{
+		Base: &VirtualMachineSpec{
+			Resources: rock3,
+			Zone:      &Zone{Permalink: "uk-lon-01a"},
+			Tags:      []string{"web"},
+		},
+		Count:           2,
+		HostnamePattern: "web-{index}",
+		Instances:       []*FleetInstance{{Tags: []string{"primary"}}},
+	}
+
+	specs, err := fleet.Expand()
+	require.NoError(t, err)
+	specs[1].Zone.Permalink = "changed"
+
+	assert.Equal(t, &VirtualMachineSpec{
+		Resources: rock3,
+		Zone:      &Zone{Permalink: "uk-lon-01a"},
+		Tags:      []string{"web"},
+	}, fleet.Base)
+	assert.Equal(t, []string{"web", "primary"}, specs[0].Tags)
+	assert.Equal(t, []string{"web"}, specs[1].Tags)
+}
+
+func TestFleetSpec_Validate(t *testing.T) {
+	base := &VirtualMachineSpec{
+		Zone:      &Zone{Permalink: "uk-lon-01a"},
+		Resources: rock3,
+	}
+
+	tests := []struct {
+		name  string
+		fleet *FleetSpec
+		want  []*FieldError
+	}{
+		{
+			name:  "valid",
+			fleet: &FleetSpec{Base: base, Count: 3},
+		},
+		{
+			name:  "missing base",
+			fleet: &FleetSpec{Count: 1},
+			want:  []*FieldError{{Field: "base", Message: "is required"}},
+		},
+		{
+			name:  "negative count",
+			fleet: &FleetSpec{Base: base, Count: -1},
+			want: []*FieldError{
+				{Field: "count", Message: "must not be negative"},
+			},
+		},
+		{
+			name: "base hostname without pattern",
+			fleet: &FleetSpec{
+				Base: &VirtualMachineSpec{
+					Resources: rock3,
+					Zone:      &Zone{Permalink: "uk-lon-01a"},
+					Hostname:  "web",
+				},
+				Count: 2,
+			},
+			want: []*FieldError{
+				{
+					Field: "hostname_pattern",
+					Message: "is required when base has a hostname and " +
+						"more than one virtual machine has no hostname",
+				},
+			},
+		},
+		{
+			name: "pattern without unique placeholder",
+			fleet: &FleetSpec{
+				Base:            base,
+				Count:           2,
+				HostnamePattern: "web",
+			},
+			want: []*FieldError{
+				{
+					Field:   "hostname_pattern",
+					Message: "must contain {index} or {random}",
+				},
+			},
+		},
+		{
+			name: "pattern with unknown placeholder",
+			fleet: &FleetSpec{
+				Base:            base,
+				Count:           2,
+				HostnamePattern: "web-{zone}",
+			},
+			want: []*FieldError{
+				{
+					Field:   "hostname_pattern",
+					Message: `unknown placeholder "{zone}"`,
+				},
+			},
+		},
+		{
+			name: "pattern producing invalid hostnames",
+			fleet: &FleetSpec{
+				Base:            base,
+				Count:           2,
+				HostnamePattern: "web_{index}",
+			},
+			want: []*FieldError{
+				{
+					Field: "hostname_pattern",
+					Message: "does not produce a valid hostname, for " +
+						`example "web_1"`,
+				},
+			},
+		},
+		{
+			name: "invalid placements",
+			fleet: &FleetSpec{
+				Base:  base,
+				Count: 1,
+				Placements: []*FleetPlacement{
+					{},
+					{
+						Zone:       &Zone{ID: "zone_1"},
+						DataCenter: &DataCenter{ID: "dc_1"},
+					},
+				},
+			},
+			want: []*FieldError{
+				{
+					Field:   "placements[0]",
+					Message: "zone or data_center is required",
+				},
+				{
+					Field:   "placements[1]",
+					Message: "zone and data_center are mutually exclusive",
+				},
+			},
+		},
+		{
+			name: "duplicate instance hostnames",
+			fleet: &FleetSpec{
+				Base: base,
+				Instances: []*FleetInstance{
+					{Hostname: "web-1"},
+					{Hostname: "web-1"},
+				},
+			},
+			want: []*FieldError{
+				{
+					Field:   "instances[1].hostname",
+					Message: `"web-1" is also used by instances[0]`,
+				},
+			},
+		},
+		{
+			name: "invalid base and instance specs",
+			fleet: &FleetSpec{
+				Base:  &VirtualMachineSpec{Resources: rock3},
+				Count: 2,
+				Instances: []*FleetInstance{
+					{Zone: &Zone{Permalink: "uk-lon-01a"}},
+				},
+			},
+			want: []*FieldError{
+				{
+					Field:   "base.zone",
+					Message: "zone or data_center is required",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fleet.Validate()
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrValidation)
+			var verr *ValidationError
+			require.True(t, errors.As(err, &verr))
+			assert.Equal(t, tt.want, verr.Errors)
+		})
+	}
+}
+
+func TestFleetSpec_Expand_hostnameCollision(t *testing.T) {
+	fleet := &FleetSpec{
+		Base: &VirtualMachineSpec{
+			Zone:      &Zone{ID: "zone_1"},
+			Resources: rock3,
+		},
+		Count:           2,
+		HostnamePattern: "{random}",
+		RandomName:      func() string { return "brave-otter" },
+	}
+
+	_, err := fleet.Expand()
+
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.EqualError(t, err, "validation: hostname_pattern: produced "+
+		`hostname "brave-otter" for virtual machine 2, which is already `+
+		"in use",
+	)
+}
@@ -0,0 +1,127 @@
+package buildspec
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// writeHCL writes the fields of v, a pointer to a struct with hcl struct
+// tags, as a HCL body.
+func writeHCL(w io.Writer, v any) error {
+	f := hclwrite.NewEmptyFile()
+	err := encodeHCLBody(f.Body(), reflect.ValueOf(v).Elem())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(hclwrite.Format(f.Bytes()))
+
+	return err
+}
+
+// encodeHCLBody writes the fields of the struct v which are not empty to
+// body, attributes first and followed by blocks, as terraform fmt expects.
+func encodeHCLBody(body *hclwrite.Body, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, kind, _ := strings.Cut(t.Field(i).Tag.Get("hcl"), ",")
+		f := v.Field(i)
+		if name == "" || kind == "block" || f.IsZero() {
+			continue
+		}
+
+		ty, err := gocty.ImpliedType(f.Interface())
+		if err != nil {
+			return err
+		}
+		val, err := gocty.ToCtyValue(f.Interface(), ty)
+		if err != nil {
+			return err
+		}
+		body.SetAttributeValue(name, val)
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		name, kind, _ := strings.Cut(t.Field(i).Tag.Get("hcl"), ",")
+		if kind != "block" {
+			continue
+		}
+
+		f := v.Field(i)
+		elems := []reflect.Value{f}
+		if f.Kind() == reflect.Slice {
+			elems = make([]reflect.Value, f.Len())
+			for j := range elems {
+				elems[j] = f.Index(j)
+			}
+		}
+
+		for _, elem := range elems {
+			if elem.IsNil() {
+				continue
+			}
+			if len(body.Attributes())+len(body.Blocks()) > 0 {
+				body.AppendNewline()
+			}
+
+			block := body.AppendNewBlock(name, nil)
+			if err := encodeHCLBody(block.Body(), elem.Elem()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readHCL parses the HCL document in r into v, a pointer to a struct with
+// hcl struct tags.
+func readHCL(r io.Reader, v any) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	f, diags := hclsyntax.ParseConfig(src, "", hcl.InitialPos)
+	if !diags.HasErrors() {
+		diags = gohcl.DecodeBody(f.Body, nil, v)
+	}
+
+	return hclError(diags)
+}
+
+// hclError returns the error in diags which occurs first in the document as
+// a *HCLError, or nil if diags has no errors.
+func hclError(diags hcl.Diagnostics) error {
+	var first *hcl.Diagnostic
+	for _, d := range diags {
+		if d.Severity != hcl.DiagError {
+			continue
+		}
+		if first == nil || first.Subject == nil {
+			first = d
+		} else if d.Subject != nil &&
+			d.Subject.Start.Byte < first.Subject.Start.Byte {
+			first = d
+		}
+	}
+	if first == nil {
+		return nil
+	}
+
+	err := &HCLError{Err: fmt.Errorf("%s; %s", first.Summary, first.Detail)}
+	if first.Subject != nil {
+		err.Line = first.Subject.Start.Line
+		err.Column = first.Subject.Start.Column
+	}
+
+	return err
+}
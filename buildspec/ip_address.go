@@ -6,8 +6,8 @@ import (
 )
 
 type IPAddress struct {
-	ID      string `json:"id,omitempty" yaml:"id,omitempty"`
-	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+	ID      string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Address string `json:"address,omitempty" yaml:"address,omitempty" hcl:"address,optional" toml:"address,omitempty"`
 }
 
 func (s *IPAddress) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -26,7 +26,10 @@ func (s *IPAddress) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 func (s *IPAddress) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	x := &xmlIPAddress{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := IPAddress{}
 
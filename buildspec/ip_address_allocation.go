@@ -8,8 +8,8 @@ const (
 )
 
 type IPAddressAllocation struct {
-	Type      IPAddressAllocationType `xml:"type,attr,omitempty" json:"type,omitempty" yaml:"type,omitempty"`
-	IPAddress *IPAddress              `xml:",omitempty" json:"ip_address,omitempty" yaml:"ip_address,omitempty"`
-	Version   IPVersion               `xml:",omitempty" json:"version,omitempty" yaml:"version,omitempty"`
-	Subnet    *Subnet                 `xml:",omitempty" json:"subnet,omitempty" yaml:"subnet,omitempty"`
+	Type      IPAddressAllocationType `xml:"type,attr,omitempty" json:"type,omitempty" yaml:"type,omitempty" hcl:"type,optional" toml:"type,omitempty"`
+	IPAddress *IPAddress              `xml:",omitempty" json:"ip_address,omitempty" yaml:"ip_address,omitempty" hcl:"ip_address,block" toml:"ip_address,omitempty"`
+	Version   IPVersion               `xml:",omitempty" json:"version,omitempty" yaml:"version,omitempty" hcl:"version,optional" toml:"version,omitempty"`
+	Subnet    *Subnet                 `xml:",omitempty" json:"subnet,omitempty" yaml:"subnet,omitempty" hcl:"subnet,block" toml:"subnet,omitempty"`
 }
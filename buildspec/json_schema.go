@@ -0,0 +1,364 @@
+package buildspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JSONSchemaDialect is the JSON Schema draft the schema returned by
+// JSONSchema is written in.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaEnums lists the values allowed for types with a fixed set of
+// values.
+var jsonSchemaEnums = map[reflect.Type][]any{
+	reflect.TypeOf(ScheduleInterval("")): {
+		string(ScheduledHourly),
+		string(ScheduledDaily),
+		string(ScheduledWeekly),
+		string(ScheduledMonthly),
+	},
+	reflect.TypeOf(IPAddressAllocationType("")): {
+		string(NewIPAddressAllocation),
+		string(ExistingIPAddressAllocation),
+	},
+	reflect.TypeOf(IPVersion(0)): {int(IPv4), int(IPv6)},
+}
+
+// jsonSchemaBounds holds the minimum and maximum of numeric fields, keyed by
+// type name and JSON field name. A nil bound is not enforced.
+var jsonSchemaBounds = map[string][2]*int{
+	"Resources.memory":       {intPtr(1), nil},
+	"Resources.cpu_cores":    {intPtr(1), nil},
+	"DiskTemplate.version":   {intPtr(0), nil},
+	"SystemDisk.size":        {intPtr(1), nil},
+	"BackupPolicy.retention": {intPtr(1), nil},
+	"Schedule.frequency":     {intPtr(1), nil},
+	"Schedule.time":          {intPtr(0), intPtr(23)},
+}
+
+// jsonSchema is a JSON Schema, limited to the keywords needed to describe
+// build specs.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []any                  `json:"enum,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Maximum              *int                   `json:"maximum,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// JSONSchema returns a JSON Schema describing JSON and YAML build spec
+// documents, for use by editors and linters.
+//
+// The schema describes the structure of a spec, including the values
+// allowed for enums such as backup schedule intervals and IP versions. Rules
+// which span several fields, such as requiring exactly one of a reference's
+// id or permalink, are left to Validate.
+func JSONSchema() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := WriteJSONSchema(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteJSONSchema writes the JSON Schema returned by JSONSchema to given
+// io.Writer.
+func WriteJSONSchema(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(newJSONSchema())
+}
+
+// ValidateJSONSchema checks a JSON or YAML build spec document read from r
+// against the schema returned by JSONSchema. It returns a *ValidationError
+// listing every problem found, or nil if the document is valid.
+//
+// Unlike FromJSON and FromYAML, every problem in the document is reported,
+// rather than only the first.
+func ValidateJSONSchema(r io.Reader) error {
+	// YAML is a superset of JSON, so both are decoded as YAML.
+	var doc any
+	err := yaml.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return err
+	}
+	if _, ok := jsonSchemaObject(doc); !ok {
+		return fmt.Errorf("%w: document is not an object", ErrParse)
+	}
+
+	s := newJSONSchema()
+	sv := &schemaValidator{defs: s.Defs, v: &validator{}}
+	sv.check("", s, doc)
+
+	return sv.v.err()
+}
+
+func newJSONSchema() *jsonSchema {
+	g := &schemaGenerator{defs: map[string]*jsonSchema{}}
+
+	s := g.object(reflect.TypeOf(VirtualMachineSpec{}))
+	s.Schema = JSONSchemaDialect
+	s.Title = "Katapult virtual machine build spec"
+	s.Defs = g.defs
+
+	return s
+}
+
+// schemaGenerator builds a JSON Schema from the json struct tags of Go
+// types. Structs and enums are added to defs and referenced by name.
+type schemaGenerator struct {
+	defs map[string]*jsonSchema
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) *jsonSchema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if enum, ok := jsonSchemaEnums[t]; ok {
+		if _, ok := g.defs[t.Name()]; !ok {
+			g.defs[t.Name()] = &jsonSchema{
+				Type: jsonSchemaType(t.Kind()),
+				Enum: enum,
+			}
+		}
+
+		return &jsonSchema{Ref: "#/$defs/" + t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, ok := g.defs[t.Name()]; !ok {
+			// Reserve the name first, in case the type refers to itself.
+			g.defs[t.Name()] = nil
+			g.defs[t.Name()] = g.object(t)
+		}
+
+		return &jsonSchema{Ref: "#/$defs/" + t.Name()}
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: g.schema(t.Elem())}
+	}
+
+	return &jsonSchema{Type: jsonSchemaType(t.Kind())}
+}
+
+func (g *schemaGenerator) object(t reflect.Type) *jsonSchema {
+	s := &jsonSchema{
+		Type:                 "object",
+		Properties:           map[string]*jsonSchema{},
+		AdditionalProperties: new(bool),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		p := g.schema(f.Type)
+		if b, ok := jsonSchemaBounds[t.Name()+"."+name]; ok {
+			p.Minimum, p.Maximum = b[0], b[1]
+		}
+		s.Properties[name] = p
+	}
+
+	return s
+}
+
+func jsonSchemaType(k reflect.Kind) string {
+	switch k {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+
+	return "string"
+}
+
+// schemaValidator checks decoded JSON or YAML documents against a schema
+// built by schemaGenerator.
+type schemaValidator struct {
+	defs map[string]*jsonSchema
+	v    *validator
+}
+
+func (sv *schemaValidator) check(field string, s *jsonSchema, value any) {
+	if s.Ref != "" {
+		s = sv.defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := jsonSchemaObject(value)
+		if !ok {
+			sv.v.add(field, "must be an object")
+
+			return
+		}
+
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			p, ok := s.Properties[k]
+			if !ok {
+				sv.v.add(jsonSchemaField(field, k), "is not a known field")
+
+				continue
+			}
+			sv.check(jsonSchemaField(field, k), p, obj[k])
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			sv.v.add(field, "must be an array")
+
+			return
+		}
+
+		for i, item := range items {
+			sv.check(index(field, i), s.Items, item)
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			sv.v.add(field, "must be a string")
+
+			return
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			sv.v.add(field, "must be a boolean")
+
+			return
+		}
+	case "integer":
+		n, ok := jsonSchemaInt(value)
+		if !ok {
+			sv.v.add(field, "must be an integer")
+
+			return
+		}
+
+		if s.Minimum != nil && n < *s.Minimum {
+			sv.v.add(field, "must be at least %d", *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			sv.v.add(field, "must be at most %d", *s.Maximum)
+		}
+		value = n
+	}
+
+	if len(s.Enum) > 0 && !jsonSchemaInEnum(s.Enum, value) {
+		sv.v.add(field, "%s is not one of %s",
+			jsonSchemaValue(value), jsonSchemaValues(s.Enum),
+		)
+	}
+}
+
+// jsonSchemaObject returns value as a map if it is an object. YAML mappings
+// with keys other than strings have their keys formatted as strings.
+func jsonSchemaObject(value any) (map[string]any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		return v, true
+	case map[any]any:
+		obj := make(map[string]any, len(v))
+		for k, item := range v {
+			obj[fmt.Sprint(k)] = item
+		}
+
+		return obj, true
+	}
+
+	return nil, false
+}
+
+// jsonSchemaInt returns value as an int if it is a whole number.
+func jsonSchemaInt(value any) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case uint64:
+		if v <= math.MaxInt {
+			return int(v), true
+		}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+			return int(v), true
+		}
+	}
+
+	return 0, false
+}
+
+func jsonSchemaInEnum(enum []any, value any) bool {
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonSchemaField(field, name string) string {
+	if field == "" {
+		return name
+	}
+
+	return field + "." + name
+}
+
+func jsonSchemaValue(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// jsonSchemaValues formats enum values as a list, such as `"a", "b" or "c"`.
+func jsonSchemaValues(values []any) string {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		s = append(s, jsonSchemaValue(v))
+	}
+	if len(s) == 1 {
+		return s[0]
+	}
+
+	return strings.Join(s[:len(s)-1], ", ") + " or " + s[len(s)-1]
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,239 @@
+package buildspec
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/undent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	got, err := JSONSchema()
+	require.NoError(t, err)
+
+	t.Run("matches checked-in schema", func(t *testing.T) {
+		want, err := os.ReadFile(filepath.Join(
+			"..", "schemas", "buildspec", "virtual_machine_spec.json",
+		))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(want), string(got),
+			"schema is out of date, run: go generate ./schemas",
+		)
+	})
+
+	t.Run("enums", func(t *testing.T) {
+		s := &jsonSchema{}
+		require.NoError(t, json.Unmarshal(got, s))
+
+		assert.Equal(t,
+			[]any{"hourly", "daily", "weekly", "monthly"},
+			s.Defs["ScheduleInterval"].Enum,
+		)
+		assert.Equal(t,
+			[]any{"new", "existing"}, s.Defs["IPAddressAllocationType"].Enum,
+		)
+		assert.Equal(t, []any{4.0, 6.0}, s.Defs["IPVersion"].Enum)
+		assert.Equal(t, "integer", s.Defs["IPVersion"].Type)
+		assert.Equal(t,
+			"#/$defs/IPVersion",
+			s.Defs["IPAddressAllocation"].Properties["version"].Ref,
+		)
+	})
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "empty object",
+			doc:  "{}",
+		},
+		{
+			name: "valid JSON",
+			doc: undent.String(`
+				{
+				  "zone": {"permalink": "uk-lon-01a"},
+				  "resources": {"memory": 8, "cpu_cores": 4},
+				  "system_disks": [{"name": "Boot", "size": 20}],
+				  "network_interfaces": [
+				    {
+				      "network": {"permalink": "public"},
+				      "ip_address_allocations": [
+				        {"type": "new", "version": 6}
+				      ]
+				    }
+				  ],
+				  "authorized_keys": {"all_users": true},
+				  "backup_policies": [
+				    {
+				      "retention": 7,
+				      "schedule": {"interval": "daily", "time": 23}
+				    }
+				  ],
+				  "tags": ["web"]
+				}`,
+			),
+		},
+		{
+			name: "valid YAML",
+			doc: undent.String(`
+				zone:
+				  permalink: uk-lon-01a
+				resources:
+				  package:
+				    permalink: rock-3
+				network_interfaces:
+				  - ip_address_allocations:
+				      - type: existing
+				        ip_address:
+				          address: 203.0.113.10
+				backup_policies:
+				  - retention: 4
+				    schedule:
+				      interval: weekly
+				      frequency: 2`,
+			),
+		},
+		{
+			name: "unknown fields",
+			doc: undent.String(`
+				{
+				  "zone": {"permalink": "uk-lon-01a", "name": "London"},
+				  "memory": 8
+				}`,
+			),
+			want: []string{
+				"memory: is not a known field",
+				"zone.name: is not a known field",
+			},
+		},
+		{
+			name: "wrong types",
+			doc: undent.String(`
+				hostname: 42
+				resources:
+				  memory: "8"
+				  cpu_cores: 1.5
+				system_disks:
+				  name: Boot
+				authorized_keys:
+				  all_users: "yes"
+				tags: [web, [db]]`,
+			),
+			want: []string{
+				"authorized_keys.all_users: must be a boolean",
+				"hostname: must be a string",
+				"resources.cpu_cores: must be an integer",
+				"resources.memory: must be an integer",
+				"system_disks: must be an array",
+				"tags[1]: must be a string",
+			},
+		},
+		{
+			name: "enums",
+			doc: undent.String(`
+				network_interfaces:
+				  - ip_address_allocations:
+				      - type: reserved
+				        version: 5
+				backup_policies:
+				  - schedule:
+				      interval: fortnightly`,
+			),
+			want: []string{
+				`backup_policies[0].schedule.interval: "fortnightly" ` +
+					`is not one of "hourly", "daily", "weekly" or "monthly"`,
+				"network_interfaces[0].ip_address_allocations[0].type: " +
+					`"reserved" is not one of "new" or "existing"`,
+				"network_interfaces[0].ip_address_allocations[0].version: " +
+					"5 is not one of 4 or 6",
+			},
+		},
+		{
+			name: "bounds",
+			doc: undent.String(`
+				{
+				  "system_disks": [{"size": 0}],
+				  "backup_policies": [
+				    {"retention": 0, "schedule": {"frequency": 0, "time": 24}}
+				  ]
+				}`,
+			),
+			want: []string{
+				"backup_policies[0].retention: must be at least 1",
+				"backup_policies[0].schedule.frequency: must be at least 1",
+				"backup_policies[0].schedule.time: must be at most 23",
+				"system_disks[0].size: must be at least 1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateJSONSchema(strings.NewReader(tt.doc))
+
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			got := make([]string, 0, len(verr.Errors))
+			for _, fe := range verr.Errors {
+				got = append(got, fe.Error())
+			}
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestValidateJSONSchema_fixtures(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{
+		"TestVirtualMachineSpec_ToFromJSON/*.golden",
+		"TestVirtualMachineSpec_ToFromYAML/*.golden",
+		"TestVirtualMachineSpec_Marshaling/json_*.golden",
+		"TestVirtualMachineSpec_Marshaling/yaml_*.golden",
+	} {
+		matches, err := filepath.Glob(filepath.Join("testdata", pattern))
+		require.NoError(t, err)
+		files = append(files, matches...)
+	}
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			f, err := os.Open(file)
+			require.NoError(t, err)
+			defer f.Close()
+
+			assert.NoError(t, ValidateJSONSchema(f))
+		})
+	}
+}
+
+func TestValidateJSONSchema_errors(t *testing.T) {
+	t.Run("not an object", func(t *testing.T) {
+		err := ValidateJSONSchema(strings.NewReader(`["zone"]`))
+
+		assert.ErrorIs(t, err, ErrParse)
+		assert.EqualError(t, err, "parse: document is not an object")
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		err := ValidateJSONSchema(strings.NewReader(`{"zone": `))
+
+		assert.Error(t, err)
+	})
+}
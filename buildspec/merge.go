@@ -0,0 +1,367 @@
+package buildspec
+
+import "strings"
+
+// DeleteMarker is used in an overlay passed to Merge to remove values from
+// the base spec. See Merge for where it can be used.
+const DeleteMarker = "$delete"
+
+// deletePrefix prefixes the key of a list item in an overlay to remove the
+// base item with that key.
+const deletePrefix = DeleteMarker + ":"
+
+// MergeOptions controls how Merge combines lists without keys.
+type MergeOptions struct {
+	// AppendNetworkInterfaces appends the overlay's network interfaces to
+	// those of the base, instead of replacing them.
+	AppendNetworkInterfaces bool
+
+	// AppendBackupPolicies appends the overlay's backup policies to those of
+	// the base, instead of replacing them. It applies to the backup policies
+	// of the virtual machine and of each system disk.
+	AppendBackupPolicies bool
+}
+
+// Merge returns a new spec with overlay layered on top of base. Neither base
+// nor overlay are modified, and opts may be nil.
+//
+// Values are merged as follows:
+//
+//   - Strings, numbers and booleans set in overlay replace those of base. A
+//     string of DeleteMarker clears the value. Numbers and booleans cannot
+//     be cleared.
+//   - References to other resources, such as zone, group or a disk's IO
+//     profile, are replaced as a whole. A reference with an ID of
+//     DeleteMarker removes it. Setting zone removes data_center and vice
+//     versa, and setting a package in resources removes memory and cpu_cores
+//     and vice versa.
+//   - System disks are keyed by name, shared disks and authorized users by
+//     ID, or name and email address respectively when ID is empty, and disk
+//     template options by key. An item matching an item in base is merged
+//     into it, and other items are appended.
+//   - Tags and authorized SSH keys are appended, skipping values already in
+//     base.
+//   - Network interfaces and backup policies are replaced as a whole, or
+//     appended depending on opts.
+//
+// In keyed lists and lists of strings, an item with a key or value of
+// DeleteMarker removes all items in base, and one of DeleteMarker followed
+// by ":" and a key, such as "$delete:web", removes the item with that key.
+//
+// The returned spec contains no delete markers. A *ValidationError is
+// returned when overlay uses a delete marker without a key.
+func Merge(
+	base *VirtualMachineSpec,
+	overlay *VirtualMachineSpec,
+	opts *MergeOptions,
+) (*VirtualMachineSpec, error) {
+	if opts == nil {
+		opts = &MergeOptions{}
+	}
+
+	s := &VirtualMachineSpec{}
+	if base != nil {
+		var err error
+		s, err = base.clone()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if overlay == nil {
+		return s, nil
+	}
+	o, err := overlay.clone()
+	if err != nil {
+		return nil, err
+	}
+	m := &merger{opts: opts, v: &validator{}}
+
+	if o.Zone != nil && !o.Zone.deleted() {
+		s.DataCenter = nil
+	}
+	if o.DataCenter != nil && !o.DataCenter.deleted() {
+		s.Zone = nil
+	}
+	s.Zone = mergeRef(s.Zone, o.Zone)
+	s.DataCenter = mergeRef(s.DataCenter, o.DataCenter)
+	if o.Resources != nil {
+		s.Resources = mergeResources(s.Resources, o.Resources)
+	}
+	if o.DiskTemplate != nil {
+		s.DiskTemplate = m.diskTemplate(s.DiskTemplate, o.DiskTemplate)
+	}
+
+	s.SystemDisks = mergeKeyed(m, "system_disks",
+		s.SystemDisks, o.SystemDisks, (*SystemDisk).mergeKey, m.systemDisk,
+	)
+	s.SharedDisks = mergeKeyed(m, "shared_disks",
+		s.SharedDisks, o.SharedDisks, (*SharedDisk).mergeKey,
+		replaceItem[SharedDisk],
+	)
+	s.NetworkInterfaces = mergeList(
+		s.NetworkInterfaces, o.NetworkInterfaces,
+		opts.AppendNetworkInterfaces,
+	)
+
+	s.Hostname = mergeString(s.Hostname, o.Hostname)
+	s.Name = mergeString(s.Name, o.Name)
+	s.Description = mergeString(s.Description, o.Description)
+	s.Group = mergeRef(s.Group, o.Group)
+
+	if o.AuthorizedKeys != nil {
+		s.AuthorizedKeys = m.authorizedKeys(s.AuthorizedKeys, o.AuthorizedKeys)
+	}
+
+	s.BackupPolicies = mergeList(
+		s.BackupPolicies, o.BackupPolicies, opts.AppendBackupPolicies,
+	)
+	s.Tags = mergeStrings(m, "tags", s.Tags, o.Tags)
+	s.ISO = mergeString(s.ISO, o.ISO)
+
+	if err := m.v.err(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+type merger struct {
+	opts *MergeOptions
+	v    *validator
+}
+
+func (m *merger) diskTemplate(base, o *DiskTemplate) *DiskTemplate {
+	if o.ID == DeleteMarker {
+		return nil
+	}
+	if base == nil {
+		base = &DiskTemplate{}
+	}
+
+	if o.ID != "" || o.Permalink != "" {
+		base.ID, base.Permalink, base.Version = o.ID, o.Permalink, o.Version
+	} else if o.Version != 0 {
+		base.Version = o.Version
+	}
+
+	base.Options = mergeKeyed(m, "disk_template.options",
+		base.Options, o.Options, (*DiskTemplateOption).mergeKey,
+		replaceItem[DiskTemplateOption],
+	)
+
+	return base
+}
+
+func (m *merger) systemDisk(base, o *SystemDisk) *SystemDisk {
+	if base == nil {
+		base = &SystemDisk{}
+	}
+
+	base.Name = mergeString(base.Name, o.Name)
+	if o.Size != 0 {
+		base.Size = o.Size
+	}
+	base.Speed = mergeString(base.Speed, o.Speed)
+	base.IOProfile = mergeRef(base.IOProfile, o.IOProfile)
+	base.FileSystemType = mergeString(base.FileSystemType, o.FileSystemType)
+	base.BackupPolicies = mergeList(
+		base.BackupPolicies, o.BackupPolicies, m.opts.AppendBackupPolicies,
+	)
+
+	return base
+}
+
+func (m *merger) authorizedKeys(base, o *AuthorizedKeys) *AuthorizedKeys {
+	if base == nil {
+		base = &AuthorizedKeys{}
+	}
+
+	base.AllUsers = base.AllUsers || o.AllUsers
+	base.AllSSHKeys = base.AllSSHKeys || o.AllSSHKeys
+	base.Users = mergeKeyed(m, "authorized_keys.users",
+		base.Users, o.Users, (*User).mergeKey, replaceItem[User],
+	)
+	base.SSHKeys = mergeStrings(
+		m, "authorized_keys.ssh_keys", base.SSHKeys, o.SSHKeys,
+	)
+
+	return base
+}
+
+func mergeResources(base, o *Resources) *Resources {
+	if base == nil {
+		base = &Resources{}
+	}
+
+	if o.Package != nil && !o.Package.deleted() {
+		base.Memory, base.CPUCores = 0, 0
+	}
+	base.Package = mergeRef(base.Package, o.Package)
+	if o.Memory != 0 || o.CPUCores != 0 {
+		base.Package = nil
+		if o.Memory != 0 {
+			base.Memory = o.Memory
+		}
+		if o.CPUCores != 0 {
+			base.CPUCores = o.CPUCores
+		}
+	}
+
+	return base
+}
+
+func (s *SystemDisk) mergeKey() string         { return s.Name }
+func (s *DiskTemplateOption) mergeKey() string { return s.Key }
+
+func (s *SharedDisk) mergeKey() string {
+	if s.ID != "" {
+		return s.ID
+	}
+
+	return s.Name
+}
+
+func (s *User) mergeKey() string {
+	if s.ID != "" {
+		return s.ID
+	}
+
+	return s.EmailAddress
+}
+
+// mergeString returns o if set, an empty string if o is DeleteMarker, and
+// base otherwise.
+func mergeString(base, o string) string {
+	switch o {
+	case "":
+		return base
+	case DeleteMarker:
+		return ""
+	}
+
+	return o
+}
+
+// deletableRef is implemented by pointers to references to other resources,
+// which are removed by Merge when their ID is DeleteMarker.
+type deletableRef interface {
+	comparable
+	deleted() bool
+}
+
+func (s *Zone) deleted() bool          { return s.ID == DeleteMarker }
+func (s *DataCenter) deleted() bool    { return s.ID == DeleteMarker }
+func (s *Package) deleted() bool       { return s.ID == DeleteMarker }
+func (s *Group) deleted() bool         { return s.ID == DeleteMarker }
+func (s *DiskIOProfile) deleted() bool { return s.ID == DeleteMarker }
+
+// mergeRef returns o in place of base if it is set, or nil if o is deleted.
+func mergeRef[T deletableRef](base, o T) T {
+	var zero T
+	switch {
+	case o == zero:
+		return base
+	case o.deleted():
+		return zero
+	}
+
+	return o
+}
+
+// mergeList returns o in place of base if it has any items, or base with
+// the items of o appended if appendItems is true.
+func mergeList[T any](base, o []T, appendItems bool) []T {
+	switch {
+	case len(o) == 0:
+		return base
+	case appendItems:
+		return append(base, o...)
+	}
+
+	return o
+}
+
+// mergeKeyed merges the items of o into base by the key returned by key.
+// Items matching a base item are merged into it with merge, and others are
+// merged into their zero value and appended, which clears any delete
+// markers within them.
+func mergeKeyed[T any](
+	m *merger,
+	field string,
+	base []*T,
+	o []*T,
+	key func(*T) string,
+	merge func(base, o *T) *T,
+) []*T {
+	for i, item := range o {
+		k := key(item)
+
+		switch {
+		case k == DeleteMarker:
+			base = nil
+		case strings.HasPrefix(k, deletePrefix):
+			target := strings.TrimPrefix(k, deletePrefix)
+			if target == "" {
+				m.v.add(index(field, i), "%q requires a key", deletePrefix)
+
+				continue
+			}
+
+			kept := base[:0]
+			for _, b := range base {
+				if key(b) != target {
+					kept = append(kept, b)
+				}
+			}
+			base = kept
+		default:
+			matched := false
+			for j, b := range base {
+				if k != "" && key(b) == k {
+					base[j] = merge(b, item)
+					matched = true
+				}
+			}
+			if !matched {
+				base = append(base, merge(new(T), item))
+			}
+		}
+	}
+
+	return base
+}
+
+// replaceItem is a merge function for mergeKeyed which replaces items.
+func replaceItem[T any](_, o *T) *T {
+	return o
+}
+
+// mergeStrings appends the values of o to base, skipping those already in
+// base, and handling delete markers like mergeKeyed.
+func mergeStrings(m *merger, field string, base, o []string) []string {
+	for i, s := range o {
+		switch {
+		case s == DeleteMarker:
+			base = nil
+		case strings.HasPrefix(s, deletePrefix):
+			target := strings.TrimPrefix(s, deletePrefix)
+			if target == "" {
+				m.v.add(index(field, i), "%q requires a value", deletePrefix)
+
+				continue
+			}
+
+			kept := base[:0]
+			for _, b := range base {
+				if b != target {
+					kept = append(kept, b)
+				}
+			}
+			base = kept
+		case !containsString(base, s):
+			base = append(base, s)
+		}
+	}
+
+	return base
+}
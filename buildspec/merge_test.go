@@ -0,0 +1,526 @@
+package buildspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/undent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureMergeBase() *VirtualMachineSpec {
+	return &VirtualMachineSpec{
+		Zone:      &Zone{Permalink: "uk-lon-01a"},
+		Resources: &Resources{Package: &Package{Permalink: "rock-3"}},
+		DiskTemplate: &DiskTemplate{
+			Permalink: "templates/ubuntu-22-04",
+			Version:   2,
+			Options: []*DiskTemplateOption{
+				{Key: "timezone", Value: "UTC"},
+				{Key: "locale", Value: "en_GB"},
+			},
+		},
+		SystemDisks: []*SystemDisk{
+			{Name: "System Disk", Size: 20, Speed: "ssd"},
+			{Name: "Data", Size: 100},
+		},
+		SharedDisks: []*SharedDisk{{ID: "shared_1"}, {Name: "assets"}},
+		NetworkInterfaces: []*NetworkInterface{
+			{Network: &Network{Permalink: "public"}},
+		},
+		Hostname:    "web",
+		Name:        "Web",
+		Description: "Web server",
+		Group:       &Group{Name: "web"},
+		AuthorizedKeys: &AuthorizedKeys{
+			Users:   []*User{{EmailAddress: "ops@example.com"}},
+			SSHKeys: []string{"ssh_1"},
+		},
+		BackupPolicies: []*BackupPolicy{
+			{Retention: 7, Schedule: &Schedule{Interval: ScheduledDaily}},
+		},
+		Tags: []string{"web", "base"},
+		ISO:  "iso_1",
+	}
+}
+
+// mergeBaseWith returns fixtureMergeBase modified by f.
+func mergeBaseWith(f func(s *VirtualMachineSpec)) *VirtualMachineSpec {
+	s := fixtureMergeBase()
+	f(s)
+
+	return s
+}
+
+func TestMerge(t *testing.T) {
+	weekly := &BackupPolicy{
+		Retention: 4,
+		Schedule:  &Schedule{Interval: ScheduledWeekly},
+	}
+
+	tests := []struct {
+		name    string
+		base    *VirtualMachineSpec
+		overlay *VirtualMachineSpec
+		opts    *MergeOptions
+		nilBase bool
+		want    *VirtualMachineSpec
+	}{
+		{
+			name:    "nil overlay",
+			overlay: nil,
+			want:    fixtureMergeBase(),
+		},
+		{
+			name:    "empty overlay",
+			overlay: &VirtualMachineSpec{},
+			want:    fixtureMergeBase(),
+		},
+		{
+			name:    "nil base",
+			nilBase: true,
+			overlay: &VirtualMachineSpec{
+				Hostname:    "db",
+				Description: DeleteMarker,
+				Tags:        []string{"db", "$delete:web"},
+			},
+			want: &VirtualMachineSpec{Hostname: "db", Tags: []string{"db"}},
+		},
+		{
+			name: "scalars",
+			overlay: &VirtualMachineSpec{
+				Hostname:    "web-staging",
+				Description: DeleteMarker,
+				ISO:         DeleteMarker,
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Hostname = "web-staging"
+				s.Description = ""
+				s.ISO = ""
+			}),
+		},
+		{
+			name:    "zone replaces zone",
+			overlay: &VirtualMachineSpec{Zone: &Zone{ID: "zone_1"}},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Zone = &Zone{ID: "zone_1"}
+			}),
+		},
+		{
+			name: "data center replaces zone",
+			overlay: &VirtualMachineSpec{
+				DataCenter: &DataCenter{Permalink: "uk-lon-01"},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Zone = nil
+				s.DataCenter = &DataCenter{Permalink: "uk-lon-01"}
+			}),
+		},
+		{
+			name: "deleted references",
+			overlay: &VirtualMachineSpec{
+				Zone:         &Zone{ID: DeleteMarker},
+				Group:        &Group{ID: DeleteMarker},
+				DiskTemplate: &DiskTemplate{ID: DeleteMarker},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Zone = nil
+				s.Group = nil
+				s.DiskTemplate = nil
+			}),
+		},
+		{
+			name: "package replaces package",
+			overlay: &VirtualMachineSpec{
+				Resources: &Resources{Package: &Package{Permalink: "rock-6"}},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Resources.Package = &Package{Permalink: "rock-6"}
+			}),
+		},
+		{
+			name: "memory and cpu cores replace package",
+			overlay: &VirtualMachineSpec{
+				Resources: &Resources{Memory: 8, CPUCores: 4},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Resources = &Resources{Memory: 8, CPUCores: 4}
+			}),
+		},
+		{
+			name: "package replaces memory and cpu cores",
+			base: &VirtualMachineSpec{
+				Resources: &Resources{Memory: 8, CPUCores: 4},
+			},
+			overlay: &VirtualMachineSpec{
+				Resources: &Resources{Package: &Package{ID: "pkg_1"}},
+			},
+			want: &VirtualMachineSpec{
+				Resources: &Resources{Package: &Package{ID: "pkg_1"}},
+			},
+		},
+		{
+			name: "disk template",
+			overlay: &VirtualMachineSpec{
+				DiskTemplate: &DiskTemplate{
+					Options: []*DiskTemplateOption{
+						{Key: "timezone", Value: "Europe/London"},
+						{Key: "$delete:locale"},
+						{Key: "keyboard", Value: "gb"},
+					},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.DiskTemplate.Options = []*DiskTemplateOption{
+					{Key: "timezone", Value: "Europe/London"},
+					{Key: "keyboard", Value: "gb"},
+				}
+			}),
+		},
+		{
+			name: "disk template version",
+			overlay: &VirtualMachineSpec{
+				DiskTemplate: &DiskTemplate{Version: 3},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.DiskTemplate.Version = 3
+			}),
+		},
+		{
+			name: "disk template replaced",
+			overlay: &VirtualMachineSpec{
+				DiskTemplate: &DiskTemplate{
+					Permalink: "templates/debian-12",
+					Options:   []*DiskTemplateOption{{Key: DeleteMarker}},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.DiskTemplate = &DiskTemplate{
+					Permalink: "templates/debian-12",
+				}
+			}),
+		},
+		{
+			name: "system disks",
+			overlay: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{
+					{
+						Name:      "System Disk",
+						Size:      40,
+						Speed:     DeleteMarker,
+						IOProfile: &DiskIOProfile{Permalink: "fast"},
+					},
+					{Name: "$delete:Data"},
+					{Name: "Logs", Size: 10, IOProfile: &DiskIOProfile{
+						ID: DeleteMarker,
+					}},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.SystemDisks = []*SystemDisk{
+					{
+						Name:      "System Disk",
+						Size:      40,
+						IOProfile: &DiskIOProfile{Permalink: "fast"},
+					},
+					{Name: "Logs", Size: 10},
+				}
+			}),
+		},
+		{
+			name: "system disks replaced",
+			overlay: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{
+					{Name: DeleteMarker},
+					{Name: "Data", Size: 50},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.SystemDisks = []*SystemDisk{{Name: "Data", Size: 50}}
+			}),
+		},
+		{
+			name: "system disk backup policies",
+			base: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{
+					{Name: "Data", BackupPolicies: []*BackupPolicy{weekly}},
+				},
+			},
+			overlay: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{
+					{Name: "Data", BackupPolicies: []*BackupPolicy{weekly}},
+				},
+			},
+			opts: &MergeOptions{AppendBackupPolicies: true},
+			want: &VirtualMachineSpec{
+				SystemDisks: []*SystemDisk{
+					{
+						Name:           "Data",
+						BackupPolicies: []*BackupPolicy{weekly, weekly},
+					},
+				},
+			},
+		},
+		{
+			name: "shared disks",
+			overlay: &VirtualMachineSpec{
+				SharedDisks: []*SharedDisk{
+					{ID: "$delete:shared_1"},
+					{Name: "uploads"},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.SharedDisks = []*SharedDisk{
+					{Name: "assets"}, {Name: "uploads"},
+				}
+			}),
+		},
+		{
+			name: "network interfaces replaced",
+			overlay: &VirtualMachineSpec{
+				NetworkInterfaces: []*NetworkInterface{
+					{VirtualNetwork: &VirtualNetwork{ID: "vnet_1"}},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.NetworkInterfaces = []*NetworkInterface{
+					{VirtualNetwork: &VirtualNetwork{ID: "vnet_1"}},
+				}
+			}),
+		},
+		{
+			name: "network interfaces appended",
+			overlay: &VirtualMachineSpec{
+				NetworkInterfaces: []*NetworkInterface{
+					{VirtualNetwork: &VirtualNetwork{ID: "vnet_1"}},
+				},
+			},
+			opts: &MergeOptions{AppendNetworkInterfaces: true},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.NetworkInterfaces = append(s.NetworkInterfaces,
+					&NetworkInterface{
+						VirtualNetwork: &VirtualNetwork{ID: "vnet_1"},
+					},
+				)
+			}),
+		},
+		{
+			name: "authorized keys",
+			overlay: &VirtualMachineSpec{
+				AuthorizedKeys: &AuthorizedKeys{
+					AllSSHKeys: true,
+					Users: []*User{
+						{EmailAddress: "$delete:ops@example.com"},
+						{ID: "user_1"},
+					},
+					SSHKeys: []string{"ssh_1", "ssh_2"},
+				},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.AuthorizedKeys = &AuthorizedKeys{
+					AllSSHKeys: true,
+					Users:      []*User{{ID: "user_1"}},
+					SSHKeys:    []string{"ssh_1", "ssh_2"},
+				}
+			}),
+		},
+		{
+			name: "backup policies replaced",
+			overlay: &VirtualMachineSpec{
+				BackupPolicies: []*BackupPolicy{weekly},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.BackupPolicies = []*BackupPolicy{weekly}
+			}),
+		},
+		{
+			name: "backup policies appended",
+			overlay: &VirtualMachineSpec{
+				BackupPolicies: []*BackupPolicy{weekly},
+			},
+			opts: &MergeOptions{AppendBackupPolicies: true},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.BackupPolicies = append(s.BackupPolicies, weekly)
+			}),
+		},
+		{
+			name: "tags",
+			overlay: &VirtualMachineSpec{
+				Tags: []string{"staging", "web", "$delete:base"},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Tags = []string{"web", "staging"}
+			}),
+		},
+		{
+			name: "tags replaced",
+			overlay: &VirtualMachineSpec{
+				Tags: []string{DeleteMarker, "staging"},
+			},
+			want: mergeBaseWith(func(s *VirtualMachineSpec) {
+				s.Tags = []string{"staging"}
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := tt.base
+			if base == nil && !tt.nilBase {
+				base = fixtureMergeBase()
+			}
+
+			got, err := Merge(base, tt.overlay, tt.opts)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMerge_doesNotModifyInputs(t *testing.T) {
+	base := fixtureMergeBase()
+	overlay := &VirtualMachineSpec{
+		SystemDisks: []*SystemDisk{{Name: "System Disk", Size: 40}},
+		Tags:        []string{"$delete:web"},
+	}
+
+	got, err := Merge(base, overlay, nil)
+	require.NoError(t, err)
+
+	got.SystemDisks[0].Name = "Changed"
+	assert.Equal(t, fixtureMergeBase(), base)
+	assert.Equal(t, &VirtualMachineSpec{
+		SystemDisks: []*SystemDisk{{Name: "System Disk", Size: 40}},
+		Tags:        []string{"$delete:web"},
+	}, overlay)
+}
+
+func TestMerge_invalidDeleteMarkers(t *testing.T) {
+	overlay := &VirtualMachineSpec{
+		SystemDisks: []*SystemDisk{{Name: "$delete:"}},
+		Tags:        []string{"ok", "$delete:"},
+	}
+
+	got, err := Merge(fixtureMergeBase(), overlay, nil)
+
+	assert.Nil(t, got)
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.EqualError(t, err,
+		`validation: system_disks[0]: "$delete:" requires a key; `+
+			`tags[1]: "$delete:" requires a value`,
+	)
+}
+
+func TestMerge_formats(t *testing.T) {
+	overlays := map[string]struct {
+		parse func(string) (*VirtualMachineSpec, error)
+		src   string
+	}{
+		"JSON": {
+			parse: func(s string) (*VirtualMachineSpec, error) {
+				return FromJSON(strings.NewReader(s))
+			},
+			src: undent.String(`
+				{
+					"data_center": {"permalink": "uk-lon-01"},
+					"resources": {"memory": 8, "cpu_cores": 4},
+					"system_disks": [
+						{"name": "$delete:Data"},
+						{"name": "System Disk", "size": 40}
+					],
+					"group": {"id": "$delete"},
+					"tags": ["staging", "$delete:base"]
+				}`,
+			),
+		},
+		"XML": {
+			parse: func(s string) (*VirtualMachineSpec, error) {
+				return FromXML(strings.NewReader(s))
+			},
+			src: undent.String(`
+				<VirtualMachineSpec>
+					<DataCenter by="permalink">uk-lon-01</DataCenter>
+					<Resources>
+						<Memory>8</Memory>
+						<CPUCores>4</CPUCores>
+					</Resources>
+					<SystemDisks>
+						<Disk><Name>$delete:Data</Name></Disk>
+						<Disk><Name>System Disk</Name><Size>40</Size></Disk>
+					</SystemDisks>
+					<Group>$delete</Group>
+					<Tags>
+						<Tag>staging</Tag>
+						<Tag>$delete:base</Tag>
+					</Tags>
+				</VirtualMachineSpec>`,
+			),
+		},
+		"YAML": {
+			parse: func(s string) (*VirtualMachineSpec, error) {
+				return FromYAML(strings.NewReader(s))
+			},
+			src: undent.String(`
+				data_center:
+				  permalink: uk-lon-01
+				resources:
+				  memory: 8
+				  cpu_cores: 4
+				system_disks:
+				  - name: $delete:Data
+				  - name: System Disk
+				    size: 40
+				group:
+				  id: $delete
+				tags: [staging, $delete:base]`,
+			),
+		},
+	}
+
+	want := fixtureMergeBase()
+	want.Zone = nil
+	want.DataCenter = &DataCenter{Permalink: "uk-lon-01"}
+	want.Resources = &Resources{Memory: 8, CPUCores: 4}
+	want.SystemDisks = []*SystemDisk{
+		{Name: "System Disk", Size: 40, Speed: "ssd"},
+	}
+	want.Group = nil
+	want.Tags = []string{"web", "staging"}
+
+	for format, overlay := range overlays {
+		t.Run(format, func(t *testing.T) {
+			o, err := overlay.parse(overlay.src)
+			require.NoError(t, err)
+
+			got, err := Merge(fixtureMergeBase(), o, nil)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			t.Run("round-trip JSON", func(t *testing.T) {
+				b, err := got.JSON()
+				require.NoError(t, err)
+				parsed, err := FromJSON(bytes.NewReader(b))
+				require.NoError(t, err)
+				assert.Equal(t, got, parsed)
+			})
+
+			t.Run("round-trip XML", func(t *testing.T) {
+				b, err := got.XML()
+				require.NoError(t, err)
+				parsed, err := FromXML(bytes.NewReader(b))
+				require.NoError(t, err)
+				assert.Equal(t, got, parsed)
+			})
+
+			t.Run("round-trip YAML", func(t *testing.T) {
+				b, err := got.YAML()
+				require.NoError(t, err)
+				parsed, err := FromYAML(bytes.NewReader(b))
+				require.NoError(t, err)
+				assert.Equal(t, got, parsed)
+			})
+		})
+	}
+}
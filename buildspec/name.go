@@ -8,7 +8,10 @@ type xmlName struct {
 
 func (s *xmlName) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	x := &xmlNameNested{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	if x.Name != nil {
 		s.Value = x.Name.Value
@@ -6,8 +6,8 @@ import (
 )
 
 type Network struct {
-	ID        string `json:"id,omitempty" yaml:"id,omitempty"`
-	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty"`
+	ID        string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty" hcl:"permalink,optional" toml:"permalink,omitempty"`
 }
 
 func (s *Network) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -26,7 +26,10 @@ func (s *Network) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 func (s *Network) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
 	x := &xmlNetwork{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := Network{}
 
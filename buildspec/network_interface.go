@@ -1,10 +1,10 @@
 package buildspec
 
 type NetworkInterface struct {
-	Network              *Network               `xml:",omitempty" json:"network,omitempty" yaml:"network,omitempty"`
-	VirtualNetwork       *VirtualNetwork        `xml:",omitempty" json:"virtual_network,omitempty" yaml:"virtual_network,omitempty"`
-	SpeedProfile         *NetworkSpeedProfile   `xml:",omitempty" json:"speed_profile,omitempty" yaml:"speed_profile,omitempty"`
-	IPAddressAllocations []*IPAddressAllocation `xml:"IPAddressAllocation,omitempty" json:"ip_address_allocations,omitempty" yaml:"ip_address_allocations,omitempty"`
+	Network              *Network               `xml:",omitempty" json:"network,omitempty" yaml:"network,omitempty" hcl:"network,block" toml:"network,omitempty"`
+	VirtualNetwork       *VirtualNetwork        `xml:",omitempty" json:"virtual_network,omitempty" yaml:"virtual_network,omitempty" hcl:"virtual_network,block" toml:"virtual_network,omitempty"`
+	SpeedProfile         *NetworkSpeedProfile   `xml:",omitempty" json:"speed_profile,omitempty" yaml:"speed_profile,omitempty" hcl:"speed_profile,block" toml:"speed_profile,omitempty"`
+	IPAddressAllocations []*IPAddressAllocation `xml:"IPAddressAllocation,omitempty" json:"ip_address_allocations,omitempty" yaml:"ip_address_allocations,omitempty" hcl:"ip_address_allocation,block" toml:"ip_address_allocations,omitempty"`
 }
 
 type xmlNetworkInterfaces struct {
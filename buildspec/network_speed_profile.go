@@ -6,8 +6,8 @@ import (
 )
 
 type NetworkSpeedProfile struct {
-	ID        string `json:"id,omitempty" yaml:"id,omitempty"`
-	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty"`
+	ID        string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Permalink string `json:"permalink,omitempty" yaml:"permalink,omitempty" hcl:"permalink,optional" toml:"permalink,omitempty"`
 }
 
 func (s *NetworkSpeedProfile) MarshalXML(
@@ -32,7 +32,10 @@ func (s *NetworkSpeedProfile) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlNetworkSpeedProfile{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := NetworkSpeedProfile{}
 
@@ -1,7 +1,7 @@
 package buildspec
 
 type Resources struct {
-	Package  *Package `xml:",omitempty" json:"package,omitempty" yaml:"package,omitempty"`
-	Memory   int      `xml:",omitempty" json:"memory,omitempty" yaml:"memory,omitempty"`
-	CPUCores int      `xml:",omitempty" json:"cpu_cores,omitempty" yaml:"cpu_cores,omitempty"`
+	Package  *Package `xml:",omitempty" json:"package,omitempty" yaml:"package,omitempty" hcl:"package,block" toml:"package,omitempty"`
+	Memory   int      `xml:",omitempty" json:"memory,omitempty" yaml:"memory,omitempty" hcl:"memory,optional" toml:"memory,omitempty"`
+	CPUCores int      `xml:",omitempty" json:"cpu_cores,omitempty" yaml:"cpu_cores,omitempty" hcl:"cpu_cores,optional" toml:"cpu_cores,omitempty"`
 }
@@ -10,7 +10,7 @@ const (
 )
 
 type Schedule struct {
-	Interval  ScheduleInterval `xml:",omitempty" json:"interval,omitempty" yaml:"interval,omitempty"`
-	Frequency int              `xml:",omitempty" json:"frequency,omitempty" yaml:"frequency,omitempty"`
-	Time      int              `xml:",omitempty" json:"time,omitempty" yaml:"time,omitempty"`
+	Interval  ScheduleInterval `xml:",omitempty" json:"interval,omitempty" yaml:"interval,omitempty" hcl:"interval,optional" toml:"interval,omitempty"`
+	Frequency int              `xml:",omitempty" json:"frequency,omitempty" yaml:"frequency,omitempty" hcl:"frequency,optional" toml:"frequency,omitempty"`
+	Time      int              `xml:",omitempty" json:"time,omitempty" yaml:"time,omitempty" hcl:"time,optional" toml:"time,omitempty"`
 }
@@ -6,8 +6,8 @@ import (
 )
 
 type SharedDisk struct {
-	ID   string `json:"id,omitempty" yaml:"id,omitempty"`
-	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	ID   string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty" hcl:"name,optional" toml:"name,omitempty"`
 }
 
 func (s *SharedDisk) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -29,7 +29,10 @@ func (s *SharedDisk) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlSharedDisk{}
-	_ = d.DecodeElement(&x, &start)
+	err := decodeXMLElement(d, &x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := SharedDisk{}
 
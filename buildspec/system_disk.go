@@ -3,12 +3,12 @@ package buildspec
 import "encoding/xml"
 
 type SystemDisk struct {
-	Name           string          `json:"name,omitempty" yaml:"name,omitempty"`
-	Size           int             `json:"size,omitempty" yaml:"size,omitempty"`
-	Speed          string          `json:"speed,omitempty" yaml:"speed,omitempty"`
-	IOProfile      *DiskIOProfile  `json:"io_profile,omitempty" yaml:"io_profile,omitempty"`
-	FileSystemType string          `json:"file_system_type,omitempty" yaml:"file_system_type,omitempty"`
-	BackupPolicies []*BackupPolicy `json:"backup_policies,omitempty" yaml:"backup_policies,omitempty"`
+	Name           string          `json:"name,omitempty" yaml:"name,omitempty" hcl:"name,optional" toml:"name,omitempty"`
+	Size           int             `json:"size,omitempty" yaml:"size,omitempty" hcl:"size,optional" toml:"size,omitempty"`
+	Speed          string          `json:"speed,omitempty" yaml:"speed,omitempty" hcl:"speed,optional" toml:"speed,omitempty"`
+	IOProfile      *DiskIOProfile  `json:"io_profile,omitempty" yaml:"io_profile,omitempty" hcl:"io_profile,block" toml:"io_profile,omitempty"`
+	FileSystemType string          `json:"file_system_type,omitempty" yaml:"file_system_type,omitempty" hcl:"file_system_type,optional" toml:"file_system_type,omitempty"`
+	BackupPolicies []*BackupPolicy `json:"backup_policies,omitempty" yaml:"backup_policies,omitempty" hcl:"backup_policy,block" toml:"backup_policies,omitempty"`
 }
 
 func (s *SystemDisk) MarshalXML(
@@ -37,7 +37,10 @@ func (s *SystemDisk) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlSystemDisk{}
-	_ = d.DecodeElement(x, &start)
+	err := decodeXMLElement(d, x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := SystemDisk{
 		Name:           x.Name,
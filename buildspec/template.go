@@ -0,0 +1,319 @@
+package buildspec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// maxIncludeDepth is the maximum depth of nested includes a Template
+// renders, guarding against runaway recursion.
+const maxIncludeDepth = 16
+
+const includePrefix = "include:"
+
+var (
+	templateVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	yamlLinePrefix       = regexp.MustCompile(`^[ \t]*(?:- +)*$`)
+)
+
+// Template renders build spec documents which contain variables and
+// includes, before they are parsed. It works on the raw text of a document,
+// so the same syntax is supported in JSON, XML and YAML documents:
+//
+//	${NAME}              value of variable NAME, an error if not defined
+//	${NAME:-default}     value of NAME, or default if NAME is not defined or
+//	                     empty
+//	${include:path}      contents of the fragment at path, rendered with the
+//	                     same variables
+//	$${                  a literal "${"
+//
+// Defaults and include paths may themselves contain expressions, for example
+// "${include:backups/${ENV:-production}.yaml}".
+//
+// When an include is the only thing on its line, other than indentation and
+// YAML sequence markers ("- "), every line of the fragment is indented to
+// match it, so YAML fragments can be included at any level of nesting.
+//
+// Include paths are relative to the root of FS when used in the document
+// being rendered, and relative to the including fragment when used within a
+// fragment.
+//
+// Values are inserted as-is, without any escaping for the document's format.
+type Template struct {
+	// Vars holds the values of variables.
+	Vars map[string]string
+
+	// Env enables looking up variables which are not in Vars from
+	// environment variables.
+	Env bool
+
+	// FS is the file system fragments are included from. Includes return an
+	// error when FS is nil.
+	FS fs.FS
+}
+
+// Render reads a build spec document from r, and returns it with all
+// variables and includes expanded. Problems are returned as a
+// *TemplateError, which matches ErrUndefinedVariable with errors.Is when a
+// variable without a default is not defined.
+func (t *Template) Render(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &templateRenderer{template: t, src: b}
+
+	return tr.expand(0, len(b))
+}
+
+// FromJSON renders a JSON build spec document with Render, and parses the
+// result like FromJSON.
+func (t *Template) FromJSON(r io.Reader) (*VirtualMachineSpec, error) {
+	b, err := t.Render(r)
+	if err != nil {
+		return &VirtualMachineSpec{}, err
+	}
+
+	return FromJSON(bytes.NewReader(b))
+}
+
+// FromXML renders a XML build spec document with Render, and parses the
+// result like FromXML.
+func (t *Template) FromXML(r io.Reader) (*VirtualMachineSpec, error) {
+	b, err := t.Render(r)
+	if err != nil {
+		return &VirtualMachineSpec{}, err
+	}
+
+	return FromXML(bytes.NewReader(b))
+}
+
+// FromYAML renders a YAML build spec document with Render, and parses the
+// result like FromYAML.
+func (t *Template) FromYAML(r io.Reader) (*VirtualMachineSpec, error) {
+	b, err := t.Render(r)
+	if err != nil {
+		return &VirtualMachineSpec{}, err
+	}
+
+	return FromYAML(bytes.NewReader(b))
+}
+
+func (t *Template) lookup(name string) (string, bool) {
+	if v, ok := t.Vars[name]; ok {
+		return v, true
+	}
+	if t.Env {
+		return os.LookupEnv(name)
+	}
+
+	return "", false
+}
+
+// templateRenderer renders a single document or fragment of a Template.
+type templateRenderer struct {
+	template *Template
+
+	// source is the path of the fragment being rendered, or empty for the
+	// document passed to Render.
+	source string
+	src    []byte
+
+	// includes is the chain of fragments which led to this one being
+	// included, used to detect include cycles.
+	includes []string
+}
+
+// expand returns src[from:to] with all expressions expanded.
+func (r *templateRenderer) expand(from, to int) ([]byte, error) {
+	out := &bytes.Buffer{}
+	for i := from; i < to; {
+		switch {
+		case r.src[i] != '$' || i+1 >= to:
+			out.WriteByte(r.src[i])
+			i++
+		case r.src[i+1] == '$' && i+2 < to && r.src[i+2] == '{':
+			out.WriteString("${")
+			i += 3
+		case r.src[i+1] == '{':
+			end := r.closingBrace(i+2, to)
+			if end < 0 {
+				return nil, r.errorf(
+					i, "%w: unterminated expression", ErrTemplate,
+				)
+			}
+
+			v, err := r.eval(i, i+2, end)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(v)
+			i = end + 1
+		default:
+			out.WriteByte(r.src[i])
+			i++
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// closingBrace returns the index of the "}" closing an expression which
+// starts at from, taking nested expressions into account, or -1 if there is
+// none before to.
+func (r *templateRenderer) closingBrace(from, to int) int {
+	depth := 1
+	for i := from; i < to; i++ {
+		switch {
+		case r.src[i] == '$' && i+1 < to && r.src[i+1] == '$':
+			i++
+		case r.src[i] == '$' && i+1 < to && r.src[i+1] == '{':
+			depth++
+			i++
+		case r.src[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// eval returns the value of the expression in src[from:to], which starts at
+// pos.
+func (r *templateRenderer) eval(pos, from, to int) ([]byte, error) {
+	expr := r.src[from:to]
+	if bytes.HasPrefix(expr, []byte(includePrefix)) {
+		return r.include(pos, from+len(includePrefix), to)
+	}
+
+	name := string(expr)
+	sep := bytes.Index(expr, []byte(":-"))
+	if sep >= 0 {
+		name = string(expr[:sep])
+	}
+	if !templateVariableName.MatchString(name) {
+		return nil, r.errorf(
+			pos, "%w: invalid variable name %q", ErrTemplate, name,
+		)
+	}
+
+	v, ok := r.template.lookup(name)
+	switch {
+	case ok && v != "":
+		return []byte(v), nil
+	case sep >= 0:
+		return r.expand(from+sep+2, to)
+	case ok:
+		return nil, nil
+	}
+
+	return nil, r.errorf(pos, "%w: %s", ErrUndefinedVariable, name)
+}
+
+// include returns the rendered fragment at the path in src[from:to], for an
+// include expression which starts at pos.
+func (r *templateRenderer) include(pos, from, to int) ([]byte, error) {
+	p, err := r.expand(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(string(p))
+	if r.source != "" {
+		name = path.Join(path.Dir(r.source), name)
+	}
+
+	switch {
+	case r.template.FS == nil:
+		return nil, r.errorf(
+			pos, "%w: include %q: no file system to include from",
+			ErrTemplate, name,
+		)
+	case !fs.ValidPath(name):
+		return nil, r.errorf(
+			pos, "%w: include %q: invalid path", ErrTemplate, name,
+		)
+	case containsString(r.includes, name):
+		return nil, r.errorf(
+			pos, "%w: include %q: cycle via %s",
+			ErrTemplate, name, strings.Join(r.includes, ", "),
+		)
+	case len(r.includes) >= maxIncludeDepth:
+		return nil, r.errorf(
+			pos, "%w: include %q: exceeds maximum depth of %d",
+			ErrTemplate, name, maxIncludeDepth,
+		)
+	}
+
+	b, err := fs.ReadFile(r.template.FS, name)
+	if err != nil {
+		return nil, r.errorf(pos, "%w: include: %w", ErrTemplate, err)
+	}
+
+	includes := make([]string, 0, len(r.includes)+1)
+	includes = append(includes, r.includes...)
+	fr := &templateRenderer{
+		template: r.template,
+		source:   name,
+		src:      b,
+		includes: append(includes, name),
+	}
+	out, err := fr.expand(0, len(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return indentLines(bytes.TrimRight(out, "\r\n"), r.indentAt(pos)), nil
+}
+
+// indentAt returns the indentation for lines included at pos. When pos is
+// preceded on its line only by whitespace and YAML sequence markers ("- "),
+// that is the prefix with markers replaced by spaces, and nil otherwise.
+func (r *templateRenderer) indentAt(pos int) []byte {
+	start := bytes.LastIndexByte(r.src[:pos], '\n') + 1
+	prefix := r.src[start:pos]
+	if !yamlLinePrefix.Match(prefix) {
+		return nil
+	}
+
+	return bytes.ReplaceAll(prefix, []byte{'-'}, []byte{' '})
+}
+
+func (r *templateRenderer) errorf(pos int, format string, a ...any) error {
+	line := bytes.Count(r.src[:pos], []byte{'\n'}) + 1
+	col := pos - bytes.LastIndexByte(r.src[:pos], '\n')
+
+	return &TemplateError{
+		Source: r.source,
+		Line:   line,
+		Column: col,
+		Err:    fmt.Errorf(format, a...),
+	}
+}
+
+// indentLines prefixes all but the first line in b with indent, leaving
+// empty lines as they are.
+func indentLines(b []byte, indent []byte) []byte {
+	if len(indent) == 0 {
+		return b
+	}
+
+	lines := bytes.Split(b, []byte{'\n'})
+	for i := 1; i < len(lines); i++ {
+		if len(lines[i]) > 0 {
+			lines[i] = append(append([]byte{}, indent...), lines[i]...)
+		}
+	}
+
+	return bytes.Join(lines, []byte{'\n'})
+}
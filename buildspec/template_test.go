@@ -0,0 +1,396 @@
+package buildspec
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jimeh/undent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var templateFS = fstest.MapFS{
+	"backups.yaml": {Data: []byte(undent.String(`
+		- retention: ${RETENTION:-7}
+		  schedule:
+		    interval: daily
+		    frequency: 1
+		    time: 3`,
+	))},
+	"backups/production.yaml": {Data: []byte(undent.String(`
+		- retention: 30
+		  schedule:
+		    interval: hourly
+		    frequency: 6`,
+	))},
+	"network/nic.yaml": {Data: []byte(undent.String(`
+		network:
+		  permalink: public
+		${include:ip.yaml}`,
+	))},
+	"network/ip.yaml": {Data: []byte(undent.String(`
+		ip_address_allocations:
+		  - allocation_type: new
+		    ip_version: ipv4`,
+	))},
+	"undefined.yaml":  {Data: []byte("name: ${NOPE}\n")},
+	"cycle/a.yaml":    {Data: []byte("${include:b.yaml}")},
+	"cycle/b.yaml":    {Data: []byte("${include:a.yaml}")},
+	"plain.txt":       {Data: []byte("plain")},
+	"blank-lines.txt": {Data: []byte("a:\n\n  b: 1\n")},
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   *Template
+		env    map[string]string
+		src    string
+		want   string
+		errIs  error
+		errStr string
+	}{
+		{
+			name: "no expressions",
+			tmpl: &Template{},
+			src:  "hostname: web-1 # costs $5\n",
+			want: "hostname: web-1 # costs $5\n",
+		},
+		{
+			name: "variable",
+			tmpl: &Template{Vars: map[string]string{"ENV": "staging"}},
+			src:  "hostname: web-${ENV}-1",
+			want: "hostname: web-staging-1",
+		},
+		{
+			name: "default for undefined variable",
+			tmpl: &Template{},
+			src:  "zone: ${ZONE:-uk-lon-01}",
+			want: "zone: uk-lon-01",
+		},
+		{
+			name: "default for empty variable",
+			tmpl: &Template{Vars: map[string]string{"ZONE": ""}},
+			src:  "zone: ${ZONE:-uk-lon-01}",
+			want: "zone: uk-lon-01",
+		},
+		{
+			name: "empty default",
+			tmpl: &Template{},
+			src:  "description: '${DESC:-}'",
+			want: "description: ''",
+		},
+		{
+			name: "empty variable without default",
+			tmpl: &Template{Vars: map[string]string{"DESC": ""}},
+			src:  "description: '${DESC}'",
+			want: "description: ''",
+		},
+		{
+			name: "nested default",
+			tmpl: &Template{Vars: map[string]string{"DEFAULT_ZONE": "a"}},
+			src:  "zone: ${ZONE:-${DEFAULT_ZONE}}",
+			want: "zone: a",
+		},
+		{
+			name: "escaped expression",
+			tmpl: &Template{},
+			src:  "description: $${NOT_A_VARIABLE}",
+			want: "description: ${NOT_A_VARIABLE}",
+		},
+		{
+			name: "environment variable",
+			tmpl: &Template{Env: true},
+			env:  map[string]string{"BUILDSPEC_TEST_ENV": "production"},
+			src:  "env: ${BUILDSPEC_TEST_ENV}",
+			want: "env: production",
+		},
+		{
+			name: "variables take precedence over environment",
+			tmpl: &Template{
+				Vars: map[string]string{"BUILDSPEC_TEST_ENV": "staging"},
+				Env:  true,
+			},
+			env:  map[string]string{"BUILDSPEC_TEST_ENV": "production"},
+			src:  "env: ${BUILDSPEC_TEST_ENV}",
+			want: "env: staging",
+		},
+		{
+			name:  "environment disabled",
+			tmpl:  &Template{},
+			env:   map[string]string{"BUILDSPEC_TEST_ENV": "production"},
+			src:   "env: ${BUILDSPEC_TEST_ENV}",
+			errIs: ErrUndefinedVariable,
+			errStr: "template: line 1, column 6: undefined_variable: " +
+				"BUILDSPEC_TEST_ENV",
+		},
+		{
+			name:  "undefined variable",
+			tmpl:  &Template{},
+			src:   "name: web\nhostname: ${HOSTNAME_PREFIX}-1",
+			errIs: ErrUndefinedVariable,
+			errStr: "template: line 2, column 11: undefined_variable: " +
+				"HOSTNAME_PREFIX",
+		},
+		{
+			name:   "invalid variable name",
+			tmpl:   &Template{},
+			src:    "name: ${1NAME}",
+			errIs:  ErrTemplate,
+			errStr: `template: line 1, column 7: invalid variable name "1NAME"`,
+		},
+		{
+			name:   "unterminated expression",
+			tmpl:   &Template{},
+			src:    "name: ${NAME",
+			errIs:  ErrTemplate,
+			errStr: "template: line 1, column 7: unterminated expression",
+		},
+		{
+			name: "include",
+			tmpl: &Template{FS: templateFS},
+			src:  "name: ${include:plain.txt}",
+			want: "name: plain",
+		},
+		{
+			name: "include indented YAML fragment",
+			tmpl: &Template{FS: templateFS},
+			src: undent.String(`
+				hostname: web-1
+				backup_policies:
+				  ${include:backups.yaml}
+				tags: [web]`,
+			),
+			want: undent.String(`
+				hostname: web-1
+				backup_policies:
+				  - retention: 7
+				    schedule:
+				      interval: daily
+				      frequency: 1
+				      time: 3
+				tags: [web]`,
+			),
+		},
+		{
+			name: "include with variables",
+			tmpl: &Template{
+				Vars: map[string]string{"RETENTION": "14"},
+				FS:   templateFS,
+			},
+			src: "${include:backups.yaml}",
+			want: undent.String(`
+				- retention: 14
+				  schedule:
+				    interval: daily
+				    frequency: 1
+				    time: 3`,
+			),
+		},
+		{
+			name: "include path with variable",
+			tmpl: &Template{
+				Vars: map[string]string{"ENV": "production"},
+				FS:   templateFS,
+			},
+			src: "${include:backups/${ENV}.yaml}",
+			want: undent.String(`
+				- retention: 30
+				  schedule:
+				    interval: hourly
+				    frequency: 6`,
+			),
+		},
+		{
+			name: "nested include relative to fragment",
+			tmpl: &Template{FS: templateFS},
+			src: undent.String(`
+				network_interfaces:
+				  - ${include:network/nic.yaml}`,
+			),
+			want: undent.String(`
+				network_interfaces:
+				  - network:
+				      permalink: public
+				    ip_address_allocations:
+				      - allocation_type: new
+				        ip_version: ipv4`,
+			),
+		},
+		{
+			name: "include keeps blank lines empty",
+			tmpl: &Template{FS: templateFS},
+			src:  "  ${include:blank-lines.txt}",
+			want: "  a:\n\n    b: 1",
+		},
+		{
+			name:  "include without file system",
+			tmpl:  &Template{},
+			src:   "${include:plain.txt}",
+			errIs: ErrTemplate,
+			errStr: `template: line 1, column 1: include "plain.txt": ` +
+				"no file system to include from",
+		},
+		{
+			name:  "include invalid path",
+			tmpl:  &Template{FS: templateFS},
+			src:   "${include:../plain.txt}",
+			errIs: ErrTemplate,
+			errStr: `template: line 1, column 1: include "../plain.txt": ` +
+				"invalid path",
+		},
+		{
+			name:  "include missing file",
+			tmpl:  &Template{FS: templateFS},
+			src:   "\n  ${include:missing.yaml}",
+			errIs: fs.ErrNotExist,
+			errStr: "template: line 2, column 3: include: " +
+				"open missing.yaml: file does not exist",
+		},
+		{
+			name:  "include cycle",
+			tmpl:  &Template{FS: templateFS},
+			src:   "${include:cycle/a.yaml}",
+			errIs: ErrTemplate,
+			errStr: "template: cycle/b.yaml: line 1, column 1: " +
+				`include "cycle/a.yaml": cycle via cycle/a.yaml, cycle/b.yaml`,
+		},
+		{
+			name:  "undefined variable in fragment",
+			tmpl:  &Template{FS: templateFS},
+			src:   "${include:undefined.yaml}",
+			errIs: ErrUndefinedVariable,
+			errStr: "template: undefined.yaml: line 1, column 7: " +
+				"undefined_variable: NOPE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			got, err := tt.tmpl.Render(strings.NewReader(tt.src))
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+
+			if tt.errStr != "" {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.errIs == nil && tt.errStr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestTemplate_FromFormats(t *testing.T) {
+	tmpl := &Template{
+		Vars: map[string]string{"ENV": "staging", "PACKAGE": "rock-3"},
+		FS:   templateFS,
+	}
+	want := &VirtualMachineSpec{
+		Hostname: "web-staging",
+		Resources: &Resources{
+			Package: &Package{Permalink: "rock-3"},
+		},
+		BackupPolicies: []*BackupPolicy{
+			{
+				Retention: 7,
+				Schedule: &Schedule{
+					Interval:  ScheduledDaily,
+					Frequency: 1,
+					Time:      3,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		parse func(*Template, string) (*VirtualMachineSpec, error)
+		src   string
+	}{
+		{
+			name: "JSON",
+			parse: func(t *Template, s string) (*VirtualMachineSpec, error) {
+				return t.FromJSON(strings.NewReader(s))
+			},
+			src: undent.String(`
+				{
+					"hostname": "web-${ENV}",
+					"resources": {"package": {"permalink": "${PACKAGE}"}},
+					"backup_policies": [
+						{
+							"retention": ${RETENTION:-7},
+							"schedule": {
+								"interval": "daily",
+								"frequency": 1,
+								"time": 3
+							}
+						}
+					]
+				}`,
+			),
+		},
+		{
+			name: "XML",
+			parse: func(t *Template, s string) (*VirtualMachineSpec, error) {
+				return t.FromXML(strings.NewReader(s))
+			},
+			src: undent.String(`
+				<VirtualMachineSpec>
+					<Resources>
+						<Package by="permalink">${PACKAGE}</Package>
+					</Resources>
+					<Hostname><Hostname>web-${ENV}</Hostname></Hostname>
+					<BackupPolicies>
+						<BackupPolicy>
+							<Retention>${RETENTION:-7}</Retention>
+							<Schedule>
+								<Interval>daily</Interval>
+								<Frequency>1</Frequency>
+								<Time>3</Time>
+							</Schedule>
+						</BackupPolicy>
+					</BackupPolicies>
+				</VirtualMachineSpec>`,
+			),
+		},
+		{
+			name: "YAML",
+			parse: func(t *Template, s string) (*VirtualMachineSpec, error) {
+				return t.FromYAML(strings.NewReader(s))
+			},
+			src: undent.String(`
+				hostname: web-${ENV}
+				resources:
+				  package:
+				    permalink: ${PACKAGE}
+				backup_policies:
+				  ${include:backups.yaml}`,
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tmpl, tt.src)
+			require.NoError(t, err)
+
+			assert.Equal(t, want, got)
+		})
+
+		t.Run(tt.name+" undefined variable", func(t *testing.T) {
+			got, err := tt.parse(&Template{}, tt.src)
+
+			assert.ErrorIs(t, err, ErrUndefinedVariable)
+			assert.Equal(t, &VirtualMachineSpec{}, got)
+		})
+	}
+}
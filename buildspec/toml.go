@@ -0,0 +1,73 @@
+package buildspec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// writeTOML writes v, a pointer to a struct with toml struct tags, as a TOML
+// document.
+func writeTOML(w io.Writer, v any) error {
+	enc := toml.NewEncoder(w)
+	enc.Indent = ""
+
+	return enc.Encode(v)
+}
+
+// readTOML parses the TOML document in r into v, a pointer to a struct with
+// toml struct tags, rejecting keys which are not decoded into a field.
+func readTOML(r io.Reader, v any) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	md, err := toml.NewDecoder(bytes.NewReader(src)).Decode(v)
+	if err != nil {
+		return tomlError(src, err)
+	}
+
+	if keys := md.Undecoded(); len(keys) > 0 {
+		return &TOMLError{
+			Err: fmt.Errorf("unknown field %q", keys[0].String()),
+		}
+	}
+
+	return nil
+}
+
+// tomlError returns err as a *TOMLError, with the position of syntax errors
+// in src.
+func tomlError(src []byte, err error) error {
+	var pe toml.ParseError
+	if !errors.As(err, &pe) {
+		return &TOMLError{Err: err}
+	}
+
+	// Errors from the lexer have no Message, so take it from Error without
+	// the position it starts with.
+	msg := pe.Message
+	if msg == "" {
+		prefix := fmt.Sprintf("toml: line %d: ", pe.Position.Line)
+		if pe.LastKey != "" {
+			prefix = fmt.Sprintf(
+				"toml: line %d (last key %q): ", pe.Position.Line, pe.LastKey,
+			)
+		}
+		msg = strings.TrimPrefix(pe.Error(), prefix)
+	}
+
+	start := min(pe.Position.Start, len(src))
+	column := start - bytes.LastIndexByte(src[:start], '\n')
+
+	return &TOMLError{
+		Line:   pe.Position.Line,
+		Column: column,
+		Err:    errors.New(msg),
+	}
+}
@@ -6,8 +6,8 @@ import (
 )
 
 type User struct {
-	ID           string `json:"id,omitempty" yaml:"id,omitempty"`
-	EmailAddress string `json:"email_address,omitempty" yaml:"email_address,omitempty"`
+	ID           string `json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
+	EmailAddress string `json:"email_address,omitempty" yaml:"email_address,omitempty" hcl:"email_address,optional" toml:"email_address,omitempty"`
 }
 
 func (s *User) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -29,7 +29,10 @@ func (s *User) UnmarshalXML(
 	start xml.StartElement,
 ) error {
 	x := &xmlUser{}
-	_ = d.DecodeElement(&x, &start)
+	err := decodeXMLElement(d, &x, &start)
+	if err != nil {
+		return err
+	}
 
 	v := User{}
 
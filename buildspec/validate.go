@@ -0,0 +1,315 @@
+package buildspec
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var hostnameLabelPattern = regexp.MustCompile(
+	`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`,
+)
+
+// Validate checks the spec for problems which would cause the API to reject
+// it, without making any requests. It returns a *ValidationError listing
+// every problem found, or nil if the spec is valid.
+//
+// Validation is limited to the structure of the spec. References to
+// resources, such as data centers and packages, are not checked to exist.
+func (s *VirtualMachineSpec) Validate() error {
+	v := &validator{}
+
+	if s.Zone == nil && s.DataCenter == nil {
+		v.add("zone", "zone or data_center is required")
+	}
+	if s.Zone != nil {
+		v.lookup("zone", "id", s.Zone.ID, "permalink", s.Zone.Permalink)
+	}
+	if s.DataCenter != nil {
+		v.lookup("data_center",
+			"id", s.DataCenter.ID,
+			"name", s.DataCenter.Name,
+			"permalink", s.DataCenter.Permalink,
+		)
+	}
+
+	s.validateResources(v)
+
+	if s.DiskTemplate != nil {
+		s.validateDiskTemplate(v)
+	}
+
+	s.validateSystemDisks(v)
+
+	for i, d := range s.SharedDisks {
+		v.lookup(index("shared_disks", i), "id", d.ID, "name", d.Name)
+	}
+
+	for i, nic := range s.NetworkInterfaces {
+		validateNetworkInterface(v, index("network_interfaces", i), nic)
+	}
+
+	if s.Hostname != "" && !validHostname(s.Hostname) {
+		v.add("hostname", "%q is not a valid hostname", s.Hostname)
+	}
+
+	if s.Group != nil {
+		v.lookup("group", "id", s.Group.ID, "name", s.Group.Name)
+	}
+
+	if s.AuthorizedKeys != nil {
+		for i, u := range s.AuthorizedKeys.Users {
+			v.lookup(index("authorized_keys.users", i),
+				"id", u.ID, "email_address", u.EmailAddress,
+			)
+		}
+	}
+
+	for i, bp := range s.BackupPolicies {
+		validateBackupPolicy(v, index("backup_policies", i), bp)
+	}
+
+	return v.err()
+}
+
+func (s *VirtualMachineSpec) validateResources(v *validator) {
+	r := s.Resources
+	if r == nil {
+		v.add("resources", "is required")
+
+		return
+	}
+
+	if r.Package != nil {
+		v.lookup("resources.package",
+			"id", r.Package.ID, "permalink", r.Package.Permalink,
+		)
+
+		return
+	}
+
+	switch {
+	case r.Memory == 0 && r.CPUCores == 0:
+		v.add("resources", "package, or memory and cpu_cores are required")
+	case r.Memory <= 0:
+		v.add("resources.memory", "must be greater than 0")
+	case r.CPUCores <= 0:
+		v.add("resources.cpu_cores", "must be greater than 0")
+	}
+}
+
+func (s *VirtualMachineSpec) validateDiskTemplate(v *validator) {
+	dt := s.DiskTemplate
+	v.lookup("disk_template", "id", dt.ID, "permalink", dt.Permalink)
+
+	if dt.Version < 0 {
+		v.add("disk_template.version", "must not be negative")
+	}
+
+	keys := map[string]bool{}
+	for i, o := range dt.Options {
+		field := index("disk_template.options", i)
+		switch {
+		case o.Key == "":
+			v.add(field+".key", "is required")
+		case keys[o.Key]:
+			v.add(field+".key", "duplicate option %q", o.Key)
+		}
+		keys[o.Key] = true
+	}
+}
+
+func (s *VirtualMachineSpec) validateSystemDisks(v *validator) {
+	names := map[string]bool{}
+	for i, d := range s.SystemDisks {
+		field := index("system_disks", i)
+
+		if d.Name != "" {
+			if names[d.Name] {
+				v.add(field+".name", "duplicate disk name %q", d.Name)
+			}
+			names[d.Name] = true
+		}
+
+		validateSystemDisk(v, field, d)
+	}
+}
+
+func validateSystemDisk(v *validator, field string, d *SystemDisk) {
+	if d.Size <= 0 {
+		v.add(field+".size", "must be greater than 0")
+	}
+
+	if d.IOProfile != nil {
+		v.lookup(field+".io_profile",
+			"id", d.IOProfile.ID, "permalink", d.IOProfile.Permalink,
+		)
+	}
+
+	for j, bp := range d.BackupPolicies {
+		validateBackupPolicy(
+			v, fmt.Sprintf("%s.backup_policies[%d]", field, j), bp,
+		)
+	}
+}
+
+func validateNetworkInterface(
+	v *validator,
+	field string,
+	nic *NetworkInterface,
+) {
+	if nic.Network != nil && nic.VirtualNetwork != nil {
+		v.add(field, "network and virtual_network are mutually exclusive")
+	}
+	if nic.Network != nil {
+		v.lookup(field+".network",
+			"id", nic.Network.ID, "permalink", nic.Network.Permalink,
+		)
+	}
+	if nic.VirtualNetwork != nil && nic.VirtualNetwork.ID == "" {
+		v.add(field+".virtual_network.id", "is required")
+	}
+	if nic.SpeedProfile != nil {
+		v.lookup(field+".speed_profile",
+			"id", nic.SpeedProfile.ID,
+			"permalink", nic.SpeedProfile.Permalink,
+		)
+	}
+
+	for i, a := range nic.IPAddressAllocations {
+		validateIPAddressAllocation(
+			v, fmt.Sprintf("%s.ip_address_allocations[%d]", field, i), a,
+		)
+	}
+}
+
+func validateIPAddressAllocation(
+	v *validator,
+	field string,
+	a *IPAddressAllocation,
+) {
+	if a.Version != 0 && a.Version != IPv4 && a.Version != IPv6 {
+		v.add(field+".version", "must be 4 or 6")
+	}
+	if a.Subnet != nil {
+		v.lookup(field+".subnet",
+			"id", a.Subnet.ID, "address", a.Subnet.Address,
+		)
+	}
+
+	switch a.Type {
+	case NewIPAddressAllocation:
+		if a.IPAddress != nil {
+			v.add(field+".ip_address", "must not be set for new allocations")
+		}
+		if a.Version == 0 && a.Subnet == nil {
+			v.add(field+".version", "version or subnet is required")
+		}
+	case ExistingIPAddressAllocation:
+		if a.IPAddress == nil {
+			v.add(field+".ip_address", "is required")
+		} else {
+			v.lookup(field+".ip_address",
+				"id", a.IPAddress.ID, "address", a.IPAddress.Address,
+			)
+		}
+		if a.Version != 0 {
+			v.add(field+".version",
+				"must not be set for existing allocations",
+			)
+		}
+		if a.Subnet != nil {
+			v.add(field+".subnet", "must not be set for existing allocations")
+		}
+	case "":
+		v.add(field+".type", "is required")
+	default:
+		v.add(field+".type", "%q is not one of %q or %q",
+			a.Type, NewIPAddressAllocation, ExistingIPAddressAllocation,
+		)
+	}
+}
+
+func validateBackupPolicy(v *validator, field string, bp *BackupPolicy) {
+	if bp.Retention <= 0 {
+		v.add(field+".retention", "must be greater than 0")
+	}
+
+	sch := bp.Schedule
+	if sch == nil {
+		return
+	}
+
+	switch sch.Interval {
+	case ScheduledHourly, ScheduledDaily, ScheduledWeekly, ScheduledMonthly:
+	case "":
+		v.add(field+".schedule.interval", "is required")
+	default:
+		v.add(field+".schedule.interval",
+			"%q is not one of hourly, daily, weekly or monthly", sch.Interval,
+		)
+	}
+	if sch.Frequency < 1 {
+		v.add(field+".schedule.frequency", "must be at least 1")
+	}
+	if sch.Time < 0 || sch.Time > 23 {
+		v.add(field+".schedule.time", "must be an hour between 0 and 23")
+	}
+}
+
+// validHostname reports whether h is a valid RFC 1123 hostname.
+func validHostname(h string) bool {
+	if len(h) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(h, ".") {
+		if !hostnameLabelPattern.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validator collects field errors found during validation.
+type validator struct {
+	errs []*FieldError
+}
+
+func (v *validator) add(field string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// lookup validates that exactly one of a lookup's keys has a value. Keys and
+// values are given as alternating pairs of arguments.
+func (v *validator) lookup(field string, pairs ...string) {
+	var keys, set []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		keys = append(keys, pairs[i])
+		if pairs[i+1] != "" {
+			set = append(set, pairs[i])
+		}
+	}
+
+	switch {
+	case len(set) == 0:
+		v.add(field, "one of %s is required", strings.Join(keys, ", "))
+	case len(set) > 1:
+		v.add(field, "%s are mutually exclusive", strings.Join(set, ", "))
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Errors: v.errs}
+}
+
+func index(field string, i int) string {
+	return fmt.Sprintf("%s[%d]", field, i)
+}
@@ -0,0 +1,330 @@
+package buildspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualMachineSpec_Validate(t *testing.T) {
+	valid := func() *VirtualMachineSpec {
+		return &VirtualMachineSpec{
+			Hostname:   "web-3",
+			DataCenter: &DataCenter{Permalink: "uk-lon-01"},
+			Resources:  &Resources{Package: &Package{Permalink: "rock-3"}},
+			DiskTemplate: &DiskTemplate{
+				Permalink: "templates/ubuntu-18-04",
+			},
+			SystemDisks: []*SystemDisk{
+				{Name: "System Disk", Size: 10},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *VirtualMachineSpec)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			modify: func(s *VirtualMachineSpec) {},
+		},
+		{
+			name: "valid with custom resources and zone",
+			modify: func(s *VirtualMachineSpec) {
+				s.DataCenter = nil
+				s.Zone = &Zone{ID: "zone_xmVotL1zwMwo2eXf"}
+				s.Resources = &Resources{Memory: 4, CPUCores: 2}
+				s.Hostname = "Web-3.example.com"
+			},
+		},
+		{
+			name: "empty",
+			modify: func(s *VirtualMachineSpec) {
+				*s = VirtualMachineSpec{}
+			},
+			want: []string{
+				"zone: zone or data_center is required",
+				"resources: is required",
+			},
+		},
+		{
+			name: "ambiguous lookups",
+			modify: func(s *VirtualMachineSpec) {
+				s.Zone = &Zone{ID: "zone_1", Permalink: "uk-lon-01-a"}
+				s.DataCenter = &DataCenter{
+					ID: "dc_1", Name: "London", Permalink: "uk-lon-01",
+				}
+				s.DiskTemplate = &DiskTemplate{
+					ID: "dtpl_1", Permalink: "templates/ubuntu-18-04",
+				}
+			},
+			want: []string{
+				"zone: id, permalink are mutually exclusive",
+				"data_center: id, name, permalink are mutually exclusive",
+				"disk_template: id, permalink are mutually exclusive",
+			},
+		},
+		{
+			name: "empty lookups",
+			modify: func(s *VirtualMachineSpec) {
+				s.DataCenter = &DataCenter{}
+				s.Resources.Package = &Package{}
+				s.Group = &Group{}
+			},
+			want: []string{
+				"data_center: one of id, name, permalink is required",
+				"resources.package: one of id, permalink is required",
+				"group: one of id, name is required",
+			},
+		},
+		{
+			name: "incomplete custom resources",
+			modify: func(s *VirtualMachineSpec) {
+				s.Resources = &Resources{Memory: 4}
+			},
+			want: []string{"resources.cpu_cores: must be greater than 0"},
+		},
+		{
+			name: "empty resources",
+			modify: func(s *VirtualMachineSpec) {
+				s.Resources = &Resources{}
+			},
+			want: []string{
+				"resources: package, or memory and cpu_cores are required",
+			},
+		},
+		{
+			name: "disk template options",
+			modify: func(s *VirtualMachineSpec) {
+				s.DiskTemplate.Version = -1
+				s.DiskTemplate.Options = []*DiskTemplateOption{
+					{Key: "foo", Value: "1"},
+					{Value: "2"},
+					{Key: "foo", Value: "3"},
+				}
+			},
+			want: []string{
+				"disk_template.version: must not be negative",
+				"disk_template.options[1].key: is required",
+				`disk_template.options[2].key: duplicate option "foo"`,
+			},
+		},
+		{
+			name: "system disks",
+			modify: func(s *VirtualMachineSpec) {
+				s.SystemDisks = append(s.SystemDisks,
+					&SystemDisk{Name: "System Disk"},
+					&SystemDisk{Size: 20, IOProfile: &DiskIOProfile{}},
+				)
+			},
+			want: []string{
+				`system_disks[1].name: duplicate disk name "System Disk"`,
+				"system_disks[1].size: must be greater than 0",
+				"system_disks[2].io_profile: " +
+					"one of id, permalink is required",
+			},
+		},
+		{
+			name: "shared disks",
+			modify: func(s *VirtualMachineSpec) {
+				s.SharedDisks = []*SharedDisk{
+					{ID: "disk_1"},
+					{ID: "disk_2", Name: "data"},
+				}
+			},
+			want: []string{
+				"shared_disks[1]: id, name are mutually exclusive",
+			},
+		},
+		{
+			name: "backup policies",
+			modify: func(s *VirtualMachineSpec) {
+				s.BackupPolicies = []*BackupPolicy{
+					{
+						Retention: 7,
+						Schedule: &Schedule{
+							Interval: ScheduledDaily, Frequency: 1, Time: 3,
+						},
+					},
+					{},
+					{
+						Retention: 1,
+						Schedule: &Schedule{
+							Interval: "yearly", Frequency: -1, Time: 24,
+						},
+					},
+				}
+				s.SystemDisks[0].BackupPolicies = []*BackupPolicy{
+					{Retention: 1, Schedule: &Schedule{}},
+				}
+			},
+			want: []string{
+				"system_disks[0].backup_policies[0].schedule.interval: " +
+					"is required",
+				"system_disks[0].backup_policies[0].schedule.frequency: " +
+					"must be at least 1",
+				"backup_policies[1].retention: must be greater than 0",
+				`backup_policies[2].schedule.interval: "yearly" is not ` +
+					"one of hourly, daily, weekly or monthly",
+				"backup_policies[2].schedule.frequency: must be at least 1",
+				"backup_policies[2].schedule.time: " +
+					"must be an hour between 0 and 23",
+			},
+		},
+		{
+			name: "network interfaces",
+			modify: func(s *VirtualMachineSpec) {
+				s.NetworkInterfaces = []*NetworkInterface{
+					{
+						Network:      &Network{Permalink: "public"},
+						SpeedProfile: &NetworkSpeedProfile{Permalink: "1gbps"},
+						IPAddressAllocations: []*IPAddressAllocation{
+							{Type: NewIPAddressAllocation, Version: IPv4},
+							{
+								Type:      ExistingIPAddressAllocation,
+								IPAddress: &IPAddress{Address: "10.0.0.1"},
+							},
+							{
+								Type:   NewIPAddressAllocation,
+								Subnet: &Subnet{ID: "sn_1"},
+							},
+						},
+					},
+					{
+						Network:        &Network{ID: "netw_1"},
+						VirtualNetwork: &VirtualNetwork{},
+						SpeedProfile:   &NetworkSpeedProfile{},
+					},
+				}
+			},
+			want: []string{
+				"network_interfaces[1]: " +
+					"network and virtual_network are mutually exclusive",
+				"network_interfaces[1].virtual_network.id: is required",
+				"network_interfaces[1].speed_profile: " +
+					"one of id, permalink is required",
+			},
+		},
+		{
+			name: "ip address allocations",
+			modify: func(s *VirtualMachineSpec) {
+				s.NetworkInterfaces = []*NetworkInterface{
+					{
+						Network: &Network{Permalink: "public"},
+						IPAddressAllocations: []*IPAddressAllocation{
+							{
+								Type:      NewIPAddressAllocation,
+								IPAddress: &IPAddress{ID: "ip_1"},
+							},
+							{
+								Type:    ExistingIPAddressAllocation,
+								Version: IPv6,
+								Subnet:  &Subnet{ID: "sn_1"},
+							},
+							{Version: 5},
+							{Type: "reserved", Version: IPv4},
+						},
+					},
+				}
+			},
+			want: []string{
+				"network_interfaces[0].ip_address_allocations[0]" +
+					".ip_address: must not be set for new allocations",
+				"network_interfaces[0].ip_address_allocations[0]" +
+					".version: version or subnet is required",
+				"network_interfaces[0].ip_address_allocations[1]" +
+					".ip_address: is required",
+				"network_interfaces[0].ip_address_allocations[1]" +
+					".version: must not be set for existing allocations",
+				"network_interfaces[0].ip_address_allocations[1]" +
+					".subnet: must not be set for existing allocations",
+				"network_interfaces[0].ip_address_allocations[2]" +
+					".version: must be 4 or 6",
+				"network_interfaces[0].ip_address_allocations[2]" +
+					".type: is required",
+				"network_interfaces[0].ip_address_allocations[3]" +
+					`.type: "reserved" is not one of "new" or "existing"`,
+			},
+		},
+		{
+			name: "invalid hostnames",
+			modify: func(s *VirtualMachineSpec) {
+				s.Hostname = "-web_3"
+			},
+			want: []string{`hostname: "-web_3" is not a valid hostname`},
+		},
+		{
+			name: "authorized key users",
+			modify: func(s *VirtualMachineSpec) {
+				s.AuthorizedKeys = &AuthorizedKeys{
+					Users: []*User{
+						{ID: "user_1"},
+						{ID: "user_2", EmailAddress: "jane@example.com"},
+					},
+				}
+			},
+			want: []string{
+				"authorized_keys.users[1]: " +
+					"id, email_address are mutually exclusive",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			tt.modify(s)
+
+			err := s.Validate()
+
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			got := make([]string, 0, len(verr.Errors))
+			for _, fe := range verr.Errors {
+				got = append(got, fe.Error())
+			}
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, ErrValidation)
+		})
+	}
+}
+
+func TestVirtualMachineSpec_Validate_fixture(t *testing.T) {
+	// The full fixture includes retention-only backup policies, which do not
+	// require a schedule.
+	err := fixtureVirtualMachineSpecFullStruct.Validate()
+
+	assert.NoError(t, err)
+}
+
+func Test_validHostname(t *testing.T) {
+	tests := []struct {
+		hostname string
+		want     bool
+	}{
+		{hostname: "web-3", want: true},
+		{hostname: "WEB3", want: true},
+		{hostname: "web-3.example.com", want: true},
+		{hostname: "a", want: true},
+		{hostname: "", want: false},
+		{hostname: "-web", want: false},
+		{hostname: "web-", want: false},
+		{hostname: "web_3", want: false},
+		{hostname: "web..example", want: false},
+		{hostname: strings.Repeat("a", 64), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			assert.Equal(t, tt.want, validHostname(tt.hostname))
+		})
+	}
+}
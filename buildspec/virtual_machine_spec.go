@@ -10,24 +10,24 @@ import (
 )
 
 // VirtualMachineSpec is the top-level object representing a virtual machine
-// build specification. It can be created manually, or parsed from XML, JSON, or
-// YAML, and can output itself as XML, JSON, and YAML.
+// build specification. It can be created manually, or parsed from XML, JSON,
+// YAML, HCL, or TOML, and can output itself in any of those formats.
 type VirtualMachineSpec struct {
-	Zone              *Zone               `json:"zone,omitempty" yaml:"zone,omitempty"`
-	DataCenter        *DataCenter         `json:"data_center,omitempty" yaml:"data_center,omitempty"`
-	Resources         *Resources          `json:"resources,omitempty" yaml:"resources,omitempty"`
-	DiskTemplate      *DiskTemplate       `json:"disk_template,omitempty" yaml:"disk_template,omitempty"`
-	SystemDisks       []*SystemDisk       `json:"system_disks,omitempty" yaml:"system_disks,omitempty"`
-	SharedDisks       []*SharedDisk       `json:"shared_disks,omitempty" yaml:"shared_disks,omitempty"`
-	NetworkInterfaces []*NetworkInterface `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty"`
-	Hostname          string              `json:"hostname,omitempty" yaml:"hostname,omitempty"`
-	Name              string              `json:"name,omitempty" yaml:"name,omitempty"`
-	Description       string              `json:"description,omitempty" yaml:"description,omitempty"`
-	Group             *Group              `json:"group,omitempty" yaml:"group,omitempty"`
-	AuthorizedKeys    *AuthorizedKeys     `json:"authorized_keys,omitempty" yaml:"authorized_keys,omitempty"`
-	BackupPolicies    []*BackupPolicy     `json:"backup_policies,omitempty" yaml:"backup_policies,omitempty"`
-	Tags              []string            `json:"tags,omitempty" yaml:"tags,omitempty"`
-	ISO               string              `json:"iso,omitempty" yaml:"iso,omitempty"`
+	Zone              *Zone               `json:"zone,omitempty" yaml:"zone,omitempty" hcl:"zone,block" toml:"zone,omitempty"`
+	DataCenter        *DataCenter         `json:"data_center,omitempty" yaml:"data_center,omitempty" hcl:"data_center,block" toml:"data_center,omitempty"`
+	Resources         *Resources          `json:"resources,omitempty" yaml:"resources,omitempty" hcl:"resources,block" toml:"resources,omitempty"`
+	DiskTemplate      *DiskTemplate       `json:"disk_template,omitempty" yaml:"disk_template,omitempty" hcl:"disk_template,block" toml:"disk_template,omitempty"`
+	SystemDisks       []*SystemDisk       `json:"system_disks,omitempty" yaml:"system_disks,omitempty" hcl:"system_disk,block" toml:"system_disks,omitempty"`
+	SharedDisks       []*SharedDisk       `json:"shared_disks,omitempty" yaml:"shared_disks,omitempty" hcl:"shared_disk,block" toml:"shared_disks,omitempty"`
+	NetworkInterfaces []*NetworkInterface `json:"network_interfaces,omitempty" yaml:"network_interfaces,omitempty" hcl:"network_interface,block" toml:"network_interfaces,omitempty"`
+	Hostname          string              `json:"hostname,omitempty" yaml:"hostname,omitempty" hcl:"hostname,optional" toml:"hostname,omitempty"`
+	Name              string              `json:"name,omitempty" yaml:"name,omitempty" hcl:"name,optional" toml:"name,omitempty"`
+	Description       string              `json:"description,omitempty" yaml:"description,omitempty" hcl:"description,optional" toml:"description,omitempty"`
+	Group             *Group              `json:"group,omitempty" yaml:"group,omitempty" hcl:"group,block" toml:"group,omitempty"`
+	AuthorizedKeys    *AuthorizedKeys     `json:"authorized_keys,omitempty" yaml:"authorized_keys,omitempty" hcl:"authorized_keys,block" toml:"authorized_keys,omitempty"`
+	BackupPolicies    []*BackupPolicy     `json:"backup_policies,omitempty" yaml:"backup_policies,omitempty" hcl:"backup_policy,block" toml:"backup_policies,omitempty"`
+	Tags              []string            `json:"tags,omitempty" yaml:"tags,omitempty" hcl:"tags,optional" toml:"tags,omitempty"`
+	ISO               string              `json:"iso,omitempty" yaml:"iso,omitempty" hcl:"iso,optional" toml:"iso,omitempty"`
 }
 
 // JSON returns the build spec in JSON format as a byte slice.
@@ -102,6 +102,32 @@ func (s *VirtualMachineSpec) WriteYAML(w io.Writer) error {
 	return enc.Encode(s)
 }
 
+// HCL returns the build spec in HCL format as a byte slice.
+func (s *VirtualMachineSpec) HCL() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := s.WriteHCL(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteHCL writes the build spec in HCL format to given io.Writer.
+func (s *VirtualMachineSpec) WriteHCL(w io.Writer) error {
+	return writeHCL(w, s)
+}
+
+// TOML returns the build spec in TOML format as a byte slice.
+func (s *VirtualMachineSpec) TOML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := s.WriteTOML(buf)
+
+	return buf.Bytes(), err
+}
+
+// WriteTOML writes the build spec in TOML format to given io.Writer.
+func (s *VirtualMachineSpec) WriteTOML(w io.Writer) error {
+	return writeTOML(w, s)
+}
+
 func (s *VirtualMachineSpec) MarshalXML(
 	e *xml.Encoder,
 	start xml.StartElement,
@@ -1,6 +1,7 @@
 package buildspec_test
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/krystal/go-katapult/buildspec"
@@ -131,3 +132,29 @@ func ExampleVirtualMachineSpec_YAML() {
 	//   permalink: templates/ubuntu-18-04
 	// hostname: web-3
 }
+
+func ExampleVirtualMachineSpec_Validate() {
+	spec := &buildspec.VirtualMachineSpec{
+		DataCenter: &buildspec.DataCenter{Permalink: "london"},
+		Resources: &buildspec.Resources{
+			Package: &buildspec.Package{ID: "vmpkg_1", Permalink: "rock-3"},
+		},
+		SystemDisks: []*buildspec.SystemDisk{
+			{Name: "System Disk", Size: 10},
+			{Name: "System Disk"},
+		},
+	}
+
+	err := spec.Validate()
+
+	var verr *buildspec.ValidationError
+	if errors.As(err, &verr) {
+		for _, fe := range verr.Errors {
+			fmt.Println(fe)
+		}
+	}
+	// Output:
+	// resources.package: id, permalink are mutually exclusive
+	// system_disks[1].name: duplicate disk name "System Disk"
+	// system_disks[1].size: must be greater than 0
+}
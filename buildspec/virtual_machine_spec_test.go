@@ -24,143 +24,145 @@ var fixtureVirtualMachineSpecBasicStruct = &VirtualMachineSpec{
 	},
 }
 
-func TestVirtualMachineSpec_Marshaling(t *testing.T) {
-	tests := []struct {
-		name string
-		obj  *VirtualMachineSpec
-	}{
-		{
-			name: "empty",
-			obj:  &VirtualMachineSpec{},
+var fixtureVirtualMachineSpecFullStruct = &VirtualMachineSpec{
+	Zone: &Zone{
+		ID: "zone_xmVotL1zwMwo2eXf",
+	},
+	DataCenter: &DataCenter{
+		ID: "dc_0KVdXStXduYtcypG",
+	},
+	Resources: &Resources{
+		Package:  &Package{ID: "vmpkg_m7mV5O0MafbDFp2n"},
+		Memory:   16,
+		CPUCores: 4,
+	},
+	DiskTemplate: &DiskTemplate{
+		ID:      "dtpl_rlinMl51Lb1uvTez",
+		Version: 4,
+		Options: []*DiskTemplateOption{
+			{Key: "foo", Value: "bar"},
+			{Key: "hello", Value: "world"},
 		},
+	},
+	SystemDisks: []*SystemDisk{
 		{
-			name: "full",
-			obj: &VirtualMachineSpec{
-				Zone: &Zone{
-					ID: "zone_xmVotL1zwMwo2eXf",
-				},
-				DataCenter: &DataCenter{
-					ID: "dc_0KVdXStXduYtcypG",
-				},
-				Resources: &Resources{
-					Package:  &Package{ID: "vmpkg_m7mV5O0MafbDFp2n"},
-					Memory:   16,
-					CPUCores: 4,
-				},
-				DiskTemplate: &DiskTemplate{
-					ID:      "dtpl_rlinMl51Lb1uvTez",
-					Version: 4,
-					Options: []*DiskTemplateOption{
-						{Key: "foo", Value: "bar"},
-						{Key: "hello", Value: "world"},
+			Name:  "System Disk",
+			Size:  10,
+			Speed: "ssd",
+			IOProfile: &DiskIOProfile{
+				ID: "diop_xPlNw7iDmrGOnPRA",
+			},
+			FileSystemType: "ext4",
+			BackupPolicies: []*BackupPolicy{
+				{
+					Retention: 24,
+					Schedule: &Schedule{
+						Interval:  ScheduledDaily,
+						Frequency: 1,
+						Time:      13,
 					},
 				},
-				SystemDisks: []*SystemDisk{
-					{
-						Name:  "System Disk",
-						Size:  10,
-						Speed: "ssd",
-						IOProfile: &DiskIOProfile{
-							ID: "diop_xPlNw7iDmrGOnPRA",
-						},
-						FileSystemType: "ext4",
-						BackupPolicies: []*BackupPolicy{
-							{
-								Retention: 24,
-								Schedule: &Schedule{
-									Interval:  ScheduledDaily,
-									Frequency: 1,
-									Time:      13,
-								},
-							},
-							{
-								Retention: 30,
-							},
-						},
-					},
-					{
-						Name:  "Another Disk",
-						Size:  22,
-						Speed: "nvme",
-					},
+				{
+					Retention: 30,
 				},
-				SharedDisks: []*SharedDisk{
-					{ID: "disk_gJRNxe3h7zi0Hdh5"},
-					{Name: "image-uploads"},
+			},
+		},
+		{
+			Name:  "Another Disk",
+			Size:  22,
+			Speed: "nvme",
+		},
+	},
+	SharedDisks: []*SharedDisk{
+		{ID: "disk_gJRNxe3h7zi0Hdh5"},
+		{Name: "image-uploads"},
+	},
+	NetworkInterfaces: []*NetworkInterface{
+		{
+			Network: &Network{ID: "netw_DRIS3BaTWfKaHlWW"},
+			SpeedProfile: &NetworkSpeedProfile{
+				ID: "nsp_eHwC5NG3DRAHzVfD",
+			},
+		},
+		{
+			Network: &Network{ID: "netw_17w3MepxvWE4J3Zx"},
+			SpeedProfile: &NetworkSpeedProfile{
+				ID: "nsp_bFQhDNAluyp4t2A9",
+			},
+			IPAddressAllocations: []*IPAddressAllocation{
+				{
+					Type:    NewIPAddressAllocation,
+					Version: IPv4,
 				},
-				NetworkInterfaces: []*NetworkInterface{
-					{
-						Network: &Network{ID: "netw_DRIS3BaTWfKaHlWW"},
-						SpeedProfile: &NetworkSpeedProfile{
-							ID: "nsp_eHwC5NG3DRAHzVfD",
-						},
-					},
-					{
-						Network: &Network{ID: "netw_17w3MepxvWE4J3Zx"},
-						SpeedProfile: &NetworkSpeedProfile{
-							ID: "nsp_bFQhDNAluyp4t2A9",
-						},
-						IPAddressAllocations: []*IPAddressAllocation{
-							{
-								Type:    NewIPAddressAllocation,
-								Version: IPv4,
-							},
-							{
-								Type:    NewIPAddressAllocation,
-								Version: IPv6,
-							},
-							{
-								Type:    NewIPAddressAllocation,
-								Version: IPv4,
-								Subnet:  &Subnet{ID: "sbnt_xxhvuhr3dsvEHcM5"},
-							},
-							{
-								Type:    NewIPAddressAllocation,
-								Version: IPv6,
-								Subnet:  &Subnet{ID: "sbnt_Pms921K2pYf35nae"},
-							},
-							{
-								Type: ExistingIPAddressAllocation,
-								IPAddress: &IPAddress{
-									ID: "ip_Hb8WpvV9qRMznHwZ",
-								},
-							},
-						},
-					},
-					{
-						VirtualNetwork: &VirtualNetwork{
-							ID: "vnet_Cuc45YcBaUhWqx6u",
-						},
-					},
+				{
+					Type:    NewIPAddressAllocation,
+					Version: IPv6,
 				},
-				Hostname:    "bitter-beautiful-mango",
-				Name:        "web-1",
-				Description: "Web Server #1",
-				Group:       &Group{ID: "vmgrp_dZDXXLw7e54Ep6CG"},
-				AuthorizedKeys: &AuthorizedKeys{
-					AllSSHKeys: true,
-					Users: []*User{
-						{ID: "user_yUfYcKHgU1ywBWzP"},
-						{EmailAddress: "jane@doe.com"},
-					},
+				{
+					Type:    NewIPAddressAllocation,
+					Version: IPv4,
+					Subnet:  &Subnet{ID: "sbnt_xxhvuhr3dsvEHcM5"},
 				},
-				BackupPolicies: []*BackupPolicy{
-					{
-						Retention: 24,
-						Schedule: &Schedule{
-							Interval:  ScheduledWeekly,
-							Frequency: 1,
-							Time:      13,
-						},
-					},
-					{
-						Retention: 30,
+				{
+					Type:    NewIPAddressAllocation,
+					Version: IPv6,
+					Subnet:  &Subnet{ID: "sbnt_Pms921K2pYf35nae"},
+				},
+				{
+					Type: ExistingIPAddressAllocation,
+					IPAddress: &IPAddress{
+						ID: "ip_Hb8WpvV9qRMznHwZ",
 					},
 				},
-				Tags: []string{"ha", "db", "web"},
-				ISO:  "iso_R6hPTR62bTSj5hQe",
 			},
 		},
+		{
+			VirtualNetwork: &VirtualNetwork{
+				ID: "vnet_Cuc45YcBaUhWqx6u",
+			},
+		},
+	},
+	Hostname:    "bitter-beautiful-mango",
+	Name:        "web-1",
+	Description: "Web Server #1",
+	Group:       &Group{ID: "vmgrp_dZDXXLw7e54Ep6CG"},
+	AuthorizedKeys: &AuthorizedKeys{
+		AllSSHKeys: true,
+		Users: []*User{
+			{ID: "user_yUfYcKHgU1ywBWzP"},
+			{EmailAddress: "jane@doe.com"},
+		},
+	},
+	BackupPolicies: []*BackupPolicy{
+		{
+			Retention: 24,
+			Schedule: &Schedule{
+				Interval:  ScheduledWeekly,
+				Frequency: 1,
+				Time:      13,
+			},
+		},
+		{
+			Retention: 30,
+		},
+	},
+	Tags: []string{"ha", "db", "web"},
+	ISO:  "iso_R6hPTR62bTSj5hQe",
+}
+
+func TestVirtualMachineSpec_Marshaling(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *VirtualMachineSpec
+	}{
+		{
+			name: "empty",
+			obj:  &VirtualMachineSpec{},
+		},
+		{
+			name: "full",
+			obj:  fixtureVirtualMachineSpecFullStruct,
+		},
 	}
 	for _, tt := range tests {
 		t.Run("json_"+tt.name, func(t *testing.T) {
@@ -561,6 +563,126 @@ func TestVirtualMachineSpec_ToFromYAML(t *testing.T) {
 	}
 }
 
+func TestVirtualMachineSpec_ToFromHCL(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *VirtualMachineSpec
+	}{
+		{
+			name: "empty spec",
+			spec: &VirtualMachineSpec{},
+		},
+		{
+			name: "basic spec",
+			spec: fixtureVirtualMachineSpecBasicStruct,
+		},
+		{
+			name: "full spec",
+			spec: fixtureVirtualMachineSpecFullStruct,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marshaled, err := tt.spec.HCL()
+			require.NoError(t, err, "failed marshaling with HCL()")
+
+			buf := &bytes.Buffer{}
+			err = tt.spec.WriteHCL(buf)
+			require.NoError(t, err, "failed marshaling with WriteHCL()")
+
+			assert.Equal(t, string(marshaled), buf.String(),
+				"output of WriteHCL() does not match that of HCL()",
+			)
+
+			if golden.Update() {
+				golden.Set(t, marshaled)
+			}
+
+			g := golden.Get(t)
+			assert.Equal(t, string(g), string(marshaled),
+				"hcl encoded value does not match golden",
+			)
+
+			r := bytes.NewReader(g)
+			got, err := FromHCL(r)
+			require.NoError(t, err, "hcl decoding golden failed")
+			assert.Equal(t, tt.spec, got,
+				"hcl decoding from golden does not match expected object",
+			)
+		})
+	}
+}
+
+func TestVirtualMachineSpec_WriteHCL_Error(t *testing.T) {
+	errStr := "failed to write"
+	spec := &VirtualMachineSpec{Name: "web-3"}
+	w := &badWriter{err: errors.New(errStr)}
+
+	err := spec.WriteHCL(w)
+
+	assert.EqualError(t, err, errStr)
+}
+
+func TestVirtualMachineSpec_ToFromTOML(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *VirtualMachineSpec
+	}{
+		{
+			name: "empty spec",
+			spec: &VirtualMachineSpec{},
+		},
+		{
+			name: "basic spec",
+			spec: fixtureVirtualMachineSpecBasicStruct,
+		},
+		{
+			name: "full spec",
+			spec: fixtureVirtualMachineSpecFullStruct,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			marshaled, err := tt.spec.TOML()
+			require.NoError(t, err, "failed marshaling with TOML()")
+
+			buf := &bytes.Buffer{}
+			err = tt.spec.WriteTOML(buf)
+			require.NoError(t, err, "failed marshaling with WriteTOML()")
+
+			assert.Equal(t, string(marshaled), buf.String(),
+				"output of WriteTOML() does not match that of TOML()",
+			)
+
+			if golden.Update() {
+				golden.Set(t, marshaled)
+			}
+
+			g := golden.Get(t)
+			assert.Equal(t, string(g), string(marshaled),
+				"toml encoded value does not match golden",
+			)
+
+			r := bytes.NewReader(g)
+			got, err := FromTOML(r)
+			require.NoError(t, err, "toml decoding golden failed")
+			assert.Equal(t, tt.spec, got,
+				"toml decoding from golden does not match expected object",
+			)
+		})
+	}
+}
+
+func TestVirtualMachineSpec_WriteTOML_Error(t *testing.T) {
+	errStr := "failed to write"
+	spec := &VirtualMachineSpec{Name: "web-3"}
+	w := &badWriter{err: errors.New(errStr)}
+
+	err := spec.WriteTOML(w)
+
+	assert.EqualError(t, err, errStr)
+}
+
 func TestVirtualMachineSpec_WriteYAML_Error(t *testing.T) {
 	errStr := "failed to write"
 	spec := &VirtualMachineSpec{Name: "web-3"}
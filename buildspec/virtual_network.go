@@ -1,5 +1,5 @@
 package buildspec
 
 type VirtualNetwork struct {
-	ID string `xml:",chardata" json:"id,omitempty" yaml:"id,omitempty"`
+	ID string `xml:",chardata" json:"id,omitempty" yaml:"id,omitempty" hcl:"id,optional" toml:"id,omitempty"`
 }
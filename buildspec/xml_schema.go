@@ -0,0 +1,432 @@
+package buildspec
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed virtual_machine_spec.xsd
+var xsdDocument []byte
+
+// xsdLoad parses the embedded XML Schema once.
+var xsdLoad = sync.OnceValue(func() *xsdSchema {
+	s := &xsdSchema{}
+	if err := xml.Unmarshal(xsdDocument, s); err != nil {
+		panic(fmt.Sprintf("buildspec: invalid XML schema: %s", err))
+	}
+
+	return s
+})
+
+// XMLSchema returns an XML Schema (XSD) describing XML build spec documents,
+// as written by VirtualMachineSpec's MarshalXML method.
+//
+// References to other resources are described as elements holding an ID, or
+// a value of the lookup named by their "by" attribute, such as
+// <Package by="permalink">rock-3</Package>. Rules which span several
+// elements, such as zone and data center being mutually exclusive, are left
+// to Validate.
+func XMLSchema() []byte {
+	return bytes.Clone(xsdDocument)
+}
+
+// ValidateXMLSchema checks a XML build spec document read from r against the
+// schema returned by XMLSchema, without any network access. The first
+// problem found is returned as a *XMLError reporting the line and column of
+// the offending element.
+//
+// Unlike FromXMLStrict, which only rejects unknown elements and attributes,
+// the order and number of elements, the values of lookup "by" attributes,
+// and the values of enums and numbers are all checked.
+func ValidateXMLSchema(r io.Reader) error {
+	root, err := parseXSDNode(r)
+	if err != nil {
+		return err
+	}
+
+	s := xsdLoad()
+	for _, el := range s.Elements {
+		if el.Name == root.name {
+			return s.element(root, el.Type)
+		}
+	}
+
+	return root.errorf("unknown root element <%s>", root.name)
+}
+
+type xsdSchema struct {
+	Elements     []*xsdElement     `xml:"element"`
+	ComplexTypes []*xsdComplexType `xml:"complexType"`
+	SimpleTypes  []*xsdSimpleType  `xml:"simpleType"`
+}
+
+type xsdElement struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	MinOccurs string `xml:"minOccurs,attr"`
+	MaxOccurs string `xml:"maxOccurs,attr"`
+}
+
+type xsdComplexType struct {
+	Name          string          `xml:"name,attr"`
+	All           []*xsdElement   `xml:"all>element"`
+	Sequence      []*xsdElement   `xml:"sequence>element"`
+	Attributes    []*xsdAttribute `xml:"attribute"`
+	SimpleContent *xsdExtension   `xml:"simpleContent>extension"`
+}
+
+type xsdExtension struct {
+	Base       string          `xml:"base,attr"`
+	Attributes []*xsdAttribute `xml:"attribute"`
+}
+
+type xsdAttribute struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+	Use  string `xml:"use,attr"`
+}
+
+type xsdSimpleType struct {
+	Name        string `xml:"name,attr"`
+	Restriction struct {
+		Base         string      `xml:"base,attr"`
+		Enumerations []*xsdFacet `xml:"enumeration"`
+		MinInclusive *xsdFacet   `xml:"minInclusive"`
+		MaxInclusive *xsdFacet   `xml:"maxInclusive"`
+	} `xml:"restriction"`
+}
+
+type xsdFacet struct {
+	Value string `xml:"value,attr"`
+}
+
+func (s *xsdSchema) complexType(name string) *xsdComplexType {
+	for _, ct := range s.ComplexTypes {
+		if ct.Name == name {
+			return ct
+		}
+	}
+
+	return nil
+}
+
+func (s *xsdSchema) simpleType(name string) *xsdSimpleType {
+	for _, st := range s.SimpleTypes {
+		if st.Name == name {
+			return st
+		}
+	}
+
+	return nil
+}
+
+// element checks that n and its children are valid for the named type.
+func (s *xsdSchema) element(n *xsdNode, typ string) error {
+	ct := s.complexType(typ)
+	if ct == nil {
+		if len(n.children) > 0 {
+			return n.children[0].errorf(
+				"unknown element <%s> in <%s>", n.children[0].name, n.name,
+			)
+		}
+		if err := s.attributes(n, nil); err != nil {
+			return err
+		}
+
+		return s.value(n, typ, n.text, fmt.Sprintf("<%s>", n.name))
+	}
+
+	if ext := ct.SimpleContent; ext != nil {
+		if len(n.children) > 0 {
+			return n.children[0].errorf(
+				"unknown element <%s> in <%s>", n.children[0].name, n.name,
+			)
+		}
+		if err := s.attributes(n, ext.Attributes); err != nil {
+			return err
+		}
+
+		return s.value(n, ext.Base, n.text, fmt.Sprintf("<%s>", n.name))
+	}
+
+	if strings.TrimSpace(n.text) != "" {
+		return n.errorf("unexpected text in <%s>", n.name)
+	}
+	if err := s.attributes(n, ct.Attributes); err != nil {
+		return err
+	}
+	if ct.Sequence != nil {
+		return s.sequence(n, ct.Sequence)
+	}
+
+	return s.all(n, ct.All)
+}
+
+// all checks the children of n against an xs:all group, where each element
+// may appear at most once, in any order.
+func (s *xsdSchema) all(n *xsdNode, decls []*xsdElement) error {
+	seen := map[string]bool{}
+	for _, c := range n.children {
+		d := findXSDElement(decls, c.name)
+		switch {
+		case d == nil:
+			return c.errorf("unknown element <%s> in <%s>", c.name, n.name)
+		case seen[c.name]:
+			return c.errorf("duplicate element <%s> in <%s>", c.name, n.name)
+		}
+		seen[c.name] = true
+
+		if err := s.element(c, d.Type); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range decls {
+		if d.MinOccurs != "0" && !seen[d.Name] {
+			return n.errorf("missing element <%s> in <%s>", d.Name, n.name)
+		}
+	}
+
+	return nil
+}
+
+// sequence checks the children of n against an xs:sequence group, where
+// elements must appear in the declared order.
+func (s *xsdSchema) sequence(n *xsdNode, decls []*xsdElement) error {
+	i := 0
+	for _, d := range decls {
+		count := 0
+		for i < len(n.children) && n.children[i].name == d.Name &&
+			(d.MaxOccurs == "unbounded" || count < 1) {
+			if err := s.element(n.children[i], d.Type); err != nil {
+				return err
+			}
+			count++
+			i++
+		}
+		if count == 0 && d.MinOccurs != "0" {
+			return n.errorf("missing element <%s> in <%s>", d.Name, n.name)
+		}
+	}
+
+	if i < len(n.children) {
+		c := n.children[i]
+		if findXSDElement(decls, c.name) != nil {
+			return c.errorf(
+				"element <%s> is out of order in <%s>", c.name, n.name,
+			)
+		}
+
+		return c.errorf("unknown element <%s> in <%s>", c.name, n.name)
+	}
+
+	return nil
+}
+
+func (s *xsdSchema) attributes(n *xsdNode, decls []*xsdAttribute) error {
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+
+		var decl *xsdAttribute
+		for _, d := range decls {
+			if d.Name == a.Name.Local {
+				decl = d
+			}
+		}
+		if decl == nil {
+			return n.errorf(
+				"unknown attribute %q on <%s>", a.Name.Local, n.name,
+			)
+		}
+
+		err := s.value(n, decl.Type, a.Value,
+			fmt.Sprintf("attribute %q on <%s>", a.Name.Local, n.name),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, d := range decls {
+		if d.Use == "required" && n.attr(d.Name) == nil {
+			return n.errorf("missing attribute %q on <%s>", d.Name, n.name)
+		}
+	}
+
+	return nil
+}
+
+// value checks that v is valid for the named simple type, describing where
+// it was found with what.
+func (s *xsdSchema) value(n *xsdNode, typ, v, what string) error {
+	st := s.simpleType(typ)
+	if st == nil {
+		if msg := xsdBuiltinValue(typ, v); msg != "" {
+			return n.errorf("invalid value %q for %s: %s", v, what, msg)
+		}
+
+		return nil
+	}
+
+	r := st.Restriction
+	if msg := xsdBuiltinValue(r.Base, v); msg != "" {
+		return n.errorf("invalid value %q for %s: %s", v, what, msg)
+	}
+	if r.Base != "xs:string" {
+		v = strings.TrimSpace(v)
+	}
+
+	if len(r.Enumerations) > 0 {
+		values := make([]any, 0, len(r.Enumerations))
+		for _, e := range r.Enumerations {
+			if e.Value == v {
+				return nil
+			}
+			values = append(values, e.Value)
+		}
+
+		return n.errorf("invalid value %q for %s: must be one of %s",
+			v, what, jsonSchemaValues(values),
+		)
+	}
+
+	i, _ := strconv.Atoi(v)
+	if f := r.MinInclusive; f != nil {
+		if min, _ := strconv.Atoi(f.Value); i < min {
+			return n.errorf("invalid value %q for %s: must be at least %d",
+				v, what, min,
+			)
+		}
+	}
+	if f := r.MaxInclusive; f != nil {
+		if max, _ := strconv.Atoi(f.Value); i > max {
+			return n.errorf("invalid value %q for %s: must be at most %d",
+				v, what, max,
+			)
+		}
+	}
+
+	return nil
+}
+
+// xsdBuiltinValue checks v against one of the built-in XML Schema types used
+// by the build spec schema, and returns a description of the problem, if
+// any.
+func xsdBuiltinValue(typ, v string) string {
+	if typ == "xs:string" {
+		return ""
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return "must be an integer"
+	}
+
+	switch {
+	case typ == "xs:positiveInteger" && i <= 0:
+		return "must be greater than 0"
+	case typ == "xs:nonNegativeInteger" && i < 0:
+		return "must not be negative"
+	}
+
+	return ""
+}
+
+func findXSDElement(decls []*xsdElement, name string) *xsdElement {
+	for _, d := range decls {
+		if d.Name == name {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// xsdNode is an element of a XML document being validated.
+type xsdNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xsdNode
+	text     string
+	line     int
+	column   int
+}
+
+func (n *xsdNode) attr(name string) *xml.Attr {
+	for i, a := range n.attrs {
+		if a.Name.Local == name {
+			return &n.attrs[i]
+		}
+	}
+
+	return nil
+}
+
+func (n *xsdNode) errorf(format string, args ...any) error {
+	return &XMLError{
+		Line:   n.line,
+		Column: n.column,
+		Err:    fmt.Errorf(format, args...),
+	}
+}
+
+// parseXSDNode reads the XML document in r into a tree of elements.
+func parseXSDNode(r io.Reader) (*xsdNode, error) {
+	dec := xml.NewDecoder(r)
+
+	var root *xsdNode
+	var stack []*xsdNode
+	for {
+		line, col := dec.InputPos()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			line, col = dec.InputPos()
+
+			return nil, &XMLError{Line: line, Column: col, Err: err}
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xsdNode{
+				name:   t.Name.Local,
+				attrs:  t.Attr,
+				line:   line,
+				column: col,
+			}
+			if len(stack) == 0 {
+				root = n
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+
+	if root == nil {
+		line, col := dec.InputPos()
+
+		return nil, &XMLError{
+			Line: line, Column: col, Err: io.ErrUnexpectedEOF,
+		}
+	}
+
+	return root, nil
+}
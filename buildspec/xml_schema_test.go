@@ -0,0 +1,342 @@
+package buildspec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/undent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLSchema(t *testing.T) {
+	t.Run("matches VirtualMachineSpec XML tags", func(t *testing.T) {
+		s := xsdLoad()
+		seen := map[string]bool{}
+
+		// decodeOnly lists elements which are still accepted for backwards
+		// compatibility, but are never emitted, so are not in the schema.
+		decodeOnly := map[string]bool{"VirtualMachineSpec>Name>Name": true}
+
+		var walk func(path string, typ reflect.Type, xsdType string)
+		walk = func(path string, typ reflect.Type, xsdType string) {
+			key := xsdType + " " + typ.String()
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+
+			var elems []*xsdElement
+			var attrs []*xsdAttribute
+			if ct := s.complexType(xsdType); ct != nil {
+				elems = append(ct.All, ct.Sequence...)
+				attrs = ct.Attributes
+				if ct.SimpleContent != nil {
+					attrs = ct.SimpleContent.Attributes
+				}
+			}
+
+			schema := xmlSchemaFor(typ)
+			wantElems := map[string]bool{}
+			for name := range schema.elems {
+				if !decodeOnly[path+">"+name] {
+					wantElems[name] = true
+				}
+			}
+			gotElems := map[string]bool{}
+			for _, el := range elems {
+				gotElems[el.Name] = true
+			}
+			assert.Equal(t, wantElems, gotElems, "elements of <%s>", path)
+
+			gotAttrs := map[string]bool{}
+			for _, a := range attrs {
+				gotAttrs[a.Name] = true
+			}
+			assert.Equal(t, schema.attrs, gotAttrs, "attributes of <%s>", path)
+
+			for _, el := range elems {
+				if typ, ok := schema.elems[el.Name]; ok {
+					walk(path+">"+el.Name, typ, el.Type)
+				}
+			}
+		}
+
+		walk("VirtualMachineSpec", typeOf[VirtualMachineSpec](),
+			"VirtualMachineSpec",
+		)
+	})
+
+	t.Run("declares every type it references", func(t *testing.T) {
+		s := xsdLoad()
+		known := func(typ string) bool {
+			return strings.HasPrefix(typ, "xs:") ||
+				s.complexType(typ) != nil || s.simpleType(typ) != nil
+		}
+
+		for _, el := range s.Elements {
+			assert.True(t, known(el.Type), el.Type)
+		}
+		for _, ct := range s.ComplexTypes {
+			for _, el := range append(ct.All, ct.Sequence...) {
+				assert.True(t, known(el.Type), "%s: %s", ct.Name, el.Type)
+			}
+			attrs := ct.Attributes
+			if ct.SimpleContent != nil {
+				assert.True(t, known(ct.SimpleContent.Base), ct.Name)
+				attrs = ct.SimpleContent.Attributes
+			}
+			for _, a := range attrs {
+				assert.True(t, known(a.Type), "%s: %s", ct.Name, a.Type)
+			}
+		}
+	})
+}
+
+func TestValidateXMLSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name: "minimal",
+			doc:  "<VirtualMachineSpec></VirtualMachineSpec>",
+		},
+		{
+			name: "elements in any order",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Hostname><Hostname type="random"/></Hostname>
+				  <Resources>
+				    <CPUCores>2</CPUCores>
+				    <Memory>4</Memory>
+				  </Resources>
+				  <Zone by="permalink">uk-lon-01a</Zone>
+				</VirtualMachineSpec>`,
+			),
+		},
+		{
+			name: "unknown root element",
+			doc:  "<FleetSpec></FleetSpec>",
+			wantErr: "parse_xml: line 1, column 1: " +
+				"unknown root element <FleetSpec>",
+		},
+		{
+			name: "unknown element",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Resources>
+				    <Storage>20</Storage>
+				  </Resources>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 3, column 5: " +
+				"unknown element <Storage> in <Resources>",
+		},
+		{
+			name: "duplicate element",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Zone>zone_1</Zone>
+				  <Zone>zone_2</Zone>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 3, column 3: " +
+				"duplicate element <Zone> in <VirtualMachineSpec>",
+		},
+		{
+			name: "missing element",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <SystemDisks>
+				    <Disk><Name>Boot</Name></Disk>
+				  </SystemDisks>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 3, column 5: " +
+				"missing element <Size> in <Disk>",
+		},
+		{
+			name: "element out of order",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <NetworkInterfaces>
+				    <NetworkInterface>
+				      <IPAddressAllocation type="new">
+				        <Version>4</Version>
+				      </IPAddressAllocation>
+				      <Network>public</Network>
+				    </NetworkInterface>
+				  </NetworkInterfaces>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 7, column 7: " +
+				"element <Network> is out of order in <NetworkInterface>",
+		},
+		{
+			name: "unsupported lookup",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Resources><Package by="name">Rock 3</Package></Resources>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 2, column 14: " +
+				`invalid value "name" for attribute "by" on <Package>: ` +
+				`must be one of "permalink"`,
+		},
+		{
+			name: "unknown attribute",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Group by="name" scope="org">web</Group>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 2, column 3: " +
+				`unknown attribute "scope" on <Group>`,
+		},
+		{
+			name: "missing attribute",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <NetworkInterfaces>
+				    <NetworkInterface>
+				      <IPAddressAllocation>
+				        <Version>6</Version>
+				      </IPAddressAllocation>
+				    </NetworkInterface>
+				  </NetworkInterfaces>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 4, column 7: " +
+				`missing attribute "type" on <IPAddressAllocation>`,
+		},
+		{
+			name: "invalid enum",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <BackupPolicies>
+				    <BackupPolicy>
+				      <Schedule><Interval>fortnightly</Interval></Schedule>
+				    </BackupPolicy>
+				  </BackupPolicies>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 4, column 17: " +
+				`invalid value "fortnightly" for <Interval>: ` +
+				`must be one of "hourly", "daily", "weekly" or "monthly"`,
+		},
+		{
+			name: "invalid IP version",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <NetworkInterfaces>
+				    <NetworkInterface>
+				      <IPAddressAllocation type="new">
+				        <Version>5</Version>
+				      </IPAddressAllocation>
+				    </NetworkInterface>
+				  </NetworkInterfaces>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 5, column 9: " +
+				`invalid value "5" for <Version>: must be one of "4" or "6"`,
+		},
+		{
+			name: "not an integer",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Resources><Memory>lots</Memory></Resources>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 2, column 14: " +
+				`invalid value "lots" for <Memory>: must be an integer`,
+		},
+		{
+			name: "out of range",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <BackupPolicies>
+				    <BackupPolicy>
+				      <Schedule><Time>24</Time></Schedule>
+				    </BackupPolicy>
+				  </BackupPolicies>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 4, column 17: " +
+				`invalid value "24" for <Time>: must be at most 23`,
+		},
+		{
+			name: "zero frequency",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <BackupPolicies>
+				    <BackupPolicy>
+				      <Schedule><Frequency>0</Frequency></Schedule>
+				    </BackupPolicy>
+				  </BackupPolicies>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 4, column 17: " +
+				`invalid value "0" for <Frequency>: must be greater than 0`,
+		},
+		{
+			name: "text in complex element",
+			doc: undent.String(`
+				<VirtualMachineSpec>
+				  <Resources>rock-3</Resources>
+				</VirtualMachineSpec>`,
+			),
+			wantErr: "parse_xml: line 2, column 3: " +
+				"unexpected text in <Resources>",
+		},
+		{
+			name: "malformed",
+			doc:  "<VirtualMachineSpec><Zone></VirtualMachineSpec>",
+			wantErr: "parse_xml: line 1, column 48: XML syntax error " +
+				"on line 1: element <Zone> closed by </VirtualMachineSpec>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateXMLSchema(strings.NewReader(tt.doc))
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var xerr *XMLError
+			require.ErrorAs(t, err, &xerr)
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, ErrParseXML)
+		})
+	}
+}
+
+func TestValidateXMLSchema_fixtures(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{
+		"TestVirtualMachineSpec_ToFromXML/*.golden",
+		"TestVirtualMachineSpec_Marshaling/xml_*.golden",
+	} {
+		matches, err := filepath.Glob(filepath.Join("testdata", pattern))
+		require.NoError(t, err)
+		files = append(files, matches...)
+	}
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			f, err := os.Open(file)
+			require.NoError(t, err)
+			defer f.Close()
+
+			assert.NoError(t, ValidateXMLSchema(f))
+		})
+	}
+}
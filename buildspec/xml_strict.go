@@ -0,0 +1,248 @@
+package buildspec
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// xmlShadowTypes maps types with custom UnmarshalXML methods to the types
+// they decode XML elements into.
+var xmlShadowTypes = map[reflect.Type]reflect.Type{
+	typeOf[VirtualMachineSpec]():  typeOf[xmlVirtualMachineSpec](),
+	typeOf[AuthorizedKeys]():      typeOf[xmlAuthorizedKeys](),
+	typeOf[DataCenter]():          typeOf[xmlDataCenter](),
+	typeOf[DiskIOProfile]():       typeOf[xmlDiskIOProfile](),
+	typeOf[DiskTemplate]():        typeOf[xmlDiskTemplate](),
+	typeOf[Group]():               typeOf[xmlGroup](),
+	typeOf[IPAddress]():           typeOf[xmlIPAddress](),
+	typeOf[Network]():             typeOf[xmlNetwork](),
+	typeOf[NetworkSpeedProfile](): typeOf[xmlNetworkSpeedProfile](),
+	typeOf[Package]():             typeOf[xmlPackage](),
+	typeOf[SharedDisk]():          typeOf[xmlSharedDisk](),
+	typeOf[Subnet]():              typeOf[xmlSubnet](),
+	typeOf[SystemDisk]():          typeOf[xmlSystemDisk](),
+	typeOf[User]():                typeOf[xmlUser](),
+	typeOf[Zone]():                typeOf[xmlZone](),
+	typeOf[xmlName]():             typeOf[xmlNameNested](),
+}
+
+var (
+	xmlUnmarshalerType = typeOf[xml.Unmarshaler]()
+	xmlSchemaCache     sync.Map
+
+	// xmlStrictDecoders holds the decoders of documents being parsed by
+	// FromXMLStrict.
+	xmlStrictDecoders sync.Map
+)
+
+// FromXMLStrict parses a XML build spec document into a *VirtualMachineSpec
+// object like FromXML, but rejects unknown elements and attributes. Errors
+// are returned as a *XMLError reporting the line and column of the offending
+// element.
+func FromXMLStrict(r io.Reader) (*VirtualMachineSpec, error) {
+	spec := &VirtualMachineSpec{}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return spec, err
+	}
+
+	err = checkXMLElements(b)
+	if err != nil {
+		return spec, err
+	}
+
+	pr := &xmlPositionReader{dec: xml.NewDecoder(bytes.NewReader(b))}
+	dec := xml.NewTokenDecoder(pr)
+	xmlStrictDecoders.Store(dec, true)
+	defer xmlStrictDecoders.Delete(dec)
+
+	err = dec.Decode(spec)
+	if err != nil {
+		line, col := pr.pos()
+
+		return spec, &XMLError{Line: line, Column: col, Err: err}
+	}
+
+	return spec, nil
+}
+
+// decodeXMLElement decodes the element start into v like d.DecodeElement.
+// Errors are only returned when d is decoding a document for FromXMLStrict,
+// and are otherwise ignored as FromXML has always done.
+func decodeXMLElement(d *xml.Decoder, v any, start *xml.StartElement) error {
+	err := d.DecodeElement(v, start)
+	if _, strict := xmlStrictDecoders.Load(d); !strict {
+		return nil
+	}
+
+	return err
+}
+
+// xmlPositionReader is a xml.TokenReader which records where the element
+// most recently started or ended begins. Values are decoded once their
+// element has ended, and attributes once it has started, so when decoding
+// fails that element is the one being decoded.
+type xmlPositionReader struct {
+	dec    *xml.Decoder
+	starts [][2]int
+	last   [2]int
+}
+
+func (r *xmlPositionReader) Token() (xml.Token, error) {
+	line, col := r.dec.InputPos()
+	tok, err := r.dec.Token()
+	if err != nil {
+		return tok, err
+	}
+
+	switch tok.(type) {
+	case xml.StartElement:
+		r.last = [2]int{line, col}
+		r.starts = append(r.starts, r.last)
+	case xml.EndElement:
+		r.last = r.starts[len(r.starts)-1]
+		r.starts = r.starts[:len(r.starts)-1]
+	}
+
+	return tok, nil
+}
+
+// pos returns the line and column of the element being decoded, or the
+// current position of the decoder if no element has been read yet.
+func (r *xmlPositionReader) pos() (line, column int) {
+	if r.last[0] == 0 {
+		return r.dec.InputPos()
+	}
+
+	return r.last[0], r.last[1]
+}
+
+// checkXMLElements walks the XML document in b, and returns a *XMLError for
+// the first element or attribute which is not part of the build spec format.
+func checkXMLElements(b []byte) error {
+	type elem struct {
+		name   string
+		schema *xmlSchema
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	var stack []elem
+	for {
+		line, col := dec.InputPos()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return &XMLError{Line: line, Column: col, Err: err}
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var typ reflect.Type
+			if len(stack) == 0 {
+				if t.Name.Local != "VirtualMachineSpec" {
+					return &XMLError{Line: line, Column: col, Err: fmt.Errorf(
+						"unknown root element <%s>", t.Name.Local,
+					)}
+				}
+				typ = reflect.TypeOf(VirtualMachineSpec{})
+			} else {
+				parent := stack[len(stack)-1]
+				var ok bool
+				typ, ok = parent.schema.elems[t.Name.Local]
+				if !ok {
+					return &XMLError{Line: line, Column: col, Err: fmt.Errorf(
+						"unknown element <%s> in <%s>",
+						t.Name.Local, parent.name,
+					)}
+				}
+			}
+
+			schema := xmlSchemaFor(typ)
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+					continue
+				}
+				if !schema.attrs[a.Name.Local] {
+					return &XMLError{Line: line, Column: col, Err: fmt.Errorf(
+						"unknown attribute %q on <%s>",
+						a.Name.Local, t.Name.Local,
+					)}
+				}
+			}
+
+			stack = append(stack, elem{name: t.Name.Local, schema: schema})
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// xmlSchema holds the child elements and attributes allowed on an element.
+type xmlSchema struct {
+	elems map[string]reflect.Type
+	attrs map[string]bool
+}
+
+// xmlSchemaFor returns the schema of elements decoded into typ, derived from
+// the struct tags of the type the element is decoded into.
+func xmlSchemaFor(typ reflect.Type) *xmlSchema {
+	for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if v, ok := xmlSchemaCache.Load(typ); ok {
+		return v.(*xmlSchema)
+	}
+	key := typ
+
+	schema := &xmlSchema{
+		elems: map[string]reflect.Type{},
+		attrs: map[string]bool{},
+	}
+
+	if shadow, ok := xmlShadowTypes[typ]; ok {
+		typ = shadow
+	} else if reflect.PtrTo(typ).Implements(xmlUnmarshalerType) {
+		panic(fmt.Sprintf("buildspec: no XML shadow type for %s", typ))
+	}
+
+	if typ.Kind() == reflect.Struct {
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			tag := f.Tag.Get("xml")
+			if tag == "-" || f.Name == "XMLName" {
+				continue
+			}
+
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = f.Name
+			}
+
+			switch {
+			case strings.Contains(opts, "attr"):
+				schema.attrs[name] = true
+			case strings.Contains(opts, "chardata"),
+				strings.Contains(opts, "innerxml"),
+				strings.Contains(opts, "comment"):
+			default:
+				schema.elems[name] = f.Type
+			}
+		}
+	}
+
+	xmlSchemaCache.Store(key, schema)
+
+	return schema
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
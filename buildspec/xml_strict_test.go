@@ -0,0 +1,239 @@
+package buildspec
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/undent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromXMLStrict(t *testing.T) {
+	tests := []struct {
+		name   string
+		xml    string
+		want   *VirtualMachineSpec
+		errIs  error
+		errStr string
+	}{
+		{
+			name:  "empty string",
+			xml:   ``,
+			want:  &VirtualMachineSpec{},
+			errIs: io.EOF,
+		},
+		{
+			name: "valid VirtualMachineSpec",
+			xml: undent.String(`
+				<?xml version="1.0" encoding="UTF-8"?>
+				<VirtualMachineSpec>
+					<DataCenter by="permalink">uk-lon-01</DataCenter>
+					<Resources>
+						<Package by="permalink">rock-3</Package>
+					</Resources>
+					<SystemDisks>
+						<Disk>
+							<Name>System Disk</Name>
+							<Size>10</Size>
+						</Disk>
+					</SystemDisks>
+					<NetworkInterfaces>
+						<NetworkInterface>
+							<Network by="permalink">public</Network>
+							<IPAddressAllocation type="new">
+								<Version>4</Version>
+							</IPAddressAllocation>
+						</NetworkInterface>
+					</NetworkInterfaces>
+					<Hostname><Hostname>web-3</Hostname></Hostname>
+					<Name><Name>Web 3</Name></Name>
+					<AuthorizedKeys>
+						<Users all="no"><User>user_1</User></Users>
+					</AuthorizedKeys>
+					<Tags><Tag>web</Tag></Tags>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{
+				DataCenter: &DataCenter{Permalink: "uk-lon-01"},
+				Resources: &Resources{
+					Package: &Package{Permalink: "rock-3"},
+				},
+				SystemDisks: []*SystemDisk{{Name: "System Disk", Size: 10}},
+				NetworkInterfaces: []*NetworkInterface{
+					{
+						Network: &Network{Permalink: "public"},
+						IPAddressAllocations: []*IPAddressAllocation{
+							{Type: NewIPAddressAllocation, Version: IPv4},
+						},
+					},
+				},
+				Hostname: "web-3",
+				Name:     "Web 3",
+				AuthorizedKeys: &AuthorizedKeys{
+					Users: []*User{{ID: "user_1"}},
+				},
+				Tags: []string{"web"},
+			},
+		},
+		{
+			name: "unknown root element",
+			xml: undent.String(`
+				<?xml version="1.0" encoding="UTF-8"?>
+				<Spec></Spec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 2, column 1: " +
+				"unknown root element <Spec>",
+		},
+		{
+			name: "unknown child element",
+			xml: undent.String(`
+				<?xml version="1.0" encoding="UTF-8"?>
+				<VirtualMachineSpec>
+					<RocketFuel>maybe</RocketFuel>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 3, column 2: " +
+				"unknown element <RocketFuel> in <VirtualMachineSpec>",
+		},
+		{
+			name: "unknown nested element",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<SystemDisks>
+						<Disk>
+							<Size>10</Size>
+							<Colour>red</Colour>
+						</Disk>
+					</SystemDisks>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 5, column 4: " +
+				"unknown element <Colour> in <Disk>",
+		},
+		{
+			name: "unknown attribute",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<Zone by="permalink" strict="yes">uk-lon-01-a</Zone>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 2, column 2: " +
+				`unknown attribute "strict" on <Zone>`,
+		},
+		{
+			name: "unsupported lookup",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<Zone by="name">London</Zone>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 2, column 2: " +
+				`Zone by="name" is not supported`,
+		},
+		{
+			name: "invalid value",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<SystemDisks>
+						<Disk><Size>ten</Size></Disk>
+					</SystemDisks>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 3, column 9: " +
+				`strconv.ParseInt: parsing "ten": invalid syntax`,
+		},
+		{
+			name: "malformed XML",
+			xml: undent.String(`
+				<VirtualMachineSpec>
+					<Name>web-3</Hostname>
+				</VirtualMachineSpec>`,
+			),
+			want: &VirtualMachineSpec{},
+			errStr: "parse_xml: line 2, column 13: XML syntax error on " +
+				"line 2: element <Name> closed by </Hostname>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromXMLStrict(strings.NewReader(tt.xml))
+
+			if tt.errIs != nil {
+				assert.True(t, errors.Is(err, tt.errIs))
+			}
+
+			if tt.errStr != "" {
+				assert.EqualError(t, err, tt.errStr)
+				assert.ErrorIs(t, err, ErrParseXML)
+
+				var xmlErr *XMLError
+				assert.ErrorAs(t, err, &xmlErr)
+			}
+
+			if tt.errIs == nil && tt.errStr == "" {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFromXMLStrict_goldenFiles(t *testing.T) {
+	paths, err := filepath.Glob(
+		"testdata/TestVirtualMachineSpec_*/xml_*.golden",
+	)
+	require.NoError(t, err)
+	more, err := filepath.Glob("testdata/TestVirtualMachineSpec_ToFromXML/*")
+	require.NoError(t, err)
+	paths = append(paths, more...)
+	require.NotEmpty(t, paths)
+
+	for _, p := range paths {
+		t.Run(p, func(t *testing.T) {
+			b, err := os.ReadFile(p)
+			require.NoError(t, err)
+
+			want, err := FromXML(bytes.NewReader(b))
+			require.NoError(t, err)
+
+			got, err := FromXMLStrict(bytes.NewReader(b))
+			require.NoError(t, err)
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_xmlSchemaFor(t *testing.T) {
+	seen := map[reflect.Type]bool{}
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		if seen[typ] {
+			return
+		}
+		seen[typ] = true
+
+		schema := xmlSchemaFor(typ)
+		for _, child := range schema.elems {
+			walk(child)
+		}
+	}
+
+	assert.NotPanics(t, func() {
+		walk(typeOf[VirtualMachineSpec]())
+	}, "all types with UnmarshalXML methods must have a shadow type")
+}
@@ -0,0 +1,411 @@
+package katapult
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HeaderCache is set on responses served by a Cache, with a value of
+// CacheHit or CacheRevalidated.
+const HeaderCache = "X-Katapult-Cache"
+
+// Values of the HeaderCache response header.
+const (
+	// CacheHit indicates the response was served from the cache without
+	// contacting the API.
+	CacheHit = "hit"
+
+	// CacheRevalidated indicates a stale cached response was confirmed to be
+	// unchanged by the API with a 304 Not Modified response.
+	CacheRevalidated = "revalidated"
+)
+
+// DefaultCacheTTLs are the TTLs used by NewCache for catalog endpoints which
+// rarely change, keyed by path prefix.
+var DefaultCacheTTLs = map[string]time.Duration{
+	"/core/v1/data_centers":                           time.Hour,
+	"/core/v1/virtual_machine_packages":               time.Hour,
+	"/core/v1/disk_templates":                         time.Hour,
+	"/core/v1/organizations/_/disk_templates":         time.Hour,
+	"/core/v1/organizations/_/network_speed_profiles": time.Hour,
+}
+
+// CacheEntry is a cached response.
+type CacheEntry struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// Body is the complete response body.
+	Body []byte
+
+	// ETag is the response's entity tag, used to revalidate the entry once it
+	// expires. Entries without one are refetched once expired.
+	ETag string
+
+	// Expires is when the entry becomes stale.
+	Expires time.Time
+}
+
+// Fresh reports whether the entry has not yet expired.
+func (e *CacheEntry) Fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// response builds a new *http.Response for req from the entry.
+func (e *CacheEntry) response(req *http.Request, status string) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(HeaderCache, status)
+
+	return &http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// CacheStore stores cache entries by key. Implementations must be safe for
+// concurrent use, and must not modify entries passed to or returned from them.
+type CacheStore interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+	Keys() []string
+}
+
+// DefaultCacheMaxStale is how long a MemoryCacheStore keeps expired entries
+// with an ETag by default.
+const DefaultCacheMaxStale = 24 * time.Hour
+
+// MemoryCacheStore is an in-memory CacheStore.
+//
+// Expired entries are removed, so entries cached for API keys or tokens which
+// are no longer used do not build up. Entries without an ETag are removed as
+// soon as they expire, and entries with one once they have been expired for
+// longer than MaxStale.
+type MemoryCacheStore struct {
+	// MaxStale is how long expired entries with an ETag are kept for, so
+	// they can still be revalidated.
+	MaxStale time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]*CacheEntry
+}
+
+var _ CacheStore = (*MemoryCacheStore)(nil)
+
+// NewMemoryCacheStore returns a new empty MemoryCacheStore, which keeps
+// expired entries with an ETag for DefaultCacheMaxStale.
+func NewMemoryCacheStore() *MemoryCacheStore {
+	return &MemoryCacheStore{
+		MaxStale: DefaultCacheMaxStale,
+		entries:  map[string]*CacheEntry{},
+	}
+}
+
+// Get returns the entry for key, removing it instead if it has expired and
+// can no longer be revalidated.
+func (s *MemoryCacheStore) Get(key string) (*CacheEntry, bool) {
+	s.mu.RLock()
+	e, ok := s.entries[key]
+	s.mu.RUnlock()
+
+	if ok && s.evictable(e, time.Now()) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.entries[key] == e {
+			delete(s.entries, key)
+		}
+
+		return nil, false
+	}
+
+	return e, ok
+}
+
+// Set stores entry under key, and removes all entries which have expired and
+// can no longer be revalidated.
+func (s *MemoryCacheStore) Set(key string, entry *CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range s.entries {
+		if s.evictable(e, now) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = entry
+}
+
+// evictable reports whether e has expired, and is either without an ETag or
+// has been expired for longer than MaxStale.
+func (s *MemoryCacheStore) evictable(e *CacheEntry, now time.Time) bool {
+	expires := e.Expires
+	if e.ETag != "" {
+		expires = expires.Add(s.MaxStale)
+	}
+
+	return !now.Before(expires)
+}
+
+func (s *MemoryCacheStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+func (s *MemoryCacheStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.entries))
+	for k := range s.entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type CacheOption func(c *Cache)
+
+// WithCacheStore sets the store cached responses are kept in. Defaults to a
+// new MemoryCacheStore.
+func WithCacheStore(s CacheStore) CacheOption {
+	return func(c *Cache) {
+		c.Store = s
+	}
+}
+
+// WithCacheTTL sets the TTL of responses for paths starting with prefix,
+// replacing any existing TTL for the same prefix. A TTL of zero or less
+// disables caching for the prefix.
+func WithCacheTTL(prefix string, ttl time.Duration) CacheOption {
+	return func(c *Cache) {
+		c.TTLs[prefix] = ttl
+	}
+}
+
+// WithoutDefaultCacheTTLs removes DefaultCacheTTLs, so only paths given with
+// WithCacheTTL are cached.
+func WithoutDefaultCacheTTLs() CacheOption {
+	return func(c *Cache) {
+		for prefix := range DefaultCacheTTLs {
+			delete(c.TTLs, prefix)
+		}
+	}
+}
+
+// Cache caches successful GET responses for configured paths, and
+// revalidates stale responses with If-None-Match when the API provided an
+// ETag. Requests with any other method are never cached.
+//
+// Cached responses are scoped to the Authorization header they were fetched
+// with, so they are never shared between API keys or tokens. Requests with a
+// "Cache-Control: no-cache" header skip the cache, but still update it.
+type Cache struct {
+	// Store holds cached responses.
+	Store CacheStore
+
+	// TTLs holds how long responses are cached for, keyed by path prefix.
+	// When several prefixes match a path, the longest one is used. Paths
+	// without a matching prefix are not cached.
+	TTLs map[string]time.Duration
+}
+
+// NewCache returns a new Cache, using an in-memory store and
+// DefaultCacheTTLs unless changed by opts.
+func NewCache(opts ...CacheOption) *Cache {
+	c := &Cache{
+		Store: NewMemoryCacheStore(),
+		TTLs:  make(map[string]time.Duration, len(DefaultCacheTTLs)),
+	}
+	for prefix, ttl := range DefaultCacheTTLs {
+		c.TTLs[prefix] = ttl
+	}
+
+	for _, o := range opts {
+		o(c)
+	}
+
+	return c
+}
+
+// TTL returns how long responses for path are cached for, or zero if they are
+// not cached.
+func (c *Cache) TTL(path string) time.Duration {
+	var ttl time.Duration
+	longest := -1
+	for prefix, d := range c.TTLs {
+		if len(prefix) > longest && hasPathPrefix(path, prefix) {
+			ttl = d
+			longest = len(prefix)
+		}
+	}
+
+	return ttl
+}
+
+// Invalidate removes all cached responses for paths starting with prefix,
+// regardless of their query string or credentials.
+func (c *Cache) Invalidate(prefix string) {
+	for _, key := range c.Store.Keys() {
+		_, rawURL, _ := strings.Cut(key, " ")
+		u, err := url.Parse(rawURL)
+		if err == nil && hasPathPrefix(u.Path, prefix) {
+			c.Store.Delete(key)
+		}
+	}
+}
+
+// Purge removes all cached responses.
+func (c *Cache) Purge() {
+	for _, key := range c.Store.Keys() {
+		c.Store.Delete(key)
+	}
+}
+
+// HTTPClient returns a HTTPClient which serves requests from the cache where
+// possible, and sends all others with hc.
+func (c *Cache) HTTPClient(hc HTTPClient) HTTPClient {
+	return &cacheHTTPClient{cache: c, client: hc}
+}
+
+type cacheHTTPClient struct {
+	cache  *Cache
+	client HTTPClient
+}
+
+func (c *cacheHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.client.Do(req)
+	}
+	ttl := c.cache.TTL(req.URL.Path)
+	if ttl <= 0 {
+		return c.client.Do(req)
+	}
+
+	key := cacheKey(req)
+	entry, ok := c.cache.Store.Get(key)
+	if ok && !noCache(req) {
+		if entry.Fresh() {
+			return entry.response(req, CacheHit), nil
+		}
+		if entry.ETag != "" {
+			return c.revalidate(req, key, entry, ttl)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.store(key, resp, ttl)
+}
+
+func (c *cacheHTTPClient) revalidate(
+	req *http.Request,
+	key string,
+	entry *CacheEntry,
+	ttl time.Duration,
+) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.Header.Set("If-None-Match", entry.ETag)
+
+	resp, err := c.client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusNotModified {
+		return c.store(key, resp, ttl)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	updated := *entry
+	updated.Header = entry.Header.Clone()
+	for k, v := range resp.Header {
+		updated.Header[k] = v
+	}
+	updated.Expires = time.Now().Add(ttl)
+	c.cache.Store.Set(key, &updated)
+
+	return updated.response(req, CacheRevalidated), nil
+}
+
+// store caches resp if it was successful and does not forbid caching, and
+// returns it with its body intact.
+func (c *cacheHTTPClient) store(
+	key string,
+	resp *http.Response,
+	ttl time.Duration,
+) (*http.Response, error) {
+	if resp.StatusCode != http.StatusOK ||
+		strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.cache.Store.Set(key, &CacheEntry{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		ETag:       resp.Header.Get("ETag"),
+		Expires:    time.Now().Add(ttl),
+	})
+
+	return resp, nil
+}
+
+// cacheKey returns the key req is cached under, made up of a hash of its
+// Authorization header and its URL, separated by a space.
+func cacheKey(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+
+	return hex.EncodeToString(sum[:8]) + " " + req.URL.String()
+}
+
+func noCache(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Cache-Control"), "no-cache")
+}
+
+// hasPathPrefix reports whether path is prefix, or is below it.
+func hasPathPrefix(path string, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+
+	return len(path) == len(prefix) ||
+		strings.HasSuffix(prefix, "/") ||
+		path[len(prefix)] == '/'
+}
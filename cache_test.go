@@ -0,0 +1,355 @@
+package katapult
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCache(t *testing.T) {
+	c := NewCache()
+	assert.IsType(t, &MemoryCacheStore{}, c.Store)
+	assert.Equal(t, DefaultCacheTTLs, c.TTLs)
+
+	store := NewMemoryCacheStore()
+	c = NewCache(
+		WithCacheStore(store),
+		WithoutDefaultCacheTTLs(),
+		WithCacheTTL("/core/v1/zones", time.Minute),
+	)
+	assert.Same(t, store, c.Store)
+	assert.Equal(t,
+		map[string]time.Duration{"/core/v1/zones": time.Minute}, c.TTLs,
+	)
+	assert.NotEmpty(t, DefaultCacheTTLs, "defaults must not be modified")
+}
+
+func TestCache_TTL(t *testing.T) {
+	c := NewCache(
+		WithoutDefaultCacheTTLs(),
+		WithCacheTTL("/core/v1/data_centers", time.Hour),
+		WithCacheTTL("/core/v1/data_centers/_/default_network", time.Minute),
+		WithCacheTTL("/core/v1/virtual_machine_packages", 0),
+	)
+
+	tests := []struct {
+		name string
+		path string
+		want time.Duration
+	}{
+		{
+			name: "exact prefix",
+			path: "/core/v1/data_centers",
+			want: time.Hour,
+		},
+		{
+			name: "below prefix",
+			path: "/core/v1/data_centers/_",
+			want: time.Hour,
+		},
+		{
+			name: "longest prefix wins",
+			path: "/core/v1/data_centers/_/default_network",
+			want: time.Minute,
+		},
+		{
+			name: "partial path segment",
+			path: "/core/v1/data_centers_extra",
+			want: 0,
+		},
+		{
+			name: "disabled prefix",
+			path: "/core/v1/virtual_machine_packages",
+			want: 0,
+		},
+		{
+			name: "unknown path",
+			path: "/core/v1/virtual_machines",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.TTL(tt.path))
+		})
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c := NewCache()
+	for _, key := range []string{
+		"aa https://api.katapult.io/core/v1/data_centers",
+		"bb https://api.katapult.io/core/v1/data_centers/_?id=dc_1",
+		"aa https://api.katapult.io/core/v1/virtual_machine_packages",
+	} {
+		c.Store.Set(key, &CacheEntry{Expires: time.Now().Add(time.Hour)})
+	}
+
+	c.Invalidate("/core/v1/data_centers")
+	assert.Equal(t, []string{
+		"aa https://api.katapult.io/core/v1/virtual_machine_packages",
+	}, c.Store.Keys())
+
+	c.Purge()
+	assert.Empty(t, c.Store.Keys())
+}
+
+func TestMemoryCacheStore_expired(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryCacheStore()
+	s.MaxStale = time.Hour
+
+	entries := map[string]*CacheEntry{
+		"fresh":        {Expires: now.Add(time.Minute)},
+		"expired":      {Expires: now.Add(-time.Minute)},
+		"stale etag":   {ETag: `"1"`, Expires: now.Add(-time.Minute)},
+		"expired etag": {ETag: `"2"`, Expires: now.Add(-2 * time.Hour)},
+	}
+	for key, e := range entries {
+		s.entries[key] = e
+	}
+
+	got, ok := s.Get("expired")
+	assert.False(t, ok)
+	assert.Nil(t, got)
+	assert.Equal(t,
+		[]string{"expired etag", "fresh", "stale etag"}, s.Keys(),
+	)
+
+	got, ok = s.Get("stale etag")
+	assert.True(t, ok)
+	assert.Same(t, entries["stale etag"], got)
+
+	s.Set("new", &CacheEntry{Expires: now.Add(time.Minute)})
+	assert.Equal(t, []string{"fresh", "new", "stale etag"}, s.Keys())
+}
+
+func TestCache_HTTPClient(t *testing.T) {
+	type step struct {
+		method     string
+		path       string
+		header     http.Header
+		wait       time.Duration
+		wantStatus string
+		wantBody   string
+	}
+	tests := []struct {
+		name         string
+		etag         bool
+		noStore      bool
+		steps        []step
+		wantRequests int
+	}{
+		{
+			name: "fresh responses are served from the cache",
+			steps: []step{
+				{path: "/cached", wantBody: "1"},
+				{path: "/cached", wantStatus: CacheHit, wantBody: "1"},
+				{path: "/cached?page=2", wantBody: "2"},
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "uncached paths and methods are not cached",
+			steps: []step{
+				{path: "/other", wantBody: "1"},
+				{path: "/other", wantBody: "2"},
+				{method: "POST", path: "/cached", wantBody: "3"},
+				{method: "POST", path: "/cached", wantBody: "4"},
+			},
+			wantRequests: 4,
+		},
+		{
+			name: "credentials are not shared",
+			steps: []step{
+				{
+					path:     "/cached",
+					header:   http.Header{"Authorization": {"Bearer a"}},
+					wantBody: "1",
+				},
+				{
+					path:     "/cached",
+					header:   http.Header{"Authorization": {"Bearer b"}},
+					wantBody: "2",
+				},
+				{
+					path:       "/cached",
+					header:     http.Header{"Authorization": {"Bearer a"}},
+					wantStatus: CacheHit,
+					wantBody:   "1",
+				},
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "no-cache requests refresh the cache",
+			steps: []step{
+				{path: "/cached", wantBody: "1"},
+				{
+					path:     "/cached",
+					header:   http.Header{"Cache-Control": {"no-cache"}},
+					wantBody: "2",
+				},
+				{path: "/cached", wantStatus: CacheHit, wantBody: "2"},
+			},
+			wantRequests: 2,
+		},
+		{
+			name:    "no-store responses are not cached",
+			noStore: true,
+			steps: []step{
+				{path: "/cached", wantBody: "1"},
+				{path: "/cached", wantBody: "2"},
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "stale responses without an ETag are refetched",
+			steps: []step{
+				{path: "/short", wantBody: "1"},
+				{path: "/short", wait: 20 * time.Millisecond, wantBody: "2"},
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "stale responses with an ETag are revalidated",
+			etag: true,
+			steps: []step{
+				{path: "/short", wantBody: "1"},
+				{
+					path:       "/short",
+					wait:       20 * time.Millisecond,
+					wantStatus: CacheRevalidated,
+					wantBody:   "1",
+				},
+				{path: "/short", wantStatus: CacheHit, wantBody: "1"},
+			},
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					requests++
+					if tt.etag {
+						if r.Header.Get("If-None-Match") == `"v1"` {
+							w.WriteHeader(http.StatusNotModified)
+
+							return
+						}
+						w.Header().Set("ETag", `"v1"`)
+					}
+					if tt.noStore {
+						w.Header().Set("Cache-Control", "no-store")
+					}
+					fmt.Fprint(w, requests)
+				},
+			))
+			defer server.Close()
+
+			cache := NewCache(
+				WithoutDefaultCacheTTLs(),
+				WithCacheTTL("/cached", time.Hour),
+				WithCacheTTL("/short", 10*time.Millisecond),
+			)
+			hc := cache.HTTPClient(server.Client())
+
+			for _, s := range tt.steps {
+				time.Sleep(s.wait)
+
+				method := s.method
+				if method == "" {
+					method = "GET"
+				}
+				req, err := http.NewRequest(method, server.URL+s.path, nil)
+				require.NoError(t, err)
+				for k, v := range s.header {
+					req.Header[k] = v
+				}
+
+				resp, err := hc.Do(req)
+				require.NoError(t, err)
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				resp.Body.Close()
+
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, s.wantStatus, resp.Header.Get(HeaderCache))
+				assert.Equal(t, s.wantBody, string(body))
+			}
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestClient_Do_cache(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			fmt.Fprintf(w, `{"n":%d}`, requests)
+		},
+	))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c, err := New(
+		WithBaseURL(u),
+		WithAPIKey("key"),
+		WithCache(NewCache()),
+		WithHTTPClient(server.Client()),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	get := func(opts ...RequestOption) (int, *Response) {
+		req := NewRequest(
+			"GET", &url.URL{Path: "/core/v1/data_centers"}, nil, opts...,
+		)
+		body := &struct{ N int }{}
+		resp, err := c.Do(ctx, req, body)
+		require.NoError(t, err)
+
+		return body.N, resp
+	}
+
+	n, resp := get()
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "", resp.CacheStatus)
+	assert.Equal(t, 1, resp.Attempts)
+
+	n, resp = get()
+	assert.Equal(t, 1, n)
+	assert.Equal(t, CacheHit, resp.CacheStatus)
+	assert.Equal(t, 0, resp.Attempts)
+
+	n, _ = get(RequestNoCache())
+	assert.Equal(t, 2, n)
+
+	c.Cache.Invalidate("/core/v1/data_centers")
+	n, _ = get()
+	assert.Equal(t, 3, n)
+}
+
+func TestWithCache(t *testing.T) {
+	c := &Client{}
+	cache := NewCache()
+
+	err := WithCache(cache)(c)
+	require.NoError(t, err)
+	assert.Same(t, cache, c.Cache)
+
+	err = WithCache(nil)(c)
+	assert.EqualError(t, err, "katapult: cache cannot be nil")
+}
@@ -0,0 +1,47 @@
+package katapult
+
+import (
+	"context"
+	"net/url"
+)
+
+type contextKey int
+
+const (
+	apiKeyContextKey contextKey = iota
+	baseURLContextKey
+)
+
+// ContextWithAPIKey returns a copy of ctx which carries key as the API key for
+// any requests made with it, overriding the Client's APIKey. This allows a
+// single Client, and its connection pool, to act on behalf of many API tokens
+// concurrently.
+func ContextWithAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey, key)
+}
+
+// APIKeyFromContext returns the API key carried by ctx, if any.
+func APIKeyFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	key, ok := ctx.Value(apiKeyContextKey).(string)
+
+	return key, ok && key != ""
+}
+
+// ContextWithBaseURL returns a copy of ctx which carries u as the base URL for
+// any requests made with it, overriding the Client's BaseURL.
+func ContextWithBaseURL(ctx context.Context, u *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLContextKey, u)
+}
+
+// BaseURLFromContext returns the base URL carried by ctx, if any.
+func BaseURLFromContext(ctx context.Context) (*url.URL, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	u, ok := ctx.Value(baseURLContextKey).(*url.URL)
+
+	return u, ok && u != nil
+}
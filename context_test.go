@@ -0,0 +1,83 @@
+package katapult
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyFromContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   string
+		wantOK bool
+	}{
+		{
+			name: "nil context",
+			ctx:  nil,
+		},
+		{
+			name: "without API key",
+			ctx:  context.Background(),
+		},
+		{
+			name: "empty API key",
+			ctx:  ContextWithAPIKey(context.Background(), ""),
+		},
+		{
+			name:   "with API key",
+			ctx:    ContextWithAPIKey(context.Background(), "tenant-key"),
+			want:   "tenant-key",
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := APIKeyFromContext(tt.ctx)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBaseURLFromContext(t *testing.T) {
+	u := &url.URL{Scheme: "https", Host: "api.example.com"}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   *url.URL
+		wantOK bool
+	}{
+		{
+			name: "nil context",
+			ctx:  nil,
+		},
+		{
+			name: "without base URL",
+			ctx:  context.Background(),
+		},
+		{
+			name: "nil base URL",
+			ctx:  ContextWithBaseURL(context.Background(), nil),
+		},
+		{
+			name:   "with base URL",
+			ctx:    ContextWithBaseURL(context.Background(), u),
+			want:   u,
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := BaseURLFromContext(tt.ctx)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -62,6 +62,7 @@ func (s *CertificatesClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*Certificate, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/certificates",
@@ -6,11 +6,15 @@ import (
 	"github.com/krystal/go-katapult"
 )
 
+//go:generate go run github.com/krystal/go-katapult/tools/fakegen -i github.com/krystal/go-katapult/core -o corefake -p corefake
+
 type Client struct {
 	Certificates                    *CertificatesClient
 	DNSZones                        *DNSZonesClient
 	DataCenters                     *DataCentersClient
+	DiskBackupPolicies              *DiskBackupPoliciesClient
 	DiskTemplates                   *DiskTemplatesClient
+	Disks                           *DisksClient
 	FileStorageVolumes              *FileStorageVolumesClient
 	IPAddresses                     *IPAddressesClient
 	LoadBalancers                   *LoadBalancersClient
@@ -47,7 +51,9 @@ func New(rm RequestMaker) *Client {
 		Certificates:         NewCertificatesClient(rm),
 		DNSZones:             NewDNSZonesClient(rm),
 		DataCenters:          NewDataCentersClient(rm),
+		DiskBackupPolicies:   NewDiskBackupPoliciesClient(rm),
 		DiskTemplates:        NewDiskTemplatesClient(rm),
+		Disks:                NewDisksClient(rm),
 		FileStorageVolumes:   NewFileStorageVolumesClient(rm),
 		IPAddresses:          NewIPAddressesClient(rm),
 		LoadBalancers:        NewLoadBalancersClient(rm),
@@ -134,3 +134,28 @@ func TestNew(t *testing.T) {
 		}
 	}
 }
+
+func TestNewAPI(t *testing.T) {
+	t.Parallel()
+
+	tc := &testclient.Client{}
+	c := New(tc)
+	api := NewAPI(tc)
+
+	assert.Equal(t, c.API(), api)
+
+	rv := reflect.ValueOf(api).Elem()
+	cv := reflect.ValueOf(c).Elem()
+
+	// Every field on Client must have an interface-typed counterpart on API.
+	assert.Equal(t, cv.NumField(), rv.NumField())
+	for i := 0; i < cv.NumField(); i++ {
+		name := cv.Type().Field(i).Name
+		f := rv.FieldByName(name)
+
+		if assert.True(t, f.IsValid(), "API field: "+name+" (missing)") {
+			assert.Equal(t, reflect.Interface, f.Kind(), "API field: "+name)
+			assert.False(t, f.IsNil(), "API field: "+name+" (is nil)")
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+)
+
+type contextKey int
+
+const organizationContextKey contextKey = iota
+
+// ContextWithOrganization returns a copy of ctx which carries org as the
+// default organization. Methods which take an OrganizationRef use it in place
+// of an empty reference, allowing a single Client to act on behalf of many
+// organizations concurrently.
+//
+// Combine with katapult.ContextWithAPIKey to also use a per-organization API
+// token.
+func ContextWithOrganization(
+	ctx context.Context,
+	org OrganizationRef,
+) context.Context {
+	return context.WithValue(ctx, organizationContextKey, org)
+}
+
+// OrganizationFromContext returns the default organization carried by ctx, if
+// any.
+func OrganizationFromContext(ctx context.Context) (OrganizationRef, bool) {
+	if ctx == nil {
+		return OrganizationRef{}, false
+	}
+	org, ok := ctx.Value(organizationContextKey).(OrganizationRef)
+
+	return org, ok && !org.empty()
+}
+
+// withDefault returns or, or when it is empty, the default organization
+// carried by ctx.
+func (or OrganizationRef) withDefault(ctx context.Context) OrganizationRef {
+	if !or.empty() {
+		return or
+	}
+	if org, ok := OrganizationFromContext(ctx); ok {
+		return org
+	}
+
+	return or
+}
+
+func (or OrganizationRef) empty() bool {
+	return or.ID == "" && or.SubDomain == ""
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWithOrganization(t *testing.T) {
+	org := OrganizationRef{ID: "org_O648YDMEYeLmqdmn"}
+	ctx := ContextWithOrganization(context.Background(), org)
+
+	got, ok := OrganizationFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, org, got)
+}
+
+func TestOrganizationFromContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   OrganizationRef
+		wantOK bool
+	}{
+		{
+			name: "nil context",
+			ctx:  nil,
+		},
+		{
+			name: "without organization",
+			ctx:  context.Background(),
+		},
+		{
+			name: "empty organization",
+			ctx: ContextWithOrganization(
+				context.Background(), OrganizationRef{},
+			),
+		},
+		{
+			name: "with organization",
+			ctx: ContextWithOrganization(
+				context.Background(), OrganizationRef{SubDomain: "acme"},
+			),
+			want:   OrganizationRef{SubDomain: "acme"},
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := OrganizationFromContext(tt.ctx)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOrganizationRef_withDefault(t *testing.T) {
+	ctx := ContextWithOrganization(
+		context.Background(), OrganizationRef{SubDomain: "acme"},
+	)
+
+	tests := []struct {
+		name string
+		ref  OrganizationRef
+		ctx  context.Context
+		want OrganizationRef
+	}{
+		{
+			name: "empty ref without context organization",
+			ctx:  context.Background(),
+			want: OrganizationRef{},
+		},
+		{
+			name: "empty ref with context organization",
+			ctx:  ctx,
+			want: OrganizationRef{SubDomain: "acme"},
+		},
+		{
+			name: "ref takes precedence over context",
+			ref:  OrganizationRef{ID: "org_O648YDMEYeLmqdmn"},
+			ctx:  ctx,
+			want: OrganizationRef{ID: "org_O648YDMEYeLmqdmn"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ref.withDefault(tt.ctx))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Package corefake contains in-memory fakes of every core resource client,
+// each satisfying the matching interface in the core package (for example
+// *corefake.VirtualMachines satisfies core.VirtualMachinesAPI).
+//
+// Every method call is recorded, and behavior is customized by assigning the
+// fake's <Method>Func fields. Methods without a Func return zero values.
+//
+//	fake := corefake.New()
+//	fake.VirtualMachines.GetFunc = func(
+//		ctx context.Context,
+//		ref core.VirtualMachineRef,
+//		reqOpts ...katapult.RequestOption,
+//	) (*core.VirtualMachine, *katapult.Response, error) {
+//		return &core.VirtualMachine{ID: ref.ID}, nil, nil
+//	}
+//
+//	api := fake.API() // *core.API
+//	// ...
+//	calls := fake.Calls()
+package corefake
+
+import (
+	"sync"
+)
+
+// Call is a single recorded call to a fake's method.
+type Call struct {
+	// Resource is the name of the resource client the call was made to, as it
+	// appears on core.Client (e.g. "VirtualMachines").
+	Resource string
+
+	// Method is the name of the method which was called (e.g. "Get").
+	Method string
+
+	// Args are the arguments the method was called with, in order. Variadic
+	// arguments are recorded as a single slice.
+	Args []interface{}
+}
+
+// Recorder records calls made to fakes. It is safe for concurrent use.
+//
+// Fakes lazily create their own Recorder if none is set, which is not safe
+// for concurrent use. Use New, or assign a Recorder before sharing a fake
+// across goroutines.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns a copy of all calls recorded so far, in the order they were
+// made.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call{}, r.calls...)
+}
+
+// CallsTo returns all recorded calls to the given resource and method.
+func (r *Recorder) CallsTo(resource string, method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := []Call{}
+	for _, c := range r.calls {
+		if c.Resource == resource && c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// Reset discards all recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
+
+func record(
+	r **Recorder,
+	resource string,
+	method string,
+	args ...interface{},
+) {
+	if *r == nil {
+		*r = &Recorder{}
+	}
+
+	(*r).mu.Lock()
+	defer (*r).mu.Unlock()
+
+	(*r).calls = append((*r).calls, Call{
+		Resource: resource,
+		Method:   method,
+		Args:     args,
+	})
+}
@@ -0,0 +1,130 @@
+package corefake
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	c := New()
+	api := c.API()
+
+	assert.Same(t, c.VirtualMachines, api.VirtualMachines)
+	assert.Same(t, c.Recorder, c.VirtualMachines.Recorder)
+	assert.Same(t, c.Recorder, c.DNSZones.Recorder)
+}
+
+func TestClient_recordsCalls(t *testing.T) {
+	ctx := context.Background()
+	c := New()
+	api := c.API()
+
+	vm := &core.VirtualMachine{ID: "vm_1"}
+	c.VirtualMachines.GetFunc = func(
+		_ context.Context,
+		ref core.VirtualMachineRef,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachine, *katapult.Response, error) {
+		if ref.ID != vm.ID {
+			return nil, nil, core.ErrVirtualMachineNotFound
+		}
+
+		return vm, nil, nil
+	}
+
+	got, _, err := api.VirtualMachines.Get(
+		ctx, core.VirtualMachineRef{ID: "vm_1"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, vm, got)
+
+	_, _, err = api.VirtualMachines.Get(ctx, core.VirtualMachineRef{ID: "x"})
+	assert.True(t, errors.Is(err, core.ErrVirtualMachineNotFound))
+
+	zones, resp, err := api.DNSZones.List(
+		ctx, core.OrganizationRef{SubDomain: "acme"}, nil,
+	)
+	assert.Nil(t, zones)
+	assert.Nil(t, resp)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Call{
+		{
+			Resource: "VirtualMachines",
+			Method:   "Get",
+			Args: []interface{}{
+				ctx,
+				core.VirtualMachineRef{ID: "vm_1"},
+				[]katapult.RequestOption(nil),
+			},
+		},
+		{
+			Resource: "VirtualMachines",
+			Method:   "Get",
+			Args: []interface{}{
+				ctx,
+				core.VirtualMachineRef{ID: "x"},
+				[]katapult.RequestOption(nil),
+			},
+		},
+		{
+			Resource: "DNSZones",
+			Method:   "List",
+			Args: []interface{}{
+				ctx,
+				core.OrganizationRef{SubDomain: "acme"},
+				(*core.ListOptions)(nil),
+				[]katapult.RequestOption(nil),
+			},
+		},
+	}, c.Calls())
+	assert.Len(t, c.CallsTo("VirtualMachines", "Get"), 2)
+	assert.Len(t, c.CallsTo("VirtualMachines", "Delete"), 0)
+
+	c.Reset()
+	assert.Empty(t, c.Calls())
+}
+
+func TestFake_withoutRecorder(t *testing.T) {
+	f := &Tags{}
+
+	_, _, err := f.Delete(context.Background(), core.TagRef{ID: "tag_1"})
+	require.NoError(t, err)
+
+	require.NotNil(t, f.Recorder)
+	assert.Equal(t, []Call{
+		{
+			Resource: "Tags",
+			Method:   "Delete",
+			Args: []interface{}{
+				context.Background(),
+				core.TagRef{ID: "tag_1"},
+				[]katapult.RequestOption(nil),
+			},
+		},
+	}, f.Calls())
+}
+
+func TestRecorder_concurrent(t *testing.T) {
+	c := New()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, _ = c.Tasks.Get(ctx, "task_1")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, c.CallsTo("Tasks", "Get"), 20)
+}
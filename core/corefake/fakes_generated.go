@@ -0,0 +1,1618 @@
+package corefake
+
+import (
+	"context"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/namegenerator"
+)
+
+// Code generated by github.com/krystal/go-katapult/tools/fakegen. DO NOT EDIT.
+
+// Certificates is an in-memory fake of core.CertificatesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type Certificates struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.Certificate, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.Certificate, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.Certificate, *katapult.Response, error)
+}
+
+func (f *Certificates) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.Certificate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Certificates", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Certificates) Get(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.Certificate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Certificates", "Get", ctx, id, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Certificates) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.Certificate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Certificates", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+// DNSZones is an in-memory fake of core.DNSZonesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type DNSZones struct {
+	*Recorder
+
+	ListFunc        func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.DNSZone, *katapult.Response, error)
+	NameserversFunc func(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) ([]string, *katapult.Response, error)
+	GetFunc         func(ctx context.Context, ref core.DNSZoneRef, reqOpts ...katapult.RequestOption) (*core.DNSZone, *katapult.Response, error)
+	GetByIDFunc     func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.DNSZone, *katapult.Response, error)
+	GetByNameFunc   func(ctx context.Context, name string, reqOpts ...katapult.RequestOption) (*core.DNSZone, *katapult.Response, error)
+	CreateFunc      func(ctx context.Context, org core.OrganizationRef, args *core.DNSZoneCreateArguments, reqOpts ...katapult.RequestOption) (*core.DNSZone, *katapult.Response, error)
+	UpdateFunc      func(ctx context.Context, zone core.DNSZoneRef, args *core.DNSZoneUpdateArguments, reqOpts ...katapult.RequestOption) (*core.DNSZone, *katapult.Response, error)
+	DeleteFunc      func(ctx context.Context, zone core.DNSZoneRef, reqOpts ...katapult.RequestOption) (*bool, *katapult.Response, error)
+	VerifyFunc      func(ctx context.Context, ref core.DNSZoneRef, reqOpts ...katapult.RequestOption) (*core.DNSZone, *katapult.Response, error)
+}
+
+func (f *DNSZones) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) Nameservers(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (r0 []string, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "Nameservers", ctx, org, reqOpts)
+	if f.NameserversFunc != nil {
+		return f.NameserversFunc(ctx, org, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) Get(ctx context.Context, ref core.DNSZoneRef, reqOpts ...katapult.RequestOption) (r0 *core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) GetByName(ctx context.Context, name string, reqOpts ...katapult.RequestOption) (r0 *core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "GetByName", ctx, name, reqOpts)
+	if f.GetByNameFunc != nil {
+		return f.GetByNameFunc(ctx, name, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) Create(ctx context.Context, org core.OrganizationRef, args *core.DNSZoneCreateArguments, reqOpts ...katapult.RequestOption) (r0 *core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) Update(ctx context.Context, zone core.DNSZoneRef, args *core.DNSZoneUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "Update", ctx, zone, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, zone, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) Delete(ctx context.Context, zone core.DNSZoneRef, reqOpts ...katapult.RequestOption) (r0 *bool, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "Delete", ctx, zone, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, zone, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DNSZones) Verify(ctx context.Context, ref core.DNSZoneRef, reqOpts ...katapult.RequestOption) (r0 *core.DNSZone, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DNSZones", "Verify", ctx, ref, reqOpts)
+	if f.VerifyFunc != nil {
+		return f.VerifyFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// DataCenters is an in-memory fake of core.DataCentersAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type DataCenters struct {
+	*Recorder
+
+	ListFunc           func(ctx context.Context, reqOpts ...katapult.RequestOption) ([]*core.DataCenter, *katapult.Response, error)
+	GetFunc            func(ctx context.Context, ref core.DataCenterRef, reqOpts ...katapult.RequestOption) (*core.DataCenter, *katapult.Response, error)
+	GetByIDFunc        func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.DataCenter, *katapult.Response, error)
+	GetByPermalinkFunc func(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*core.DataCenter, *katapult.Response, error)
+	DefaultNetworkFunc func(ctx context.Context, ref core.DataCenterRef, reqOpts ...katapult.RequestOption) (*core.Network, *katapult.Response, error)
+}
+
+func (f *DataCenters) List(ctx context.Context, reqOpts ...katapult.RequestOption) (r0 []*core.DataCenter, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DataCenters", "List", ctx, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DataCenters) Get(ctx context.Context, ref core.DataCenterRef, reqOpts ...katapult.RequestOption) (r0 *core.DataCenter, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DataCenters", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DataCenters) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.DataCenter, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DataCenters", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DataCenters) GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (r0 *core.DataCenter, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DataCenters", "GetByPermalink", ctx, permalink, reqOpts)
+	if f.GetByPermalinkFunc != nil {
+		return f.GetByPermalinkFunc(ctx, permalink, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DataCenters) DefaultNetwork(ctx context.Context, ref core.DataCenterRef, reqOpts ...katapult.RequestOption) (r0 *core.Network, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DataCenters", "DefaultNetwork", ctx, ref, reqOpts)
+	if f.DefaultNetworkFunc != nil {
+		return f.DefaultNetworkFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// DiskBackupPolicies is an in-memory fake of core.DiskBackupPoliciesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type DiskBackupPolicies struct {
+	*Recorder
+
+	ListForVirtualMachineFunc func(ctx context.Context, vm core.VirtualMachineRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.DiskBackupPolicy, *katapult.Response, error)
+	ListForDiskFunc           func(ctx context.Context, disk core.DiskRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.DiskBackupPolicy, *katapult.Response, error)
+	GetFunc                   func(ctx context.Context, ref core.DiskBackupPolicyRef, reqOpts ...katapult.RequestOption) (*core.DiskBackupPolicy, *katapult.Response, error)
+	GetByIDFunc               func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.DiskBackupPolicy, *katapult.Response, error)
+}
+
+func (f *DiskBackupPolicies) ListForVirtualMachine(ctx context.Context, vm core.VirtualMachineRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.DiskBackupPolicy, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskBackupPolicies", "ListForVirtualMachine", ctx, vm, opts, reqOpts)
+	if f.ListForVirtualMachineFunc != nil {
+		return f.ListForVirtualMachineFunc(ctx, vm, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DiskBackupPolicies) ListForDisk(ctx context.Context, disk core.DiskRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.DiskBackupPolicy, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskBackupPolicies", "ListForDisk", ctx, disk, opts, reqOpts)
+	if f.ListForDiskFunc != nil {
+		return f.ListForDiskFunc(ctx, disk, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DiskBackupPolicies) Get(ctx context.Context, ref core.DiskBackupPolicyRef, reqOpts ...katapult.RequestOption) (r0 *core.DiskBackupPolicy, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskBackupPolicies", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DiskBackupPolicies) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.DiskBackupPolicy, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskBackupPolicies", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+// DiskTemplates is an in-memory fake of core.DiskTemplatesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type DiskTemplates struct {
+	*Recorder
+
+	ListFunc           func(ctx context.Context, org core.OrganizationRef, opts *core.DiskTemplateListOptions, reqOpts ...katapult.RequestOption) ([]*core.DiskTemplate, *katapult.Response, error)
+	GetFunc            func(ctx context.Context, ref core.DiskTemplateRef, reqOpts ...katapult.RequestOption) (*core.DiskTemplate, *katapult.Response, error)
+	GetByIDFunc        func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.DiskTemplate, *katapult.Response, error)
+	GetByPermalinkFunc func(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*core.DiskTemplate, *katapult.Response, error)
+}
+
+func (f *DiskTemplates) List(ctx context.Context, org core.OrganizationRef, opts *core.DiskTemplateListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.DiskTemplate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskTemplates", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DiskTemplates) Get(ctx context.Context, ref core.DiskTemplateRef, reqOpts ...katapult.RequestOption) (r0 *core.DiskTemplate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskTemplates", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DiskTemplates) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.DiskTemplate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskTemplates", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *DiskTemplates) GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (r0 *core.DiskTemplate, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "DiskTemplates", "GetByPermalink", ctx, permalink, reqOpts)
+	if f.GetByPermalinkFunc != nil {
+		return f.GetByPermalinkFunc(ctx, permalink, reqOpts...)
+	}
+
+	return
+}
+
+// Disks is an in-memory fake of core.DisksAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type Disks struct {
+	*Recorder
+
+	ListForVirtualMachineFunc func(ctx context.Context, vm core.VirtualMachineRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.VirtualMachineDisk, *katapult.Response, error)
+	GetFunc                   func(ctx context.Context, ref core.DiskRef, reqOpts ...katapult.RequestOption) (*core.Disk, *katapult.Response, error)
+	GetByIDFunc               func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.Disk, *katapult.Response, error)
+}
+
+func (f *Disks) ListForVirtualMachine(ctx context.Context, vm core.VirtualMachineRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.VirtualMachineDisk, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Disks", "ListForVirtualMachine", ctx, vm, opts, reqOpts)
+	if f.ListForVirtualMachineFunc != nil {
+		return f.ListForVirtualMachineFunc(ctx, vm, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Disks) Get(ctx context.Context, ref core.DiskRef, reqOpts ...katapult.RequestOption) (r0 *core.Disk, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Disks", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Disks) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.Disk, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Disks", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+// FileStorageVolumes is an in-memory fake of core.FileStorageVolumesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type FileStorageVolumes struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.FileStorageVolume, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, ref core.FileStorageVolumeRef, reqOpts ...katapult.RequestOption) (*core.FileStorageVolume, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.FileStorageVolume, *katapult.Response, error)
+	CreateFunc  func(ctx context.Context, org core.OrganizationRef, args *core.FileStorageVolumeCreateArguments, reqOpts ...katapult.RequestOption) (*core.FileStorageVolume, *katapult.Response, error)
+	UpdateFunc  func(ctx context.Context, ref core.FileStorageVolumeRef, args *core.FileStorageVolumeUpdateArguments, reqOpts ...katapult.RequestOption) (*core.FileStorageVolume, *katapult.Response, error)
+	DeleteFunc  func(ctx context.Context, ref core.FileStorageVolumeRef, reqOpts ...katapult.RequestOption) (*core.FileStorageVolume, *core.TrashObject, *katapult.Response, error)
+}
+
+func (f *FileStorageVolumes) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.FileStorageVolume, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "FileStorageVolumes", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *FileStorageVolumes) Get(ctx context.Context, ref core.FileStorageVolumeRef, reqOpts ...katapult.RequestOption) (r0 *core.FileStorageVolume, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "FileStorageVolumes", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *FileStorageVolumes) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.FileStorageVolume, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "FileStorageVolumes", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *FileStorageVolumes) Create(ctx context.Context, org core.OrganizationRef, args *core.FileStorageVolumeCreateArguments, reqOpts ...katapult.RequestOption) (r0 *core.FileStorageVolume, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "FileStorageVolumes", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *FileStorageVolumes) Update(ctx context.Context, ref core.FileStorageVolumeRef, args *core.FileStorageVolumeUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.FileStorageVolume, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "FileStorageVolumes", "Update", ctx, ref, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *FileStorageVolumes) Delete(ctx context.Context, ref core.FileStorageVolumeRef, reqOpts ...katapult.RequestOption) (r0 *core.FileStorageVolume, r1 *core.TrashObject, r2 *katapult.Response, r3 error) {
+	record(&f.Recorder, "FileStorageVolumes", "Delete", ctx, ref, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// IPAddresses is an in-memory fake of core.IPAddressesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type IPAddresses struct {
+	*Recorder
+
+	ListFunc         func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.IPAddress, *katapult.Response, error)
+	GetFunc          func(ctx context.Context, ref core.IPAddressRef, reqOpts ...katapult.RequestOption) (*core.IPAddress, *katapult.Response, error)
+	GetByIDFunc      func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.IPAddress, *katapult.Response, error)
+	GetByAddressFunc func(ctx context.Context, address string, reqOpts ...katapult.RequestOption) (*core.IPAddress, *katapult.Response, error)
+	CreateFunc       func(ctx context.Context, org core.OrganizationRef, args *core.IPAddressCreateArguments, reqOpts ...katapult.RequestOption) (*core.IPAddress, *katapult.Response, error)
+	UpdateFunc       func(ctx context.Context, ip core.IPAddressRef, args *core.IPAddressUpdateArguments, reqOpts ...katapult.RequestOption) (*core.IPAddress, *katapult.Response, error)
+	DeleteFunc       func(ctx context.Context, ip core.IPAddressRef, reqOpts ...katapult.RequestOption) (*katapult.Response, error)
+	UnallocateFunc   func(ctx context.Context, ip core.IPAddressRef, reqOpts ...katapult.RequestOption) (*katapult.Response, error)
+}
+
+func (f *IPAddresses) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "IPAddresses", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) Get(ctx context.Context, ref core.IPAddressRef, reqOpts ...katapult.RequestOption) (r0 *core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "IPAddresses", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "IPAddresses", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) GetByAddress(ctx context.Context, address string, reqOpts ...katapult.RequestOption) (r0 *core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "IPAddresses", "GetByAddress", ctx, address, reqOpts)
+	if f.GetByAddressFunc != nil {
+		return f.GetByAddressFunc(ctx, address, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) Create(ctx context.Context, org core.OrganizationRef, args *core.IPAddressCreateArguments, reqOpts ...katapult.RequestOption) (r0 *core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "IPAddresses", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) Update(ctx context.Context, ip core.IPAddressRef, args *core.IPAddressUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "IPAddresses", "Update", ctx, ip, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ip, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) Delete(ctx context.Context, ip core.IPAddressRef, reqOpts ...katapult.RequestOption) (r0 *katapult.Response, r1 error) {
+	record(&f.Recorder, "IPAddresses", "Delete", ctx, ip, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ip, reqOpts...)
+	}
+
+	return
+}
+
+func (f *IPAddresses) Unallocate(ctx context.Context, ip core.IPAddressRef, reqOpts ...katapult.RequestOption) (r0 *katapult.Response, r1 error) {
+	record(&f.Recorder, "IPAddresses", "Unallocate", ctx, ip, reqOpts)
+	if f.UnallocateFunc != nil {
+		return f.UnallocateFunc(ctx, ip, reqOpts...)
+	}
+
+	return
+}
+
+// LoadBalancers is an in-memory fake of core.LoadBalancersAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type LoadBalancers struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.LoadBalancer, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, ref core.LoadBalancerRef, reqOpts ...katapult.RequestOption) (*core.LoadBalancer, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.LoadBalancer, *katapult.Response, error)
+	CreateFunc  func(ctx context.Context, org core.OrganizationRef, args *core.LoadBalancerCreateArguments, reqOpts ...katapult.RequestOption) (*core.LoadBalancer, *katapult.Response, error)
+	UpdateFunc  func(ctx context.Context, lb core.LoadBalancerRef, args *core.LoadBalancerUpdateArguments, reqOpts ...katapult.RequestOption) (*core.LoadBalancer, *katapult.Response, error)
+	DeleteFunc  func(ctx context.Context, lb core.LoadBalancerRef, reqOpts ...katapult.RequestOption) (*core.LoadBalancer, *katapult.Response, error)
+}
+
+func (f *LoadBalancers) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.LoadBalancer, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancers", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancers) Get(ctx context.Context, ref core.LoadBalancerRef, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancer, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancers", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancers) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancer, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancers", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancers) Create(ctx context.Context, org core.OrganizationRef, args *core.LoadBalancerCreateArguments, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancer, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancers", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancers) Update(ctx context.Context, lb core.LoadBalancerRef, args *core.LoadBalancerUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancer, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancers", "Update", ctx, lb, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, lb, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancers) Delete(ctx context.Context, lb core.LoadBalancerRef, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancer, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancers", "Delete", ctx, lb, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, lb, reqOpts...)
+	}
+
+	return
+}
+
+// LoadBalancerRules is an in-memory fake of core.LoadBalancerRulesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type LoadBalancerRules struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, lb core.LoadBalancerRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.LoadBalancerRule, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, ref core.LoadBalancerRuleRef, reqOpts ...katapult.RequestOption) (*core.LoadBalancerRule, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.LoadBalancerRule, *katapult.Response, error)
+	CreateFunc  func(ctx context.Context, lb core.LoadBalancerRef, args *core.LoadBalancerRuleArguments, reqOpts ...katapult.RequestOption) (*core.LoadBalancerRule, *katapult.Response, error)
+	UpdateFunc  func(ctx context.Context, ref core.LoadBalancerRuleRef, args *core.LoadBalancerRuleArguments, reqOpts ...katapult.RequestOption) (*core.LoadBalancerRule, *katapult.Response, error)
+	DeleteFunc  func(ctx context.Context, ref core.LoadBalancerRuleRef, reqOpts ...katapult.RequestOption) (*core.LoadBalancerRule, *katapult.Response, error)
+}
+
+func (f *LoadBalancerRules) List(ctx context.Context, lb core.LoadBalancerRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.LoadBalancerRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancerRules", "List", ctx, lb, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, lb, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancerRules) Get(ctx context.Context, ref core.LoadBalancerRuleRef, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancerRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancerRules", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancerRules) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancerRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancerRules", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancerRules) Create(ctx context.Context, lb core.LoadBalancerRef, args *core.LoadBalancerRuleArguments, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancerRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancerRules", "Create", ctx, lb, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, lb, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancerRules) Update(ctx context.Context, ref core.LoadBalancerRuleRef, args *core.LoadBalancerRuleArguments, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancerRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancerRules", "Update", ctx, ref, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *LoadBalancerRules) Delete(ctx context.Context, ref core.LoadBalancerRuleRef, reqOpts ...katapult.RequestOption) (r0 *core.LoadBalancerRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "LoadBalancerRules", "Delete", ctx, ref, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// NetworkSpeedProfiles is an in-memory fake of core.NetworkSpeedProfilesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type NetworkSpeedProfiles struct {
+	*Recorder
+
+	ListFunc func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.NetworkSpeedProfile, *katapult.Response, error)
+}
+
+func (f *NetworkSpeedProfiles) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.NetworkSpeedProfile, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "NetworkSpeedProfiles", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+// Networks is an in-memory fake of core.NetworksAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type Networks struct {
+	*Recorder
+
+	ListFunc           func(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) ([]*core.Network, []*core.VirtualNetwork, *katapult.Response, error)
+	GetFunc            func(ctx context.Context, ref core.NetworkRef, reqOpts ...katapult.RequestOption) (*core.Network, *katapult.Response, error)
+	GetByIDFunc        func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.Network, *katapult.Response, error)
+	GetByPermalinkFunc func(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*core.Network, *katapult.Response, error)
+}
+
+func (f *Networks) List(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (r0 []*core.Network, r1 []*core.VirtualNetwork, r2 *katapult.Response, r3 error) {
+	record(&f.Recorder, "Networks", "List", ctx, org, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Networks) Get(ctx context.Context, ref core.NetworkRef, reqOpts ...katapult.RequestOption) (r0 *core.Network, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Networks", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Networks) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.Network, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Networks", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Networks) GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (r0 *core.Network, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Networks", "GetByPermalink", ctx, permalink, reqOpts)
+	if f.GetByPermalinkFunc != nil {
+		return f.GetByPermalinkFunc(ctx, permalink, reqOpts...)
+	}
+
+	return
+}
+
+// Organizations is an in-memory fake of core.OrganizationsAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type Organizations struct {
+	*Recorder
+
+	ListFunc           func(ctx context.Context, reqOpts ...katapult.RequestOption) ([]*core.Organization, *katapult.Response, error)
+	GetFunc            func(ctx context.Context, ref core.OrganizationRef, reqOpts ...katapult.RequestOption) (*core.Organization, *katapult.Response, error)
+	GetByIDFunc        func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.Organization, *katapult.Response, error)
+	GetBySubDomainFunc func(ctx context.Context, subDomain string, reqOpts ...katapult.RequestOption) (*core.Organization, *katapult.Response, error)
+	CreateManagedFunc  func(ctx context.Context, parent core.OrganizationRef, args *core.OrganizationManagedArguments, reqOpts ...katapult.RequestOption) (*core.Organization, *katapult.Response, error)
+}
+
+func (f *Organizations) List(ctx context.Context, reqOpts ...katapult.RequestOption) (r0 []*core.Organization, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Organizations", "List", ctx, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Organizations) Get(ctx context.Context, ref core.OrganizationRef, reqOpts ...katapult.RequestOption) (r0 *core.Organization, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Organizations", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Organizations) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.Organization, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Organizations", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Organizations) GetBySubDomain(ctx context.Context, subDomain string, reqOpts ...katapult.RequestOption) (r0 *core.Organization, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Organizations", "GetBySubDomain", ctx, subDomain, reqOpts)
+	if f.GetBySubDomainFunc != nil {
+		return f.GetBySubDomainFunc(ctx, subDomain, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Organizations) CreateManaged(ctx context.Context, parent core.OrganizationRef, args *core.OrganizationManagedArguments, reqOpts ...katapult.RequestOption) (r0 *core.Organization, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Organizations", "CreateManaged", ctx, parent, args, reqOpts)
+	if f.CreateManagedFunc != nil {
+		return f.CreateManagedFunc(ctx, parent, args, reqOpts...)
+	}
+
+	return
+}
+
+// SecurityGroups is an in-memory fake of core.SecurityGroupsAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type SecurityGroups struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.SecurityGroup, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, ref core.SecurityGroupRef, reqOpts ...katapult.RequestOption) (*core.SecurityGroup, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.SecurityGroup, *katapult.Response, error)
+	CreateFunc  func(ctx context.Context, org core.OrganizationRef, args *core.SecurityGroupCreateArguments, reqOpts ...katapult.RequestOption) (*core.SecurityGroup, *katapult.Response, error)
+	UpdateFunc  func(ctx context.Context, sg core.SecurityGroupRef, args *core.SecurityGroupUpdateArguments, reqOpts ...katapult.RequestOption) (*core.SecurityGroup, *katapult.Response, error)
+	DeleteFunc  func(ctx context.Context, sg core.SecurityGroupRef, reqOpts ...katapult.RequestOption) (*core.SecurityGroup, *katapult.Response, error)
+}
+
+func (f *SecurityGroups) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.SecurityGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroups", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroups) Get(ctx context.Context, ref core.SecurityGroupRef, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroups", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroups) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroups", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroups) Create(ctx context.Context, org core.OrganizationRef, args *core.SecurityGroupCreateArguments, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroups", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroups) Update(ctx context.Context, sg core.SecurityGroupRef, args *core.SecurityGroupUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroups", "Update", ctx, sg, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, sg, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroups) Delete(ctx context.Context, sg core.SecurityGroupRef, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroups", "Delete", ctx, sg, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, sg, reqOpts...)
+	}
+
+	return
+}
+
+// SecurityGroupRules is an in-memory fake of core.SecurityGroupRulesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type SecurityGroupRules struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, sg core.SecurityGroupRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.SecurityGroupRule, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, ref core.SecurityGroupRuleRef, reqOpts ...katapult.RequestOption) (*core.SecurityGroupRule, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.SecurityGroupRule, *katapult.Response, error)
+	CreateFunc  func(ctx context.Context, sg core.SecurityGroupRef, args *core.SecurityGroupRuleArguments, reqOpts ...katapult.RequestOption) (*core.SecurityGroupRule, *katapult.Response, error)
+	UpdateFunc  func(ctx context.Context, ref core.SecurityGroupRuleRef, args *core.SecurityGroupRuleArguments, reqOpts ...katapult.RequestOption) (*core.SecurityGroupRule, *katapult.Response, error)
+	DeleteFunc  func(ctx context.Context, ref core.SecurityGroupRuleRef, reqOpts ...katapult.RequestOption) (*core.SecurityGroupRule, *katapult.Response, error)
+}
+
+func (f *SecurityGroupRules) List(ctx context.Context, sg core.SecurityGroupRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.SecurityGroupRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroupRules", "List", ctx, sg, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, sg, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroupRules) Get(ctx context.Context, ref core.SecurityGroupRuleRef, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroupRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroupRules", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroupRules) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroupRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroupRules", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroupRules) Create(ctx context.Context, sg core.SecurityGroupRef, args *core.SecurityGroupRuleArguments, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroupRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroupRules", "Create", ctx, sg, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, sg, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroupRules) Update(ctx context.Context, ref core.SecurityGroupRuleRef, args *core.SecurityGroupRuleArguments, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroupRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroupRules", "Update", ctx, ref, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SecurityGroupRules) Delete(ctx context.Context, ref core.SecurityGroupRuleRef, reqOpts ...katapult.RequestOption) (r0 *core.SecurityGroupRule, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SecurityGroupRules", "Delete", ctx, ref, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// Tags is an in-memory fake of core.TagsAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type Tags struct {
+	*Recorder
+
+	ListFunc   func(ctx context.Context, ref core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.Tag, *katapult.Response, error)
+	GetFunc    func(ctx context.Context, ref core.TagRef, reqOpts ...katapult.RequestOption) (*core.Tag, *katapult.Response, error)
+	CreateFunc func(ctx context.Context, ref core.OrganizationRef, args core.TagArguments, reqOpts ...katapult.RequestOption) (*core.Tag, *katapult.Response, error)
+	UpdateFunc func(ctx context.Context, ref core.TagRef, args core.TagArguments, reqOpts ...katapult.RequestOption) (*core.Tag, *katapult.Response, error)
+	DeleteFunc func(ctx context.Context, ref core.TagRef, reqOpts ...katapult.RequestOption) (*core.Tag, *katapult.Response, error)
+}
+
+func (f *Tags) List(ctx context.Context, ref core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.Tag, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Tags", "List", ctx, ref, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, ref, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Tags) Get(ctx context.Context, ref core.TagRef, reqOpts ...katapult.RequestOption) (r0 *core.Tag, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Tags", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Tags) Create(ctx context.Context, ref core.OrganizationRef, args core.TagArguments, reqOpts ...katapult.RequestOption) (r0 *core.Tag, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Tags", "Create", ctx, ref, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Tags) Update(ctx context.Context, ref core.TagRef, args core.TagArguments, reqOpts ...katapult.RequestOption) (r0 *core.Tag, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Tags", "Update", ctx, ref, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *Tags) Delete(ctx context.Context, ref core.TagRef, reqOpts ...katapult.RequestOption) (r0 *core.Tag, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Tags", "Delete", ctx, ref, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// SSHKeys is an in-memory fake of core.SSHKeysAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type SSHKeys struct {
+	*Recorder
+
+	ListFunc   func(ctx context.Context, ref core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.AuthSSHKey, *katapult.Response, error)
+	AddFunc    func(ctx context.Context, ref core.OrganizationRef, properties core.AuthSSHKeyProperties, reqOpts ...katapult.RequestOption) (*core.AuthSSHKey, *katapult.Response, error)
+	DeleteFunc func(ctx context.Context, ref core.SSHKeyRef, reqOpts ...katapult.RequestOption) (*core.AuthSSHKey, *katapult.Response, error)
+}
+
+func (f *SSHKeys) List(ctx context.Context, ref core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.AuthSSHKey, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SSHKeys", "List", ctx, ref, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, ref, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SSHKeys) Add(ctx context.Context, ref core.OrganizationRef, properties core.AuthSSHKeyProperties, reqOpts ...katapult.RequestOption) (r0 *core.AuthSSHKey, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SSHKeys", "Add", ctx, ref, properties, reqOpts)
+	if f.AddFunc != nil {
+		return f.AddFunc(ctx, ref, properties, reqOpts...)
+	}
+
+	return
+}
+
+func (f *SSHKeys) Delete(ctx context.Context, ref core.SSHKeyRef, reqOpts ...katapult.RequestOption) (r0 *core.AuthSSHKey, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "SSHKeys", "Delete", ctx, ref, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// Tasks is an in-memory fake of core.TasksAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type Tasks struct {
+	*Recorder
+
+	GetFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+}
+
+func (f *Tasks) Get(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "Tasks", "Get", ctx, id, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+// TrashObjects is an in-memory fake of core.TrashObjectsAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type TrashObjects struct {
+	*Recorder
+
+	ListFunc          func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.TrashObject, *katapult.Response, error)
+	GetFunc           func(ctx context.Context, ref core.TrashObjectRef, reqOpts ...katapult.RequestOption) (*core.TrashObject, *katapult.Response, error)
+	GetByIDFunc       func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.TrashObject, *katapult.Response, error)
+	GetByObjectIDFunc func(ctx context.Context, objectID string, reqOpts ...katapult.RequestOption) (*core.TrashObject, *katapult.Response, error)
+	PurgeFunc         func(ctx context.Context, ref core.TrashObjectRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+	PurgeAllFunc      func(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+	RestoreFunc       func(ctx context.Context, ref core.TrashObjectRef, reqOpts ...katapult.RequestOption) (*core.TrashObject, *katapult.Response, error)
+}
+
+func (f *TrashObjects) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.TrashObject, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *TrashObjects) Get(ctx context.Context, ref core.TrashObjectRef, reqOpts ...katapult.RequestOption) (r0 *core.TrashObject, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *TrashObjects) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.TrashObject, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *TrashObjects) GetByObjectID(ctx context.Context, objectID string, reqOpts ...katapult.RequestOption) (r0 *core.TrashObject, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "GetByObjectID", ctx, objectID, reqOpts)
+	if f.GetByObjectIDFunc != nil {
+		return f.GetByObjectIDFunc(ctx, objectID, reqOpts...)
+	}
+
+	return
+}
+
+func (f *TrashObjects) Purge(ctx context.Context, ref core.TrashObjectRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "Purge", ctx, ref, reqOpts)
+	if f.PurgeFunc != nil {
+		return f.PurgeFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *TrashObjects) PurgeAll(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "PurgeAll", ctx, org, reqOpts)
+	if f.PurgeAllFunc != nil {
+		return f.PurgeAllFunc(ctx, org, reqOpts...)
+	}
+
+	return
+}
+
+func (f *TrashObjects) Restore(ctx context.Context, ref core.TrashObjectRef, reqOpts ...katapult.RequestOption) (r0 *core.TrashObject, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "TrashObjects", "Restore", ctx, ref, reqOpts)
+	if f.RestoreFunc != nil {
+		return f.RestoreFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// VirtualMachineBuilds is an in-memory fake of core.VirtualMachineBuildsAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type VirtualMachineBuilds struct {
+	*Recorder
+
+	GetFunc               func(ctx context.Context, ref core.VirtualMachineBuildRef, reqOpts ...katapult.RequestOption) (*core.VirtualMachineBuild, *katapult.Response, error)
+	GetByIDFunc           func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.VirtualMachineBuild, *katapult.Response, error)
+	CreateFunc            func(ctx context.Context, org core.OrganizationRef, args *core.VirtualMachineBuildArguments, reqOpts ...katapult.RequestOption) (*core.VirtualMachineBuild, *katapult.Response, error)
+	CreateFromSpecFunc    func(ctx context.Context, org core.OrganizationRef, spec *buildspec.VirtualMachineSpec, reqOpts ...katapult.RequestOption) (*core.VirtualMachineBuild, *katapult.Response, error)
+	CreateFromSpecXMLFunc func(ctx context.Context, org core.OrganizationRef, specXML string, reqOpts ...katapult.RequestOption) (*core.VirtualMachineBuild, *katapult.Response, error)
+}
+
+func (f *VirtualMachineBuilds) Get(ctx context.Context, ref core.VirtualMachineBuildRef, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineBuild, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineBuilds", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineBuilds) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineBuild, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineBuilds", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineBuilds) Create(ctx context.Context, org core.OrganizationRef, args *core.VirtualMachineBuildArguments, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineBuild, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineBuilds", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineBuilds) CreateFromSpec(ctx context.Context, org core.OrganizationRef, spec *buildspec.VirtualMachineSpec, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineBuild, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineBuilds", "CreateFromSpec", ctx, org, spec, reqOpts)
+	if f.CreateFromSpecFunc != nil {
+		return f.CreateFromSpecFunc(ctx, org, spec, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineBuilds) CreateFromSpecXML(ctx context.Context, org core.OrganizationRef, specXML string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineBuild, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineBuilds", "CreateFromSpecXML", ctx, org, specXML, reqOpts)
+	if f.CreateFromSpecXMLFunc != nil {
+		return f.CreateFromSpecXMLFunc(ctx, org, specXML, reqOpts...)
+	}
+
+	return
+}
+
+// VirtualMachineGroups is an in-memory fake of core.VirtualMachineGroupsAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type VirtualMachineGroups struct {
+	*Recorder
+
+	ListFunc    func(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) ([]*core.VirtualMachineGroup, *katapult.Response, error)
+	GetFunc     func(ctx context.Context, ref core.VirtualMachineGroupRef, reqOpts ...katapult.RequestOption) (*core.VirtualMachineGroup, *katapult.Response, error)
+	GetByIDFunc func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.VirtualMachineGroup, *katapult.Response, error)
+	CreateFunc  func(ctx context.Context, org core.OrganizationRef, args *core.VirtualMachineGroupCreateArguments, reqOpts ...katapult.RequestOption) (*core.VirtualMachineGroup, *katapult.Response, error)
+	UpdateFunc  func(ctx context.Context, ref core.VirtualMachineGroupRef, args *core.VirtualMachineGroupUpdateArguments, reqOpts ...katapult.RequestOption) (*core.VirtualMachineGroup, *katapult.Response, error)
+	DeleteFunc  func(ctx context.Context, group core.VirtualMachineGroupRef, reqOpts ...katapult.RequestOption) (*katapult.Response, error)
+}
+
+func (f *VirtualMachineGroups) List(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (r0 []*core.VirtualMachineGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineGroups", "List", ctx, org, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineGroups) Get(ctx context.Context, ref core.VirtualMachineGroupRef, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineGroups", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineGroups) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineGroups", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineGroups) Create(ctx context.Context, org core.OrganizationRef, args *core.VirtualMachineGroupCreateArguments, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineGroups", "Create", ctx, org, args, reqOpts)
+	if f.CreateFunc != nil {
+		return f.CreateFunc(ctx, org, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineGroups) Update(ctx context.Context, ref core.VirtualMachineGroupRef, args *core.VirtualMachineGroupUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineGroup, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineGroups", "Update", ctx, ref, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineGroups) Delete(ctx context.Context, group core.VirtualMachineGroupRef, reqOpts ...katapult.RequestOption) (r0 *katapult.Response, r1 error) {
+	record(&f.Recorder, "VirtualMachineGroups", "Delete", ctx, group, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, group, reqOpts...)
+	}
+
+	return
+}
+
+// VirtualMachineNetworkInterfaces is an in-memory fake of core.VirtualMachineNetworkInterfacesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type VirtualMachineNetworkInterfaces struct {
+	*Recorder
+
+	ListFunc               func(ctx context.Context, vm core.VirtualMachineRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.VirtualMachineNetworkInterface, *katapult.Response, error)
+	GetFunc                func(ctx context.Context, ref core.VirtualMachineNetworkInterfaceRef, reqOpts ...katapult.RequestOption) (*core.VirtualMachineNetworkInterface, *katapult.Response, error)
+	GetByIDFunc            func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.VirtualMachineNetworkInterface, *katapult.Response, error)
+	AvailableIPsFunc       func(ctx context.Context, vmnet *core.VirtualMachineNetworkInterface, ipVer core.IPVersion, reqOpts ...katapult.RequestOption) ([]*core.IPAddress, *katapult.Response, error)
+	AllocateIPFunc         func(ctx context.Context, vmnet core.VirtualMachineNetworkInterfaceRef, ip core.IPAddressRef, reqOpts ...katapult.RequestOption) (*core.VirtualMachineNetworkInterface, *katapult.Response, error)
+	AllocateNewIPFunc      func(ctx context.Context, vmnet core.VirtualMachineNetworkInterfaceRef, ipVer core.IPVersion, reqOpts ...katapult.RequestOption) (*core.IPAddress, *katapult.Response, error)
+	UpdateSpeedProfileFunc func(ctx context.Context, vmnet core.VirtualMachineNetworkInterfaceRef, speedProfile core.NetworkSpeedProfileRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+}
+
+func (f *VirtualMachineNetworkInterfaces) List(ctx context.Context, vm core.VirtualMachineRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.VirtualMachineNetworkInterface, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "List", ctx, vm, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, vm, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineNetworkInterfaces) Get(ctx context.Context, ref core.VirtualMachineNetworkInterfaceRef, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineNetworkInterface, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineNetworkInterfaces) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineNetworkInterface, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineNetworkInterfaces) AvailableIPs(ctx context.Context, vmnet *core.VirtualMachineNetworkInterface, ipVer core.IPVersion, reqOpts ...katapult.RequestOption) (r0 []*core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "AvailableIPs", ctx, vmnet, ipVer, reqOpts)
+	if f.AvailableIPsFunc != nil {
+		return f.AvailableIPsFunc(ctx, vmnet, ipVer, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineNetworkInterfaces) AllocateIP(ctx context.Context, vmnet core.VirtualMachineNetworkInterfaceRef, ip core.IPAddressRef, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachineNetworkInterface, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "AllocateIP", ctx, vmnet, ip, reqOpts)
+	if f.AllocateIPFunc != nil {
+		return f.AllocateIPFunc(ctx, vmnet, ip, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineNetworkInterfaces) AllocateNewIP(ctx context.Context, vmnet core.VirtualMachineNetworkInterfaceRef, ipVer core.IPVersion, reqOpts ...katapult.RequestOption) (r0 *core.IPAddress, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "AllocateNewIP", ctx, vmnet, ipVer, reqOpts)
+	if f.AllocateNewIPFunc != nil {
+		return f.AllocateNewIPFunc(ctx, vmnet, ipVer, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachineNetworkInterfaces) UpdateSpeedProfile(ctx context.Context, vmnet core.VirtualMachineNetworkInterfaceRef, speedProfile core.NetworkSpeedProfileRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachineNetworkInterfaces", "UpdateSpeedProfile", ctx, vmnet, speedProfile, reqOpts)
+	if f.UpdateSpeedProfileFunc != nil {
+		return f.UpdateSpeedProfileFunc(ctx, vmnet, speedProfile, reqOpts...)
+	}
+
+	return
+}
+
+// VirtualMachinePackages is an in-memory fake of core.VirtualMachinePackagesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type VirtualMachinePackages struct {
+	*Recorder
+
+	ListFunc           func(ctx context.Context, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.VirtualMachinePackage, *katapult.Response, error)
+	GetFunc            func(ctx context.Context, ref core.VirtualMachinePackageRef, reqOpts ...katapult.RequestOption) (*core.VirtualMachinePackage, *katapult.Response, error)
+	GetByIDFunc        func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.VirtualMachinePackage, *katapult.Response, error)
+	GetByPermalinkFunc func(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*core.VirtualMachinePackage, *katapult.Response, error)
+}
+
+func (f *VirtualMachinePackages) List(ctx context.Context, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.VirtualMachinePackage, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachinePackages", "List", ctx, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachinePackages) Get(ctx context.Context, ref core.VirtualMachinePackageRef, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachinePackage, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachinePackages", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachinePackages) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachinePackage, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachinePackages", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachinePackages) GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachinePackage, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachinePackages", "GetByPermalink", ctx, permalink, reqOpts)
+	if f.GetByPermalinkFunc != nil {
+		return f.GetByPermalinkFunc(ctx, permalink, reqOpts...)
+	}
+
+	return
+}
+
+// VirtualMachines is an in-memory fake of core.VirtualMachinesAPI.
+//
+// Each method records the call and then delegates to its <Method>Func
+// field, returning zero values when it is nil.
+type VirtualMachines struct {
+	*Recorder
+
+	ListFunc           func(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) ([]*core.VirtualMachine, *katapult.Response, error)
+	HostnamesFunc      func(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (namegenerator.HostnameSet, error)
+	UniqueHostnameFunc func(ctx context.Context, org core.OrganizationRef, gen *namegenerator.NameGenerator, reqOpts ...katapult.RequestOption) (string, error)
+	GetFunc            func(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (*core.VirtualMachine, *katapult.Response, error)
+	GetByIDFunc        func(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*core.VirtualMachine, *katapult.Response, error)
+	GetByFQDNFunc      func(ctx context.Context, fqdn string, reqOpts ...katapult.RequestOption) (*core.VirtualMachine, *katapult.Response, error)
+	ChangePackageFunc  func(ctx context.Context, ref core.VirtualMachineRef, pkg core.VirtualMachinePackageRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+	UpdateFunc         func(ctx context.Context, ref core.VirtualMachineRef, args *core.VirtualMachineUpdateArguments, reqOpts ...katapult.RequestOption) (*core.VirtualMachine, *katapult.Response, error)
+	DeleteFunc         func(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (*core.TrashObject, *katapult.Response, error)
+	StartFunc          func(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+	StopFunc           func(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+	ShutdownFunc       func(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+	ResetFunc          func(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (*core.Task, *katapult.Response, error)
+}
+
+func (f *VirtualMachines) List(ctx context.Context, org core.OrganizationRef, opts *core.ListOptions, reqOpts ...katapult.RequestOption) (r0 []*core.VirtualMachine, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "List", ctx, org, opts, reqOpts)
+	if f.ListFunc != nil {
+		return f.ListFunc(ctx, org, opts, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Hostnames(ctx context.Context, org core.OrganizationRef, reqOpts ...katapult.RequestOption) (r0 namegenerator.HostnameSet, r1 error) {
+	record(&f.Recorder, "VirtualMachines", "Hostnames", ctx, org, reqOpts)
+	if f.HostnamesFunc != nil {
+		return f.HostnamesFunc(ctx, org, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) UniqueHostname(ctx context.Context, org core.OrganizationRef, gen *namegenerator.NameGenerator, reqOpts ...katapult.RequestOption) (r0 string, r1 error) {
+	record(&f.Recorder, "VirtualMachines", "UniqueHostname", ctx, org, gen, reqOpts)
+	if f.UniqueHostnameFunc != nil {
+		return f.UniqueHostnameFunc(ctx, org, gen, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Get(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachine, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Get", ctx, ref, reqOpts)
+	if f.GetFunc != nil {
+		return f.GetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachine, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "GetByID", ctx, id, reqOpts)
+	if f.GetByIDFunc != nil {
+		return f.GetByIDFunc(ctx, id, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) GetByFQDN(ctx context.Context, fqdn string, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachine, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "GetByFQDN", ctx, fqdn, reqOpts)
+	if f.GetByFQDNFunc != nil {
+		return f.GetByFQDNFunc(ctx, fqdn, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) ChangePackage(ctx context.Context, ref core.VirtualMachineRef, pkg core.VirtualMachinePackageRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "ChangePackage", ctx, ref, pkg, reqOpts)
+	if f.ChangePackageFunc != nil {
+		return f.ChangePackageFunc(ctx, ref, pkg, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Update(ctx context.Context, ref core.VirtualMachineRef, args *core.VirtualMachineUpdateArguments, reqOpts ...katapult.RequestOption) (r0 *core.VirtualMachine, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Update", ctx, ref, args, reqOpts)
+	if f.UpdateFunc != nil {
+		return f.UpdateFunc(ctx, ref, args, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Delete(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (r0 *core.TrashObject, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Delete", ctx, ref, reqOpts)
+	if f.DeleteFunc != nil {
+		return f.DeleteFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Start(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Start", ctx, ref, reqOpts)
+	if f.StartFunc != nil {
+		return f.StartFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Stop(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Stop", ctx, ref, reqOpts)
+	if f.StopFunc != nil {
+		return f.StopFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Shutdown(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Shutdown", ctx, ref, reqOpts)
+	if f.ShutdownFunc != nil {
+		return f.ShutdownFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+func (f *VirtualMachines) Reset(ctx context.Context, ref core.VirtualMachineRef, reqOpts ...katapult.RequestOption) (r0 *core.Task, r1 *katapult.Response, r2 error) {
+	record(&f.Recorder, "VirtualMachines", "Reset", ctx, ref, reqOpts)
+	if f.ResetFunc != nil {
+		return f.ResetFunc(ctx, ref, reqOpts...)
+	}
+
+	return
+}
+
+// Client holds a fake for every resource, all sharing a single Recorder.
+type Client struct {
+	*Recorder
+
+	Certificates                    *Certificates
+	DNSZones                        *DNSZones
+	DataCenters                     *DataCenters
+	DiskBackupPolicies              *DiskBackupPolicies
+	DiskTemplates                   *DiskTemplates
+	Disks                           *Disks
+	FileStorageVolumes              *FileStorageVolumes
+	IPAddresses                     *IPAddresses
+	LoadBalancers                   *LoadBalancers
+	LoadBalancerRules               *LoadBalancerRules
+	NetworkSpeedProfiles            *NetworkSpeedProfiles
+	Networks                        *Networks
+	Organizations                   *Organizations
+	SecurityGroups                  *SecurityGroups
+	SecurityGroupRules              *SecurityGroupRules
+	Tags                            *Tags
+	SSHKeys                         *SSHKeys
+	Tasks                           *Tasks
+	TrashObjects                    *TrashObjects
+	VirtualMachineBuilds            *VirtualMachineBuilds
+	VirtualMachineGroups            *VirtualMachineGroups
+	VirtualMachineNetworkInterfaces *VirtualMachineNetworkInterfaces
+	VirtualMachinePackages          *VirtualMachinePackages
+	VirtualMachines                 *VirtualMachines
+}
+
+// New returns a Client with a fake for every resource.
+func New() *Client {
+	rec := &Recorder{}
+
+	return &Client{
+		Recorder:                        rec,
+		Certificates:                    &Certificates{Recorder: rec},
+		DNSZones:                        &DNSZones{Recorder: rec},
+		DataCenters:                     &DataCenters{Recorder: rec},
+		DiskBackupPolicies:              &DiskBackupPolicies{Recorder: rec},
+		DiskTemplates:                   &DiskTemplates{Recorder: rec},
+		Disks:                           &Disks{Recorder: rec},
+		FileStorageVolumes:              &FileStorageVolumes{Recorder: rec},
+		IPAddresses:                     &IPAddresses{Recorder: rec},
+		LoadBalancers:                   &LoadBalancers{Recorder: rec},
+		LoadBalancerRules:               &LoadBalancerRules{Recorder: rec},
+		NetworkSpeedProfiles:            &NetworkSpeedProfiles{Recorder: rec},
+		Networks:                        &Networks{Recorder: rec},
+		Organizations:                   &Organizations{Recorder: rec},
+		SecurityGroups:                  &SecurityGroups{Recorder: rec},
+		SecurityGroupRules:              &SecurityGroupRules{Recorder: rec},
+		Tags:                            &Tags{Recorder: rec},
+		SSHKeys:                         &SSHKeys{Recorder: rec},
+		Tasks:                           &Tasks{Recorder: rec},
+		TrashObjects:                    &TrashObjects{Recorder: rec},
+		VirtualMachineBuilds:            &VirtualMachineBuilds{Recorder: rec},
+		VirtualMachineGroups:            &VirtualMachineGroups{Recorder: rec},
+		VirtualMachineNetworkInterfaces: &VirtualMachineNetworkInterfaces{Recorder: rec},
+		VirtualMachinePackages:          &VirtualMachinePackages{Recorder: rec},
+		VirtualMachines:                 &VirtualMachines{Recorder: rec},
+	}
+}
+
+// API returns a core.API backed by the fakes of c.
+func (c *Client) API() *core.API {
+	return &core.API{
+		Certificates:                    c.Certificates,
+		DNSZones:                        c.DNSZones,
+		DataCenters:                     c.DataCenters,
+		DiskBackupPolicies:              c.DiskBackupPolicies,
+		DiskTemplates:                   c.DiskTemplates,
+		Disks:                           c.Disks,
+		FileStorageVolumes:              c.FileStorageVolumes,
+		IPAddresses:                     c.IPAddresses,
+		LoadBalancers:                   c.LoadBalancers,
+		LoadBalancerRules:               c.LoadBalancerRules,
+		NetworkSpeedProfiles:            c.NetworkSpeedProfiles,
+		Networks:                        c.Networks,
+		Organizations:                   c.Organizations,
+		SecurityGroups:                  c.SecurityGroups,
+		SecurityGroupRules:              c.SecurityGroupRules,
+		Tags:                            c.Tags,
+		SSHKeys:                         c.SSHKeys,
+		Tasks:                           c.Tasks,
+		TrashObjects:                    c.TrashObjects,
+		VirtualMachineBuilds:            c.VirtualMachineBuilds,
+		VirtualMachineGroups:            c.VirtualMachineGroups,
+		VirtualMachineNetworkInterfaces: c.VirtualMachineNetworkInterfaces,
+		VirtualMachinePackages:          c.VirtualMachinePackages,
+		VirtualMachines:                 c.VirtualMachines,
+	}
+}
+
+var (
+	_ core.CertificatesAPI                    = (*Certificates)(nil)
+	_ core.DNSZonesAPI                        = (*DNSZones)(nil)
+	_ core.DataCentersAPI                     = (*DataCenters)(nil)
+	_ core.DiskBackupPoliciesAPI              = (*DiskBackupPolicies)(nil)
+	_ core.DiskTemplatesAPI                   = (*DiskTemplates)(nil)
+	_ core.DisksAPI                           = (*Disks)(nil)
+	_ core.FileStorageVolumesAPI              = (*FileStorageVolumes)(nil)
+	_ core.IPAddressesAPI                     = (*IPAddresses)(nil)
+	_ core.LoadBalancersAPI                   = (*LoadBalancers)(nil)
+	_ core.LoadBalancerRulesAPI               = (*LoadBalancerRules)(nil)
+	_ core.NetworkSpeedProfilesAPI            = (*NetworkSpeedProfiles)(nil)
+	_ core.NetworksAPI                        = (*Networks)(nil)
+	_ core.OrganizationsAPI                   = (*Organizations)(nil)
+	_ core.SecurityGroupsAPI                  = (*SecurityGroups)(nil)
+	_ core.SecurityGroupRulesAPI              = (*SecurityGroupRules)(nil)
+	_ core.TagsAPI                            = (*Tags)(nil)
+	_ core.SSHKeysAPI                         = (*SSHKeys)(nil)
+	_ core.TasksAPI                           = (*Tasks)(nil)
+	_ core.TrashObjectsAPI                    = (*TrashObjects)(nil)
+	_ core.VirtualMachineBuildsAPI            = (*VirtualMachineBuilds)(nil)
+	_ core.VirtualMachineGroupsAPI            = (*VirtualMachineGroups)(nil)
+	_ core.VirtualMachineNetworkInterfacesAPI = (*VirtualMachineNetworkInterfaces)(nil)
+	_ core.VirtualMachinePackagesAPI          = (*VirtualMachinePackages)(nil)
+	_ core.VirtualMachinesAPI                 = (*VirtualMachines)(nil)
+)
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult"
+)
+
+type Disk struct {
+	ID                 string               `json:"id,omitempty"`
+	Name               string               `json:"name,omitempty"`
+	SizeInGB           int                  `json:"size_in_gb,omitempty"`
+	WWN                string               `json:"wwn,omitempty"`
+	State              string               `json:"state,omitempty"`
+	CreatedAt          *timestamp.Timestamp `json:"created_at,omitempty"`
+	StorageSpeed       string               `json:"storage_speed,omitempty"`
+	IOProfile          *DiskIOProfile       `json:"io_profile,omitempty"`
+	VirtualMachineDisk *VirtualMachineDisk  `json:"virtual_machine_disk,omitempty"`
+	Installation       *DiskInstallation    `json:"installation,omitempty"`
+}
+
+func (s *Disk) Ref() DiskRef {
+	return DiskRef{ID: s.ID}
+}
+
+type DiskRef struct {
+	ID string `json:"id,omitempty"`
+}
+
+func (s DiskRef) queryValues() *url.Values {
+	v := &url.Values{}
+	v.Set("disk[id]", s.ID)
+
+	return v
+}
+
+type DiskIOProfile struct {
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
+	SpeedInMB int    `json:"speed_in_mb,omitempty"`
+	IOPS      int    `json:"iops,omitempty"`
+}
+
+type DiskInstallation struct {
+	ID                  string                       `json:"id,omitempty"`
+	DiskTemplateVersion *DiskTemplateVersion         `json:"disk_template_version,omitempty"`
+	Attributes          []*DiskInstallationAttribute `json:"attributes,omitempty"`
+}
+
+// DiskInstallationAttribute is a disk template option a disk was installed
+// with. Protected attributes, such as passwords, have their value omitted.
+type DiskInstallationAttribute struct {
+	Key         string `json:"key,omitempty"`
+	Label       string `json:"label,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Description string `json:"description,omitempty"`
+	Protect     bool   `json:"protect,omitempty"`
+}
+
+type VirtualMachineDisk struct {
+	VirtualMachine *VirtualMachine `json:"virtual_machine,omitempty"`
+	Disk           *Disk           `json:"disk,omitempty"`
+	AttachOnBoot   bool            `json:"attach_on_boot,omitempty"`
+	Boot           bool            `json:"boot,omitempty"`
+	State          string          `json:"state,omitempty"`
+}
+
+type disksResponseBody struct {
+	Pagination *katapult.Pagination  `json:"pagination,omitempty"`
+	Disk       *Disk                 `json:"disk,omitempty"`
+	Disks      []*VirtualMachineDisk `json:"disks,omitempty"`
+}
+
+type DisksClient struct {
+	client   RequestMaker
+	basePath *url.URL
+}
+
+func NewDisksClient(rm RequestMaker) *DisksClient {
+	return &DisksClient{
+		client:   rm,
+		basePath: &url.URL{Path: "/core/v1/"},
+	}
+}
+
+// ListForVirtualMachine returns the disks attached to a virtual machine.
+func (s *DisksClient) ListForVirtualMachine(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	opts *ListOptions,
+	reqOpts ...katapult.RequestOption,
+) ([]*VirtualMachineDisk, *katapult.Response, error) {
+	qs := queryValues(vm, opts)
+	u := &url.URL{
+		Path:     "virtual_machines/_/disks",
+		RawQuery: qs.Encode(),
+	}
+
+	body, resp, err := s.doRequest(ctx, "GET", u, nil, reqOpts...)
+	resp.Pagination = body.Pagination
+
+	return body.Disks, resp, err
+}
+
+func (s *DisksClient) Get(
+	ctx context.Context,
+	ref DiskRef,
+	reqOpts ...katapult.RequestOption,
+) (*Disk, *katapult.Response, error) {
+	u := &url.URL{
+		Path:     "disks/_",
+		RawQuery: ref.queryValues().Encode(),
+	}
+
+	body, resp, err := s.doRequest(ctx, "GET", u, nil, reqOpts...)
+
+	return body.Disk, resp, err
+}
+
+func (s *DisksClient) GetByID(
+	ctx context.Context,
+	id string,
+	reqOpts ...katapult.RequestOption,
+) (*Disk, *katapult.Response, error) {
+	return s.Get(ctx, DiskRef{ID: id}, reqOpts...)
+}
+
+func (s *DisksClient) doRequest(
+	ctx context.Context,
+	method string,
+	u *url.URL,
+	body interface{},
+	reqOpts ...katapult.RequestOption,
+) (*disksResponseBody, *katapult.Response, error) {
+	u = s.basePath.ResolveReference(u)
+	respBody := &disksResponseBody{}
+
+	req := katapult.NewRequest(method, u, body, reqOpts...)
+	resp, err := s.client.Do(ctx, req, respBody)
+	if resp == nil {
+		resp = katapult.NewResponse(nil)
+	}
+
+	return respBody, resp, handleResponseError(err)
+}
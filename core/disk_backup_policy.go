@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult"
+)
+
+type DiskBackupPolicy struct {
+	ID                string                  `json:"id,omitempty"`
+	Retention         int                     `json:"retention,omitempty"`
+	TotalSize         float64                 `json:"total_size,omitempty"`
+	Target            *DiskBackupPolicyTarget `json:"target,omitempty"`
+	Schedule          *Schedule               `json:"schedule,omitempty"`
+	AutoMoveToTrashAt *timestamp.Timestamp    `json:"auto_move_to_trash_at,omitempty"`
+}
+
+func (s *DiskBackupPolicy) Ref() DiskBackupPolicyRef {
+	return DiskBackupPolicyRef{ID: s.ID}
+}
+
+// DiskBackupPolicyTarget is the virtual machine or disk a DiskBackupPolicy
+// applies to.
+type DiskBackupPolicyTarget struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type DiskBackupPolicyRef struct {
+	ID string `json:"id,omitempty"`
+}
+
+func (s DiskBackupPolicyRef) queryValues() *url.Values {
+	v := &url.Values{}
+	v.Set("disk_backup_policy[id]", s.ID)
+
+	return v
+}
+
+type ScheduleInterval string
+
+const (
+	ScheduleHourly  ScheduleInterval = "hourly"
+	ScheduleDaily   ScheduleInterval = "daily"
+	ScheduleWeekly  ScheduleInterval = "weekly"
+	ScheduleMonthly ScheduleInterval = "monthly"
+)
+
+type Schedule struct {
+	Interval         ScheduleInterval     `json:"interval,omitempty"`
+	Frequency        int                  `json:"frequency,omitempty"`
+	Time             int                  `json:"time,omitempty"`
+	Minute           int                  `json:"minute,omitempty"`
+	NextInvocationAt *timestamp.Timestamp `json:"next_invocation_at,omitempty"`
+}
+
+type diskBackupPoliciesResponseBody struct {
+	Pagination         *katapult.Pagination `json:"pagination,omitempty"`
+	DiskBackupPolicy   *DiskBackupPolicy    `json:"disk_backup_policy,omitempty"`
+	DiskBackupPolicies []*DiskBackupPolicy  `json:"disk_backup_policies,omitempty"`
+}
+
+type DiskBackupPoliciesClient struct {
+	client   RequestMaker
+	basePath *url.URL
+}
+
+func NewDiskBackupPoliciesClient(rm RequestMaker) *DiskBackupPoliciesClient {
+	return &DiskBackupPoliciesClient{
+		client:   rm,
+		basePath: &url.URL{Path: "/core/v1/"},
+	}
+}
+
+// ListForVirtualMachine returns the backup policies which apply to all disks
+// of a virtual machine. Policies for individual disks are not included, use
+// ListForDisk for those.
+//
+// Listed policies only include the interval of their schedule, use Get to
+// fetch a policy's full schedule.
+func (s *DiskBackupPoliciesClient) ListForVirtualMachine(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	opts *ListOptions,
+	reqOpts ...katapult.RequestOption,
+) ([]*DiskBackupPolicy, *katapult.Response, error) {
+	qs := queryValues(vm, opts)
+	u := &url.URL{
+		Path:     "virtual_machines/_/disk_backup_policies",
+		RawQuery: qs.Encode(),
+	}
+
+	return s.list(ctx, u, reqOpts...)
+}
+
+// ListForDisk returns the backup policies which apply to a single disk.
+//
+// Listed policies only include the interval of their schedule, use Get to
+// fetch a policy's full schedule.
+func (s *DiskBackupPoliciesClient) ListForDisk(
+	ctx context.Context,
+	disk DiskRef,
+	opts *ListOptions,
+	reqOpts ...katapult.RequestOption,
+) ([]*DiskBackupPolicy, *katapult.Response, error) {
+	qs := queryValues(disk, opts)
+	u := &url.URL{
+		Path:     "disks/_/disk_backup_policies",
+		RawQuery: qs.Encode(),
+	}
+
+	return s.list(ctx, u, reqOpts...)
+}
+
+func (s *DiskBackupPoliciesClient) Get(
+	ctx context.Context,
+	ref DiskBackupPolicyRef,
+	reqOpts ...katapult.RequestOption,
+) (*DiskBackupPolicy, *katapult.Response, error) {
+	u := &url.URL{
+		Path:     "disk_backup_policies/_",
+		RawQuery: ref.queryValues().Encode(),
+	}
+
+	body, resp, err := s.doRequest(ctx, "GET", u, nil, reqOpts...)
+
+	return body.DiskBackupPolicy, resp, err
+}
+
+func (s *DiskBackupPoliciesClient) GetByID(
+	ctx context.Context,
+	id string,
+	reqOpts ...katapult.RequestOption,
+) (*DiskBackupPolicy, *katapult.Response, error) {
+	return s.Get(ctx, DiskBackupPolicyRef{ID: id}, reqOpts...)
+}
+
+func (s *DiskBackupPoliciesClient) list(
+	ctx context.Context,
+	u *url.URL,
+	reqOpts ...katapult.RequestOption,
+) ([]*DiskBackupPolicy, *katapult.Response, error) {
+	body, resp, err := s.doRequest(ctx, "GET", u, nil, reqOpts...)
+	resp.Pagination = body.Pagination
+
+	return body.DiskBackupPolicies, resp, err
+}
+
+func (s *DiskBackupPoliciesClient) doRequest(
+	ctx context.Context,
+	method string,
+	u *url.URL,
+	body interface{},
+	reqOpts ...katapult.RequestOption,
+) (*diskBackupPoliciesResponseBody, *katapult.Response, error) {
+	u = s.basePath.ResolveReference(u)
+	respBody := &diskBackupPoliciesResponseBody{}
+
+	req := katapult.NewRequest(method, u, body, reqOpts...)
+	resp, err := s.client.Do(ctx, req, respBody)
+	if resp == nil {
+		resp = katapult.NewResponse(nil)
+	}
+
+	return respBody, resp, handleResponseError(err)
+}
@@ -0,0 +1,511 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/internal/testclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_DiskBackupPolicies(t *testing.T) {
+	c := New(&testclient.Client{})
+
+	assert.IsType(t, &DiskBackupPoliciesClient{}, c.DiskBackupPolicies)
+}
+
+var (
+	fixtureDiskBackupPolicyNotFoundErr = "katapult: not_found: " +
+		"disk_backup_policy_not_found: No disk backup policy was found " +
+		"matching any of the criteria provided in the arguments"
+	fixtureDiskBackupPolicyNotFoundResponseError = &katapult.ResponseError{
+		Code: "disk_backup_policy_not_found",
+		Description: "No disk backup policy was found matching any of the " +
+			"criteria provided in the arguments",
+		Detail: json.RawMessage(`{}`),
+	}
+
+	fixtureDiskBackupPolicyFull = &DiskBackupPolicy{
+		ID:        "dbp_3lNtm6xQHuvzPUQD",
+		Retention: 7,
+		TotalSize: 12.5,
+		Target: &DiskBackupPolicyTarget{
+			ID:   "vm_i5qfOrvEI1CmNrJx",
+			Name: "bitter-beautiful-mango",
+		},
+		Schedule: &Schedule{
+			Interval:         ScheduleDaily,
+			Frequency:        1,
+			Time:             3,
+			Minute:           30,
+			NextInvocationAt: timestampPtr(1630000000),
+		},
+	}
+
+	fixtureDiskBackupPoliciesList = []*DiskBackupPolicy{
+		{
+			ID:        "dbp_3lNtm6xQHuvzPUQD",
+			Retention: 7,
+			TotalSize: 12.5,
+			Target: &DiskBackupPolicyTarget{
+				ID:   "vm_i5qfOrvEI1CmNrJx",
+				Name: "bitter-beautiful-mango",
+			},
+			Schedule: &Schedule{Interval: ScheduleDaily},
+		},
+		{
+			ID:        "dbp_WbRdK2tOeOY8g1Ls",
+			Retention: 4,
+			Target: &DiskBackupPolicyTarget{
+				ID:   "vm_i5qfOrvEI1CmNrJx",
+				Name: "bitter-beautiful-mango",
+			},
+			Schedule: &Schedule{Interval: ScheduleWeekly},
+		},
+	}
+)
+
+func TestDiskBackupPolicy_JSONMarshaling(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *DiskBackupPolicy
+	}{
+		{
+			name: "empty",
+			obj:  &DiskBackupPolicy{},
+		},
+		{
+			name: "full",
+			obj:  fixtureDiskBackupPolicyFull,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONMarshaling(t, tt.obj)
+		})
+	}
+}
+
+func TestDiskBackupPolicy_Ref(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *DiskBackupPolicy
+		want DiskBackupPolicyRef
+	}{
+		{
+			name: "empty",
+			obj:  &DiskBackupPolicy{},
+			want: DiskBackupPolicyRef{},
+		},
+		{
+			name: "full",
+			obj:  fixtureDiskBackupPolicyFull,
+			want: DiskBackupPolicyRef{ID: "dbp_3lNtm6xQHuvzPUQD"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.obj.Ref()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiskBackupPolicyRef_queryValues(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  DiskBackupPolicyRef
+	}{
+		{
+			name: "id",
+			obj:  DiskBackupPolicyRef{ID: "dbp_3lNtm6xQHuvzPUQD"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testQueryableEncoding(t, tt.obj)
+		})
+	}
+}
+
+func Test_diskBackupPoliciesResponseBody_JSONMarshaling(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *diskBackupPoliciesResponseBody
+	}{
+		{
+			name: "empty",
+			obj:  &diskBackupPoliciesResponseBody{},
+		},
+		{
+			name: "full",
+			obj: &diskBackupPoliciesResponseBody{
+				Pagination:         &katapult.Pagination{CurrentPage: 345},
+				DiskBackupPolicy:   &DiskBackupPolicy{ID: "id1"},
+				DiskBackupPolicies: []*DiskBackupPolicy{{ID: "id2"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONMarshaling(t, tt.obj)
+		})
+	}
+}
+
+func TestDiskBackupPoliciesClient_ListForVirtualMachine(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		vm   VirtualMachineRef
+		opts *ListOptions
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           []*DiskBackupPolicy
+		wantQuery      *url.Values
+		wantPagination *katapult.Pagination
+		errStr         string
+		errResp        *katapult.ResponseError
+		errIs          error
+		respStatus     int
+		respBody       []byte
+	}{
+		{
+			name: "by virtual machine ID",
+			args: args{
+				ctx: context.Background(),
+				vm:  VirtualMachineRef{ID: "vm_i5qfOrvEI1CmNrJx"},
+			},
+			want: fixtureDiskBackupPoliciesList,
+			wantQuery: &url.Values{
+				"virtual_machine[id]": []string{"vm_i5qfOrvEI1CmNrJx"},
+			},
+			wantPagination: &katapult.Pagination{
+				CurrentPage: 1,
+				TotalPages:  1,
+				Total:       2,
+				PerPage:     30,
+			},
+			respStatus: http.StatusOK,
+			respBody:   fixture("disk_backup_policies_list"),
+		},
+		{
+			name: "with pagination",
+			args: args{
+				ctx:  context.Background(),
+				vm:   VirtualMachineRef{FQDN: "acme"},
+				opts: &ListOptions{Page: 2, PerPage: 5},
+			},
+			wantQuery: &url.Values{
+				"virtual_machine[fqdn]": []string{"acme"},
+				"page":                  []string{"2"},
+				"per_page":              []string{"5"},
+			},
+			respStatus: http.StatusOK,
+			respBody:   fixture("disk_backup_policies_list"),
+		},
+		{
+			name: "non-existent virtual machine",
+			args: args{
+				ctx: context.Background(),
+				vm:  VirtualMachineRef{ID: "vm_nopethisbegone"},
+			},
+			errStr:     fixtureVirtualMachineNotFoundErr,
+			errResp:    fixtureVirtualMachineNotFoundResponseError,
+			errIs:      ErrVirtualMachineNotFound,
+			respStatus: http.StatusNotFound,
+			respBody:   fixture("virtual_machine_not_found_error"),
+		},
+		{
+			name: "nil context",
+			args: args{
+				ctx: nil,
+				vm:  VirtualMachineRef{ID: "vm_i5qfOrvEI1CmNrJx"},
+			},
+			errStr: "net/http: nil Context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, mux, _, teardown := prepareTestClient(t)
+			defer teardown()
+			c := NewDiskBackupPoliciesClient(rm)
+
+			mux.HandleFunc(
+				"/core/v1/virtual_machines/_/disk_backup_policies",
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "GET", r.Method)
+					assertEmptyFieldSpec(t, r)
+					assertAuthorization(t, r)
+					assertRequestOptionHeader(t, r)
+
+					if tt.wantQuery != nil {
+						assert.Equal(t, *tt.wantQuery, r.URL.Query())
+					}
+
+					w.WriteHeader(tt.respStatus)
+					_, _ = w.Write(tt.respBody)
+				},
+			)
+
+			got, resp, err := c.ListForVirtualMachine(
+				tt.args.ctx, tt.args.vm, tt.args.opts, testRequestOption,
+			)
+
+			if tt.respStatus != 0 {
+				assert.Equal(t, tt.respStatus, resp.StatusCode)
+			}
+
+			if tt.errStr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+
+			if tt.wantPagination != nil {
+				assert.Equal(t, tt.wantPagination, resp.Pagination)
+			}
+
+			if tt.errResp != nil {
+				assert.Equal(t, tt.errResp, resp.Error)
+			}
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+func TestDiskBackupPoliciesClient_ListForDisk(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		disk DiskRef
+		opts *ListOptions
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           []*DiskBackupPolicy
+		wantQuery      *url.Values
+		wantPagination *katapult.Pagination
+		errStr         string
+		errResp        *katapult.ResponseError
+		errIs          error
+		respStatus     int
+		respBody       []byte
+	}{
+		{
+			name: "by disk ID",
+			args: args{
+				ctx:  context.Background(),
+				disk: DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+			},
+			want: fixtureDiskBackupPoliciesList,
+			wantQuery: &url.Values{
+				"disk[id]": []string{"disk_9ZBTJvvsaZHsaTmQ"},
+			},
+			wantPagination: &katapult.Pagination{
+				CurrentPage: 1,
+				TotalPages:  1,
+				Total:       2,
+				PerPage:     30,
+			},
+			respStatus: http.StatusOK,
+			respBody:   fixture("disk_backup_policies_list"),
+		},
+		{
+			name: "with pagination",
+			args: args{
+				ctx:  context.Background(),
+				disk: DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+				opts: &ListOptions{Page: 2, PerPage: 5},
+			},
+			wantQuery: &url.Values{
+				"disk[id]": []string{"disk_9ZBTJvvsaZHsaTmQ"},
+				"page":     []string{"2"},
+				"per_page": []string{"5"},
+			},
+			respStatus: http.StatusOK,
+			respBody:   fixture("disk_backup_policies_list"),
+		},
+		{
+			name: "non-existent disk",
+			args: args{
+				ctx:  context.Background(),
+				disk: DiskRef{ID: "disk_nopethisbegone"},
+			},
+			errStr:     fixtureDiskNotFoundErr,
+			errResp:    fixtureDiskNotFoundResponseError,
+			errIs:      ErrDiskNotFound,
+			respStatus: http.StatusNotFound,
+			respBody:   fixture("disk_not_found_error"),
+		},
+		{
+			name: "nil context",
+			args: args{
+				ctx:  nil,
+				disk: DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+			},
+			errStr: "net/http: nil Context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, mux, _, teardown := prepareTestClient(t)
+			defer teardown()
+			c := NewDiskBackupPoliciesClient(rm)
+
+			mux.HandleFunc(
+				"/core/v1/disks/_/disk_backup_policies",
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "GET", r.Method)
+					assertEmptyFieldSpec(t, r)
+					assertAuthorization(t, r)
+					assertRequestOptionHeader(t, r)
+
+					if tt.wantQuery != nil {
+						assert.Equal(t, *tt.wantQuery, r.URL.Query())
+					}
+
+					w.WriteHeader(tt.respStatus)
+					_, _ = w.Write(tt.respBody)
+				},
+			)
+
+			got, resp, err := c.ListForDisk(
+				tt.args.ctx, tt.args.disk, tt.args.opts, testRequestOption,
+			)
+
+			if tt.respStatus != 0 {
+				assert.Equal(t, tt.respStatus, resp.StatusCode)
+			}
+
+			if tt.errStr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+
+			if tt.wantPagination != nil {
+				assert.Equal(t, tt.wantPagination, resp.Pagination)
+			}
+
+			if tt.errResp != nil {
+				assert.Equal(t, tt.errResp, resp.Error)
+			}
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+func TestDiskBackupPoliciesClient_Get(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		ref DiskBackupPolicyRef
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       *DiskBackupPolicy
+		errStr     string
+		errResp    *katapult.ResponseError
+		errIs      error
+		respStatus int
+		respBody   []byte
+	}{
+		{
+			name: "by ID",
+			args: args{
+				ctx: context.Background(),
+				ref: DiskBackupPolicyRef{ID: "dbp_3lNtm6xQHuvzPUQD"},
+			},
+			want:       fixtureDiskBackupPolicyFull,
+			respStatus: http.StatusOK,
+			respBody:   fixture("disk_backup_policy_get"),
+		},
+		{
+			name: "non-existent disk backup policy",
+			args: args{
+				ctx: context.Background(),
+				ref: DiskBackupPolicyRef{ID: "dbp_nopethisbegone"},
+			},
+			errStr:     fixtureDiskBackupPolicyNotFoundErr,
+			errResp:    fixtureDiskBackupPolicyNotFoundResponseError,
+			errIs:      ErrDiskBackupPolicyNotFound,
+			respStatus: http.StatusNotFound,
+			respBody:   fixture("disk_backup_policy_not_found_error"),
+		},
+		{
+			name: "nil context",
+			args: args{
+				ctx: nil,
+				ref: DiskBackupPolicyRef{ID: "dbp_3lNtm6xQHuvzPUQD"},
+			},
+			errStr: "net/http: nil Context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, mux, _, teardown := prepareTestClient(t)
+			defer teardown()
+			c := NewDiskBackupPoliciesClient(rm)
+
+			mux.HandleFunc(
+				"/core/v1/disk_backup_policies/_",
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "GET", r.Method)
+					assertEmptyFieldSpec(t, r)
+					assertAuthorization(t, r)
+					assertRequestOptionHeader(t, r)
+
+					assert.Equal(t, *tt.args.ref.queryValues(), r.URL.Query())
+
+					w.WriteHeader(tt.respStatus)
+					_, _ = w.Write(tt.respBody)
+				},
+			)
+
+			got, resp, err := c.Get(tt.args.ctx, tt.args.ref, testRequestOption)
+
+			if tt.respStatus != 0 {
+				assert.Equal(t, tt.respStatus, resp.StatusCode)
+			}
+
+			if tt.errStr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+
+			if tt.errResp != nil {
+				assert.Equal(t, tt.errResp, resp.Error)
+			}
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
@@ -40,10 +40,11 @@ func (s DiskTemplateRef) queryValues() *url.Values {
 }
 
 type DiskTemplateVersion struct {
-	ID       string `json:"id,omitempty"`
-	Number   int    `json:"number,omitempty"`
-	Stable   bool   `json:"stable,omitempty"`
-	SizeInGB int    `json:"size_in_gb,omitempty"`
+	ID           string        `json:"id,omitempty"`
+	Number       int           `json:"number,omitempty"`
+	Stable       bool          `json:"stable,omitempty"`
+	SizeInGB     int           `json:"size_in_gb,omitempty"`
+	DiskTemplate *DiskTemplate `json:"disk_template,omitempty"`
 }
 
 type DiskTemplateOption struct {
@@ -99,6 +100,7 @@ func (s *DiskTemplatesClient) List(
 	opts *DiskTemplateListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*DiskTemplate, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/disk_templates",
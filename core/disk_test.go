@@ -0,0 +1,439 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/internal/testclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Disks(t *testing.T) {
+	c := New(&testclient.Client{})
+
+	assert.IsType(t, &DisksClient{}, c.Disks)
+}
+
+var (
+	fixtureDiskNotFoundErr = "katapult: not_found: disk_not_found: " +
+		"No disk was found matching any of the criteria provided in the " +
+		"arguments"
+	fixtureDiskNotFoundResponseError = &katapult.ResponseError{
+		Code: "disk_not_found",
+		Description: "No disk was found matching any of the criteria " +
+			"provided in the arguments",
+		Detail: json.RawMessage(`{}`),
+	}
+
+	fixtureDiskFull = &Disk{
+		ID:           "disk_9ZBTJvvsaZHsaTmQ",
+		Name:         "bitter-beautiful-mango-disk-1",
+		SizeInGB:     20,
+		WWN:          "0x5000c500a1b2c3d4",
+		State:        "ready",
+		CreatedAt:    timestampPtr(1630000000),
+		StorageSpeed: "ssd",
+		IOProfile: &DiskIOProfile{
+			ID:        "dio_8dPXuw6jeXMbImcE",
+			Name:      "Standard",
+			Permalink: "standard",
+			SpeedInMB: 250,
+			IOPS:      5000,
+		},
+		VirtualMachineDisk: &VirtualMachineDisk{
+			VirtualMachine: &VirtualMachine{
+				ID:   "vm_i5qfOrvEI1CmNrJx",
+				FQDN: "bitter-beautiful-mango.test.kpult.com",
+			},
+			AttachOnBoot: true,
+			Boot:         true,
+			State:        "attached",
+		},
+		Installation: &DiskInstallation{
+			ID: "dinst_lXbSY6ZZdqTYEH0k",
+			DiskTemplateVersion: &DiskTemplateVersion{
+				Number: 3,
+				Stable: true,
+				DiskTemplate: &DiskTemplate{
+					ID:        "dtpl_ytP13XD5DE1RdSL9",
+					Name:      "Ubuntu 22.04",
+					Permalink: "templates/ubuntu-22-04",
+				},
+			},
+			Attributes: []*DiskInstallationAttribute{
+				{
+					Key:   "timezone",
+					Label: "Timezone",
+					Value: "Europe/London",
+				},
+			},
+		},
+	}
+)
+
+func TestDisk_JSONMarshaling(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *Disk
+	}{
+		{
+			name: "empty",
+			obj:  &Disk{},
+		},
+		{
+			name: "full",
+			obj:  fixtureDiskFull,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONMarshaling(t, tt.obj)
+		})
+	}
+}
+
+func TestDisk_Ref(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *Disk
+		want DiskRef
+	}{
+		{
+			name: "empty",
+			obj:  &Disk{},
+			want: DiskRef{},
+		},
+		{
+			name: "full",
+			obj:  fixtureDiskFull,
+			want: DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.obj.Ref()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiskRef_queryValues(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  DiskRef
+	}{
+		{
+			name: "id",
+			obj:  DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testQueryableEncoding(t, tt.obj)
+		})
+	}
+}
+
+func Test_disksResponseBody_JSONMarshaling(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  *disksResponseBody
+	}{
+		{
+			name: "empty",
+			obj:  &disksResponseBody{},
+		},
+		{
+			name: "full",
+			obj: &disksResponseBody{
+				Pagination: &katapult.Pagination{CurrentPage: 345},
+				Disk:       &Disk{ID: "id1"},
+				Disks: []*VirtualMachineDisk{
+					{Disk: &Disk{ID: "id2"}, Boot: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testJSONMarshaling(t, tt.obj)
+		})
+	}
+}
+
+func TestDisksClient_ListForVirtualMachine(t *testing.T) {
+	type args struct {
+		ctx  context.Context
+		vm   VirtualMachineRef
+		opts *ListOptions
+	}
+	tests := []struct {
+		name           string
+		args           args
+		want           []*VirtualMachineDisk
+		wantQuery      *url.Values
+		wantPagination *katapult.Pagination
+		errStr         string
+		errResp        *katapult.ResponseError
+		errIs          error
+		respStatus     int
+		respBody       []byte
+	}{
+		{
+			name: "by virtual machine ID",
+			args: args{
+				ctx: context.Background(),
+				vm:  VirtualMachineRef{ID: "vm_i5qfOrvEI1CmNrJx"},
+			},
+			want: []*VirtualMachineDisk{
+				{
+					Disk: &Disk{
+						ID:       "disk_9ZBTJvvsaZHsaTmQ",
+						Name:     "bitter-beautiful-mango-disk-1",
+						SizeInGB: 20,
+					},
+					AttachOnBoot: true,
+					Boot:         true,
+					State:        "attached",
+				},
+				{
+					Disk: &Disk{
+						ID:       "disk_a8tcBgmfIwU6FlnK",
+						Name:     "bitter-beautiful-mango-disk-2",
+						SizeInGB: 100,
+					},
+					AttachOnBoot: true,
+					State:        "attached",
+				},
+			},
+			wantQuery: &url.Values{
+				"virtual_machine[id]": []string{"vm_i5qfOrvEI1CmNrJx"},
+			},
+			wantPagination: &katapult.Pagination{
+				CurrentPage: 1,
+				TotalPages:  1,
+				Total:       2,
+				PerPage:     30,
+			},
+			respStatus: http.StatusOK,
+			respBody:   fixture("virtual_machine_disks_list"),
+		},
+		{
+			name: "with pagination",
+			args: args{
+				ctx:  context.Background(),
+				vm:   VirtualMachineRef{FQDN: "acme"},
+				opts: &ListOptions{Page: 2, PerPage: 5},
+			},
+			wantQuery: &url.Values{
+				"virtual_machine[fqdn]": []string{"acme"},
+				"page":                  []string{"2"},
+				"per_page":              []string{"5"},
+			},
+			respStatus: http.StatusOK,
+			respBody:   fixture("virtual_machine_disks_list"),
+		},
+		{
+			name: "non-existent virtual machine",
+			args: args{
+				ctx: context.Background(),
+				vm:  VirtualMachineRef{ID: "vm_nopethisbegone"},
+			},
+			errStr:     fixtureVirtualMachineNotFoundErr,
+			errResp:    fixtureVirtualMachineNotFoundResponseError,
+			errIs:      ErrVirtualMachineNotFound,
+			respStatus: http.StatusNotFound,
+			respBody:   fixture("virtual_machine_not_found_error"),
+		},
+		{
+			name: "nil context",
+			args: args{
+				ctx: nil,
+				vm:  VirtualMachineRef{ID: "vm_i5qfOrvEI1CmNrJx"},
+			},
+			errStr: "net/http: nil Context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, mux, _, teardown := prepareTestClient(t)
+			defer teardown()
+			c := NewDisksClient(rm)
+
+			mux.HandleFunc(
+				"/core/v1/virtual_machines/_/disks",
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "GET", r.Method)
+					assertEmptyFieldSpec(t, r)
+					assertAuthorization(t, r)
+					assertRequestOptionHeader(t, r)
+
+					if tt.wantQuery != nil {
+						assert.Equal(t, *tt.wantQuery, r.URL.Query())
+					}
+
+					w.WriteHeader(tt.respStatus)
+					_, _ = w.Write(tt.respBody)
+				},
+			)
+
+			got, resp, err := c.ListForVirtualMachine(
+				tt.args.ctx, tt.args.vm, tt.args.opts, testRequestOption,
+			)
+
+			if tt.respStatus != 0 {
+				assert.Equal(t, tt.respStatus, resp.StatusCode)
+			}
+
+			if tt.errStr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+
+			if tt.wantPagination != nil {
+				assert.Equal(t, tt.wantPagination, resp.Pagination)
+			}
+
+			if tt.errResp != nil {
+				assert.Equal(t, tt.errResp, resp.Error)
+			}
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+func TestDisksClient_Get(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		ref DiskRef
+	}
+	tests := []struct {
+		name       string
+		args       args
+		want       *Disk
+		errStr     string
+		errResp    *katapult.ResponseError
+		errIs      error
+		respStatus int
+		respBody   []byte
+	}{
+		{
+			name: "by ID",
+			args: args{
+				ctx: context.Background(),
+				ref: DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+			},
+			want:       fixtureDiskFull,
+			respStatus: http.StatusOK,
+			respBody:   fixture("disk_get"),
+		},
+		{
+			name: "non-existent disk",
+			args: args{
+				ctx: context.Background(),
+				ref: DiskRef{ID: "disk_nopethisbegone"},
+			},
+			errStr:     fixtureDiskNotFoundErr,
+			errResp:    fixtureDiskNotFoundResponseError,
+			errIs:      ErrDiskNotFound,
+			respStatus: http.StatusNotFound,
+			respBody:   fixture("disk_not_found_error"),
+		},
+		{
+			name: "nil context",
+			args: args{
+				ctx: nil,
+				ref: DiskRef{ID: "disk_9ZBTJvvsaZHsaTmQ"},
+			},
+			errStr: "net/http: nil Context",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, mux, _, teardown := prepareTestClient(t)
+			defer teardown()
+			c := NewDisksClient(rm)
+
+			mux.HandleFunc(
+				"/core/v1/disks/_",
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "GET", r.Method)
+					assertEmptyFieldSpec(t, r)
+					assertAuthorization(t, r)
+					assertRequestOptionHeader(t, r)
+
+					assert.Equal(t, *tt.args.ref.queryValues(), r.URL.Query())
+
+					w.WriteHeader(tt.respStatus)
+					_, _ = w.Write(tt.respBody)
+				},
+			)
+
+			got, resp, err := c.Get(tt.args.ctx, tt.args.ref, testRequestOption)
+
+			if tt.respStatus != 0 {
+				assert.Equal(t, tt.respStatus, resp.StatusCode)
+			}
+
+			if tt.errStr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errStr)
+			}
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, got)
+			}
+
+			if tt.errResp != nil {
+				assert.Equal(t, tt.errResp, resp.Error)
+			}
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+func TestDisksClient_GetByID(t *testing.T) {
+	rm, mux, _, teardown := prepareTestClient(t)
+	defer teardown()
+	c := NewDisksClient(rm)
+
+	mux.HandleFunc(
+		"/core/v1/disks/_",
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			assertRequestOptionHeader(t, r)
+
+			assert.Equal(t,
+				url.Values{"disk[id]": []string{"disk_9ZBTJvvsaZHsaTmQ"}},
+				r.URL.Query(),
+			)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(fixture("disk_get"))
+		},
+	)
+
+	got, _, err := c.GetByID(
+		context.Background(), "disk_9ZBTJvvsaZHsaTmQ", testRequestOption,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, fixtureDiskFull, got)
+}
@@ -82,6 +82,7 @@ func (s *DNSZonesClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*DNSZone, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 
 	u := &url.URL{
@@ -100,6 +101,7 @@ func (s *DNSZonesClient) Nameservers(
 	org OrganizationRef,
 	reqOpts ...katapult.RequestOption,
 ) ([]string, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{
 		Path:     "organizations/_/dns_zones/nameservers",
 		RawQuery: org.queryValues().Encode(),
@@ -144,6 +146,7 @@ func (s *DNSZonesClient) Create(
 	args *DNSZoneCreateArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*DNSZone, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{
 		Path:     "organizations/_/dns_zones",
 		RawQuery: org.queryValues().Encode(),
@@ -0,0 +1,354 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+// ErrVirtualMachineDrifted is returned by Drift.Err when a virtual machine
+// no longer matches its build spec.
+var ErrVirtualMachineDrifted = fmt.Errorf(
+	"%w: virtual_machine_drifted", Err,
+)
+
+// Drift is the report returned by DriftChecker.Check.
+type Drift struct {
+	// VirtualMachine is the virtual machine which was checked.
+	VirtualMachine *VirtualMachine
+
+	// Diff lists the changes from the build spec to the current state of
+	// the virtual machine. It can be rendered with its Text and JSON methods.
+	Diff *buildspec.SpecDiff
+}
+
+// Drifted returns true if the virtual machine does not match its spec.
+func (d *Drift) Drifted() bool {
+	return !d.Diff.Empty()
+}
+
+// Err returns a *DriftError if the virtual machine does not match its spec,
+// and nil otherwise.
+func (d *Drift) Err() error {
+	if !d.Drifted() {
+		return nil
+	}
+
+	return &DriftError{VirtualMachine: d.VirtualMachine, Diff: d.Diff}
+}
+
+// DriftError lists the ways a virtual machine differs from its build spec.
+type DriftError struct {
+	VirtualMachine *VirtualMachine
+	Diff           *buildspec.SpecDiff
+}
+
+func (e *DriftError) Error() string {
+	msgs := make([]string, 0, len(e.Diff.Changes))
+	for _, c := range e.Diff.Changes {
+		msgs = append(msgs, c.Summary)
+	}
+
+	return fmt.Sprintf("%s: %s: %s",
+		ErrVirtualMachineDrifted, e.VirtualMachine.ID,
+		strings.Join(msgs, "; "),
+	)
+}
+
+func (e *DriftError) Unwrap() error {
+	return ErrVirtualMachineDrifted
+}
+
+// DriftChecker compares existing virtual machines against the build specs
+// they were built from, to find changes made since, such as resized disks
+// or a changed package.
+//
+// Resources, system disks, network interfaces, group, backup policies and
+// tags are compared. Lists and the group describe the desired state, so
+// anything missing from the spec is reported as drift, while optional
+// attributes such as a disk's speed or IO profile, and a network interface's
+// speed profile, are only compared when the spec sets them. Disk file system
+// types and virtual networks cannot be read back from the API, and are not
+// compared.
+//
+// References are compared by the attribute the spec uses to look them up,
+// so a package referenced by permalink in the spec is compared to the
+// permalink of the virtual machine's package.
+type DriftChecker struct {
+	// API is used to look up virtual machines and their resources.
+	API *API
+}
+
+// NewDriftChecker returns a new DriftChecker which looks up virtual machines
+// with api.
+func NewDriftChecker(api *API) *DriftChecker {
+	return &DriftChecker{API: api}
+}
+
+// Check compares the virtual machine referenced by vm against spec, and
+// returns a report of the differences.
+//
+// Differences are not treated as errors, use the report's Err method to
+// check for them. An error is only returned when the API could not be
+// queried.
+func (c *DriftChecker) Check(
+	ctx context.Context,
+	spec *buildspec.VirtualMachineSpec,
+	vm VirtualMachineRef,
+	reqOpts ...katapult.RequestOption,
+) (*Drift, error) {
+	e := &SpecExporter{API: c.API}
+
+	v, _, err := c.API.VirtualMachines.Get(ctx, vm, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	ref := v.Ref()
+
+	want := &buildspec.VirtualMachineSpec{
+		Resources:         spec.Resources,
+		NetworkInterfaces: spec.NetworkInterfaces,
+		Group:             spec.Group,
+		BackupPolicies:    spec.BackupPolicies,
+		Tags:              spec.Tags,
+	}
+	live := &buildspec.VirtualMachineSpec{
+		Resources: driftResources(spec.Resources, v),
+		Group:     driftGroup(spec.Group, v.Group),
+		Tags:      exportTags(v),
+	}
+
+	disks, err := e.fetchDisks(ctx, ref, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	want.SystemDisks = driftDiskNames(spec.SystemDisks, disks)
+	for _, disk := range disks {
+		sd := driftSystemDisk(want.SystemDisks, disk)
+		sd.BackupPolicies, err = e.diskBackupPolicies(
+			ctx, disk.Ref(), reqOpts,
+		)
+		if err != nil {
+			return nil, err
+		}
+		live.SystemDisks = append(live.SystemDisks, sd)
+	}
+
+	vmnets, err := e.fetchNetworkInterfaces(ctx, ref, reqOpts)
+	if err != nil {
+		return nil, err
+	}
+	for i, vmnet := range vmnets {
+		var wantNIC *buildspec.NetworkInterface
+		if i < len(spec.NetworkInterfaces) {
+			wantNIC = spec.NetworkInterfaces[i]
+		}
+		live.NetworkInterfaces = append(live.NetworkInterfaces,
+			driftNetworkInterface(wantNIC, vmnet),
+		)
+	}
+
+	live.BackupPolicies, err = e.virtualMachineBackupPolicies(
+		ctx, ref, reqOpts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Drift{VirtualMachine: v, Diff: buildspec.Diff(want, live)}, nil
+}
+
+// driftLookup returns the ID or other identifier of a resource, depending on
+// which of them the spec looks the resource up by.
+func driftLookup(wantID, id, other string) (string, string) {
+	if wantID != "" {
+		return id, ""
+	}
+
+	return "", other
+}
+
+func driftResources(
+	want *buildspec.Resources,
+	v *VirtualMachine,
+) *buildspec.Resources {
+	switch {
+	case want == nil:
+		return nil
+	case want.Package != nil && v.Package != nil:
+		id, permalink := driftLookup(
+			want.Package.ID, v.Package.ID, v.Package.Permalink,
+		)
+
+		return &buildspec.Resources{
+			Package: &buildspec.Package{ID: id, Permalink: permalink},
+		}
+	}
+
+	return &buildspec.Resources{Memory: v.MemoryInGB, CPUCores: v.CPUCores}
+}
+
+func driftGroup(
+	want *buildspec.Group,
+	g *VirtualMachineGroup,
+) *buildspec.Group {
+	if g == nil {
+		return nil
+	}
+	if want == nil {
+		return &buildspec.Group{Name: g.Name}
+	}
+
+	id, name := driftLookup(want.ID, g.ID, g.Name)
+
+	return &buildspec.Group{ID: id, Name: name}
+}
+
+// driftDiskNames returns a copy of the spec's disks, where disks without a
+// name are given the name of the disk in the same position on the virtual
+// machine, so they can be compared.
+func driftDiskNames(
+	want []*buildspec.SystemDisk,
+	disks []*Disk,
+) []*buildspec.SystemDisk {
+	names := map[string]bool{}
+	for _, sd := range want {
+		names[sd.Name] = true
+	}
+
+	named := make([]*buildspec.SystemDisk, 0, len(want))
+	for i, sd := range want {
+		if sd.Name == "" && i < len(disks) && !names[disks[i].Name] {
+			c := *sd
+			c.Name = disks[i].Name
+			sd = &c
+		}
+		named = append(named, sd)
+	}
+
+	return named
+}
+
+// driftSystemDisk returns the current state of disk, with only the optional
+// attributes set which the spec disk of the same name sets.
+func driftSystemDisk(
+	want []*buildspec.SystemDisk,
+	disk *Disk,
+) *buildspec.SystemDisk {
+	sd := &buildspec.SystemDisk{Name: disk.Name, Size: disk.SizeInGB}
+
+	for _, w := range want {
+		if w.Name != disk.Name {
+			continue
+		}
+
+		if w.Speed != "" {
+			sd.Speed = disk.StorageSpeed
+		}
+		if w.IOProfile != nil && disk.IOProfile != nil {
+			id, permalink := driftLookup(w.IOProfile.ID,
+				disk.IOProfile.ID, disk.IOProfile.Permalink,
+			)
+			sd.IOProfile = &buildspec.DiskIOProfile{
+				ID: id, Permalink: permalink,
+			}
+		}
+		sd.FileSystemType = w.FileSystemType
+
+		break
+	}
+
+	return sd
+}
+
+// driftNetworkInterface returns the current state of vmnet, in the form
+// used by the spec's network interface in the same position, if any.
+func driftNetworkInterface(
+	want *buildspec.NetworkInterface,
+	vmnet *VirtualMachineNetworkInterface,
+) *buildspec.NetworkInterface {
+	if want == nil {
+		want = &buildspec.NetworkInterface{}
+	}
+	ni := &buildspec.NetworkInterface{VirtualNetwork: want.VirtualNetwork}
+
+	if vmnet.Network != nil && want.VirtualNetwork == nil &&
+		(want.Network != nil || len(want.IPAddressAllocations) == 0) {
+		wantID := ""
+		if want.Network != nil {
+			wantID = want.Network.ID
+		}
+		id, permalink := driftLookup(
+			wantID, vmnet.Network.ID, vmnet.Network.Permalink,
+		)
+		ni.Network = &buildspec.Network{ID: id, Permalink: permalink}
+	}
+	if want.SpeedProfile != nil && vmnet.SpeedProfile != nil {
+		id, permalink := driftLookup(want.SpeedProfile.ID,
+			vmnet.SpeedProfile.ID, vmnet.SpeedProfile.Permalink,
+		)
+		ni.SpeedProfile = &buildspec.NetworkSpeedProfile{
+			ID: id, Permalink: permalink,
+		}
+	}
+
+	ni.IPAddressAllocations = driftIPAddressAllocations(
+		want.IPAddressAllocations, vmnet.IPAddresses,
+	)
+
+	return ni
+}
+
+// driftIPAddressAllocations pairs each of the virtual machine's IP addresses
+// with an allocation in the spec. Existing allocations match addresses by ID
+// or address, and new allocations match addresses of the same IP version.
+// Paired addresses are returned as the allocation they matched, and others
+// as existing allocations of the address.
+func driftIPAddressAllocations(
+	want []*buildspec.IPAddressAllocation,
+	ips []*IPAddress,
+) []*buildspec.IPAddressAllocation {
+	used := make([]bool, len(want))
+	pair := func(ip *IPAddress, match func(*buildspec.IPAddressAllocation) bool,
+	) *buildspec.IPAddressAllocation {
+		for i, a := range want {
+			if !used[i] && match(a) {
+				used[i] = true
+
+				return a
+			}
+		}
+
+		return nil
+	}
+
+	allocs := make([]*buildspec.IPAddressAllocation, len(ips))
+	for i, ip := range ips {
+		allocs[i] = pair(ip, func(a *buildspec.IPAddressAllocation) bool {
+			return a.Type == buildspec.ExistingIPAddressAllocation &&
+				a.IPAddress != nil &&
+				(a.IPAddress.ID == ip.ID || a.IPAddress.Address == ip.Address)
+		})
+	}
+	for i, ip := range ips {
+		if allocs[i] != nil {
+			continue
+		}
+		version := exportIPVersion(ip)
+		allocs[i] = pair(ip, func(a *buildspec.IPAddressAllocation) bool {
+			return a.Type == buildspec.NewIPAddressAllocation &&
+				(a.Version == 0 || a.Version == version)
+		})
+		if allocs[i] == nil {
+			allocs[i] = &buildspec.IPAddressAllocation{
+				Type:      buildspec.ExistingIPAddressAllocation,
+				IPAddress: &buildspec.IPAddress{Address: ip.Address},
+			}
+		}
+	}
+
+	return allocs
+}
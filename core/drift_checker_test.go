@@ -0,0 +1,355 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// driftVM is the virtual machine the spec returned by driftSpec was built
+// into, as served by newExporterFake.
+func driftVM() *core.VirtualMachine {
+	return &core.VirtualMachine{
+		ID:       "vm_1",
+		Hostname: "web-1",
+		Package: &core.VirtualMachinePackage{
+			ID:        "vmpkg_1",
+			Permalink: "rock-3",
+		},
+		Group:    &core.VirtualMachineGroup{ID: "vmgrp_1", Name: "web"},
+		TagNames: []string{"web", "production"},
+	}
+}
+
+// driftSpec returns a spec matching driftVM.
+func driftSpec() *buildspec.VirtualMachineSpec {
+	return &buildspec.VirtualMachineSpec{
+		Zone: &buildspec.Zone{Permalink: "uk-lon-01a"},
+		Resources: &buildspec.Resources{
+			Package: &buildspec.Package{Permalink: "rock-3"},
+		},
+		DiskTemplate: &buildspec.DiskTemplate{
+			Permalink: "templates/ubuntu-22-04",
+		},
+		SystemDisks: []*buildspec.SystemDisk{
+			{Size: 20, FileSystemType: "ext4"},
+			{
+				Name:      "Data",
+				Size:      100,
+				Speed:     "nvme",
+				IOProfile: &buildspec.DiskIOProfile{Permalink: "fast"},
+				BackupPolicies: []*buildspec.BackupPolicy{
+					{
+						Retention: 4,
+						Schedule: &buildspec.Schedule{
+							Interval:  buildspec.ScheduledWeekly,
+							Frequency: 2,
+						},
+					},
+				},
+			},
+		},
+		NetworkInterfaces: []*buildspec.NetworkInterface{
+			{
+				Network: &buildspec.Network{Permalink: "public"},
+				IPAddressAllocations: []*buildspec.IPAddressAllocation{
+					{
+						Type:    buildspec.NewIPAddressAllocation,
+						Version: buildspec.IPv6,
+					},
+					{
+						Type:      buildspec.ExistingIPAddressAllocation,
+						IPAddress: &buildspec.IPAddress{ID: "ip_1"},
+					},
+				},
+			},
+		},
+		Hostname: "web-1",
+		Group:    &buildspec.Group{Name: "web"},
+		BackupPolicies: []*buildspec.BackupPolicy{
+			{
+				Retention: 7,
+				Schedule: &buildspec.Schedule{
+					Interval:  buildspec.ScheduledDaily,
+					Frequency: 1,
+					Time:      3,
+				},
+			},
+		},
+		Tags: []string{"production", "web"},
+	}
+}
+
+func TestDriftChecker_Check(t *testing.T) {
+	tests := []struct {
+		name string
+		vm   func(vm *core.VirtualMachine)
+		spec func(s *buildspec.VirtualMachineSpec)
+		want []*buildspec.Change
+	}{
+		{
+			name: "no drift",
+			want: []*buildspec.Change{},
+		},
+		{
+			name: "no drift with references by ID",
+			spec: func(s *buildspec.VirtualMachineSpec) {
+				s.Resources.Package = &buildspec.Package{ID: "vmpkg_1"}
+				s.SystemDisks[1].IOProfile = &buildspec.DiskIOProfile{
+					ID: "dio_2",
+				}
+				s.NetworkInterfaces[0].Network = &buildspec.Network{
+					ID: "net_1",
+				}
+				s.NetworkInterfaces[0].SpeedProfile =
+					&buildspec.NetworkSpeedProfile{ID: "nsp_1"}
+				s.Group = &buildspec.Group{ID: "vmgrp_1"}
+			},
+			want: []*buildspec.Change{},
+		},
+		{
+			name: "no drift with network from IP addresses",
+			spec: func(s *buildspec.VirtualMachineSpec) {
+				s.NetworkInterfaces[0].Network = nil
+				s.NetworkInterfaces[0].IPAddressAllocations[0].Version = 0
+			},
+			want: []*buildspec.Change{},
+		},
+		{
+			name: "changed package",
+			vm: func(vm *core.VirtualMachine) {
+				vm.Package.Permalink = "rock-6"
+			},
+			want: []*buildspec.Change{
+				{
+					Type:    buildspec.ChangeModified,
+					Path:    "resources.package",
+					From:    "rock-3",
+					To:      "rock-6",
+					Summary: "changed package from rock-3 to rock-6",
+				},
+			},
+		},
+		{
+			name: "resized resources",
+			vm: func(vm *core.VirtualMachine) {
+				vm.MemoryInGB = 16
+				vm.CPUCores = 8
+			},
+			spec: func(s *buildspec.VirtualMachineSpec) {
+				s.Resources = &buildspec.Resources{Memory: 8, CPUCores: 8}
+			},
+			want: []*buildspec.Change{
+				{
+					Type:    buildspec.ChangeModified,
+					Path:    "resources.memory",
+					From:    "8 GB",
+					To:      "16 GB",
+					Summary: "changed memory from 8 GB to 16 GB",
+				},
+			},
+		},
+		{
+			name: "disks",
+			spec: func(s *buildspec.VirtualMachineSpec) {
+				s.SystemDisks[0].Size = 10
+				s.SystemDisks[1].Speed = "ssd"
+				s.SystemDisks[1].BackupPolicies = nil
+				s.SystemDisks = append(s.SystemDisks, &buildspec.SystemDisk{
+					Name: "Logs", Size: 10,
+				})
+			},
+			want: []*buildspec.Change{
+				{
+					Type:    buildspec.ChangeRemoved,
+					Path:    `system_disks["Logs"]`,
+					From:    "10 GB",
+					Summary: `removed system disk "Logs" (10 GB)`,
+				},
+				{
+					Type:    buildspec.ChangeModified,
+					Path:    `system_disks["Boot"].size`,
+					From:    "10 GB",
+					To:      "20 GB",
+					Summary: `resized system disk "Boot" from 10 GB to 20 GB`,
+				},
+				{
+					Type: buildspec.ChangeModified,
+					Path: `system_disks["Data"].speed`,
+					From: "ssd",
+					To:   "nvme",
+					Summary: `changed speed of system disk "Data" ` +
+						"from ssd to nvme",
+				},
+				{
+					Type: buildspec.ChangeAdded,
+					Path: `system_disks["Data"].backup_policies`,
+					To:   "retention 4, weekly every 2",
+					Summary: "added backup policy " +
+						"retention 4, weekly every 2 " +
+						`to system disk "Data"`,
+				},
+			},
+		},
+		{
+			name: "network interfaces",
+			spec: func(s *buildspec.VirtualMachineSpec) {
+				nic := s.NetworkInterfaces[0]
+				nic.SpeedProfile = &buildspec.NetworkSpeedProfile{
+					Permalink: "10gbps",
+				}
+				nic.IPAddressAllocations = nic.IPAddressAllocations[1:]
+			},
+			want: []*buildspec.Change{
+				{
+					Type: buildspec.ChangeModified,
+					Path: "network_interfaces[0].speed_profile",
+					From: "10gbps",
+					To:   "1gbps",
+					Summary: "changed speed profile of network interface 0 " +
+						"from 10gbps to 1gbps",
+				},
+				{
+					Type: buildspec.ChangeAdded,
+					Path: "network_interfaces[0].ip_address_allocations",
+					To:   "existing 2001:db8::10",
+					Summary: "added IP address allocation " +
+						"existing 2001:db8::10 to network interface 0",
+				},
+			},
+		},
+		{
+			name: "group, backup policies and tags",
+			vm: func(vm *core.VirtualMachine) {
+				vm.Group = nil
+				vm.TagNames = []string{"web", "staging"}
+			},
+			spec: func(s *buildspec.VirtualMachineSpec) {
+				s.BackupPolicies = nil
+			},
+			want: []*buildspec.Change{
+				{
+					Type:    buildspec.ChangeRemoved,
+					Path:    "group",
+					From:    "web",
+					Summary: "removed group web",
+				},
+				{
+					Type: buildspec.ChangeAdded,
+					Path: "backup_policies",
+					To:   "retention 7, daily every 1 at 03:00",
+					Summary: "added backup policy " +
+						"retention 7, daily every 1 at 03:00",
+				},
+				{
+					Type:    buildspec.ChangeRemoved,
+					Path:    "tags",
+					From:    "production",
+					Summary: "removed tag production",
+				},
+				{
+					Type:    buildspec.ChangeAdded,
+					Path:    "tags",
+					To:      "staging",
+					Summary: "added tag staging",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := driftVM()
+			if tt.vm != nil {
+				tt.vm(vm)
+			}
+			spec := driftSpec()
+			if tt.spec != nil {
+				tt.spec(spec)
+			}
+			fake := newExporterFake(vm)
+
+			got, err := core.NewDriftChecker(fake.API()).Check(
+				context.Background(), spec, core.VirtualMachineRef{ID: "vm_1"},
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, vm, got.VirtualMachine)
+			assert.Equal(t, tt.want, got.Diff.Changes)
+			assert.Equal(t, len(tt.want) > 0, got.Drifted())
+			if len(tt.want) == 0 {
+				assert.NoError(t, got.Err())
+			} else {
+				assert.ErrorIs(t, got.Err(), core.ErrVirtualMachineDrifted)
+			}
+		})
+	}
+}
+
+func TestDriftChecker_Check_spec(t *testing.T) {
+	spec := driftSpec()
+	fake := newExporterFake(driftVM())
+
+	_, err := core.NewDriftChecker(fake.API()).Check(
+		context.Background(), spec, core.VirtualMachineRef{ID: "vm_1"},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, driftSpec(), spec, "spec must not be modified")
+}
+
+func TestDrift_Err(t *testing.T) {
+	fake := newExporterFake(driftVM())
+	spec := driftSpec()
+	spec.Resources.Package.Permalink = "rock-6"
+	spec.Tags = []string{"web"}
+
+	drift, err := core.NewDriftChecker(fake.API()).Check(
+		context.Background(), spec, core.VirtualMachineRef{ID: "vm_1"},
+	)
+	require.NoError(t, err)
+
+	err = drift.Err()
+	var driftErr *core.DriftError
+	require.ErrorAs(t, err, &driftErr)
+	assert.Equal(t, drift.Diff, driftErr.Diff)
+	assert.EqualError(t, err,
+		"katapult: core: virtual_machine_drifted: vm_1: "+
+			"changed package from rock-6 to rock-3; added tag production",
+	)
+}
+
+func TestDriftChecker_Check_errors(t *testing.T) {
+	t.Run("virtual machine not found", func(t *testing.T) {
+		fake := newExporterFake(driftVM())
+
+		_, err := core.NewDriftChecker(fake.API()).Check(
+			context.Background(), driftSpec(),
+			core.VirtualMachineRef{ID: "vm_nope"},
+		)
+
+		assert.ErrorIs(t, err, core.ErrVirtualMachineNotFound)
+	})
+
+	t.Run("network interface lookup fails", func(t *testing.T) {
+		fake := newExporterFake(driftVM())
+		fake.VirtualMachineNetworkInterfaces.GetFunc = func(
+			_ context.Context,
+			_ core.VirtualMachineNetworkInterfaceRef,
+			_ ...katapult.RequestOption,
+		) (*core.VirtualMachineNetworkInterface, *katapult.Response, error) {
+			return nil, nil, core.ErrVirtualMachineNetworkInterfaceNotFound
+		}
+
+		_, err := core.NewDriftChecker(fake.API()).Check(
+			context.Background(), driftSpec(),
+			core.VirtualMachineRef{ID: "vm_1"},
+		)
+
+		assert.ErrorIs(t, err, core.ErrVirtualMachineNetworkInterfaceNotFound)
+	})
+}
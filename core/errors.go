@@ -23,3 +23,10 @@ func handleResponseError(err error) error {
 
 	return err
 }
+
+// CastResponseError casts err to a core-specific error type when it is, or
+// wraps, a *katapult.ResponseError with a known error code. All other errors
+// are returned as is.
+func CastResponseError(err error) error {
+	return handleResponseError(err)
+}
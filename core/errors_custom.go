@@ -14,7 +14,9 @@ func (s *InvalidSpecXMLError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf("%s: %s", s.CommonError.BaseError(), s.Detail.Errors)
+	return s.CommonError.WithRequestID(
+		fmt.Sprintf("%s: %s", s.CommonError.BaseError(), s.Detail.Errors),
+	)
 }
 
 func (s *ObjectInTrashError) Error() string {
@@ -22,10 +24,10 @@ func (s *ObjectInTrashError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf(
+	return s.CommonError.WithRequestID(fmt.Sprintf(
 		"%s: trash_object_id=%s",
 		s.CommonError.BaseError(), s.Detail.TrashObject.ID,
-	)
+	))
 }
 
 func (s *PermissionDeniedError) Error() string {
@@ -33,7 +35,9 @@ func (s *PermissionDeniedError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf("%s: %s", s.CommonError.BaseError(), *s.Detail.Details)
+	return s.CommonError.WithRequestID(
+		fmt.Sprintf("%s: %s", s.CommonError.BaseError(), *s.Detail.Details),
+	)
 }
 
 func (s *RateLimitReachedError) Error() string {
@@ -41,10 +45,10 @@ func (s *RateLimitReachedError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf(
+	return s.CommonError.WithRequestID(fmt.Sprintf(
 		"%s: max requests per minute: %d",
 		s.CommonError.BaseError(), s.Detail.TotalPermitted,
-	)
+	))
 }
 
 func (s *ResourceCreationRestrictedError) Error() string {
@@ -52,10 +56,10 @@ func (s *ResourceCreationRestrictedError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf(
+	return s.CommonError.WithRequestID(fmt.Sprintf(
 		"%s: %s",
 		s.CommonError.BaseError(), strings.Join(s.Detail.Errors, ", "),
-	)
+	))
 }
 
 func (s *TaskQueueingError) Error() string {
@@ -63,7 +67,9 @@ func (s *TaskQueueingError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf("%s: %s", s.CommonError.BaseError(), s.Detail.Details)
+	return s.CommonError.WithRequestID(
+		fmt.Sprintf("%s: %s", s.CommonError.BaseError(), s.Detail.Details),
+	)
 }
 
 func (s *ValidationError) Error() string {
@@ -71,10 +77,10 @@ func (s *ValidationError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf(
+	return s.CommonError.WithRequestID(fmt.Sprintf(
 		"%s: %s",
 		s.CommonError.BaseError(), strings.Join(s.Detail.Errors, ", "),
-	)
+	))
 }
 
 func (s *VirtualMachineMustBeStartedError) Error() string {
@@ -82,8 +88,8 @@ func (s *VirtualMachineMustBeStartedError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf(
+	return s.CommonError.WithRequestID(fmt.Sprintf(
 		"%s: current_state=%s",
 		s.CommonError.BaseError(), s.Detail.CurrentState,
-	)
+	))
 }
@@ -599,3 +599,20 @@ func TestVirtualMachineMustBeStartedError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomErrors_requestID(t *testing.T) {
+	respErr := &katapult.ResponseError{
+		Code:        "invalid_spec_xml",
+		Description: "The spec XML provided is invalid",
+		Detail:      json.RawMessage(`{"errors":"missing disk"}`),
+		RequestID:   "req_abc123",
+	}
+
+	got := NewInvalidSpecXMLError(respErr).Error()
+
+	assert.Equal(t,
+		"katapult: bad_request: invalid_spec_xml: missing disk "+
+			"(request_id: req_abc123)",
+		got,
+	)
+}
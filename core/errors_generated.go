@@ -84,10 +84,10 @@ type AddressListEntryNotFoundError struct {
 
 func NewAddressListEntryNotFoundError(theError *katapult.ResponseError) *AddressListEntryNotFoundError {
 	return &AddressListEntryNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrAddressListEntryNotFound,
 			"address_list_entry_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -100,10 +100,10 @@ type AddressListNotFoundError struct {
 
 func NewAddressListNotFoundError(theError *katapult.ResponseError) *AddressListNotFoundError {
 	return &AddressListNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrAddressListNotFound,
 			"address_list_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -116,10 +116,10 @@ type CertificateNotFoundError struct {
 
 func NewCertificateNotFoundError(theError *katapult.ResponseError) *CertificateNotFoundError {
 	return &CertificateNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrCertificateNotFound,
 			"certificate_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -132,10 +132,10 @@ type CountryNotFoundError struct {
 
 func NewCountryNotFoundError(theError *katapult.ResponseError) *CountryNotFoundError {
 	return &CountryNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrCountryNotFound,
 			"country_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -148,10 +148,10 @@ type CountryStateNotFoundError struct {
 
 func NewCountryStateNotFoundError(theError *katapult.ResponseError) *CountryStateNotFoundError {
 	return &CountryStateNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrCountryStateNotFound,
 			"country_state_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -164,10 +164,10 @@ type CurrencyNotFoundError struct {
 
 func NewCurrencyNotFoundError(theError *katapult.ResponseError) *CurrencyNotFoundError {
 	return &CurrencyNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrCurrencyNotFound,
 			"currency_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -180,10 +180,10 @@ type DNSRecordNotFoundError struct {
 
 func NewDNSRecordNotFoundError(theError *katapult.ResponseError) *DNSRecordNotFoundError {
 	return &DNSRecordNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDNSRecordNotFound,
 			"dns_record_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -196,10 +196,10 @@ type DNSZoneNotFoundError struct {
 
 func NewDNSZoneNotFoundError(theError *katapult.ResponseError) *DNSZoneNotFoundError {
 	return &DNSZoneNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDNSZoneNotFound,
 			"dns_zone_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -212,10 +212,10 @@ type DNSZoneNotVerifiedError struct {
 
 func NewDNSZoneNotVerifiedError(theError *katapult.ResponseError) *DNSZoneNotVerifiedError {
 	return &DNSZoneNotVerifiedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDNSZoneNotVerified,
 			"dns_zone_not_verified",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -228,10 +228,10 @@ type DataCenterNotFoundError struct {
 
 func NewDataCenterNotFoundError(theError *katapult.ResponseError) *DataCenterNotFoundError {
 	return &DataCenterNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDataCenterNotFound,
 			"data_center_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -251,10 +251,10 @@ func NewDeletionRestrictedError(theError *katapult.ResponseError) *DeletionRestr
 	}
 
 	return &DeletionRestrictedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDeletionRestricted,
 			"deletion_restricted",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -272,10 +272,10 @@ type DiskBackupPolicyNotFoundError struct {
 
 func NewDiskBackupPolicyNotFoundError(theError *katapult.ResponseError) *DiskBackupPolicyNotFoundError {
 	return &DiskBackupPolicyNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDiskBackupPolicyNotFound,
 			"disk_backup_policy_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -288,10 +288,10 @@ type DiskNotFoundError struct {
 
 func NewDiskNotFoundError(theError *katapult.ResponseError) *DiskNotFoundError {
 	return &DiskNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDiskNotFound,
 			"disk_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -304,10 +304,10 @@ type DiskTemplateNotFoundError struct {
 
 func NewDiskTemplateNotFoundError(theError *katapult.ResponseError) *DiskTemplateNotFoundError {
 	return &DiskTemplateNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDiskTemplateNotFound,
 			"disk_template_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -320,10 +320,10 @@ type DiskTemplateVersionNotFoundError struct {
 
 func NewDiskTemplateVersionNotFoundError(theError *katapult.ResponseError) *DiskTemplateVersionNotFoundError {
 	return &DiskTemplateVersionNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrDiskTemplateVersionNotFound,
 			"disk_template_version_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -336,10 +336,10 @@ type FileStorageVolumeNotFoundError struct {
 
 func NewFileStorageVolumeNotFoundError(theError *katapult.ResponseError) *FileStorageVolumeNotFoundError {
 	return &FileStorageVolumeNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrFileStorageVolumeNotFound,
 			"file_storage_volume_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -352,10 +352,10 @@ type FlexibleResourcesUnavailableToOrganizationError struct {
 
 func NewFlexibleResourcesUnavailableToOrganizationError(theError *katapult.ResponseError) *FlexibleResourcesUnavailableToOrganizationError {
 	return &FlexibleResourcesUnavailableToOrganizationError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrFlexibleResourcesUnavailableToOrganization,
 			"flexible_resources_unavailable_to_organization",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -368,10 +368,10 @@ type GPUTypeNotFoundError struct {
 
 func NewGPUTypeNotFoundError(theError *katapult.ResponseError) *GPUTypeNotFoundError {
 	return &GPUTypeNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrGPUTypeNotFound,
 			"gpu_type_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -384,10 +384,10 @@ type IPAddressNotFoundError struct {
 
 func NewIPAddressNotFoundError(theError *katapult.ResponseError) *IPAddressNotFoundError {
 	return &IPAddressNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrIPAddressNotFound,
 			"ip_address_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -400,10 +400,10 @@ type IPAlreadyAllocatedError struct {
 
 func NewIPAlreadyAllocatedError(theError *katapult.ResponseError) *IPAlreadyAllocatedError {
 	return &IPAlreadyAllocatedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrIPAlreadyAllocated,
 			"ip_already_allocated",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -416,10 +416,10 @@ type IdentityNotLinkedToWebSessionError struct {
 
 func NewIdentityNotLinkedToWebSessionError(theError *katapult.ResponseError) *IdentityNotLinkedToWebSessionError {
 	return &IdentityNotLinkedToWebSessionError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrIdentityNotLinkedToWebSession,
 			"identity_not_linked_to_web_session",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -432,10 +432,10 @@ type InterfaceNotFoundError struct {
 
 func NewInterfaceNotFoundError(theError *katapult.ResponseError) *InterfaceNotFoundError {
 	return &InterfaceNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrInterfaceNotFound,
 			"interface_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -455,10 +455,10 @@ func NewInvalidAPITokenError(theError *katapult.ResponseError) *InvalidAPITokenE
 	}
 
 	return &InvalidAPITokenError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrInvalidAPIToken,
 			"invalid_api_token",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -476,10 +476,10 @@ type InvalidIPError struct {
 
 func NewInvalidIPError(theError *katapult.ResponseError) *InvalidIPError {
 	return &InvalidIPError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrInvalidIP,
 			"invalid_ip",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -499,10 +499,10 @@ func NewInvalidSpecXMLError(theError *katapult.ResponseError) *InvalidSpecXMLErr
 	}
 
 	return &InvalidSpecXMLError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrInvalidSpecXML,
 			"invalid_spec_xml",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -520,10 +520,10 @@ type InvalidTimestampError struct {
 
 func NewInvalidTimestampError(theError *katapult.ResponseError) *InvalidTimestampError {
 	return &InvalidTimestampError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrInvalidTimestamp,
 			"invalid_timestamp",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -536,10 +536,10 @@ type LoadBalancerNotFoundError struct {
 
 func NewLoadBalancerNotFoundError(theError *katapult.ResponseError) *LoadBalancerNotFoundError {
 	return &LoadBalancerNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrLoadBalancerNotFound,
 			"load_balancer_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -552,10 +552,10 @@ type LoadBalancerRuleNotFoundError struct {
 
 func NewLoadBalancerRuleNotFoundError(theError *katapult.ResponseError) *LoadBalancerRuleNotFoundError {
 	return &LoadBalancerRuleNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrLoadBalancerRuleNotFound,
 			"load_balancer_rule_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -568,10 +568,10 @@ type LocationRequiredError struct {
 
 func NewLocationRequiredError(theError *katapult.ResponseError) *LocationRequiredError {
 	return &LocationRequiredError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrLocationRequired,
 			"location_required",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -584,10 +584,10 @@ type MissingAPITokenError struct {
 
 func NewMissingAPITokenError(theError *katapult.ResponseError) *MissingAPITokenError {
 	return &MissingAPITokenError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrMissingAPIToken,
 			"missing_api_token",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -600,10 +600,10 @@ type NetworkNotFoundError struct {
 
 func NewNetworkNotFoundError(theError *katapult.ResponseError) *NetworkNotFoundError {
 	return &NetworkNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNetworkNotFound,
 			"network_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -616,10 +616,10 @@ type NetworkSpeedProfileNotFoundError struct {
 
 func NewNetworkSpeedProfileNotFoundError(theError *katapult.ResponseError) *NetworkSpeedProfileNotFoundError {
 	return &NetworkSpeedProfileNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNetworkSpeedProfileNotFound,
 			"network_speed_profile_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -632,10 +632,10 @@ type NoAllocationError struct {
 
 func NewNoAllocationError(theError *katapult.ResponseError) *NoAllocationError {
 	return &NoAllocationError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNoAllocation,
 			"no_allocation",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -648,10 +648,10 @@ type NoAvailableAddressesError struct {
 
 func NewNoAvailableAddressesError(theError *katapult.ResponseError) *NoAvailableAddressesError {
 	return &NoAvailableAddressesError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNoAvailableAddresses,
 			"no_available_addresses",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -664,10 +664,10 @@ type NoInterfaceAvailableError struct {
 
 func NewNoInterfaceAvailableError(theError *katapult.ResponseError) *NoInterfaceAvailableError {
 	return &NoInterfaceAvailableError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNoInterfaceAvailable,
 			"no_interface_available",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -680,10 +680,10 @@ type NoUserAssociatedWithIdentityError struct {
 
 func NewNoUserAssociatedWithIdentityError(theError *katapult.ResponseError) *NoUserAssociatedWithIdentityError {
 	return &NoUserAssociatedWithIdentityError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNoUserAssociatedWithIdentity,
 			"no_user_associated_with_identity",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -696,10 +696,10 @@ type NoVirtualMachineForAPITokenError struct {
 
 func NewNoVirtualMachineForAPITokenError(theError *katapult.ResponseError) *NoVirtualMachineForAPITokenError {
 	return &NoVirtualMachineForAPITokenError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrNoVirtualMachineForAPIToken,
 			"no_virtual_machine_for_api_token",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -719,10 +719,10 @@ func NewObjectInTrashError(theError *katapult.ResponseError) *ObjectInTrashError
 	}
 
 	return &ObjectInTrashError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrObjectInTrash,
 			"object_in_trash",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -740,10 +740,10 @@ type OperatingSystemNotFoundError struct {
 
 func NewOperatingSystemNotFoundError(theError *katapult.ResponseError) *OperatingSystemNotFoundError {
 	return &OperatingSystemNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrOperatingSystemNotFound,
 			"operating_system_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -756,10 +756,10 @@ type OrganizationLimitReachedError struct {
 
 func NewOrganizationLimitReachedError(theError *katapult.ResponseError) *OrganizationLimitReachedError {
 	return &OrganizationLimitReachedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrOrganizationLimitReached,
 			"organization_limit_reached",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -772,10 +772,10 @@ type OrganizationNotActivatedError struct {
 
 func NewOrganizationNotActivatedError(theError *katapult.ResponseError) *OrganizationNotActivatedError {
 	return &OrganizationNotActivatedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrOrganizationNotActivated,
 			"organization_not_activated",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -788,10 +788,10 @@ type OrganizationNotFoundError struct {
 
 func NewOrganizationNotFoundError(theError *katapult.ResponseError) *OrganizationNotFoundError {
 	return &OrganizationNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrOrganizationNotFound,
 			"organization_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -804,10 +804,10 @@ type OrganizationSuspendedError struct {
 
 func NewOrganizationSuspendedError(theError *katapult.ResponseError) *OrganizationSuspendedError {
 	return &OrganizationSuspendedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrOrganizationSuspended,
 			"organization_suspended",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -827,10 +827,10 @@ func NewPermissionDeniedError(theError *katapult.ResponseError) *PermissionDenie
 	}
 
 	return &PermissionDeniedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrPermissionDenied,
 			"permission_denied",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -855,10 +855,10 @@ func NewRateLimitReachedError(theError *katapult.ResponseError) *RateLimitReache
 	}
 
 	return &RateLimitReachedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrRateLimitReached,
 			"rate_limit_reached",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -883,10 +883,10 @@ func NewResourceCreationRestrictedError(theError *katapult.ResponseError) *Resou
 	}
 
 	return &ResourceCreationRestrictedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrResourceCreationRestricted,
 			"resource_creation_restricted",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -904,10 +904,10 @@ type ResourceDoesNotSupportUnallocationError struct {
 
 func NewResourceDoesNotSupportUnallocationError(theError *katapult.ResponseError) *ResourceDoesNotSupportUnallocationError {
 	return &ResourceDoesNotSupportUnallocationError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrResourceDoesNotSupportUnallocation,
 			"resource_does_not_support_unallocation",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -920,10 +920,10 @@ type SSHKeyNotFoundError struct {
 
 func NewSSHKeyNotFoundError(theError *katapult.ResponseError) *SSHKeyNotFoundError {
 	return &SSHKeyNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrSSHKeyNotFound,
 			"ssh_key_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -936,10 +936,10 @@ type SecurityGroupNotFoundError struct {
 
 func NewSecurityGroupNotFoundError(theError *katapult.ResponseError) *SecurityGroupNotFoundError {
 	return &SecurityGroupNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrSecurityGroupNotFound,
 			"security_group_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -952,10 +952,10 @@ type SecurityGroupRuleNotFoundError struct {
 
 func NewSecurityGroupRuleNotFoundError(theError *katapult.ResponseError) *SecurityGroupRuleNotFoundError {
 	return &SecurityGroupRuleNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrSecurityGroupRuleNotFound,
 			"security_group_rule_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -968,10 +968,10 @@ type SpeedProfileAlreadyAssignedError struct {
 
 func NewSpeedProfileAlreadyAssignedError(theError *katapult.ResponseError) *SpeedProfileAlreadyAssignedError {
 	return &SpeedProfileAlreadyAssignedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrSpeedProfileAlreadyAssigned,
 			"speed_profile_already_assigned",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -984,10 +984,10 @@ type TagNotFoundError struct {
 
 func NewTagNotFoundError(theError *katapult.ResponseError) *TagNotFoundError {
 	return &TagNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrTagNotFound,
 			"tag_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1000,10 +1000,10 @@ type TaskNotFoundError struct {
 
 func NewTaskNotFoundError(theError *katapult.ResponseError) *TaskNotFoundError {
 	return &TaskNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrTaskNotFound,
 			"task_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1023,10 +1023,10 @@ func NewTaskQueueingError(theError *katapult.ResponseError) *TaskQueueingError {
 	}
 
 	return &TaskQueueingError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrTaskQueueingError,
 			"task_queueing_error",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -1044,10 +1044,10 @@ type TrashObjectNotFoundError struct {
 
 func NewTrashObjectNotFoundError(theError *katapult.ResponseError) *TrashObjectNotFoundError {
 	return &TrashObjectNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrTrashObjectNotFound,
 			"trash_object_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1067,10 +1067,10 @@ func NewUnauthorizedNetworkForAPITokenError(theError *katapult.ResponseError) *U
 	}
 
 	return &UnauthorizedNetworkForAPITokenError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrUnauthorizedNetworkForAPIToken,
 			"unauthorized_network_for_api_token",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -1095,10 +1095,10 @@ func NewValidationError(theError *katapult.ResponseError) *ValidationError {
 	}
 
 	return &ValidationError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrValidationError,
 			"validation_error",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -1116,10 +1116,10 @@ type VirtualMachineBuildNotFoundError struct {
 
 func NewVirtualMachineBuildNotFoundError(theError *katapult.ResponseError) *VirtualMachineBuildNotFoundError {
 	return &VirtualMachineBuildNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrVirtualMachineBuildNotFound,
 			"build_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1132,10 +1132,10 @@ type VirtualMachineGroupNotFoundError struct {
 
 func NewVirtualMachineGroupNotFoundError(theError *katapult.ResponseError) *VirtualMachineGroupNotFoundError {
 	return &VirtualMachineGroupNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrVirtualMachineGroupNotFound,
 			"virtual_machine_group_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1155,10 +1155,10 @@ func NewVirtualMachineMustBeStartedError(theError *katapult.ResponseError) *Virt
 	}
 
 	return &VirtualMachineMustBeStartedError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrVirtualMachineMustBeStarted,
 			"virtual_machine_must_be_started",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -1176,10 +1176,10 @@ type VirtualMachineNetworkInterfaceNotFoundError struct {
 
 func NewVirtualMachineNetworkInterfaceNotFoundError(theError *katapult.ResponseError) *VirtualMachineNetworkInterfaceNotFoundError {
 	return &VirtualMachineNetworkInterfaceNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrVirtualMachineNetworkInterfaceNotFound,
 			"virtual_machine_network_interface_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1192,10 +1192,10 @@ type VirtualMachineNotFoundError struct {
 
 func NewVirtualMachineNotFoundError(theError *katapult.ResponseError) *VirtualMachineNotFoundError {
 	return &VirtualMachineNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrVirtualMachineNotFound,
 			"virtual_machine_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1208,10 +1208,10 @@ type VirtualMachinePackageNotFoundError struct {
 
 func NewVirtualMachinePackageNotFoundError(theError *katapult.ResponseError) *VirtualMachinePackageNotFoundError {
 	return &VirtualMachinePackageNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrVirtualMachinePackageNotFound,
 			"package_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1224,10 +1224,10 @@ type ZoneNotFoundError struct {
 
 func NewZoneNotFoundError(theError *katapult.ResponseError) *ZoneNotFoundError {
 	return &ZoneNotFoundError{
-		CommonError: katapult.NewCommonError(
+		CommonError: katapult.NewCommonErrorFromResponse(
 			ErrZoneNotFound,
 			"zone_not_found",
-			theError.Description,
+			theError,
 		),
 	}
 }
@@ -1,6 +1,9 @@
 package core
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/krystal/go-katapult"
@@ -11,3 +14,53 @@ func TestErr(t *testing.T) {
 	assert.EqualError(t, Err, "katapult: core")
 	assert.ErrorIs(t, Err, katapult.Err)
 }
+
+func TestCastResponseError(t *testing.T) {
+	respErr := katapult.NewResponseError(
+		http.StatusNotFound,
+		"virtual_machine_not_found",
+		"No virtual machine was found",
+		json.RawMessage(`{}`),
+	)
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "known response error",
+			err:  respErr,
+			want: NewVirtualMachineNotFoundError(respErr),
+		},
+		{
+			name: "wrapped response error",
+			err:  fmt.Errorf("wrapped: %w", respErr),
+			want: NewVirtualMachineNotFoundError(respErr),
+		},
+		{
+			name: "unknown response error",
+			err: katapult.NewResponseError(
+				http.StatusConflict, "not_a_thing", "", nil,
+			),
+			want: katapult.NewResponseError(
+				http.StatusConflict, "not_a_thing", "", nil,
+			),
+		},
+		{
+			name: "other error",
+			err:  katapult.ErrUnexpectedResponse,
+			want: katapult.ErrUnexpectedResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CastResponseError(tt.err))
+		})
+	}
+}
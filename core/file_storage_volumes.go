@@ -56,6 +56,7 @@ func (fsvc *FileStorageVolumesClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*FileStorageVolume, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/file_storage_volumes",
@@ -107,6 +108,7 @@ func (fsvc *FileStorageVolumesClient) Create(
 	args *FileStorageVolumeCreateArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*FileStorageVolume, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/file_storage_volumes"}
 	reqBody := &fileStorageVolumeCreateRequest{
 		Organization: org,
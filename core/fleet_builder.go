@@ -0,0 +1,244 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+// ErrVirtualMachineBuildFailed is returned for a fleet instance when its
+// virtual machine build finished in the failed state.
+var ErrVirtualMachineBuildFailed = fmt.Errorf(
+	"%w: virtual_machine_build_failed", Err,
+)
+
+// ErrFleetBuildFailed is returned by FleetBuild.Err when one or more
+// instances of a fleet could not be built.
+var ErrFleetBuildFailed = fmt.Errorf("%w: fleet_build_failed", Err)
+
+const (
+	// DefaultFleetConcurrency is the number of builds a FleetBuilder runs at
+	// once when its Concurrency is not set.
+	DefaultFleetConcurrency = 5
+
+	// DefaultFleetPollInterval is how often a FleetBuilder checks the state
+	// of builds when its PollInterval is not set.
+	DefaultFleetPollInterval = 5 * time.Second
+)
+
+// FleetBuildResult is the outcome of building a single virtual machine in a
+// fleet.
+type FleetBuildResult struct {
+	// Index is the 0-based position of the virtual machine in the fleet.
+	Index int
+
+	// Spec is the spec the virtual machine was built from.
+	Spec *buildspec.VirtualMachineSpec
+
+	// Build is the most recently fetched state of the build, or nil if the
+	// build could not be created.
+	Build *VirtualMachineBuild
+
+	// VirtualMachine is the virtual machine which was built, or nil if the
+	// build did not complete.
+	VirtualMachine *VirtualMachine
+
+	// Err is the reason the virtual machine could not be built, or nil.
+	Err error
+}
+
+// FleetBuild is the report returned by FleetBuilder.Build, with one result
+// per virtual machine in the fleet, in order.
+type FleetBuild struct {
+	Results []*FleetBuildResult
+}
+
+// Failed returns the results of all virtual machines which could not be
+// built.
+func (b *FleetBuild) Failed() []*FleetBuildResult {
+	var failed []*FleetBuildResult
+	for _, r := range b.Results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+
+	return failed
+}
+
+// Err returns a *FleetBuildError if any virtual machines could not be built,
+// and nil otherwise.
+func (b *FleetBuild) Err() error {
+	failed := b.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return &FleetBuildError{Failed: failed}
+}
+
+// FleetBuildError lists the virtual machines in a fleet which could not be
+// built. It matches ErrFleetBuildFailed with errors.Is, as well as the error
+// of each failed instance.
+type FleetBuildError struct {
+	Failed []*FleetBuildResult
+}
+
+func (e *FleetBuildError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		msgs = append(msgs, fmt.Sprintf("instance %d: %s", r.Index, r.Err))
+	}
+
+	return fmt.Sprintf(
+		"%s: %s", ErrFleetBuildFailed, strings.Join(msgs, "; "),
+	)
+}
+
+func (e *FleetBuildError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed)+1)
+	errs = append(errs, ErrFleetBuildFailed)
+	for _, r := range e.Failed {
+		errs = append(errs, r.Err)
+	}
+
+	return errs
+}
+
+// FleetBuilder builds every virtual machine described by a
+// buildspec.FleetSpec, submitting builds concurrently and waiting for each
+// of them to complete.
+type FleetBuilder struct {
+	// API is used to create and check virtual machine builds.
+	API *API
+
+	// Organization is the organization virtual machines are built in. When
+	// empty, the organization set on the context with
+	// ContextWithOrganization is used.
+	Organization OrganizationRef
+
+	// Concurrency is the maximum number of builds which are submitted and
+	// waited on at once. Defaults to DefaultFleetConcurrency.
+	Concurrency int
+
+	// PollInterval is how often the state of each build is checked while
+	// waiting for it. Defaults to DefaultFleetPollInterval.
+	PollInterval time.Duration
+}
+
+// NewFleetBuilder returns a new FleetBuilder which builds virtual machines
+// with api within org.
+func NewFleetBuilder(api *API, org OrganizationRef) *FleetBuilder {
+	return &FleetBuilder{API: api, Organization: org}
+}
+
+// Build expands fleet and builds each of its virtual machines, returning a
+// report of the outcome once all builds have completed, failed, or ctx is
+// done.
+//
+// Failed builds are not treated as errors, use the report's Err method to
+// check for them. An error is only returned when the fleet is invalid.
+func (b *FleetBuilder) Build(
+	ctx context.Context,
+	fleet *buildspec.FleetSpec,
+	reqOpts ...katapult.RequestOption,
+) (*FleetBuild, error) {
+	specs, err := fleet.Expand()
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := b.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFleetConcurrency
+	}
+	org := b.Organization.withDefault(ctx)
+
+	report := &FleetBuild{
+		Results: make([]*FleetBuildResult, len(specs)),
+	}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, spec := range specs {
+		r := &FleetBuildResult{Index: i, Spec: spec}
+		report.Results[i] = r
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+
+				return
+			}
+
+			b.build(ctx, org, r, reqOpts)
+		}()
+	}
+	wg.Wait()
+
+	return report, nil
+}
+
+// build creates the build for r.Spec and waits for it to finish, recording
+// the outcome in r.
+func (b *FleetBuilder) build(
+	ctx context.Context,
+	org OrganizationRef,
+	r *FleetBuildResult,
+	reqOpts []katapult.RequestOption,
+) {
+	r.Build, _, r.Err = b.API.VirtualMachineBuilds.CreateFromSpec(
+		ctx, org, r.Spec, reqOpts...,
+	)
+	if r.Err != nil {
+		return
+	}
+
+	interval := b.PollInterval
+	if interval <= 0 {
+		interval = DefaultFleetPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		switch r.Build.State {
+		case VirtualMachineBuildComplete:
+			r.VirtualMachine = r.Build.VirtualMachine
+
+			return
+		case VirtualMachineBuildFailed:
+			r.Err = ErrVirtualMachineBuildFailed
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			r.Err = ctx.Err()
+
+			return
+		case <-ticker.C:
+		}
+
+		build, _, err := b.API.VirtualMachineBuilds.Get(
+			ctx, r.Build.Ref(), reqOpts...,
+		)
+		if err != nil {
+			r.Err = err
+
+			return
+		}
+		r.Build = build
+	}
+}
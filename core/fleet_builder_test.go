@@ -0,0 +1,215 @@
+package core_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/core/corefake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFleetSpec(count int) *buildspec.FleetSpec {
+	return &buildspec.FleetSpec{
+		Base: &buildspec.VirtualMachineSpec{
+			Zone: &buildspec.Zone{Permalink: "uk-lon-01a"},
+			Resources: &buildspec.Resources{
+				Package: &buildspec.Package{Permalink: "rock-3"},
+			},
+		},
+		Count:           count,
+		HostnamePattern: "web-{index}",
+	}
+}
+
+// newFleetFake returns a fake API where builds complete after being fetched
+// once, except for builds of hostnames listed in failing, which fail.
+func newFleetFake(failing ...string) *corefake.Client {
+	fake := corefake.New()
+
+	fake.VirtualMachineBuilds.CreateFromSpecFunc = func(
+		_ context.Context,
+		_ core.OrganizationRef,
+		spec *buildspec.VirtualMachineSpec,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachineBuild, *katapult.Response, error) {
+		return &core.VirtualMachineBuild{
+			ID:    "vmbuild_" + spec.Hostname,
+			State: core.VirtualMachineBuildPending,
+		}, nil, nil
+	}
+	fake.VirtualMachineBuilds.GetFunc = func(
+		_ context.Context,
+		ref core.VirtualMachineBuildRef,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachineBuild, *katapult.Response, error) {
+		hostname := ref.ID[len("vmbuild_"):]
+		for _, h := range failing {
+			if h == hostname {
+				return &core.VirtualMachineBuild{
+					ID:    ref.ID,
+					State: core.VirtualMachineBuildFailed,
+				}, nil, nil
+			}
+		}
+
+		return &core.VirtualMachineBuild{
+			ID:    ref.ID,
+			State: core.VirtualMachineBuildComplete,
+			VirtualMachine: &core.VirtualMachine{
+				ID:       "vm_" + hostname,
+				Hostname: hostname,
+			},
+		}, nil, nil
+	}
+
+	return fake
+}
+
+func TestFleetBuilder_Build(t *testing.T) {
+	fake := newFleetFake()
+	b := core.NewFleetBuilder(fake.API(), core.OrganizationRef{ID: "org_1"})
+	b.PollInterval = time.Millisecond
+
+	report, err := b.Build(context.Background(), testFleetSpec(3))
+	require.NoError(t, err)
+
+	require.NoError(t, report.Err())
+	require.Len(t, report.Results, 3)
+	for i, r := range report.Results {
+		hostname := []string{"web-1", "web-2", "web-3"}[i]
+
+		assert.Equal(t, i, r.Index)
+		assert.Equal(t, hostname, r.Spec.Hostname)
+		assert.Equal(t, core.VirtualMachineBuildComplete, r.Build.State)
+		assert.Equal(t, "vm_"+hostname, r.VirtualMachine.ID)
+		assert.NoError(t, r.Err)
+	}
+
+	calls := fake.Recorder.CallsTo(
+		"VirtualMachineBuilds", "CreateFromSpec",
+	)
+	assert.Len(t, calls, 3)
+}
+
+func TestFleetBuilder_Build_failures(t *testing.T) {
+	fake := newFleetFake("web-2")
+	fake.VirtualMachineBuilds.CreateFromSpecFunc = func(
+		_ context.Context,
+		_ core.OrganizationRef,
+		spec *buildspec.VirtualMachineSpec,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachineBuild, *katapult.Response, error) {
+		if spec.Hostname == "web-3" {
+			return nil, nil, core.ErrPermissionDenied
+		}
+
+		return &core.VirtualMachineBuild{
+			ID:    "vmbuild_" + spec.Hostname,
+			State: core.VirtualMachineBuildPending,
+		}, nil, nil
+	}
+	b := core.NewFleetBuilder(fake.API(), core.OrganizationRef{ID: "org_1"})
+	b.PollInterval = time.Millisecond
+
+	report, err := b.Build(context.Background(), testFleetSpec(3))
+	require.NoError(t, err)
+
+	failed := report.Failed()
+	require.Len(t, failed, 2)
+	assert.Equal(t, 1, failed[0].Index)
+	assert.ErrorIs(t, failed[0].Err, core.ErrVirtualMachineBuildFailed)
+	assert.Nil(t, failed[0].VirtualMachine)
+	assert.Equal(t, 2, failed[1].Index)
+	assert.ErrorIs(t, failed[1].Err, core.ErrPermissionDenied)
+	assert.Nil(t, failed[1].Build)
+
+	err = report.Err()
+	assert.ErrorIs(t, err, core.ErrFleetBuildFailed)
+	assert.ErrorIs(t, err, core.ErrVirtualMachineBuildFailed)
+	assert.ErrorIs(t, err, core.ErrPermissionDenied)
+	assert.Contains(t, err.Error(), "instance 1: ")
+	assert.Contains(t, err.Error(), "instance 2: ")
+}
+
+func TestFleetBuilder_Build_concurrency(t *testing.T) {
+	fake := newFleetFake()
+
+	mu := sync.Mutex{}
+	running, peak := 0, 0
+	create := fake.VirtualMachineBuilds.CreateFromSpecFunc
+	fake.VirtualMachineBuilds.CreateFromSpecFunc = func(
+		ctx context.Context,
+		org core.OrganizationRef,
+		spec *buildspec.VirtualMachineSpec,
+		reqOpts ...katapult.RequestOption,
+	) (*core.VirtualMachineBuild, *katapult.Response, error) {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		return create(ctx, org, spec, reqOpts...)
+	}
+
+	b := core.NewFleetBuilder(fake.API(), core.OrganizationRef{ID: "org_1"})
+	b.Concurrency = 2
+	b.PollInterval = time.Millisecond
+
+	report, err := b.Build(context.Background(), testFleetSpec(6))
+	require.NoError(t, err)
+
+	assert.NoError(t, report.Err())
+	assert.LessOrEqual(t, peak, 2)
+}
+
+func TestFleetBuilder_Build_contextDone(t *testing.T) {
+	fake := newFleetFake()
+	fake.VirtualMachineBuilds.GetFunc = func(
+		_ context.Context,
+		ref core.VirtualMachineBuildRef,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachineBuild, *katapult.Response, error) {
+		return &core.VirtualMachineBuild{
+			ID:    ref.ID,
+			State: core.VirtualMachineBuildBuilding,
+		}, nil, nil
+	}
+	b := core.NewFleetBuilder(fake.API(), core.OrganizationRef{ID: "org_1"})
+	b.PollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), 20*time.Millisecond,
+	)
+	defer cancel()
+
+	report, err := b.Build(ctx, testFleetSpec(2))
+	require.NoError(t, err)
+
+	for _, r := range report.Results {
+		assert.ErrorIs(t, r.Err, context.DeadlineExceeded)
+		assert.Equal(t, core.VirtualMachineBuildBuilding, r.Build.State)
+	}
+}
+
+func TestFleetBuilder_Build_invalidFleet(t *testing.T) {
+	b := core.NewFleetBuilder(corefake.New().API(), core.OrganizationRef{})
+
+	report, err := b.Build(context.Background(), &buildspec.FleetSpec{})
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, buildspec.ErrValidation)
+}
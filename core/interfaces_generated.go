@@ -0,0 +1,325 @@
+package core
+
+import (
+	"context"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/namegenerator"
+)
+
+// Code generated by github.com/krystal/go-katapult/tools/fakegen. DO NOT EDIT.
+
+// CertificatesAPI is the interface satisfied by *CertificatesClient.
+type CertificatesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*Certificate, *katapult.Response, error)
+	Get(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*Certificate, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*Certificate, *katapult.Response, error)
+}
+
+// DNSZonesAPI is the interface satisfied by *DNSZonesClient.
+type DNSZonesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*DNSZone, *katapult.Response, error)
+	Nameservers(ctx context.Context, org OrganizationRef, reqOpts ...katapult.RequestOption) ([]string, *katapult.Response, error)
+	Get(ctx context.Context, ref DNSZoneRef, reqOpts ...katapult.RequestOption) (*DNSZone, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*DNSZone, *katapult.Response, error)
+	GetByName(ctx context.Context, name string, reqOpts ...katapult.RequestOption) (*DNSZone, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *DNSZoneCreateArguments, reqOpts ...katapult.RequestOption) (*DNSZone, *katapult.Response, error)
+	Update(ctx context.Context, zone DNSZoneRef, args *DNSZoneUpdateArguments, reqOpts ...katapult.RequestOption) (*DNSZone, *katapult.Response, error)
+	Delete(ctx context.Context, zone DNSZoneRef, reqOpts ...katapult.RequestOption) (*bool, *katapult.Response, error)
+	Verify(ctx context.Context, ref DNSZoneRef, reqOpts ...katapult.RequestOption) (*DNSZone, *katapult.Response, error)
+}
+
+// DataCentersAPI is the interface satisfied by *DataCentersClient.
+type DataCentersAPI interface {
+	List(ctx context.Context, reqOpts ...katapult.RequestOption) ([]*DataCenter, *katapult.Response, error)
+	Get(ctx context.Context, ref DataCenterRef, reqOpts ...katapult.RequestOption) (*DataCenter, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*DataCenter, *katapult.Response, error)
+	GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*DataCenter, *katapult.Response, error)
+	DefaultNetwork(ctx context.Context, ref DataCenterRef, reqOpts ...katapult.RequestOption) (*Network, *katapult.Response, error)
+}
+
+// DiskBackupPoliciesAPI is the interface satisfied by *DiskBackupPoliciesClient.
+type DiskBackupPoliciesAPI interface {
+	ListForVirtualMachine(ctx context.Context, vm VirtualMachineRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*DiskBackupPolicy, *katapult.Response, error)
+	ListForDisk(ctx context.Context, disk DiskRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*DiskBackupPolicy, *katapult.Response, error)
+	Get(ctx context.Context, ref DiskBackupPolicyRef, reqOpts ...katapult.RequestOption) (*DiskBackupPolicy, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*DiskBackupPolicy, *katapult.Response, error)
+}
+
+// DiskTemplatesAPI is the interface satisfied by *DiskTemplatesClient.
+type DiskTemplatesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *DiskTemplateListOptions, reqOpts ...katapult.RequestOption) ([]*DiskTemplate, *katapult.Response, error)
+	Get(ctx context.Context, ref DiskTemplateRef, reqOpts ...katapult.RequestOption) (*DiskTemplate, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*DiskTemplate, *katapult.Response, error)
+	GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*DiskTemplate, *katapult.Response, error)
+}
+
+// DisksAPI is the interface satisfied by *DisksClient.
+type DisksAPI interface {
+	ListForVirtualMachine(ctx context.Context, vm VirtualMachineRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*VirtualMachineDisk, *katapult.Response, error)
+	Get(ctx context.Context, ref DiskRef, reqOpts ...katapult.RequestOption) (*Disk, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*Disk, *katapult.Response, error)
+}
+
+// FileStorageVolumesAPI is the interface satisfied by *FileStorageVolumesClient.
+type FileStorageVolumesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*FileStorageVolume, *katapult.Response, error)
+	Get(ctx context.Context, ref FileStorageVolumeRef, reqOpts ...katapult.RequestOption) (*FileStorageVolume, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*FileStorageVolume, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *FileStorageVolumeCreateArguments, reqOpts ...katapult.RequestOption) (*FileStorageVolume, *katapult.Response, error)
+	Update(ctx context.Context, ref FileStorageVolumeRef, args *FileStorageVolumeUpdateArguments, reqOpts ...katapult.RequestOption) (*FileStorageVolume, *katapult.Response, error)
+	Delete(ctx context.Context, ref FileStorageVolumeRef, reqOpts ...katapult.RequestOption) (*FileStorageVolume, *TrashObject, *katapult.Response, error)
+}
+
+// IPAddressesAPI is the interface satisfied by *IPAddressesClient.
+type IPAddressesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*IPAddress, *katapult.Response, error)
+	Get(ctx context.Context, ref IPAddressRef, reqOpts ...katapult.RequestOption) (*IPAddress, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*IPAddress, *katapult.Response, error)
+	GetByAddress(ctx context.Context, address string, reqOpts ...katapult.RequestOption) (*IPAddress, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *IPAddressCreateArguments, reqOpts ...katapult.RequestOption) (*IPAddress, *katapult.Response, error)
+	Update(ctx context.Context, ip IPAddressRef, args *IPAddressUpdateArguments, reqOpts ...katapult.RequestOption) (*IPAddress, *katapult.Response, error)
+	Delete(ctx context.Context, ip IPAddressRef, reqOpts ...katapult.RequestOption) (*katapult.Response, error)
+	Unallocate(ctx context.Context, ip IPAddressRef, reqOpts ...katapult.RequestOption) (*katapult.Response, error)
+}
+
+// LoadBalancersAPI is the interface satisfied by *LoadBalancersClient.
+type LoadBalancersAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*LoadBalancer, *katapult.Response, error)
+	Get(ctx context.Context, ref LoadBalancerRef, reqOpts ...katapult.RequestOption) (*LoadBalancer, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*LoadBalancer, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *LoadBalancerCreateArguments, reqOpts ...katapult.RequestOption) (*LoadBalancer, *katapult.Response, error)
+	Update(ctx context.Context, lb LoadBalancerRef, args *LoadBalancerUpdateArguments, reqOpts ...katapult.RequestOption) (*LoadBalancer, *katapult.Response, error)
+	Delete(ctx context.Context, lb LoadBalancerRef, reqOpts ...katapult.RequestOption) (*LoadBalancer, *katapult.Response, error)
+}
+
+// LoadBalancerRulesAPI is the interface satisfied by *LoadBalancerRulesClient.
+type LoadBalancerRulesAPI interface {
+	List(ctx context.Context, lb LoadBalancerRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*LoadBalancerRule, *katapult.Response, error)
+	Get(ctx context.Context, ref LoadBalancerRuleRef, reqOpts ...katapult.RequestOption) (*LoadBalancerRule, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*LoadBalancerRule, *katapult.Response, error)
+	Create(ctx context.Context, lb LoadBalancerRef, args *LoadBalancerRuleArguments, reqOpts ...katapult.RequestOption) (*LoadBalancerRule, *katapult.Response, error)
+	Update(ctx context.Context, ref LoadBalancerRuleRef, args *LoadBalancerRuleArguments, reqOpts ...katapult.RequestOption) (*LoadBalancerRule, *katapult.Response, error)
+	Delete(ctx context.Context, ref LoadBalancerRuleRef, reqOpts ...katapult.RequestOption) (*LoadBalancerRule, *katapult.Response, error)
+}
+
+// NetworkSpeedProfilesAPI is the interface satisfied by *NetworkSpeedProfilesClient.
+type NetworkSpeedProfilesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*NetworkSpeedProfile, *katapult.Response, error)
+}
+
+// NetworksAPI is the interface satisfied by *NetworksClient.
+type NetworksAPI interface {
+	List(ctx context.Context, org OrganizationRef, reqOpts ...katapult.RequestOption) ([]*Network, []*VirtualNetwork, *katapult.Response, error)
+	Get(ctx context.Context, ref NetworkRef, reqOpts ...katapult.RequestOption) (*Network, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*Network, *katapult.Response, error)
+	GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*Network, *katapult.Response, error)
+}
+
+// OrganizationsAPI is the interface satisfied by *OrganizationsClient.
+type OrganizationsAPI interface {
+	List(ctx context.Context, reqOpts ...katapult.RequestOption) ([]*Organization, *katapult.Response, error)
+	Get(ctx context.Context, ref OrganizationRef, reqOpts ...katapult.RequestOption) (*Organization, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*Organization, *katapult.Response, error)
+	GetBySubDomain(ctx context.Context, subDomain string, reqOpts ...katapult.RequestOption) (*Organization, *katapult.Response, error)
+	CreateManaged(ctx context.Context, parent OrganizationRef, args *OrganizationManagedArguments, reqOpts ...katapult.RequestOption) (*Organization, *katapult.Response, error)
+}
+
+// SecurityGroupsAPI is the interface satisfied by *SecurityGroupsClient.
+type SecurityGroupsAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*SecurityGroup, *katapult.Response, error)
+	Get(ctx context.Context, ref SecurityGroupRef, reqOpts ...katapult.RequestOption) (*SecurityGroup, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*SecurityGroup, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *SecurityGroupCreateArguments, reqOpts ...katapult.RequestOption) (*SecurityGroup, *katapult.Response, error)
+	Update(ctx context.Context, sg SecurityGroupRef, args *SecurityGroupUpdateArguments, reqOpts ...katapult.RequestOption) (*SecurityGroup, *katapult.Response, error)
+	Delete(ctx context.Context, sg SecurityGroupRef, reqOpts ...katapult.RequestOption) (*SecurityGroup, *katapult.Response, error)
+}
+
+// SecurityGroupRulesAPI is the interface satisfied by *SecurityGroupRulesClient.
+type SecurityGroupRulesAPI interface {
+	List(ctx context.Context, sg SecurityGroupRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*SecurityGroupRule, *katapult.Response, error)
+	Get(ctx context.Context, ref SecurityGroupRuleRef, reqOpts ...katapult.RequestOption) (*SecurityGroupRule, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*SecurityGroupRule, *katapult.Response, error)
+	Create(ctx context.Context, sg SecurityGroupRef, args *SecurityGroupRuleArguments, reqOpts ...katapult.RequestOption) (*SecurityGroupRule, *katapult.Response, error)
+	Update(ctx context.Context, ref SecurityGroupRuleRef, args *SecurityGroupRuleArguments, reqOpts ...katapult.RequestOption) (*SecurityGroupRule, *katapult.Response, error)
+	Delete(ctx context.Context, ref SecurityGroupRuleRef, reqOpts ...katapult.RequestOption) (*SecurityGroupRule, *katapult.Response, error)
+}
+
+// TagsAPI is the interface satisfied by *TagsClient.
+type TagsAPI interface {
+	List(ctx context.Context, ref OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*Tag, *katapult.Response, error)
+	Get(ctx context.Context, ref TagRef, reqOpts ...katapult.RequestOption) (*Tag, *katapult.Response, error)
+	Create(ctx context.Context, ref OrganizationRef, args TagArguments, reqOpts ...katapult.RequestOption) (*Tag, *katapult.Response, error)
+	Update(ctx context.Context, ref TagRef, args TagArguments, reqOpts ...katapult.RequestOption) (*Tag, *katapult.Response, error)
+	Delete(ctx context.Context, ref TagRef, reqOpts ...katapult.RequestOption) (*Tag, *katapult.Response, error)
+}
+
+// SSHKeysAPI is the interface satisfied by *SSHKeysClient.
+type SSHKeysAPI interface {
+	List(ctx context.Context, ref OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*AuthSSHKey, *katapult.Response, error)
+	Add(ctx context.Context, ref OrganizationRef, properties AuthSSHKeyProperties, reqOpts ...katapult.RequestOption) (*AuthSSHKey, *katapult.Response, error)
+	Delete(ctx context.Context, ref SSHKeyRef, reqOpts ...katapult.RequestOption) (*AuthSSHKey, *katapult.Response, error)
+}
+
+// TasksAPI is the interface satisfied by *TasksClient.
+type TasksAPI interface {
+	Get(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+}
+
+// TrashObjectsAPI is the interface satisfied by *TrashObjectsClient.
+type TrashObjectsAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*TrashObject, *katapult.Response, error)
+	Get(ctx context.Context, ref TrashObjectRef, reqOpts ...katapult.RequestOption) (*TrashObject, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*TrashObject, *katapult.Response, error)
+	GetByObjectID(ctx context.Context, objectID string, reqOpts ...katapult.RequestOption) (*TrashObject, *katapult.Response, error)
+	Purge(ctx context.Context, ref TrashObjectRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+	PurgeAll(ctx context.Context, org OrganizationRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+	Restore(ctx context.Context, ref TrashObjectRef, reqOpts ...katapult.RequestOption) (*TrashObject, *katapult.Response, error)
+}
+
+// VirtualMachineBuildsAPI is the interface satisfied by *VirtualMachineBuildsClient.
+type VirtualMachineBuildsAPI interface {
+	Get(ctx context.Context, ref VirtualMachineBuildRef, reqOpts ...katapult.RequestOption) (*VirtualMachineBuild, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*VirtualMachineBuild, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *VirtualMachineBuildArguments, reqOpts ...katapult.RequestOption) (*VirtualMachineBuild, *katapult.Response, error)
+	CreateFromSpec(ctx context.Context, org OrganizationRef, spec *buildspec.VirtualMachineSpec, reqOpts ...katapult.RequestOption) (*VirtualMachineBuild, *katapult.Response, error)
+	CreateFromSpecXML(ctx context.Context, org OrganizationRef, specXML string, reqOpts ...katapult.RequestOption) (*VirtualMachineBuild, *katapult.Response, error)
+}
+
+// VirtualMachineGroupsAPI is the interface satisfied by *VirtualMachineGroupsClient.
+type VirtualMachineGroupsAPI interface {
+	List(ctx context.Context, org OrganizationRef, reqOpts ...katapult.RequestOption) ([]*VirtualMachineGroup, *katapult.Response, error)
+	Get(ctx context.Context, ref VirtualMachineGroupRef, reqOpts ...katapult.RequestOption) (*VirtualMachineGroup, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*VirtualMachineGroup, *katapult.Response, error)
+	Create(ctx context.Context, org OrganizationRef, args *VirtualMachineGroupCreateArguments, reqOpts ...katapult.RequestOption) (*VirtualMachineGroup, *katapult.Response, error)
+	Update(ctx context.Context, ref VirtualMachineGroupRef, args *VirtualMachineGroupUpdateArguments, reqOpts ...katapult.RequestOption) (*VirtualMachineGroup, *katapult.Response, error)
+	Delete(ctx context.Context, group VirtualMachineGroupRef, reqOpts ...katapult.RequestOption) (*katapult.Response, error)
+}
+
+// VirtualMachineNetworkInterfacesAPI is the interface satisfied by *VirtualMachineNetworkInterfacesClient.
+type VirtualMachineNetworkInterfacesAPI interface {
+	List(ctx context.Context, vm VirtualMachineRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*VirtualMachineNetworkInterface, *katapult.Response, error)
+	Get(ctx context.Context, ref VirtualMachineNetworkInterfaceRef, reqOpts ...katapult.RequestOption) (*VirtualMachineNetworkInterface, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*VirtualMachineNetworkInterface, *katapult.Response, error)
+	AvailableIPs(ctx context.Context, vmnet *VirtualMachineNetworkInterface, ipVer IPVersion, reqOpts ...katapult.RequestOption) ([]*IPAddress, *katapult.Response, error)
+	AllocateIP(ctx context.Context, vmnet VirtualMachineNetworkInterfaceRef, ip IPAddressRef, reqOpts ...katapult.RequestOption) (*VirtualMachineNetworkInterface, *katapult.Response, error)
+	AllocateNewIP(ctx context.Context, vmnet VirtualMachineNetworkInterfaceRef, ipVer IPVersion, reqOpts ...katapult.RequestOption) (*IPAddress, *katapult.Response, error)
+	UpdateSpeedProfile(ctx context.Context, vmnet VirtualMachineNetworkInterfaceRef, speedProfile NetworkSpeedProfileRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+}
+
+// VirtualMachinePackagesAPI is the interface satisfied by *VirtualMachinePackagesClient.
+type VirtualMachinePackagesAPI interface {
+	List(ctx context.Context, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*VirtualMachinePackage, *katapult.Response, error)
+	Get(ctx context.Context, ref VirtualMachinePackageRef, reqOpts ...katapult.RequestOption) (*VirtualMachinePackage, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*VirtualMachinePackage, *katapult.Response, error)
+	GetByPermalink(ctx context.Context, permalink string, reqOpts ...katapult.RequestOption) (*VirtualMachinePackage, *katapult.Response, error)
+}
+
+// VirtualMachinesAPI is the interface satisfied by *VirtualMachinesClient.
+type VirtualMachinesAPI interface {
+	List(ctx context.Context, org OrganizationRef, opts *ListOptions, reqOpts ...katapult.RequestOption) ([]*VirtualMachine, *katapult.Response, error)
+	Hostnames(ctx context.Context, org OrganizationRef, reqOpts ...katapult.RequestOption) (namegenerator.HostnameSet, error)
+	UniqueHostname(ctx context.Context, org OrganizationRef, gen *namegenerator.NameGenerator, reqOpts ...katapult.RequestOption) (string, error)
+	Get(ctx context.Context, ref VirtualMachineRef, reqOpts ...katapult.RequestOption) (*VirtualMachine, *katapult.Response, error)
+	GetByID(ctx context.Context, id string, reqOpts ...katapult.RequestOption) (*VirtualMachine, *katapult.Response, error)
+	GetByFQDN(ctx context.Context, fqdn string, reqOpts ...katapult.RequestOption) (*VirtualMachine, *katapult.Response, error)
+	ChangePackage(ctx context.Context, ref VirtualMachineRef, pkg VirtualMachinePackageRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+	Update(ctx context.Context, ref VirtualMachineRef, args *VirtualMachineUpdateArguments, reqOpts ...katapult.RequestOption) (*VirtualMachine, *katapult.Response, error)
+	Delete(ctx context.Context, ref VirtualMachineRef, reqOpts ...katapult.RequestOption) (*TrashObject, *katapult.Response, error)
+	Start(ctx context.Context, ref VirtualMachineRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+	Stop(ctx context.Context, ref VirtualMachineRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+	Shutdown(ctx context.Context, ref VirtualMachineRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+	Reset(ctx context.Context, ref VirtualMachineRef, reqOpts ...katapult.RequestOption) (*Task, *katapult.Response, error)
+}
+
+var (
+	_ CertificatesAPI                    = (*CertificatesClient)(nil)
+	_ DNSZonesAPI                        = (*DNSZonesClient)(nil)
+	_ DataCentersAPI                     = (*DataCentersClient)(nil)
+	_ DiskBackupPoliciesAPI              = (*DiskBackupPoliciesClient)(nil)
+	_ DiskTemplatesAPI                   = (*DiskTemplatesClient)(nil)
+	_ DisksAPI                           = (*DisksClient)(nil)
+	_ FileStorageVolumesAPI              = (*FileStorageVolumesClient)(nil)
+	_ IPAddressesAPI                     = (*IPAddressesClient)(nil)
+	_ LoadBalancersAPI                   = (*LoadBalancersClient)(nil)
+	_ LoadBalancerRulesAPI               = (*LoadBalancerRulesClient)(nil)
+	_ NetworkSpeedProfilesAPI            = (*NetworkSpeedProfilesClient)(nil)
+	_ NetworksAPI                        = (*NetworksClient)(nil)
+	_ OrganizationsAPI                   = (*OrganizationsClient)(nil)
+	_ SecurityGroupsAPI                  = (*SecurityGroupsClient)(nil)
+	_ SecurityGroupRulesAPI              = (*SecurityGroupRulesClient)(nil)
+	_ TagsAPI                            = (*TagsClient)(nil)
+	_ SSHKeysAPI                         = (*SSHKeysClient)(nil)
+	_ TasksAPI                           = (*TasksClient)(nil)
+	_ TrashObjectsAPI                    = (*TrashObjectsClient)(nil)
+	_ VirtualMachineBuildsAPI            = (*VirtualMachineBuildsClient)(nil)
+	_ VirtualMachineGroupsAPI            = (*VirtualMachineGroupsClient)(nil)
+	_ VirtualMachineNetworkInterfacesAPI = (*VirtualMachineNetworkInterfacesClient)(nil)
+	_ VirtualMachinePackagesAPI          = (*VirtualMachinePackagesClient)(nil)
+	_ VirtualMachinesAPI                 = (*VirtualMachinesClient)(nil)
+)
+
+// API is an interface-typed equivalent of Client, allowing each resource
+// client to be replaced, for example with a fake in tests.
+type API struct {
+	Certificates                    CertificatesAPI
+	DNSZones                        DNSZonesAPI
+	DataCenters                     DataCentersAPI
+	DiskBackupPolicies              DiskBackupPoliciesAPI
+	DiskTemplates                   DiskTemplatesAPI
+	Disks                           DisksAPI
+	FileStorageVolumes              FileStorageVolumesAPI
+	IPAddresses                     IPAddressesAPI
+	LoadBalancers                   LoadBalancersAPI
+	LoadBalancerRules               LoadBalancerRulesAPI
+	NetworkSpeedProfiles            NetworkSpeedProfilesAPI
+	Networks                        NetworksAPI
+	Organizations                   OrganizationsAPI
+	SecurityGroups                  SecurityGroupsAPI
+	SecurityGroupRules              SecurityGroupRulesAPI
+	Tags                            TagsAPI
+	SSHKeys                         SSHKeysAPI
+	Tasks                           TasksAPI
+	TrashObjects                    TrashObjectsAPI
+	VirtualMachineBuilds            VirtualMachineBuildsAPI
+	VirtualMachineGroups            VirtualMachineGroupsAPI
+	VirtualMachineNetworkInterfaces VirtualMachineNetworkInterfacesAPI
+	VirtualMachinePackages          VirtualMachinePackagesAPI
+	VirtualMachines                 VirtualMachinesAPI
+}
+
+// NewAPI returns an API backed by the standard resource clients.
+func NewAPI(rm RequestMaker) *API {
+	return New(rm).API()
+}
+
+// API returns an API which uses the resource clients of c.
+func (c *Client) API() *API {
+	return &API{
+		Certificates:                    c.Certificates,
+		DNSZones:                        c.DNSZones,
+		DataCenters:                     c.DataCenters,
+		DiskBackupPolicies:              c.DiskBackupPolicies,
+		DiskTemplates:                   c.DiskTemplates,
+		Disks:                           c.Disks,
+		FileStorageVolumes:              c.FileStorageVolumes,
+		IPAddresses:                     c.IPAddresses,
+		LoadBalancers:                   c.LoadBalancers,
+		LoadBalancerRules:               c.LoadBalancerRules,
+		NetworkSpeedProfiles:            c.NetworkSpeedProfiles,
+		Networks:                        c.Networks,
+		Organizations:                   c.Organizations,
+		SecurityGroups:                  c.SecurityGroups,
+		SecurityGroupRules:              c.SecurityGroupRules,
+		Tags:                            c.Tags,
+		SSHKeys:                         c.SSHKeys,
+		Tasks:                           c.Tasks,
+		TrashObjects:                    c.TrashObjects,
+		VirtualMachineBuilds:            c.VirtualMachineBuilds,
+		VirtualMachineGroups:            c.VirtualMachineGroups,
+		VirtualMachineNetworkInterfaces: c.VirtualMachineNetworkInterfaces,
+		VirtualMachinePackages:          c.VirtualMachinePackages,
+		VirtualMachines:                 c.VirtualMachines,
+	}
+}
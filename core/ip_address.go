@@ -102,6 +102,7 @@ func (s *IPAddressesClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*IPAddress, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/ip_addresses",
@@ -151,6 +152,7 @@ func (s *IPAddressesClient) Create(
 	args *IPAddressCreateArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*IPAddress, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/ip_addresses"}
 	reqBody := &ipAddressCreateRequest{
 		Organization: org,
@@ -84,6 +84,7 @@ func (s *LoadBalancersClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*LoadBalancer, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/load_balancers",
@@ -125,6 +126,7 @@ func (s *LoadBalancersClient) Create(
 	args *LoadBalancerCreateArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*LoadBalancer, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/load_balancers"}
 	reqBody := &loadBalancerCreateRequest{
 		Organization: org,
@@ -65,6 +65,7 @@ func (s *NetworksClient) List(
 	org OrganizationRef,
 	reqOpts ...katapult.RequestOption,
 ) ([]*Network, []*VirtualNetwork, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{
 		Path:     "organizations/_/available_networks",
 		RawQuery: org.queryValues().Encode(),
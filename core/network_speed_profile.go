@@ -49,6 +49,7 @@ func (s *NetworkSpeedProfilesClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*NetworkSpeedProfile, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/network_speed_profiles",
@@ -94,6 +94,7 @@ func (s *OrganizationsClient) Get(
 	ref OrganizationRef,
 	reqOpts ...katapult.RequestOption,
 ) (*Organization, *katapult.Response, error) {
+	ref = ref.withDefault(ctx)
 	qs := ref.queryValues()
 	u := &url.URL{Path: "organizations/_", RawQuery: qs.Encode()}
 
@@ -124,6 +125,7 @@ func (s *OrganizationsClient) CreateManaged(
 	args *OrganizationManagedArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*Organization, *katapult.Response, error) {
+	parent = parent.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/managed"}
 	reqBody := &organizationCreateManagedRequest{
 		Organization: parent,
@@ -78,6 +78,7 @@ func (sgc *SecurityGroupsClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*SecurityGroup, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/security_groups",
@@ -118,6 +119,7 @@ func (sgc *SecurityGroupsClient) Create(
 	args *SecurityGroupCreateArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*SecurityGroup, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/security_groups"}
 	reqBody := &securityGroupCreateRequest{
 		Organization: org,
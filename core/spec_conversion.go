@@ -0,0 +1,211 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+// ErrUnrepresentableFields is returned when converting between
+// VirtualMachineBuildArguments and build specs would lose some fields.
+var ErrUnrepresentableFields = fmt.Errorf(
+	"%w: unrepresentable_fields", Err,
+)
+
+// ConversionError lists the fields which could not be represented when
+// converting between VirtualMachineBuildArguments and a build spec.
+type ConversionError struct {
+	// Fields are the paths of the fields which were dropped, using the
+	// field names of the source's JSON representation, such as
+	// "system_disks" or "network_interfaces[0].speed_profile".
+	Fields []string
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("%s: %s",
+		ErrUnrepresentableFields, strings.Join(e.Fields, ", "),
+	)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return ErrUnrepresentableFields
+}
+
+// conversion collects the paths of fields dropped during a conversion.
+type conversion struct {
+	fields []string
+}
+
+func (c *conversion) drop(field string, set bool) {
+	if set {
+		c.fields = append(c.fields, field)
+	}
+}
+
+func (c *conversion) err() error {
+	if len(c.fields) == 0 {
+		return nil
+	}
+
+	return &ConversionError{Fields: c.fields}
+}
+
+// Spec returns a build spec describing the same virtual machine as the
+// arguments, for use with VirtualMachineBuildsClient.CreateFromSpec.
+//
+// The network is represented as a single network interface. Disk template
+// options can only be represented alongside a disk template, so when
+// DiskTemplate is nil they are dropped, and the spec is returned along with
+// a *ConversionError naming them.
+func (a *VirtualMachineBuildArguments) Spec() (
+	*buildspec.VirtualMachineSpec,
+	error,
+) {
+	c := &conversion{}
+	spec := &buildspec.VirtualMachineSpec{Hostname: a.Hostname}
+
+	if a.Zone != nil {
+		spec.Zone = &buildspec.Zone{
+			ID:        a.Zone.ID,
+			Permalink: a.Zone.Permalink,
+		}
+	}
+	if a.DataCenter != nil {
+		spec.DataCenter = &buildspec.DataCenter{
+			ID:        a.DataCenter.ID,
+			Permalink: a.DataCenter.Permalink,
+		}
+	}
+	if a.Package != (VirtualMachinePackageRef{}) {
+		spec.Resources = &buildspec.Resources{
+			Package: &buildspec.Package{
+				ID:        a.Package.ID,
+				Permalink: a.Package.Permalink,
+			},
+		}
+	}
+
+	if a.DiskTemplate != nil {
+		spec.DiskTemplate = &buildspec.DiskTemplate{
+			ID:        a.DiskTemplate.ID,
+			Permalink: a.DiskTemplate.Permalink,
+		}
+		for _, o := range a.DiskTemplateOptions {
+			spec.DiskTemplate.Options = append(spec.DiskTemplate.Options,
+				&buildspec.DiskTemplateOption{Key: o.Key, Value: o.Value},
+			)
+		}
+	} else {
+		c.drop("disk_template_options", len(a.DiskTemplateOptions) > 0)
+	}
+
+	if a.Network != nil {
+		spec.NetworkInterfaces = []*buildspec.NetworkInterface{
+			{
+				Network: &buildspec.Network{
+					ID:        a.Network.ID,
+					Permalink: a.Network.Permalink,
+				},
+			},
+		}
+	}
+
+	return spec, c.err()
+}
+
+// VirtualMachineBuildArgumentsFromSpec returns arguments for
+// VirtualMachineBuildsClient.Create describing the same virtual machine as
+// spec.
+//
+// Build arguments only cover a subset of build specs: a zone or data
+// center, a package, a disk template and its options, the network of a
+// single network interface, and a hostname. When spec uses anything else,
+// such as system disks, tags or custom resources, the representable fields
+// are still returned, along with a *ConversionError naming the fields which
+// were dropped. Tools accepting either input can use this to decide between
+// Create and CreateFromSpec:
+//
+//	args, err := core.VirtualMachineBuildArgumentsFromSpec(spec)
+//	if errors.Is(err, core.ErrUnrepresentableFields) {
+//		build, _, err = client.CreateFromSpec(ctx, org, spec)
+//	} else {
+//		build, _, err = client.Create(ctx, org, args)
+//	}
+func VirtualMachineBuildArgumentsFromSpec(
+	spec *buildspec.VirtualMachineSpec,
+) (*VirtualMachineBuildArguments, error) {
+	c := &conversion{}
+	args := &VirtualMachineBuildArguments{Hostname: spec.Hostname}
+
+	if z := spec.Zone; z != nil {
+		args.Zone = &ZoneRef{ID: z.ID, Permalink: z.Permalink}
+	}
+	if dc := spec.DataCenter; dc != nil {
+		if dc.ID != "" || dc.Permalink != "" {
+			args.DataCenter = &DataCenterRef{
+				ID:        dc.ID,
+				Permalink: dc.Permalink,
+			}
+		}
+		c.drop("data_center.name", dc.ID == "" && dc.Name != "")
+	}
+
+	if r := spec.Resources; r != nil {
+		if r.Package != nil {
+			args.Package = VirtualMachinePackageRef{
+				ID:        r.Package.ID,
+				Permalink: r.Package.Permalink,
+			}
+		}
+		c.drop("resources.memory", r.Memory != 0)
+		c.drop("resources.cpu_cores", r.CPUCores != 0)
+	}
+
+	if dt := spec.DiskTemplate; dt != nil {
+		args.DiskTemplate = &DiskTemplateRef{
+			ID:        dt.ID,
+			Permalink: dt.Permalink,
+		}
+		c.drop("disk_template.version", dt.Version != 0)
+		for _, o := range dt.Options {
+			args.DiskTemplateOptions = append(args.DiskTemplateOptions,
+				&DiskTemplateOption{Key: o.Key, Value: o.Value},
+			)
+		}
+	}
+
+	c.drop("system_disks", len(spec.SystemDisks) > 0)
+	c.drop("shared_disks", len(spec.SharedDisks) > 0)
+
+	for i, nic := range spec.NetworkInterfaces {
+		field := fmt.Sprintf("network_interfaces[%d]", i)
+		if i > 0 {
+			c.drop(field, true)
+
+			continue
+		}
+
+		if nic.Network != nil {
+			args.Network = &NetworkRef{
+				ID:        nic.Network.ID,
+				Permalink: nic.Network.Permalink,
+			}
+		}
+		c.drop(field+".virtual_network", nic.VirtualNetwork != nil)
+		c.drop(field+".speed_profile", nic.SpeedProfile != nil)
+		c.drop(field+".ip_address_allocations",
+			len(nic.IPAddressAllocations) > 0,
+		)
+	}
+
+	c.drop("name", spec.Name != "")
+	c.drop("description", spec.Description != "")
+	c.drop("group", spec.Group != nil)
+	c.drop("authorized_keys", spec.AuthorizedKeys != nil)
+	c.drop("backup_policies", len(spec.BackupPolicies) > 0)
+	c.drop("tags", len(spec.Tags) > 0)
+	c.drop("iso", spec.ISO != "")
+
+	return args, c.err()
+}
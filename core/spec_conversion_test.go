@@ -0,0 +1,259 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtualMachineBuildArguments_Spec(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       *VirtualMachineBuildArguments
+		want       *buildspec.VirtualMachineSpec
+		wantFields []string
+	}{
+		{
+			name: "empty",
+			args: &VirtualMachineBuildArguments{},
+			want: &buildspec.VirtualMachineSpec{},
+		},
+		{
+			name: "full",
+			args: &VirtualMachineBuildArguments{
+				Zone:         &ZoneRef{Permalink: "uk-lon-01a"},
+				DataCenter:   &DataCenterRef{ID: "dc_1"},
+				Package:      VirtualMachinePackageRef{Permalink: "rock-3"},
+				DiskTemplate: &DiskTemplateRef{ID: "dtpl_1"},
+				DiskTemplateOptions: []*DiskTemplateOption{
+					{Key: "timezone", Value: "Europe/London"},
+					{Key: "locale", Value: "en_GB"},
+				},
+				Network:  &NetworkRef{Permalink: "public"},
+				Hostname: "web-1",
+			},
+			want: &buildspec.VirtualMachineSpec{
+				Zone:       &buildspec.Zone{Permalink: "uk-lon-01a"},
+				DataCenter: &buildspec.DataCenter{ID: "dc_1"},
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{Permalink: "rock-3"},
+				},
+				DiskTemplate: &buildspec.DiskTemplate{
+					ID: "dtpl_1",
+					Options: []*buildspec.DiskTemplateOption{
+						{Key: "timezone", Value: "Europe/London"},
+						{Key: "locale", Value: "en_GB"},
+					},
+				},
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{Network: &buildspec.Network{Permalink: "public"}},
+				},
+				Hostname: "web-1",
+			},
+		},
+		{
+			name: "options without disk template",
+			args: &VirtualMachineBuildArguments{
+				Package: VirtualMachinePackageRef{ID: "vmpkg_1"},
+				DiskTemplateOptions: []*DiskTemplateOption{
+					{Key: "timezone", Value: "Europe/London"},
+				},
+			},
+			want: &buildspec.VirtualMachineSpec{
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{ID: "vmpkg_1"},
+				},
+			},
+			wantFields: []string{"disk_template_options"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.args.Spec()
+
+			assert.Equal(t, tt.want, got)
+			assertConversionFields(t, tt.wantFields, err)
+		})
+	}
+}
+
+func TestVirtualMachineBuildArgumentsFromSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       *buildspec.VirtualMachineSpec
+		want       *VirtualMachineBuildArguments
+		wantFields []string
+	}{
+		{
+			name: "empty",
+			spec: &buildspec.VirtualMachineSpec{},
+			want: &VirtualMachineBuildArguments{},
+		},
+		{
+			name: "representable",
+			spec: &buildspec.VirtualMachineSpec{
+				DataCenter: &buildspec.DataCenter{Permalink: "uk-lon-01"},
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{ID: "vmpkg_1"},
+				},
+				DiskTemplate: &buildspec.DiskTemplate{
+					Permalink: "templates/ubuntu-22-04",
+					Options: []*buildspec.DiskTemplateOption{
+						{Key: "timezone", Value: "Europe/London"},
+					},
+				},
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{Network: &buildspec.Network{ID: "netw_1"}},
+				},
+				Hostname: "web-1",
+			},
+			want: &VirtualMachineBuildArguments{
+				DataCenter: &DataCenterRef{Permalink: "uk-lon-01"},
+				Package:    VirtualMachinePackageRef{ID: "vmpkg_1"},
+				DiskTemplate: &DiskTemplateRef{
+					Permalink: "templates/ubuntu-22-04",
+				},
+				DiskTemplateOptions: []*DiskTemplateOption{
+					{Key: "timezone", Value: "Europe/London"},
+				},
+				Network:  &NetworkRef{ID: "netw_1"},
+				Hostname: "web-1",
+			},
+		},
+		{
+			name: "unrepresentable",
+			spec: &buildspec.VirtualMachineSpec{
+				Zone:       &buildspec.Zone{ID: "zone_1"},
+				DataCenter: &buildspec.DataCenter{Name: "London"},
+				Resources:  &buildspec.Resources{Memory: 8, CPUCores: 4},
+				DiskTemplate: &buildspec.DiskTemplate{
+					ID:      "dtpl_1",
+					Version: 3,
+				},
+				SystemDisks: []*buildspec.SystemDisk{{Size: 20}},
+				SharedDisks: []*buildspec.SharedDisk{{Name: "uploads"}},
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						Network: &buildspec.Network{Permalink: "public"},
+						SpeedProfile: &buildspec.NetworkSpeedProfile{
+							Permalink: "1gbps",
+						},
+						IPAddressAllocations: []*buildspec.IPAddressAllocation{
+							{
+								Type:    buildspec.NewIPAddressAllocation,
+								Version: buildspec.IPv4,
+							},
+						},
+					},
+					{
+						VirtualNetwork: &buildspec.VirtualNetwork{
+							ID: "vnet_1",
+						},
+					},
+				},
+				Hostname:       "web-1",
+				Name:           "Web 1",
+				Description:    "Primary web server",
+				Group:          &buildspec.Group{Name: "web"},
+				AuthorizedKeys: &buildspec.AuthorizedKeys{AllUsers: true},
+				BackupPolicies: []*buildspec.BackupPolicy{{Retention: 7}},
+				Tags:           []string{"web"},
+				ISO:            "iso_1",
+			},
+			want: &VirtualMachineBuildArguments{
+				Zone:         &ZoneRef{ID: "zone_1"},
+				DiskTemplate: &DiskTemplateRef{ID: "dtpl_1"},
+				Network:      &NetworkRef{Permalink: "public"},
+				Hostname:     "web-1",
+			},
+			wantFields: []string{
+				"data_center.name",
+				"resources.memory",
+				"resources.cpu_cores",
+				"disk_template.version",
+				"system_disks",
+				"shared_disks",
+				"network_interfaces[0].speed_profile",
+				"network_interfaces[0].ip_address_allocations",
+				"network_interfaces[1]",
+				"name",
+				"description",
+				"group",
+				"authorized_keys",
+				"backup_policies",
+				"tags",
+				"iso",
+			},
+		},
+		{
+			name: "virtual network",
+			spec: &buildspec.VirtualMachineSpec{
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						VirtualNetwork: &buildspec.VirtualNetwork{
+							ID: "vnet_1",
+						},
+					},
+				},
+			},
+			want: &VirtualMachineBuildArguments{},
+			wantFields: []string{
+				"network_interfaces[0].virtual_network",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VirtualMachineBuildArgumentsFromSpec(tt.spec)
+
+			assert.Equal(t, tt.want, got)
+			assertConversionFields(t, tt.wantFields, err)
+		})
+	}
+}
+
+func TestVirtualMachineBuildArguments_roundTrip(t *testing.T) {
+	args := &VirtualMachineBuildArguments{
+		Zone:         &ZoneRef{ID: "zone_1"},
+		Package:      VirtualMachinePackageRef{Permalink: "rock-3"},
+		DiskTemplate: &DiskTemplateRef{Permalink: "templates/ubuntu-22-04"},
+		DiskTemplateOptions: []*DiskTemplateOption{
+			{Key: "timezone", Value: "Europe/London"},
+		},
+		Network:  &NetworkRef{Permalink: "public"},
+		Hostname: "web-1",
+	}
+
+	spec, err := args.Spec()
+	require.NoError(t, err)
+	got, err := VirtualMachineBuildArgumentsFromSpec(spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, args, got)
+}
+
+func TestConversionError(t *testing.T) {
+	err := &ConversionError{Fields: []string{"system_disks", "tags"}}
+
+	assert.EqualError(t, err,
+		"katapult: core: unrepresentable_fields: system_disks, tags",
+	)
+	assert.ErrorIs(t, err, ErrUnrepresentableFields)
+	assert.ErrorIs(t, err, Err)
+}
+
+func assertConversionFields(t *testing.T, want []string, err error) {
+	t.Helper()
+
+	if len(want) == 0 {
+		assert.NoError(t, err)
+
+		return
+	}
+
+	var cerr *ConversionError
+	require.ErrorAs(t, err, &cerr)
+	assert.Equal(t, want, cerr.Fields)
+}
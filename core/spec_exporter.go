@@ -0,0 +1,408 @@
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+// SpecExporter generates build specs from existing virtual machines, which
+// can be used with VirtualMachineBuildsClient.CreateFromSpec to build an
+// equivalent virtual machine. Exported specs can be written as XML, JSON or
+// YAML with their WriteXML, WriteJSON and WriteYAML methods.
+//
+// Resources are referenced by permalink where they have one, and by ID
+// otherwise. Authorized keys and protected disk template options, such as
+// passwords, cannot be read back from the API and are not exported.
+type SpecExporter struct {
+	// API is used to look up the virtual machine and its resources.
+	API *API
+
+	// ReuseIPAddresses exports the virtual machine's current IP addresses as
+	// existing allocations. By default, a new allocation of the same IP
+	// version is exported for each address instead, as addresses cannot be
+	// allocated to two virtual machines at once.
+	ReuseIPAddresses bool
+
+	// SkipHostname omits the virtual machine's hostname, name and
+	// description from exported specs, so new virtual machines are named by
+	// the API instead.
+	SkipHostname bool
+}
+
+// NewSpecExporter returns a new SpecExporter which looks up virtual machines
+// with api.
+func NewSpecExporter(api *API) *SpecExporter {
+	return &SpecExporter{API: api}
+}
+
+// Export returns a build spec for the virtual machine referenced by vm.
+func (e *SpecExporter) Export(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	reqOpts ...katapult.RequestOption,
+) (*buildspec.VirtualMachineSpec, error) {
+	v, _, err := e.API.VirtualMachines.Get(ctx, vm, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+	ref := v.Ref()
+
+	spec := &buildspec.VirtualMachineSpec{
+		Resources: exportResources(v),
+		Tags:      exportTags(v),
+	}
+	if v.Zone != nil {
+		id, permalink := exportLookup(v.Zone.ID, v.Zone.Permalink)
+		spec.Zone = &buildspec.Zone{ID: id, Permalink: permalink}
+	}
+	if v.Group != nil {
+		spec.Group = &buildspec.Group{ID: v.Group.ID}
+	}
+	if v.AttachedISO != nil {
+		spec.ISO = v.AttachedISO.ID
+	}
+	if !e.SkipHostname {
+		spec.Hostname = v.Hostname
+		spec.Name = v.Name
+		spec.Description = v.Description
+	}
+
+	if err := e.disks(ctx, ref, spec, reqOpts); err != nil {
+		return nil, err
+	}
+	if err := e.networkInterfaces(ctx, ref, spec, reqOpts); err != nil {
+		return nil, err
+	}
+
+	spec.BackupPolicies, err = e.virtualMachineBackupPolicies(
+		ctx, ref, reqOpts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return spec, nil
+}
+
+// disks adds the virtual machine's disks to spec, with the boot disk first,
+// and sets the disk template the boot disk was installed from.
+func (e *SpecExporter) disks(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	spec *buildspec.VirtualMachineSpec,
+	reqOpts []katapult.RequestOption,
+) error {
+	disks, err := e.fetchDisks(ctx, vm, reqOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, disk := range disks {
+		sd := &buildspec.SystemDisk{
+			Name:  disk.Name,
+			Size:  disk.SizeInGB,
+			Speed: disk.StorageSpeed,
+		}
+		if disk.IOProfile != nil {
+			id, permalink := exportLookup(
+				disk.IOProfile.ID, disk.IOProfile.Permalink,
+			)
+			sd.IOProfile = &buildspec.DiskIOProfile{
+				ID: id, Permalink: permalink,
+			}
+		}
+		sd.BackupPolicies, err = e.diskBackupPolicies(
+			ctx, disk.Ref(), reqOpts,
+		)
+		if err != nil {
+			return err
+		}
+		spec.SystemDisks = append(spec.SystemDisks, sd)
+
+		if disk.VirtualMachineDisk.Boot && spec.DiskTemplate == nil {
+			spec.DiskTemplate = exportDiskTemplate(disk.Installation)
+		}
+	}
+
+	return nil
+}
+
+// fetchDisks returns the virtual machine's disks, with the boot disk first.
+// Each disk is fetched individually, as listed disks do not include their
+// IO profile or installation, and has its VirtualMachineDisk set.
+func (e *SpecExporter) fetchDisks(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	reqOpts []katapult.RequestOption,
+) ([]*Disk, error) {
+	vmDisks, err := allPages(
+		func(opts *ListOptions) (
+			[]*VirtualMachineDisk, *katapult.Response, error,
+		) {
+			return e.API.Disks.ListForVirtualMachine(ctx, vm, opts, reqOpts...)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(vmDisks, func(i, j int) bool {
+		return vmDisks[i].Boot && !vmDisks[j].Boot
+	})
+
+	var disks []*Disk
+	for _, vmDisk := range vmDisks {
+		if vmDisk.Disk == nil {
+			continue
+		}
+		disk, _, err := e.API.Disks.Get(ctx, vmDisk.Disk.Ref(), reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if disk.VirtualMachineDisk == nil {
+			disk.VirtualMachineDisk = vmDisk
+		}
+		disks = append(disks, disk)
+	}
+
+	return disks, nil
+}
+
+// networkInterfaces adds the virtual machine's network interfaces to spec,
+// along with an IP address allocation for each of their addresses.
+func (e *SpecExporter) networkInterfaces(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	spec *buildspec.VirtualMachineSpec,
+	reqOpts []katapult.RequestOption,
+) error {
+	vmnets, err := e.fetchNetworkInterfaces(ctx, vm, reqOpts)
+	if err != nil {
+		return err
+	}
+
+	for _, vmnet := range vmnets {
+		ni := &buildspec.NetworkInterface{}
+		if vmnet.Network != nil {
+			id, permalink := exportLookup(
+				vmnet.Network.ID, vmnet.Network.Permalink,
+			)
+			ni.Network = &buildspec.Network{ID: id, Permalink: permalink}
+		}
+		if vmnet.SpeedProfile != nil {
+			id, permalink := exportLookup(
+				vmnet.SpeedProfile.ID, vmnet.SpeedProfile.Permalink,
+			)
+			ni.SpeedProfile = &buildspec.NetworkSpeedProfile{
+				ID: id, Permalink: permalink,
+			}
+		}
+		for _, ip := range vmnet.IPAddresses {
+			ni.IPAddressAllocations = append(
+				ni.IPAddressAllocations, e.ipAddressAllocation(ip),
+			)
+		}
+		spec.NetworkInterfaces = append(spec.NetworkInterfaces, ni)
+	}
+
+	return nil
+}
+
+// fetchNetworkInterfaces returns the virtual machine's network interfaces.
+// Each interface is fetched individually, as listed interfaces do not
+// include their speed profile.
+func (e *SpecExporter) fetchNetworkInterfaces(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	reqOpts []katapult.RequestOption,
+) ([]*VirtualMachineNetworkInterface, error) {
+	listed, err := allPages(
+		func(opts *ListOptions) (
+			[]*VirtualMachineNetworkInterface, *katapult.Response, error,
+		) {
+			return e.API.VirtualMachineNetworkInterfaces.List(
+				ctx, vm, opts, reqOpts...,
+			)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vmnets := make([]*VirtualMachineNetworkInterface, 0, len(listed))
+	for _, l := range listed {
+		vmnet, _, err := e.API.VirtualMachineNetworkInterfaces.Get(
+			ctx, l.Ref(), reqOpts...,
+		)
+		if err != nil {
+			return nil, err
+		}
+		vmnets = append(vmnets, vmnet)
+	}
+
+	return vmnets, nil
+}
+
+func (e *SpecExporter) ipAddressAllocation(
+	ip *IPAddress,
+) *buildspec.IPAddressAllocation {
+	if e.ReuseIPAddresses {
+		return &buildspec.IPAddressAllocation{
+			Type:      buildspec.ExistingIPAddressAllocation,
+			IPAddress: &buildspec.IPAddress{ID: ip.ID},
+		}
+	}
+
+	return &buildspec.IPAddressAllocation{
+		Type:    buildspec.NewIPAddressAllocation,
+		Version: exportIPVersion(ip),
+	}
+}
+
+// virtualMachineBackupPolicies returns the backup policies which apply to
+// all disks of the virtual machine.
+func (e *SpecExporter) virtualMachineBackupPolicies(
+	ctx context.Context,
+	vm VirtualMachineRef,
+	reqOpts []katapult.RequestOption,
+) ([]*buildspec.BackupPolicy, error) {
+	return e.backupPolicies(ctx,
+		func(opts *ListOptions) (
+			[]*DiskBackupPolicy, *katapult.Response, error,
+		) {
+			return e.API.DiskBackupPolicies.ListForVirtualMachine(
+				ctx, vm, opts, reqOpts...,
+			)
+		},
+		reqOpts,
+	)
+}
+
+// diskBackupPolicies returns the backup policies of a single disk.
+func (e *SpecExporter) diskBackupPolicies(
+	ctx context.Context,
+	disk DiskRef,
+	reqOpts []katapult.RequestOption,
+) ([]*buildspec.BackupPolicy, error) {
+	return e.backupPolicies(ctx,
+		func(opts *ListOptions) (
+			[]*DiskBackupPolicy, *katapult.Response, error,
+		) {
+			return e.API.DiskBackupPolicies.ListForDisk(
+				ctx, disk, opts, reqOpts...,
+			)
+		},
+		reqOpts,
+	)
+}
+
+// backupPolicies returns all policies returned by list, fetching each one
+// individually as listed policies do not include their full schedule.
+func (e *SpecExporter) backupPolicies(
+	ctx context.Context,
+	list func(*ListOptions) ([]*DiskBackupPolicy, *katapult.Response, error),
+	reqOpts []katapult.RequestOption,
+) ([]*buildspec.BackupPolicy, error) {
+	listed, err := allPages(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*buildspec.BackupPolicy
+	for _, l := range listed {
+		p, _, err := e.API.DiskBackupPolicies.Get(ctx, l.Ref(), reqOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		bp := &buildspec.BackupPolicy{Retention: p.Retention}
+		if p.Schedule != nil {
+			bp.Schedule = &buildspec.Schedule{
+				Interval:  buildspec.ScheduleInterval(p.Schedule.Interval),
+				Frequency: p.Schedule.Frequency,
+				Time:      p.Schedule.Time,
+			}
+		}
+		policies = append(policies, bp)
+	}
+
+	return policies, nil
+}
+
+func exportResources(v *VirtualMachine) *buildspec.Resources {
+	if v.Package != nil {
+		id, permalink := exportLookup(v.Package.ID, v.Package.Permalink)
+
+		return &buildspec.Resources{
+			Package: &buildspec.Package{ID: id, Permalink: permalink},
+		}
+	}
+	if v.MemoryInGB == 0 && v.CPUCores == 0 {
+		return nil
+	}
+
+	return &buildspec.Resources{Memory: v.MemoryInGB, CPUCores: v.CPUCores}
+}
+
+func exportTags(v *VirtualMachine) []string {
+	if len(v.TagNames) > 0 {
+		return v.TagNames
+	}
+
+	var tags []string
+	for _, t := range v.Tags {
+		tags = append(tags, t.Name)
+	}
+
+	return tags
+}
+
+// exportDiskTemplate returns the disk template and version a disk was
+// installed from, along with its unprotected options.
+func exportDiskTemplate(inst *DiskInstallation) *buildspec.DiskTemplate {
+	if inst == nil || inst.DiskTemplateVersion == nil ||
+		inst.DiskTemplateVersion.DiskTemplate == nil {
+		return nil
+	}
+	ver := inst.DiskTemplateVersion
+
+	id, permalink := exportLookup(
+		ver.DiskTemplate.ID, ver.DiskTemplate.Permalink,
+	)
+	dt := &buildspec.DiskTemplate{
+		ID:        id,
+		Permalink: permalink,
+		Version:   ver.Number,
+	}
+	for _, attr := range inst.Attributes {
+		if attr.Protect {
+			continue
+		}
+		dt.Options = append(dt.Options, &buildspec.DiskTemplateOption{
+			Key:   attr.Key,
+			Value: attr.Value,
+		})
+	}
+
+	return dt
+}
+
+func exportIPVersion(ip *IPAddress) buildspec.IPVersion {
+	if ip.Version() == IPv6 {
+		return buildspec.IPv6
+	}
+
+	return buildspec.IPv4
+}
+
+// exportLookup returns the ID and permalink a resource should be referenced
+// by, preferring its permalink when it has one.
+func exportLookup(id, permalink string) (string, string) {
+	if permalink != "" {
+		return "", permalink
+	}
+
+	return id, ""
+}
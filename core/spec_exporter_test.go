@@ -0,0 +1,381 @@
+package core_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/core/corefake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExporterFake returns a fake API with a single virtual machine for
+// SpecExporter tests.
+func newExporterFake(vm *core.VirtualMachine) *corefake.Client {
+	fake := corefake.New()
+
+	fake.VirtualMachines.GetFunc = func(
+		_ context.Context,
+		ref core.VirtualMachineRef,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachine, *katapult.Response, error) {
+		if ref.ID == vm.ID {
+			return vm, nil, nil
+		}
+
+		return nil, nil, core.ErrVirtualMachineNotFound
+	}
+	fake.Disks.ListForVirtualMachineFunc = func(
+		_ context.Context,
+		_ core.VirtualMachineRef,
+		_ *core.ListOptions,
+		_ ...katapult.RequestOption,
+	) ([]*core.VirtualMachineDisk, *katapult.Response, error) {
+		return []*core.VirtualMachineDisk{
+			{Disk: &core.Disk{ID: "disk_data"}},
+			{Disk: &core.Disk{ID: "disk_boot"}, Boot: true},
+		}, nil, nil
+	}
+	fake.Disks.GetFunc = func(
+		_ context.Context,
+		ref core.DiskRef,
+		_ ...katapult.RequestOption,
+	) (*core.Disk, *katapult.Response, error) {
+		switch ref.ID {
+		case "disk_boot":
+			return &core.Disk{
+				ID:           "disk_boot",
+				Name:         "Boot",
+				SizeInGB:     20,
+				StorageSpeed: "ssd",
+				IOProfile:    &core.DiskIOProfile{ID: "dio_1"},
+				Installation: &core.DiskInstallation{
+					DiskTemplateVersion: &core.DiskTemplateVersion{
+						Number: 3,
+						DiskTemplate: &core.DiskTemplate{
+							ID:        "dtpl_1",
+							Permalink: "templates/ubuntu-22-04",
+						},
+					},
+					Attributes: []*core.DiskInstallationAttribute{
+						{Key: "timezone", Value: "Europe/London"},
+						{Key: "password", Protect: true},
+					},
+				},
+			}, nil, nil
+		case "disk_data":
+			return &core.Disk{
+				ID:           "disk_data",
+				Name:         "Data",
+				SizeInGB:     100,
+				StorageSpeed: "nvme",
+				IOProfile: &core.DiskIOProfile{
+					ID:        "dio_2",
+					Permalink: "fast",
+				},
+			}, nil, nil
+		}
+
+		return nil, nil, core.ErrDiskNotFound
+	}
+	fake.DiskBackupPolicies.ListForVirtualMachineFunc = func(
+		_ context.Context,
+		_ core.VirtualMachineRef,
+		_ *core.ListOptions,
+		_ ...katapult.RequestOption,
+	) ([]*core.DiskBackupPolicy, *katapult.Response, error) {
+		return []*core.DiskBackupPolicy{{ID: "dbp_vm"}}, nil, nil
+	}
+	fake.DiskBackupPolicies.ListForDiskFunc = func(
+		_ context.Context,
+		disk core.DiskRef,
+		_ *core.ListOptions,
+		_ ...katapult.RequestOption,
+	) ([]*core.DiskBackupPolicy, *katapult.Response, error) {
+		if disk.ID == "disk_data" {
+			return []*core.DiskBackupPolicy{{ID: "dbp_data"}}, nil, nil
+		}
+
+		return nil, nil, nil
+	}
+	fake.DiskBackupPolicies.GetFunc = func(
+		_ context.Context,
+		ref core.DiskBackupPolicyRef,
+		_ ...katapult.RequestOption,
+	) (*core.DiskBackupPolicy, *katapult.Response, error) {
+		switch ref.ID {
+		case "dbp_vm":
+			return &core.DiskBackupPolicy{
+				ID:        "dbp_vm",
+				Retention: 7,
+				Schedule: &core.Schedule{
+					Interval:  core.ScheduleDaily,
+					Frequency: 1,
+					Time:      3,
+				},
+			}, nil, nil
+		case "dbp_data":
+			return &core.DiskBackupPolicy{
+				ID:        "dbp_data",
+				Retention: 4,
+				Schedule: &core.Schedule{
+					Interval:  core.ScheduleWeekly,
+					Frequency: 2,
+				},
+			}, nil, nil
+		}
+
+		return nil, nil, core.ErrDiskBackupPolicyNotFound
+	}
+	fake.VirtualMachineNetworkInterfaces.ListFunc = func(
+		_ context.Context,
+		_ core.VirtualMachineRef,
+		_ *core.ListOptions,
+		_ ...katapult.RequestOption,
+	) ([]*core.VirtualMachineNetworkInterface, *katapult.Response, error) {
+		return []*core.VirtualMachineNetworkInterface{
+			{ID: "vmnet_1"},
+		}, nil, nil
+	}
+	fake.VirtualMachineNetworkInterfaces.GetFunc = func(
+		_ context.Context,
+		ref core.VirtualMachineNetworkInterfaceRef,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachineNetworkInterface, *katapult.Response, error) {
+		return &core.VirtualMachineNetworkInterface{
+			ID:      ref.ID,
+			Network: &core.Network{ID: "net_1", Permalink: "public"},
+			SpeedProfile: &core.NetworkSpeedProfile{
+				ID:        "nsp_1",
+				Permalink: "1gbps",
+			},
+			IPAddresses: []*core.IPAddress{
+				{ID: "ip_1", Address: "203.0.113.10"},
+				{ID: "ip_2", Address: "2001:db8::10"},
+			},
+		}, nil, nil
+	}
+
+	return fake
+}
+
+func TestSpecExporter_Export(t *testing.T) {
+	vm := &core.VirtualMachine{
+		ID:          "vm_1",
+		FQDN:        "web-1.example.com",
+		Name:        "Web 1",
+		Hostname:    "web-1",
+		Description: "Primary web server",
+		Zone:        &core.Zone{ID: "zone_1", Permalink: "uk-lon-01a"},
+		Package:     &core.VirtualMachinePackage{ID: "vmpkg_1"},
+		Group:       &core.VirtualMachineGroup{ID: "vmgrp_1"},
+		TagNames:    []string{"web", "production"},
+	}
+
+	existing := buildspec.ExistingIPAddressAllocation
+	wantDiskTemplate := &buildspec.DiskTemplate{
+		Permalink: "templates/ubuntu-22-04",
+		Version:   3,
+		Options: []*buildspec.DiskTemplateOption{
+			{Key: "timezone", Value: "Europe/London"},
+		},
+	}
+	wantSystemDisks := []*buildspec.SystemDisk{
+		{
+			Name:      "Boot",
+			Size:      20,
+			Speed:     "ssd",
+			IOProfile: &buildspec.DiskIOProfile{ID: "dio_1"},
+		},
+		{
+			Name:      "Data",
+			Size:      100,
+			Speed:     "nvme",
+			IOProfile: &buildspec.DiskIOProfile{Permalink: "fast"},
+			BackupPolicies: []*buildspec.BackupPolicy{
+				{
+					Retention: 4,
+					Schedule: &buildspec.Schedule{
+						Interval:  buildspec.ScheduledWeekly,
+						Frequency: 2,
+					},
+				},
+			},
+		},
+	}
+	wantBackupPolicies := []*buildspec.BackupPolicy{
+		{
+			Retention: 7,
+			Schedule: &buildspec.Schedule{
+				Interval:  buildspec.ScheduledDaily,
+				Frequency: 1,
+				Time:      3,
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		exporter func(api *core.API) *core.SpecExporter
+		vm       *core.VirtualMachine
+		want     *buildspec.VirtualMachineSpec
+	}{
+		{
+			name:     "defaults",
+			exporter: core.NewSpecExporter,
+			vm:       vm,
+			want: &buildspec.VirtualMachineSpec{
+				Zone: &buildspec.Zone{Permalink: "uk-lon-01a"},
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{ID: "vmpkg_1"},
+				},
+				DiskTemplate: wantDiskTemplate,
+				SystemDisks:  wantSystemDisks,
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						Network: &buildspec.Network{Permalink: "public"},
+						SpeedProfile: &buildspec.NetworkSpeedProfile{
+							Permalink: "1gbps",
+						},
+						IPAddressAllocations: []*buildspec.IPAddressAllocation{
+							{
+								Type:    buildspec.NewIPAddressAllocation,
+								Version: buildspec.IPv4,
+							},
+							{
+								Type:    buildspec.NewIPAddressAllocation,
+								Version: buildspec.IPv6,
+							},
+						},
+					},
+				},
+				Hostname:       "web-1",
+				Name:           "Web 1",
+				Description:    "Primary web server",
+				Group:          &buildspec.Group{ID: "vmgrp_1"},
+				BackupPolicies: wantBackupPolicies,
+				Tags:           []string{"web", "production"},
+			},
+		},
+		{
+			name: "reuse IP addresses and skip hostname",
+			exporter: func(api *core.API) *core.SpecExporter {
+				e := core.NewSpecExporter(api)
+				e.ReuseIPAddresses = true
+				e.SkipHostname = true
+
+				return e
+			},
+			vm: &core.VirtualMachine{
+				ID:         "vm_2",
+				Hostname:   "db-1",
+				MemoryInGB: 8,
+				CPUCores:   4,
+				Tags:       []*core.Tag{{Name: "db"}},
+			},
+			want: &buildspec.VirtualMachineSpec{
+				Resources:    &buildspec.Resources{Memory: 8, CPUCores: 4},
+				DiskTemplate: wantDiskTemplate,
+				SystemDisks:  wantSystemDisks,
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						Network: &buildspec.Network{Permalink: "public"},
+						SpeedProfile: &buildspec.NetworkSpeedProfile{
+							Permalink: "1gbps",
+						},
+						IPAddressAllocations: []*buildspec.IPAddressAllocation{
+							{
+								Type:      existing,
+								IPAddress: &buildspec.IPAddress{ID: "ip_1"},
+							},
+							{
+								Type:      existing,
+								IPAddress: &buildspec.IPAddress{ID: "ip_2"},
+							},
+						},
+					},
+				},
+				BackupPolicies: wantBackupPolicies,
+				Tags:           []string{"db"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newExporterFake(tt.vm)
+			e := tt.exporter(fake.API())
+
+			got, err := e.Export(
+				context.Background(), core.VirtualMachineRef{ID: tt.vm.ID},
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpecExporter_Export_roundTrip(t *testing.T) {
+	fake := newExporterFake(&core.VirtualMachine{
+		ID:       "vm_1",
+		Hostname: "web-1",
+		Zone:     &core.Zone{Permalink: "uk-lon-01a"},
+		Package:  &core.VirtualMachinePackage{Permalink: "rock-3"},
+	})
+
+	spec, err := core.NewSpecExporter(fake.API()).Export(
+		context.Background(), core.VirtualMachineRef{ID: "vm_1"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+
+	x, err := spec.XML()
+	require.NoError(t, err)
+	fromXML, err := buildspec.FromXMLStrict(bytes.NewReader(x))
+	require.NoError(t, err)
+	assert.Equal(t, spec, fromXML)
+
+	j, err := spec.JSON()
+	require.NoError(t, err)
+	fromJSON, err := buildspec.FromJSON(bytes.NewReader(j))
+	require.NoError(t, err)
+	assert.Equal(t, spec, fromJSON)
+
+	y, err := spec.YAML()
+	require.NoError(t, err)
+	fromYAML, err := buildspec.FromYAML(bytes.NewReader(y))
+	require.NoError(t, err)
+	assert.Equal(t, spec, fromYAML)
+}
+
+func TestSpecExporter_Export_errors(t *testing.T) {
+	t.Run("virtual machine not found", func(t *testing.T) {
+		fake := newExporterFake(&core.VirtualMachine{ID: "vm_1"})
+
+		_, err := core.NewSpecExporter(fake.API()).Export(
+			context.Background(), core.VirtualMachineRef{ID: "vm_nope"},
+		)
+
+		assert.ErrorIs(t, err, core.ErrVirtualMachineNotFound)
+	})
+
+	t.Run("backup policy lookup fails", func(t *testing.T) {
+		fake := newExporterFake(&core.VirtualMachine{ID: "vm_1"})
+		fake.DiskBackupPolicies.GetFunc = func(
+			_ context.Context,
+			_ core.DiskBackupPolicyRef,
+			_ ...katapult.RequestOption,
+		) (*core.DiskBackupPolicy, *katapult.Response, error) {
+			return nil, nil, core.ErrDiskBackupPolicyNotFound
+		}
+
+		_, err := core.NewSpecExporter(fake.API()).Export(
+			context.Background(), core.VirtualMachineRef{ID: "vm_1"},
+		)
+
+		assert.ErrorIs(t, err, core.ErrDiskBackupPolicyNotFound)
+	})
+}
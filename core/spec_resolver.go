@@ -0,0 +1,699 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+// ErrUnresolvedSpecReferences is returned by SpecResolution.Err when a build
+// spec references resources which are missing or ambiguous.
+var ErrUnresolvedSpecReferences = fmt.Errorf(
+	"%w: unresolved_spec_references", Err,
+)
+
+// SpecReferenceStatus is the outcome of resolving a SpecReference.
+type SpecReferenceStatus string
+
+const (
+	// SpecReferenceResolved indicates the reference matched exactly one
+	// resource.
+	SpecReferenceResolved SpecReferenceStatus = "resolved"
+
+	// SpecReferenceMissing indicates no resource matched the reference.
+	SpecReferenceMissing SpecReferenceStatus = "missing"
+
+	// SpecReferenceAmbiguous indicates more than one resource matched the
+	// reference, which can happen for lookups by name.
+	SpecReferenceAmbiguous SpecReferenceStatus = "ambiguous"
+
+	// SpecReferenceUnverified indicates the reference could not be checked,
+	// as the API provides no way to look up resources of its kind.
+	SpecReferenceUnverified SpecReferenceStatus = "unverified"
+)
+
+// SpecReference is a single reference to a resource within a build spec.
+type SpecReference struct {
+	// Field is the path to the reference within the spec, using JSON field
+	// names, for example "network_interfaces[0].network".
+	Field string
+
+	// Kind is the kind of resource referenced, for example "network".
+	Kind string
+
+	// By is the attribute the resource is looked up by, for example "id",
+	// "permalink" or "name".
+	By string
+
+	// Value is the value the resource is looked up by.
+	Value string
+
+	// Status is the outcome of resolving the reference.
+	Status SpecReferenceStatus
+
+	// ID is the ID of the referenced resource when Status is
+	// SpecReferenceResolved.
+	ID string
+
+	// Matches holds the IDs of all matching resources when Status is
+	// SpecReferenceAmbiguous.
+	Matches []string
+}
+
+func (r *SpecReference) String() string {
+	s := fmt.Sprintf("%s: %s with %s %q is %s",
+		r.Field, r.Kind, r.By, r.Value, r.Status,
+	)
+	if len(r.Matches) > 0 {
+		s += fmt.Sprintf(" (%s)", strings.Join(r.Matches, ", "))
+	}
+
+	return s
+}
+
+// SpecResolution is the report returned by SpecResolver.Resolve, listing
+// every resource reference found in a build spec.
+type SpecResolution struct {
+	References []*SpecReference
+}
+
+// Problems returns all references which are missing or ambiguous.
+func (r *SpecResolution) Problems() []*SpecReference {
+	var refs []*SpecReference
+	for _, ref := range r.References {
+		if ref.Status == SpecReferenceMissing ||
+			ref.Status == SpecReferenceAmbiguous {
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs
+}
+
+// Err returns a *SpecResolutionError if any references are missing or
+// ambiguous, and nil otherwise.
+func (r *SpecResolution) Err() error {
+	problems := r.Problems()
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return &SpecResolutionError{Problems: problems}
+}
+
+// SpecResolutionError lists references in a build spec which are missing or
+// ambiguous.
+type SpecResolutionError struct {
+	Problems []*SpecReference
+}
+
+func (e *SpecResolutionError) Error() string {
+	msgs := make([]string, 0, len(e.Problems))
+	for _, ref := range e.Problems {
+		msgs = append(msgs, ref.String())
+	}
+
+	return fmt.Sprintf(
+		"%s: %s", ErrUnresolvedSpecReferences, strings.Join(msgs, "; "),
+	)
+}
+
+func (e *SpecResolutionError) Unwrap() error {
+	return ErrUnresolvedSpecReferences
+}
+
+// SpecResolver verifies that resources referenced by a build spec exist
+// within an organization, before the spec is used to create a virtual
+// machine with VirtualMachineBuildsClient.CreateFromSpec.
+//
+// Zones, disk IO profiles, shared disks, subnets and users cannot be looked
+// up through the API, and are reported as SpecReferenceUnverified.
+type SpecResolver struct {
+	// API is used to look up referenced resources.
+	API *API
+
+	// Organization is the organization resources must belong to. When empty,
+	// the organization set on the context with ContextWithOrganization is
+	// used.
+	Organization OrganizationRef
+
+	// Canonicalize rewrites every resolved lookup in the spec to look up its
+	// resource by ID instead.
+	Canonicalize bool
+}
+
+// NewSpecResolver returns a new SpecResolver which looks up resources with
+// api within org.
+func NewSpecResolver(api *API, org OrganizationRef) *SpecResolver {
+	return &SpecResolver{API: api, Organization: org}
+}
+
+// Resolve looks up every resource referenced by spec, and returns a report
+// of the outcome. When Canonicalize is set, spec is modified in place.
+//
+// Missing and ambiguous references are not treated as errors, use the
+// report's Err method to check for them. An error is only returned when the
+// API could not be queried.
+func (r *SpecResolver) Resolve(
+	ctx context.Context,
+	spec *buildspec.VirtualMachineSpec,
+	reqOpts ...katapult.RequestOption,
+) (*SpecResolution, error) {
+	s := &specResolution{
+		SpecResolver: r,
+		ctx:          ctx,
+		org:          r.Organization.withDefault(ctx),
+		reqOpts:      reqOpts,
+		report:       &SpecResolution{},
+	}
+
+	steps := []func(spec *buildspec.VirtualMachineSpec) error{
+		s.dataCenter,
+		s.zone,
+		s.pkg,
+		s.diskTemplate,
+		s.disks,
+		s.networkInterfaces,
+		s.group,
+		s.authorizedKeys,
+	}
+	for _, step := range steps {
+		if err := step(spec); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.report, nil
+}
+
+// specResolution holds the state of a single call to SpecResolver.Resolve,
+// including lists of resources which are fetched at most once.
+type specResolution struct {
+	*SpecResolver
+
+	ctx     context.Context
+	org     OrganizationRef
+	reqOpts []katapult.RequestOption
+	report  *SpecResolution
+
+	dataCenters     []*DataCenter
+	groups          []*VirtualMachineGroup
+	sshKeys         []*AuthSSHKey
+	speedProfiles   []*NetworkSpeedProfile
+	virtualNetworks []*VirtualNetwork
+	networksListed  bool
+}
+
+func (s *specResolution) dataCenter(spec *buildspec.VirtualMachineSpec) error {
+	dc := spec.DataCenter
+	if dc == nil {
+		return nil
+	}
+
+	var ref *SpecReference
+	var err error
+	switch {
+	case dc.ID != "" || dc.Permalink != "":
+		ref = s.add("data_center", "data_center",
+			"id", dc.ID, "permalink", dc.Permalink,
+		)
+		err = s.get(ref, func() (string, error) {
+			v, _, err := s.API.DataCenters.Get(s.ctx, DataCenterRef{
+				ID: dc.ID, Permalink: dc.Permalink,
+			}, s.reqOpts...)
+
+			return idOf(v, err, func() string { return v.ID })
+		})
+	case dc.Name != "":
+		ref = s.add("data_center", "data_center", "name", dc.Name)
+		if s.dataCenters == nil {
+			s.dataCenters, _, err = s.API.DataCenters.List(s.ctx, s.reqOpts...)
+			if err != nil {
+				return err
+			}
+		}
+		var ids []string
+		for _, v := range s.dataCenters {
+			if v.Name == dc.Name {
+				ids = append(ids, v.ID)
+			}
+		}
+		match(ref, ids)
+	}
+
+	if s.resolved(ref) {
+		spec.DataCenter = &buildspec.DataCenter{ID: ref.ID}
+	}
+
+	return err
+}
+
+func (s *specResolution) zone(spec *buildspec.VirtualMachineSpec) error {
+	if z := spec.Zone; z != nil {
+		s.add("zone", "zone", "id", z.ID, "permalink", z.Permalink)
+	}
+
+	return nil
+}
+
+func (s *specResolution) pkg(spec *buildspec.VirtualMachineSpec) error {
+	if spec.Resources == nil || spec.Resources.Package == nil {
+		return nil
+	}
+
+	p := spec.Resources.Package
+	ref := s.add("resources.package", "package",
+		"id", p.ID, "permalink", p.Permalink,
+	)
+	err := s.get(ref, func() (string, error) {
+		v, _, err := s.API.VirtualMachinePackages.Get(
+			s.ctx,
+			VirtualMachinePackageRef{ID: p.ID, Permalink: p.Permalink},
+			s.reqOpts...,
+		)
+
+		return idOf(v, err, func() string { return v.ID })
+	})
+	if s.resolved(ref) {
+		spec.Resources.Package = &buildspec.Package{ID: ref.ID}
+	}
+
+	return err
+}
+
+func (s *specResolution) diskTemplate(
+	spec *buildspec.VirtualMachineSpec,
+) error {
+	dt := spec.DiskTemplate
+	if dt == nil {
+		return nil
+	}
+
+	ref := s.add("disk_template", "disk_template",
+		"id", dt.ID, "permalink", dt.Permalink,
+	)
+	err := s.get(ref, func() (string, error) {
+		v, _, err := s.API.DiskTemplates.Get(
+			s.ctx,
+			DiskTemplateRef{ID: dt.ID, Permalink: dt.Permalink},
+			s.reqOpts...,
+		)
+
+		return idOf(v, err, func() string { return v.ID })
+	})
+	if s.resolved(ref) {
+		dt.ID = ref.ID
+		dt.Permalink = ""
+	}
+
+	return err
+}
+
+func (s *specResolution) disks(spec *buildspec.VirtualMachineSpec) error {
+	for i, d := range spec.SystemDisks {
+		if d.IOProfile != nil {
+			s.add(fmt.Sprintf("system_disks[%d].io_profile", i), "io_profile",
+				"id", d.IOProfile.ID, "permalink", d.IOProfile.Permalink,
+			)
+		}
+	}
+	for i, d := range spec.SharedDisks {
+		s.add(fmt.Sprintf("shared_disks[%d]", i), "disk",
+			"id", d.ID, "name", d.Name,
+		)
+	}
+
+	return nil
+}
+
+func (s *specResolution) networkInterfaces(
+	spec *buildspec.VirtualMachineSpec,
+) error {
+	for i, nic := range spec.NetworkInterfaces {
+		field := fmt.Sprintf("network_interfaces[%d]", i)
+
+		if err := s.network(field, nic); err != nil {
+			return err
+		}
+		if err := s.virtualNetwork(field, nic); err != nil {
+			return err
+		}
+		if err := s.speedProfile(field, nic); err != nil {
+			return err
+		}
+
+		for j, a := range nic.IPAddressAllocations {
+			f := fmt.Sprintf("%s.ip_address_allocations[%d]", field, j)
+			if err := s.ipAddress(f, a); err != nil {
+				return err
+			}
+			if a.Subnet != nil {
+				s.add(f+".subnet", "subnet",
+					"id", a.Subnet.ID, "address", a.Subnet.Address,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *specResolution) network(
+	field string,
+	nic *buildspec.NetworkInterface,
+) error {
+	n := nic.Network
+	if n == nil {
+		return nil
+	}
+
+	ref := s.add(field+".network", "network",
+		"id", n.ID, "permalink", n.Permalink,
+	)
+	err := s.get(ref, func() (string, error) {
+		v, _, err := s.API.Networks.Get(
+			s.ctx, NetworkRef{ID: n.ID, Permalink: n.Permalink}, s.reqOpts...,
+		)
+
+		return idOf(v, err, func() string { return v.ID })
+	})
+	if s.resolved(ref) {
+		nic.Network = &buildspec.Network{ID: ref.ID}
+	}
+
+	return err
+}
+
+func (s *specResolution) virtualNetwork(
+	field string,
+	nic *buildspec.NetworkInterface,
+) error {
+	vn := nic.VirtualNetwork
+	if vn == nil || vn.ID == "" {
+		return nil
+	}
+
+	ref := s.add(field+".virtual_network", "virtual_network", "id", vn.ID)
+	if !s.networksListed {
+		var err error
+		_, s.virtualNetworks, _, err = s.API.Networks.List(
+			s.ctx, s.org, s.reqOpts...,
+		)
+		if err != nil {
+			return err
+		}
+		s.networksListed = true
+	}
+
+	var ids []string
+	for _, v := range s.virtualNetworks {
+		if v.ID == vn.ID {
+			ids = append(ids, v.ID)
+		}
+	}
+	match(ref, ids)
+
+	return nil
+}
+
+func (s *specResolution) speedProfile(
+	field string,
+	nic *buildspec.NetworkInterface,
+) error {
+	sp := nic.SpeedProfile
+	if sp == nil {
+		return nil
+	}
+
+	ref := s.add(field+".speed_profile", "network_speed_profile",
+		"id", sp.ID, "permalink", sp.Permalink,
+	)
+	if ref == nil {
+		return nil
+	}
+	if s.speedProfiles == nil {
+		var err error
+		s.speedProfiles, err = allPages(
+			func(opts *ListOptions) (
+				[]*NetworkSpeedProfile, *katapult.Response, error,
+			) {
+				return s.API.NetworkSpeedProfiles.List(
+					s.ctx, s.org, opts, s.reqOpts...,
+				)
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var ids []string
+	for _, v := range s.speedProfiles {
+		if (sp.ID != "" && v.ID == sp.ID) ||
+			(sp.ID == "" && v.Permalink == sp.Permalink) {
+			ids = append(ids, v.ID)
+		}
+	}
+	match(ref, ids)
+	if s.resolved(ref) {
+		nic.SpeedProfile = &buildspec.NetworkSpeedProfile{ID: ref.ID}
+	}
+
+	return nil
+}
+
+func (s *specResolution) ipAddress(
+	field string,
+	a *buildspec.IPAddressAllocation,
+) error {
+	ip := a.IPAddress
+	if ip == nil {
+		return nil
+	}
+
+	ref := s.add(field+".ip_address", "ip_address",
+		"id", ip.ID, "address", ip.Address,
+	)
+	err := s.get(ref, func() (string, error) {
+		v, _, err := s.API.IPAddresses.Get(
+			s.ctx, IPAddressRef{ID: ip.ID, Address: ip.Address}, s.reqOpts...,
+		)
+
+		return idOf(v, err, func() string { return v.ID })
+	})
+	if s.resolved(ref) {
+		a.IPAddress = &buildspec.IPAddress{ID: ref.ID}
+	}
+
+	return err
+}
+
+func (s *specResolution) group(spec *buildspec.VirtualMachineSpec) error {
+	g := spec.Group
+	if g == nil {
+		return nil
+	}
+
+	var ref *SpecReference
+	var err error
+	switch {
+	case g.ID != "":
+		ref = s.add("group", "group", "id", g.ID)
+		err = s.get(ref, func() (string, error) {
+			v, _, err := s.API.VirtualMachineGroups.Get(
+				s.ctx, VirtualMachineGroupRef{ID: g.ID}, s.reqOpts...,
+			)
+
+			return idOf(v, err, func() string { return v.ID })
+		})
+	case g.Name != "":
+		ref = s.add("group", "group", "name", g.Name)
+		if s.groups == nil {
+			s.groups, _, err = s.API.VirtualMachineGroups.List(
+				s.ctx, s.org, s.reqOpts...,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		var ids []string
+		for _, v := range s.groups {
+			if v.Name == g.Name {
+				ids = append(ids, v.ID)
+			}
+		}
+		match(ref, ids)
+	}
+
+	if s.resolved(ref) {
+		spec.Group = &buildspec.Group{ID: ref.ID}
+	}
+
+	return err
+}
+
+func (s *specResolution) authorizedKeys(
+	spec *buildspec.VirtualMachineSpec,
+) error {
+	ak := spec.AuthorizedKeys
+	if ak == nil {
+		return nil
+	}
+
+	for i, u := range ak.Users {
+		s.add(fmt.Sprintf("authorized_keys.users[%d]", i), "user",
+			"id", u.ID, "email_address", u.EmailAddress,
+		)
+	}
+
+	for i, key := range ak.SSHKeys {
+		ref := s.add(
+			fmt.Sprintf("authorized_keys.ssh_keys[%d]", i), "ssh_key",
+			"id", key,
+		)
+		if ref == nil {
+			continue
+		}
+		if s.sshKeys == nil {
+			var err error
+			s.sshKeys, err = allPages(
+				func(opts *ListOptions) (
+					[]*AuthSSHKey, *katapult.Response, error,
+				) {
+					return s.API.SSHKeys.List(s.ctx, s.org, opts, s.reqOpts...)
+				},
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		var ids, named []string
+		for _, v := range s.sshKeys {
+			switch key {
+			case v.ID:
+				ids = append(ids, v.ID)
+			case v.Name:
+				named = append(named, v.ID)
+			}
+		}
+		if len(ids) == 0 && len(named) > 0 {
+			ref.By = "name"
+			ids = named
+		}
+		match(ref, ids)
+		if s.resolved(ref) {
+			ak.SSHKeys[i] = ref.ID
+		}
+	}
+
+	return nil
+}
+
+// add records a reference using the first non-empty lookup from the given
+// alternating pairs of attribute names and values. Kinds which cannot be
+// looked up through the API are reported as SpecReferenceUnverified. It
+// returns nil when all lookup values are empty.
+func (s *specResolution) add(
+	field string,
+	kind string,
+	lookup ...string,
+) *SpecReference {
+	for i := 0; i+1 < len(lookup); i += 2 {
+		if lookup[i+1] == "" {
+			continue
+		}
+
+		ref := &SpecReference{
+			Field:  field,
+			Kind:   kind,
+			By:     lookup[i],
+			Value:  lookup[i+1],
+			Status: SpecReferenceUnverified,
+		}
+		s.report.References = append(s.report.References, ref)
+
+		return ref
+	}
+
+	return nil
+}
+
+// get resolves ref with fn, which returns the ID of the referenced resource.
+// Not found errors mark ref as missing, and all other errors are returned.
+func (s *specResolution) get(
+	ref *SpecReference,
+	fn func() (string, error),
+) error {
+	if ref == nil {
+		return nil
+	}
+
+	id, err := fn()
+	switch {
+	case katapult.IsNotFound(err):
+		ref.Status = SpecReferenceMissing
+	case err != nil:
+		return err
+	default:
+		ref.Status = SpecReferenceResolved
+		ref.ID = id
+	}
+
+	return nil
+}
+
+// resolved reports whether ref was resolved and should be rewritten to use
+// its ID.
+func (s *specResolution) resolved(ref *SpecReference) bool {
+	return s.Canonicalize && ref != nil && ref.Status == SpecReferenceResolved
+}
+
+// match sets the status of ref based on the IDs of the resources matching it.
+func match(ref *SpecReference, ids []string) {
+	switch len(ids) {
+	case 0:
+		ref.Status = SpecReferenceMissing
+	case 1:
+		ref.Status = SpecReferenceResolved
+		ref.ID = ids[0]
+	default:
+		ref.Status = SpecReferenceAmbiguous
+		ref.Matches = ids
+	}
+}
+
+// idOf returns the ID of a resource returned by a Get method, treating a nil
+// resource as not found.
+func idOf[T any](v *T, err error, id func() string) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if v == nil {
+		return "", katapult.ErrNotFound
+	}
+
+	return id(), nil
+}
+
+// allPages calls list for every page of results, and returns all results.
+func allPages[T any](
+	list func(opts *ListOptions) ([]T, *katapult.Response, error),
+) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		items, resp, err := list(&ListOptions{Page: page, PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if resp == nil || resp.Pagination == nil ||
+			page >= resp.Pagination.TotalPages {
+			return all, nil
+		}
+	}
+}
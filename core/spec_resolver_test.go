@@ -0,0 +1,339 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/core/corefake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = katapult.ErrNotFound
+
+// newResolverFake returns a fake API seeded with a small set of resources
+// for SpecResolver tests.
+func newResolverFake() *corefake.Client {
+	fake := corefake.New()
+
+	fake.DataCenters.GetFunc = func(
+		_ context.Context,
+		ref core.DataCenterRef,
+		_ ...katapult.RequestOption,
+	) (*core.DataCenter, *katapult.Response, error) {
+		if ref.ID == "dc_1" || ref.Permalink == "uk-lon-01" {
+			return &core.DataCenter{ID: "dc_1"}, nil, nil
+		}
+
+		return nil, nil, core.ErrDataCenterNotFound
+	}
+	fake.DataCenters.ListFunc = func(
+		_ context.Context,
+		_ ...katapult.RequestOption,
+	) ([]*core.DataCenter, *katapult.Response, error) {
+		return []*core.DataCenter{
+			{ID: "dc_1", Name: "London"},
+			{ID: "dc_2", Name: "Amsterdam"},
+			{ID: "dc_3", Name: "Amsterdam"},
+		}, nil, nil
+	}
+	fake.VirtualMachinePackages.GetFunc = func(
+		_ context.Context,
+		ref core.VirtualMachinePackageRef,
+		_ ...katapult.RequestOption,
+	) (*core.VirtualMachinePackage, *katapult.Response, error) {
+		if ref.Permalink == "rock-3" {
+			return &core.VirtualMachinePackage{ID: "vmpkg_3"}, nil, nil
+		}
+
+		return nil, nil, core.ErrVirtualMachinePackageNotFound
+	}
+	fake.DiskTemplates.GetFunc = func(
+		_ context.Context,
+		ref core.DiskTemplateRef,
+		_ ...katapult.RequestOption,
+	) (*core.DiskTemplate, *katapult.Response, error) {
+		if ref.Permalink == "templates/ubuntu-22-04" {
+			return &core.DiskTemplate{ID: "dtpl_1"}, nil, nil
+		}
+
+		return nil, nil, errNotFound
+	}
+	fake.Networks.GetFunc = func(
+		_ context.Context,
+		ref core.NetworkRef,
+		_ ...katapult.RequestOption,
+	) (*core.Network, *katapult.Response, error) {
+		if ref.Permalink == "public" {
+			return &core.Network{ID: "netw_1"}, nil, nil
+		}
+
+		return nil, nil, errNotFound
+	}
+	fake.Networks.ListFunc = func(
+		_ context.Context,
+		_ core.OrganizationRef,
+		_ ...katapult.RequestOption,
+	) ([]*core.Network, []*core.VirtualNetwork, *katapult.Response, error) {
+		return nil, []*core.VirtualNetwork{{ID: "vnet_1"}}, nil, nil
+	}
+	fake.NetworkSpeedProfiles.ListFunc = func(
+		_ context.Context,
+		_ core.OrganizationRef,
+		opts *core.ListOptions,
+		_ ...katapult.RequestOption,
+	) ([]*core.NetworkSpeedProfile, *katapult.Response, error) {
+		resp := &katapult.Response{
+			Pagination: &katapult.Pagination{TotalPages: 2},
+		}
+		if opts.Page == 1 {
+			return []*core.NetworkSpeedProfile{
+				{ID: "nsp_1", Permalink: "1gbps"},
+			}, resp, nil
+		}
+
+		return []*core.NetworkSpeedProfile{
+			{ID: "nsp_10", Permalink: "10gbps"},
+		}, resp, nil
+	}
+	fake.IPAddresses.GetFunc = func(
+		_ context.Context,
+		ref core.IPAddressRef,
+		_ ...katapult.RequestOption,
+	) (*core.IPAddress, *katapult.Response, error) {
+		if ref.Address == "185.1.1.1" {
+			return &core.IPAddress{ID: "ip_1"}, nil, nil
+		}
+
+		return nil, nil, errNotFound
+	}
+	fake.VirtualMachineGroups.ListFunc = func(
+		_ context.Context,
+		_ core.OrganizationRef,
+		_ ...katapult.RequestOption,
+	) ([]*core.VirtualMachineGroup, *katapult.Response, error) {
+		return []*core.VirtualMachineGroup{
+			{ID: "vmgrp_1", Name: "web"},
+		}, nil, nil
+	}
+	fake.SSHKeys.ListFunc = func(
+		_ context.Context,
+		_ core.OrganizationRef,
+		_ *core.ListOptions,
+		_ ...katapult.RequestOption,
+	) ([]*core.AuthSSHKey, *katapult.Response, error) {
+		return []*core.AuthSSHKey{
+			{ID: "key_1", Name: "laptop"},
+			{ID: "key_2", Name: "ci"},
+			{ID: "key_3", Name: "ci"},
+		}, nil, nil
+	}
+
+	return fake
+}
+
+func TestSpecResolver_Resolve(t *testing.T) {
+	newSpec := func() *buildspec.VirtualMachineSpec {
+		return &buildspec.VirtualMachineSpec{
+			DataCenter: &buildspec.DataCenter{Permalink: "uk-lon-01"},
+			Zone:       &buildspec.Zone{Permalink: "uk-lon-01-a"},
+			Resources: &buildspec.Resources{
+				Package: &buildspec.Package{Permalink: "rock-3"},
+			},
+			DiskTemplate: &buildspec.DiskTemplate{
+				Permalink: "templates/ubuntu-22-04",
+				Version:   2,
+			},
+			NetworkInterfaces: []*buildspec.NetworkInterface{
+				{
+					Network: &buildspec.Network{Permalink: "public"},
+					SpeedProfile: &buildspec.NetworkSpeedProfile{
+						Permalink: "10gbps",
+					},
+					IPAddressAllocations: []*buildspec.IPAddressAllocation{
+						{
+							Type: buildspec.ExistingIPAddressAllocation,
+							IPAddress: &buildspec.IPAddress{
+								Address: "185.1.1.1",
+							},
+						},
+					},
+				},
+				{VirtualNetwork: &buildspec.VirtualNetwork{ID: "vnet_1"}},
+			},
+			Group: &buildspec.Group{Name: "web"},
+			AuthorizedKeys: &buildspec.AuthorizedKeys{
+				SSHKeys: []string{"key_1", "laptop"},
+			},
+		}
+	}
+
+	t.Run("all references resolved", func(t *testing.T) {
+		fake := newResolverFake()
+		spec := newSpec()
+		r := core.NewSpecResolver(fake.API(), core.OrganizationRef{ID: "org_1"})
+
+		got, err := r.Resolve(context.Background(), spec)
+		require.NoError(t, err)
+
+		assert.NoError(t, got.Err())
+		assert.Empty(t, got.Problems())
+		assert.Equal(t, newSpec(), spec, "spec must not be modified")
+
+		statuses := map[string]core.SpecReferenceStatus{}
+		ids := map[string]string{}
+		for _, ref := range got.References {
+			statuses[ref.Field] = ref.Status
+			ids[ref.Field] = ref.ID
+		}
+		resolved := core.SpecReferenceResolved
+		unverified := core.SpecReferenceUnverified
+		ipField := "network_interfaces[0].ip_address_allocations[0].ip_address"
+		assert.Equal(t, map[string]core.SpecReferenceStatus{
+			"data_center":                           resolved,
+			"zone":                                  unverified,
+			"resources.package":                     resolved,
+			"disk_template":                         resolved,
+			"network_interfaces[0].network":         resolved,
+			"network_interfaces[0].speed_profile":   resolved,
+			"network_interfaces[1].virtual_network": resolved,
+			"group":                                 resolved,
+			"authorized_keys.ssh_keys[0]":           resolved,
+			"authorized_keys.ssh_keys[1]":           resolved,
+			ipField:                                 resolved,
+		}, statuses)
+		assert.Equal(t, "nsp_10", ids["network_interfaces[0].speed_profile"])
+		assert.Equal(t, "key_1", ids["authorized_keys.ssh_keys[1]"])
+
+		assert.Len(t, fake.CallsTo("NetworkSpeedProfiles", "List"), 2)
+	})
+
+	t.Run("canonicalize", func(t *testing.T) {
+		spec := newSpec()
+		r := core.NewSpecResolver(
+			newResolverFake().API(), core.OrganizationRef{ID: "org_1"},
+		)
+		r.Canonicalize = true
+
+		_, err := r.Resolve(context.Background(), spec)
+		require.NoError(t, err)
+
+		want := &buildspec.VirtualMachineSpec{
+			DataCenter: &buildspec.DataCenter{ID: "dc_1"},
+			Zone:       &buildspec.Zone{Permalink: "uk-lon-01-a"},
+			Resources: &buildspec.Resources{
+				Package: &buildspec.Package{ID: "vmpkg_3"},
+			},
+			DiskTemplate: &buildspec.DiskTemplate{ID: "dtpl_1", Version: 2},
+			NetworkInterfaces: []*buildspec.NetworkInterface{
+				{
+					Network: &buildspec.Network{ID: "netw_1"},
+					SpeedProfile: &buildspec.NetworkSpeedProfile{
+						ID: "nsp_10",
+					},
+					IPAddressAllocations: []*buildspec.IPAddressAllocation{
+						{
+							Type:      buildspec.ExistingIPAddressAllocation,
+							IPAddress: &buildspec.IPAddress{ID: "ip_1"},
+						},
+					},
+				},
+				{VirtualNetwork: &buildspec.VirtualNetwork{ID: "vnet_1"}},
+			},
+			Group: &buildspec.Group{ID: "vmgrp_1"},
+			AuthorizedKeys: &buildspec.AuthorizedKeys{
+				SSHKeys: []string{"key_1", "key_1"},
+			},
+		}
+		assert.Equal(t, want, spec)
+	})
+
+	t.Run("missing and ambiguous references", func(t *testing.T) {
+		spec := &buildspec.VirtualMachineSpec{
+			DataCenter: &buildspec.DataCenter{Name: "Amsterdam"},
+			Resources: &buildspec.Resources{
+				Package: &buildspec.Package{Permalink: "rock-33"},
+			},
+			NetworkInterfaces: []*buildspec.NetworkInterface{
+				{
+					Network:        &buildspec.Network{Permalink: "pubilc"},
+					VirtualNetwork: &buildspec.VirtualNetwork{ID: "vnet_9"},
+				},
+			},
+			Group: &buildspec.Group{Name: "db"},
+			AuthorizedKeys: &buildspec.AuthorizedKeys{
+				SSHKeys: []string{"ci"},
+			},
+		}
+		r := core.NewSpecResolver(
+			newResolverFake().API(), core.OrganizationRef{ID: "org_1"},
+		)
+		r.Canonicalize = true
+
+		got, err := r.Resolve(context.Background(), spec)
+		require.NoError(t, err)
+
+		err = got.Err()
+		assert.ErrorIs(t, err, core.ErrUnresolvedSpecReferences)
+		assert.EqualError(t, err,
+			"katapult: core: unresolved_spec_references: "+
+				`data_center: data_center with name "Amsterdam" is `+
+				"ambiguous (dc_2, dc_3); "+
+				`resources.package: package with permalink "rock-33" is `+
+				"missing; "+
+				"network_interfaces[0].network: network with permalink "+
+				`"pubilc" is missing; `+
+				"network_interfaces[0].virtual_network: virtual_network "+
+				`with id "vnet_9" is missing; `+
+				`group: group with name "db" is missing; `+
+				"authorized_keys.ssh_keys[0]: ssh_key with name "+
+				`"ci" is ambiguous (key_2, key_3)`,
+		)
+		assert.Equal(t,
+			&buildspec.DataCenter{Name: "Amsterdam"}, spec.DataCenter,
+			"unresolved references must not be rewritten",
+		)
+	})
+
+	t.Run("organization from context", func(t *testing.T) {
+		fake := newResolverFake()
+		spec := &buildspec.VirtualMachineSpec{
+			Group: &buildspec.Group{Name: "web"},
+		}
+		r := core.NewSpecResolver(fake.API(), core.OrganizationRef{})
+		ctx := core.ContextWithOrganization(
+			context.Background(), core.OrganizationRef{SubDomain: "acme"},
+		)
+
+		_, err := r.Resolve(ctx, spec)
+		require.NoError(t, err)
+
+		calls := fake.CallsTo("VirtualMachineGroups", "List")
+		require.Len(t, calls, 1)
+		assert.Equal(t,
+			core.OrganizationRef{SubDomain: "acme"}, calls[0].Args[1],
+		)
+	})
+
+	t.Run("API errors", func(t *testing.T) {
+		fake := newResolverFake()
+		apiErr := errors.New("connection refused")
+		fake.VirtualMachinePackages.GetFunc = func(
+			_ context.Context,
+			_ core.VirtualMachinePackageRef,
+			_ ...katapult.RequestOption,
+		) (*core.VirtualMachinePackage, *katapult.Response, error) {
+			return nil, nil, apiErr
+		}
+		r := core.NewSpecResolver(fake.API(), core.OrganizationRef{ID: "o"})
+
+		got, err := r.Resolve(context.Background(), newSpec())
+
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, apiErr)
+	})
+}
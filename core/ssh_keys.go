@@ -37,6 +37,7 @@ func (s *SSHKeysClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*AuthSSHKey, *katapult.Response, error) {
+	ref = ref.withDefault(ctx)
 	qs := queryValues(opts, ref)
 	u := &url.URL{Path: "organizations/_/ssh_keys", RawQuery: qs.Encode()}
 
@@ -60,6 +61,7 @@ func (s *SSHKeysClient) Add(
 	properties AuthSSHKeyProperties,
 	reqOpts ...katapult.RequestOption,
 ) (*AuthSSHKey, *katapult.Response, error) {
+	ref = ref.withDefault(ctx)
 	qs := ref.queryValues()
 	u := &url.URL{Path: "organizations/_/ssh_keys", RawQuery: qs.Encode()}
 
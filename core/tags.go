@@ -39,6 +39,7 @@ func (s *TagsClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*Tag, *katapult.Response, error) {
+	ref = ref.withDefault(ctx)
 	qs := queryValues(opts, ref)
 	u := &url.URL{Path: "organizations/_/tags", RawQuery: qs.Encode()}
 
@@ -80,6 +81,7 @@ func (s *TagsClient) Create(
 	args TagArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*Tag, *katapult.Response, error) {
+	ref = ref.withDefault(ctx)
 	qs := ref.queryValues()
 	u := &url.URL{Path: "organizations/_/tags", RawQuery: qs.Encode()}
 
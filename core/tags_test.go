@@ -189,6 +189,35 @@ func TestTagsClient_List(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "organization from context",
+			args: args{
+				ctx: ContextWithOrganization(
+					context.Background(),
+					OrganizationRef{SubDomain: "acme"},
+				),
+			},
+			resp: &katapult.Response{
+				Pagination: &katapult.Pagination{Total: 333},
+			},
+			respV: &tagsResponseBody{
+				Tags: []*Tag{
+					{ID: "tag_O574YEEEYeLmqdmn"},
+				},
+			},
+			want: []*Tag{
+				{ID: "tag_O574YEEEYeLmqdmn"},
+			},
+			wantReq: &katapult.Request{
+				Method: "GET",
+				URL: &url.URL{
+					Path: "/core/v1/organizations/_/tags",
+					RawQuery: url.Values{
+						"organization[sub_domain]": []string{"acme"},
+					}.Encode(),
+				},
+			},
+		},
 		{
 			name: "success with nil options",
 			args: args{
@@ -61,6 +61,7 @@ func (s *TrashObjectsClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*TrashObject, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/trash_objects",
@@ -123,6 +124,7 @@ func (s *TrashObjectsClient) PurgeAll(
 	org OrganizationRef,
 	reqOpts ...katapult.RequestOption,
 ) (*Task, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{
 		Path:     "organizations/_/trash_objects/purge_all",
 		RawQuery: org.queryValues().Encode(),
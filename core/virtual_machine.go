@@ -6,6 +6,7 @@ import (
 
 	"github.com/augurysys/timestamp"
 	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/namegenerator"
 )
 
 type VirtualMachine struct {
@@ -21,6 +22,8 @@ type VirtualMachine struct {
 	Organization        *Organization          `json:"organization,omitempty"`
 	Group               *VirtualMachineGroup   `json:"group,omitempty"`
 	Package             *VirtualMachinePackage `json:"package,omitempty"`
+	MemoryInGB          int                    `json:"memory_in_gb,omitempty"`
+	CPUCores            int                    `json:"cpu_cores,omitempty"`
 	AttachedISO         *ISO                   `json:"attached_iso,omitempty"`
 	Tags                []*Tag                 `json:"tags,omitempty"`
 	TagNames            []string               `json:"tag_names,omitempty"`
@@ -111,6 +114,7 @@ func (s *VirtualMachinesClient) List(
 	opts *ListOptions,
 	reqOpts ...katapult.RequestOption,
 ) ([]*VirtualMachine, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org, opts)
 	u := &url.URL{
 		Path:     "organizations/_/virtual_machines",
@@ -123,6 +127,51 @@ func (s *VirtualMachinesClient) List(
 	return body.VirtualMachines, resp, err
 }
 
+// Hostnames returns the hostnames of all virtual machines in org, fetching
+// every page of results.
+func (s *VirtualMachinesClient) Hostnames(
+	ctx context.Context,
+	org OrganizationRef,
+	reqOpts ...katapult.RequestOption,
+) (namegenerator.HostnameSet, error) {
+	vms, err := allPages(func(
+		opts *ListOptions,
+	) ([]*VirtualMachine, *katapult.Response, error) {
+		return s.List(ctx, org, opts, reqOpts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := namegenerator.NewHostnameSet()
+	for _, vm := range vms {
+		hostnames.Add(vm.Hostname)
+	}
+
+	return hostnames, nil
+}
+
+// UniqueHostname returns a random hostname from gen which is not used by any
+// virtual machine in org, as returned by Hostnames. When gen is nil, the
+// package level generator of the namegenerator package is used.
+func (s *VirtualMachinesClient) UniqueHostname(
+	ctx context.Context,
+	org OrganizationRef,
+	gen *namegenerator.NameGenerator,
+	reqOpts ...katapult.RequestOption,
+) (string, error) {
+	taken, err := s.Hostnames(ctx, org, reqOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	if gen == nil {
+		return namegenerator.UniqueHostname(taken), nil
+	}
+
+	return gen.UniqueHostname(taken), nil
+}
+
 func (s *VirtualMachinesClient) Get(
 	ctx context.Context,
 	ref VirtualMachineRef,
@@ -123,6 +123,7 @@ func (s *VirtualMachineBuildsClient) Create(
 	args *VirtualMachineBuildArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*VirtualMachineBuild, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/virtual_machines/build"}
 	reqBody := &virtualMachineBuildCreateRequest{
 		Hostname:            args.Hostname,
@@ -146,6 +147,7 @@ func (s *VirtualMachineBuildsClient) CreateFromSpec(
 	spec *buildspec.VirtualMachineSpec,
 	reqOpts ...katapult.RequestOption,
 ) (*VirtualMachineBuild, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	specXML, _ := spec.XML()
 
 	u := &url.URL{Path: "organizations/_/virtual_machines/build_from_spec"}
@@ -165,6 +167,7 @@ func (s *VirtualMachineBuildsClient) CreateFromSpecXML(
 	specXML string,
 	reqOpts ...katapult.RequestOption,
 ) (*VirtualMachineBuild, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/virtual_machines/build_from_spec"}
 	reqBody := &virtualMachineBuildCreateFromSpecRequest{
 		Organization: org,
@@ -105,6 +105,7 @@ func (s *VirtualMachineGroupsClient) List(
 	org OrganizationRef,
 	reqOpts ...katapult.RequestOption,
 ) ([]*VirtualMachineGroup, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	qs := queryValues(org)
 	u := &url.URL{
 		Path:     "organizations/_/virtual_machine_groups",
@@ -144,6 +145,7 @@ func (s *VirtualMachineGroupsClient) Create(
 	args *VirtualMachineGroupCreateArguments,
 	reqOpts ...katapult.RequestOption,
 ) (*VirtualMachineGroup, *katapult.Response, error) {
+	org = org.withDefault(ctx)
 	u := &url.URL{Path: "organizations/_/virtual_machine_groups"}
 	reqBody := &virtualMachineGroupCreateRequest{
 		Organization: org,
@@ -10,7 +10,9 @@ import (
 	"github.com/krystal/go-katapult"
 	"github.com/krystal/go-katapult/internal/test"
 	"github.com/krystal/go-katapult/internal/testclient"
+	"github.com/krystal/go-katapult/namegenerator"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -525,6 +527,112 @@ func TestVirtualMachinesClient_List(t *testing.T) {
 	}
 }
 
+func TestVirtualMachinesClient_Hostnames(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       []string
+		errStr     string
+		errIs      error
+		respStatus int
+	}{
+		{
+			name: "all pages",
+			want: []string{
+				"bitter-beautiful-mango",
+				"popular-shapely-tank",
+				"popular-blue-kumquat",
+			},
+			respStatus: http.StatusOK,
+		},
+		{
+			name:       "non-existent organization",
+			errStr:     fixtureOrganizationNotFoundErr,
+			errIs:      ErrOrganizationNotFound,
+			respStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm, mux, _, teardown := prepareTestClient(t)
+			defer teardown()
+			c := NewVirtualMachinesClient(rm)
+
+			mux.HandleFunc(
+				"/core/v1/organizations/_/virtual_machines",
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "GET", r.Method)
+					assertRequestOptionHeader(t, r)
+
+					w.WriteHeader(tt.respStatus)
+					if tt.respStatus != http.StatusOK {
+						_, _ = w.Write(
+							fixture("organization_not_found_error"),
+						)
+
+						return
+					}
+					_, _ = w.Write(fixture(
+						"virtual_machines_list_page_" +
+							r.URL.Query().Get("page"),
+					))
+				},
+			)
+
+			got, err := c.Hostnames(
+				context.Background(),
+				OrganizationRef{ID: "org_O648YDMEYeLmqdmn"},
+				testRequestOption,
+			)
+
+			if tt.errStr == "" {
+				assert.NoError(t, err)
+				assert.Len(t, got, len(tt.want))
+				for _, h := range tt.want {
+					assert.True(t, got.Has(h), "missing hostname %s", h)
+				}
+			} else {
+				assert.EqualError(t, err, tt.errStr)
+				assert.Nil(t, got)
+			}
+
+			if tt.errIs != nil {
+				assert.ErrorIs(t, err, tt.errIs)
+			}
+		})
+	}
+}
+
+func TestVirtualMachinesClient_UniqueHostname(t *testing.T) {
+	rm, mux, _, teardown := prepareTestClient(t)
+	defer teardown()
+	c := NewVirtualMachinesClient(rm)
+
+	mux.HandleFunc(
+		"/core/v1/organizations/_/virtual_machines",
+		func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(fixture("virtual_machines_list"))
+		},
+	)
+
+	// The generator can only produce two hostnames, and one of them is
+	// taken by a virtual machine in the fixture.
+	gen := namegenerator.NewWithSeed(1,
+		map[string][]string{
+			"flavor":     {"bitter"},
+			"appearance": {"beautiful"},
+		},
+		map[string][]string{"fruit": {"mango"}},
+	)
+	got, err := c.UniqueHostname(
+		context.Background(),
+		OrganizationRef{ID: "org_O648YDMEYeLmqdmn"},
+		gen,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "beautiful-bitter-mango", got)
+}
+
 func TestVirtualMachinesClient_Get(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 //go:generate go run github.com/krystal/go-katapult/tools/codegen -t errors -p katapult -o . -i (Apia|Rapid)\/.* -e .+Legacy.+ -f ./schemas/core/v1.json
@@ -17,6 +20,10 @@ var (
 	// ErrRequest is returned when there's an issue with building the request.
 	ErrRequest = fmt.Errorf("%w: request", Err)
 
+	// ErrTokenSource is returned when a token could not be obtained from a
+	// TokenSource.
+	ErrTokenSource = fmt.Errorf("%w: token_source", Err)
+
 	// ErrConfig is returned when there's a configuration related issue.
 	ErrConfig = fmt.Errorf("%w: config", Err)
 
@@ -69,6 +76,16 @@ type ResponseError struct {
 	Code        string          `json:"code,omitempty"`
 	Description string          `json:"description,omitempty"`
 	Detail      json.RawMessage `json:"detail,omitempty"`
+
+	// RetryAfter is the duration the server asked clients to wait before
+	// retrying the request, as given by the Retry-After response header. It is
+	// zero when the header was not present.
+	RetryAfter time.Duration `json:"-"`
+
+	// RequestID is the ID Katapult assigned to the failed request, as given by
+	// the X-Request-ID response header. It is empty when the header was not
+	// present.
+	RequestID string `json:"-"`
 }
 
 func NewResponseError(
@@ -77,42 +94,120 @@ func NewResponseError(
 	description string,
 	rawDetail json.RawMessage,
 ) *ResponseError {
-	var parent error
+	parent := StatusError(httpStatus)
+	if parent == ErrNotFound {
+		parent = ErrResourceNotFound
+	}
+
+	return &ResponseError{
+		parent:      parent,
+		Code:        code,
+		Description: description,
+		Detail:      rawDetail,
+	}
+}
+
+// StatusError returns the HTTP status-based error for the given status code.
+// ErrUnknown is returned for status codes which do not have a specific error.
+func StatusError(httpStatus int) error {
 	switch httpStatus {
 	case http.StatusBadRequest:
-		parent = ErrBadRequest
+		return ErrBadRequest
 	case http.StatusUnauthorized:
-		parent = ErrUnauthorized
+		return ErrUnauthorized
 	case http.StatusForbidden:
-		parent = ErrForbidden
+		return ErrForbidden
 	case http.StatusNotFound:
-		parent = ErrResourceNotFound
+		return ErrNotFound
 	case http.StatusNotAcceptable:
-		parent = ErrNotAcceptable
+		return ErrNotAcceptable
 	case http.StatusConflict:
-		parent = ErrConflict
+		return ErrConflict
 	case http.StatusUnprocessableEntity:
-		parent = ErrUnprocessableEntity
+		return ErrUnprocessableEntity
 	case http.StatusTooManyRequests:
-		parent = ErrTooManyRequests
+		return ErrTooManyRequests
 	case http.StatusInternalServerError:
-		parent = ErrInternalServerError
+		return ErrInternalServerError
 	case http.StatusBadGateway:
-		parent = ErrBadGateway
+		return ErrBadGateway
 	case http.StatusServiceUnavailable:
-		parent = ErrServiceUnavailable
+		return ErrServiceUnavailable
 	case http.StatusGatewayTimeout:
-		parent = ErrGatewayTimeout
+		return ErrGatewayTimeout
 	default:
-		parent = ErrUnknown
+		return ErrUnknown
 	}
+}
 
-	return &ResponseError{
-		parent:      parent,
-		Code:        code,
-		Description: description,
-		Detail:      rawDetail,
+// DecodeResponseError decodes the JSON body of a failed API response. Error
+// codes known to the katapult package are cast to their specific error type,
+// all others are returned as a *ResponseError. ErrUnexpectedResponse is
+// returned if body does not contain a valid error.
+//
+// The status code and headers are read from r, while the body is read from
+// body, allowing callers who have already consumed r.Body to pass a copy.
+func DecodeResponseError(r *http.Response, body io.Reader) error {
+	bodyErr, err := decodeResponseErrorBody(body)
+	if err != nil {
+		return err
+	}
+
+	return castResponseError(newResponseErrorFromHTTP(r, bodyErr))
+}
+
+func newResponseErrorFromHTTP(
+	r *http.Response,
+	bodyErr *ResponseError,
+) *ResponseError {
+	respErr := NewResponseError(
+		r.StatusCode,
+		bodyErr.Code,
+		bodyErr.Description,
+		bodyErr.Detail,
+	)
+	respErr.RetryAfter = parseRetryAfter(r.Header.Get("Retry-After"))
+	respErr.RequestID = r.Header.Get(HeaderRequestID)
+
+	return respErr
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which may either
+// be a number of seconds, or a HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
 	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+func decodeResponseErrorBody(body io.Reader) (*ResponseError, error) {
+	var b responseErrorBody
+	err := json.NewDecoder(body).Decode(&b)
+	if err != nil {
+		return nil, ErrUnexpectedResponse
+	}
+
+	if b.Error == nil || b.Error.Code == "" {
+		return nil, ErrUnexpectedResponse
+	}
+
+	return b.Error, nil
 }
 
 func (s *ResponseError) Error() string {
@@ -120,6 +215,7 @@ func (s *ResponseError) Error() string {
 	if s.Description != "" {
 		out += ": " + s.Description
 	}
+	out = withRequestID(out, s.RequestID)
 
 	// When RawDetail is not a empty JSON object ("{}" ), we prettify and
 	// include it in the error.
@@ -132,6 +228,15 @@ func (s *ResponseError) Error() string {
 	return out
 }
 
+// withRequestID appends requestID to msg, unless it is empty.
+func withRequestID(msg string, requestID string) string {
+	if requestID == "" {
+		return msg
+	}
+
+	return msg + " (request_id: " + requestID + ")"
+}
+
 func (s *ResponseError) Is(target error) bool {
 	return errors.Is(s.parent, target)
 }
@@ -142,7 +247,8 @@ func (s *ResponseError) Unwrap() error {
 
 // CommonError handles common logic shared between all API-based error types.
 type CommonError struct {
-	parent error
+	parent   error
+	response *ResponseError
 
 	Code        string `json:"code,omitempty"`
 	Description string `json:"description,omitempty"`
@@ -156,6 +262,43 @@ func NewCommonError(parent error, code, description string) CommonError {
 	}
 }
 
+// NewCommonErrorFromResponse returns a CommonError for the given
+// *ResponseError, keeping a reference to it so response metadata such as
+// RetryAfter remains accessible.
+func NewCommonErrorFromResponse(
+	parent error,
+	code string,
+	theError *ResponseError,
+) CommonError {
+	ce := NewCommonError(parent, code, theError.Description)
+	ce.response = theError
+
+	return ce
+}
+
+// ResponseError returns the *ResponseError the error was created from, or nil
+// if it was not created from one.
+func (s *CommonError) ResponseError() *ResponseError {
+	return s.response
+}
+
+// RequestID returns the ID Katapult assigned to the failed request, or an
+// empty string if it is not known.
+func (s *CommonError) RequestID() string {
+	if s.response == nil {
+		return ""
+	}
+
+	return s.response.RequestID
+}
+
+// WithRequestID appends the ID of the failed request to msg, when it is
+// known. Error types embedding CommonError with custom Error methods use it
+// so request IDs are consistently included in error strings.
+func (s *CommonError) WithRequestID(msg string) string {
+	return withRequestID(msg, s.RequestID())
+}
+
 func (s *CommonError) BaseError() string {
 	if s.parent != nil {
 		return s.parent.Error()
@@ -170,7 +313,7 @@ func (s *CommonError) Error() string {
 		out += ": " + s.Description
 	}
 
-	return out
+	return s.WithRequestID(out)
 }
 
 func (s *CommonError) Is(target error) bool {
@@ -14,8 +14,8 @@ func (s *ScopeNotGrantedError) Error() string {
 		return s.CommonError.Error()
 	}
 
-	return fmt.Sprintf(
+	return s.CommonError.WithRequestID(fmt.Sprintf(
 		"%s: required scopes: %s",
 		s.CommonError.BaseError(), strings.Join(s.Detail.Scopes, ", "),
-	)
+	))
 }
@@ -24,10 +24,10 @@ func NewScopeNotGrantedError(theError *ResponseError) *ScopeNotGrantedError {
 	}
 
 	return &ScopeNotGrantedError{
-		CommonError: NewCommonError(
+		CommonError: NewCommonErrorFromResponse(
 			ErrScopeNotGranted,
 			"scope_not_granted",
-			theError.Description,
+			theError,
 		),
 		Detail: detail,
 	}
@@ -0,0 +1,127 @@
+package katapult
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"time"
+)
+
+// This file contains helper functions for classifying errors returned by the
+// katapult, core and next packages, allowing retry loops, controllers and
+// CLIs to make consistent decisions regardless of which client was used.
+
+// apiError is implemented by ResponseError and CommonError, and hence by all
+// specific API error types which embed CommonError.
+type apiError interface {
+	apiErrorCode() string
+}
+
+// responseErrorer is implemented by CommonError, and hence by all specific
+// API error types which embed it.
+type responseErrorer interface {
+	ResponseError() *ResponseError
+}
+
+// IsNotFound returns true if err indicates the requested resource or endpoint
+// could not be found.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict returns true if err indicates the request conflicts with the
+// current state of the resource.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsPermissionDenied returns true if err indicates the request was not
+// authenticated, or the authenticated identity is not permitted to perform
+// the request.
+func IsPermissionDenied(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRetryable returns true if err is a temporary failure, where the same
+// request might succeed if retried at a later time. This includes rate
+// limiting, bad gateway, service unavailable and gateway timeout responses,
+// along with network timeouts, refused connections and reset connections.
+//
+// Errors caused by cancellation of the request's context are never
+// retryable. Callers should still check their own context before retrying, as
+// an expired context deadline is reported as a timeout.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, ErrTooManyRequests),
+		errors.Is(err, ErrBadGateway),
+		errors.Is(err, ErrServiceUnavailable),
+		errors.Is(err, ErrGatewayTimeout):
+		return true
+	case errors.Is(err, ErrResponse):
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// RetryAfter returns the duration the Katapult API asked clients to wait
+// before retrying, as given by the Retry-After header of the response which
+// caused err. The second return value is false if err was not caused by an API
+// response, or the response did not include a Retry-After header.
+func RetryAfter(err error) (time.Duration, bool) {
+	respErr := responseErrorOf(err)
+	if respErr == nil || respErr.RetryAfter <= 0 {
+		return 0, false
+	}
+
+	return respErr.RetryAfter, true
+}
+
+// ErrorCode returns the Katapult API error code of err, such as
+// "virtual_machine_not_found". An empty string is returned if err was not
+// caused by an API error response.
+func ErrorCode(err error) string {
+	var ce apiError
+	if errors.As(err, &ce) {
+		return ce.apiErrorCode()
+	}
+
+	return ""
+}
+
+func responseErrorOf(err error) *ResponseError {
+	var ce responseErrorer
+	if errors.As(err, &ce) {
+		if respErr := ce.ResponseError(); respErr != nil {
+			return respErr
+		}
+	}
+
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return respErr
+	}
+
+	return nil
+}
+
+func (s *ResponseError) apiErrorCode() string {
+	return s.Code
+}
+
+func (s *CommonError) apiErrorCode() string {
+	return s.Code
+}
@@ -0,0 +1,325 @@
+package katapult
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testEmbeddedError mimics the structure of generated API error types in
+// other packages, such as core.VirtualMachineNotFoundError.
+type testEmbeddedError struct {
+	CommonError
+}
+
+var (
+	errTestNotFound = fmt.Errorf(
+		"%w: virtual_machine_not_found", ErrResourceNotFound,
+	)
+	testNotFoundResponseError = NewResponseError(
+		http.StatusNotFound,
+		"virtual_machine_not_found",
+		"No virtual machine was found",
+		json.RawMessage(`{}`),
+	)
+	testNotFoundError = &testEmbeddedError{
+		CommonError: NewCommonErrorFromResponse(
+			errTestNotFound,
+			"virtual_machine_not_found",
+			testNotFoundResponseError,
+		),
+	}
+	testRateLimitError = &testEmbeddedError{
+		CommonError: NewCommonErrorFromResponse(
+			fmt.Errorf("%w: rate_limit_reached", ErrTooManyRequests),
+			"rate_limit_reached",
+			&ResponseError{
+				parent:     ErrTooManyRequests,
+				Code:       "rate_limit_reached",
+				RetryAfter: 42 * time.Second,
+			},
+		),
+	}
+	testTimeoutError = &url.Error{
+		Op:  "Get",
+		URL: "https://api.katapult.io/core/v1/data_centers",
+		Err: &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+	}
+	testConnRefusedError = &url.Error{
+		Op:  "Get",
+		URL: "https://api.katapult.io/core/v1/data_centers",
+		Err: &net.OpError{
+			Op:  "dial",
+			Net: "tcp",
+			Err: os.NewSyscallError("connect", syscall.ECONNREFUSED),
+		},
+	}
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "ErrNotFound", err: ErrNotFound, want: true},
+		{
+			name: "ResponseError",
+			err:  testNotFoundResponseError,
+			want: true,
+		},
+		{name: "specific error", err: testNotFoundError, want: true},
+		{
+			name: "wrapped specific error",
+			err:  fmt.Errorf("lookup failed: %w", testNotFoundError),
+			want: true,
+		},
+		{name: "other API error", err: ErrConflict, want: false},
+		{name: "other error", err: io.EOF, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsNotFound(tt.err))
+		})
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "ErrConflict", err: ErrConflict, want: true},
+		{
+			name: "ResponseError",
+			err: NewResponseError(
+				http.StatusConflict, "deletion_restricted", "", nil,
+			),
+			want: true,
+		},
+		{name: "not found", err: testNotFoundError, want: false},
+		{name: "other error", err: io.EOF, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsConflict(tt.err))
+		})
+	}
+}
+
+func TestIsPermissionDenied(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "ErrUnauthorized", err: ErrUnauthorized, want: true},
+		{name: "ErrForbidden", err: ErrForbidden, want: true},
+		{
+			name: "ScopeNotGrantedError",
+			err: NewScopeNotGrantedError(NewResponseError(
+				http.StatusForbidden, "scope_not_granted", "", nil,
+			)),
+			want: true,
+		},
+		{name: "not found", err: testNotFoundError, want: false},
+		{name: "other error", err: io.EOF, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPermissionDenied(tt.err))
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "too many requests", err: ErrTooManyRequests, want: true},
+		{name: "rate limit reached", err: testRateLimitError, want: true},
+		{name: "bad gateway", err: ErrBadGateway, want: true},
+		{
+			name: "service unavailable",
+			err:  ErrServiceUnavailable,
+			want: true,
+		},
+		{name: "gateway timeout", err: ErrGatewayTimeout, want: true},
+		{
+			name: "unexpected bad gateway response",
+			err: fmt.Errorf(
+				"%w: %w", ErrBadGateway, ErrUnexpectedResponse,
+			),
+			want: true,
+		},
+		{
+			name: "internal server error",
+			err:  ErrInternalServerError,
+			want: false,
+		},
+		{name: "not found", err: testNotFoundError, want: false},
+		{
+			name: "unexpected response",
+			err:  ErrUnexpectedResponse,
+			want: false,
+		},
+		{name: "network timeout", err: testTimeoutError, want: true},
+		{
+			name: "connection refused",
+			err:  testConnRefusedError,
+			want: true,
+		},
+		{
+			name: "connection reset",
+			err:  fmt.Errorf("read: %w", syscall.ECONNRESET),
+			want: true,
+		},
+		{name: "unexpected EOF", err: io.ErrUnexpectedEOF, want: true},
+		{
+			name: "context canceled",
+			err: &url.Error{
+				Op:  "Get",
+				URL: "https://api.katapult.io/core/v1/data_centers",
+				Err: context.Canceled,
+			},
+			want: false,
+		},
+		{name: "request error", err: ErrRequest, want: false},
+		{name: "other error", err: errors.New("nope"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "nil", err: nil},
+		{
+			name:   "ResponseError",
+			err:    &ResponseError{RetryAfter: 5 * time.Second},
+			want:   5 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "specific error",
+			err:    testRateLimitError,
+			want:   42 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "wrapped specific error",
+			err:    fmt.Errorf("failed: %w", testRateLimitError),
+			want:   42 * time.Second,
+			wantOK: true,
+		},
+		{name: "without Retry-After", err: testNotFoundError},
+		{name: "other error", err: testTimeoutError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := RetryAfter(tt.err)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: ""},
+		{
+			name: "ResponseError",
+			err:  testNotFoundResponseError,
+			want: "virtual_machine_not_found",
+		},
+		{
+			name: "specific error",
+			err:  testRateLimitError,
+			want: "rate_limit_reached",
+		},
+		{
+			name: "specific error without ResponseError",
+			err: &testEmbeddedError{
+				CommonError: NewCommonError(
+					ErrConflict, "deletion_restricted", "",
+				),
+			},
+			want: "deletion_restricted",
+		},
+		{
+			name: "wrapped specific error",
+			err:  fmt.Errorf("lookup failed: %w", testNotFoundError),
+			want: "virtual_machine_not_found",
+		},
+		{name: "sentinel error", err: ErrNotFound, want: ""},
+		{name: "other error", err: testTimeoutError, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCode(tt.err))
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "empty", value: "", want: 0},
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "negative seconds", value: "-5", want: 0},
+		{name: "invalid", value: "soon", want: 0},
+		{
+			name:  "date in the past",
+			value: "Wed, 21 Oct 2015 07:28:00 GMT",
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRetryAfter(tt.value))
+		})
+	}
+
+	t.Run("date in the future", func(t *testing.T) {
+		value := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+		got := parseRetryAfter(value)
+
+		assert.Greater(t, got, 58*time.Minute)
+		assert.LessOrEqual(t, got, time.Hour)
+	})
+}
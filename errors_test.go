@@ -3,7 +3,9 @@ package katapult
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jimeh/undent"
 	"github.com/krystal/go-katapult/internal/test"
@@ -290,11 +292,143 @@ func TestNewResponseError(t *testing.T) {
 	}
 }
 
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		httpStatus int
+		want       error
+	}{
+		{httpStatus: http.StatusBadRequest, want: ErrBadRequest},
+		{httpStatus: http.StatusUnauthorized, want: ErrUnauthorized},
+		{httpStatus: http.StatusForbidden, want: ErrForbidden},
+		{httpStatus: http.StatusNotFound, want: ErrNotFound},
+		{httpStatus: http.StatusNotAcceptable, want: ErrNotAcceptable},
+		{httpStatus: http.StatusConflict, want: ErrConflict},
+		{
+			httpStatus: http.StatusUnprocessableEntity,
+			want:       ErrUnprocessableEntity,
+		},
+		{httpStatus: http.StatusTooManyRequests, want: ErrTooManyRequests},
+		{
+			httpStatus: http.StatusInternalServerError,
+			want:       ErrInternalServerError,
+		},
+		{httpStatus: http.StatusBadGateway, want: ErrBadGateway},
+		{
+			httpStatus: http.StatusServiceUnavailable,
+			want:       ErrServiceUnavailable,
+		},
+		{httpStatus: http.StatusGatewayTimeout, want: ErrGatewayTimeout},
+		{httpStatus: http.StatusTeapot, want: ErrUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.httpStatus), func(t *testing.T) {
+			assert.Equal(t, tt.want, StatusError(tt.httpStatus))
+		})
+	}
+}
+
+func TestDecodeResponseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		httpStatus int
+		header     http.Header
+		body       string
+		want       error
+		wantErr    error
+	}{
+		{
+			name:       "generic error",
+			httpStatus: http.StatusConflict,
+			body: `{"error":{"code":"busy","description":"Try later",` +
+				`"detail":{}}}`,
+			want: &ResponseError{
+				parent:      ErrConflict,
+				Code:        "busy",
+				Description: "Try later",
+				Detail:      json.RawMessage(`{}`),
+			},
+			wantErr: ErrConflict,
+		},
+		{
+			name:       "generic error with Retry-After header",
+			httpStatus: http.StatusServiceUnavailable,
+			header:     http.Header{"Retry-After": []string{"30"}},
+			body:       `{"error":{"code":"maintenance"}}`,
+			want: &ResponseError{
+				parent:     ErrServiceUnavailable,
+				Code:       "maintenance",
+				RetryAfter: 30 * time.Second,
+			},
+			wantErr: ErrServiceUnavailable,
+		},
+		{
+			name:       "generic error with X-Request-ID header",
+			httpStatus: http.StatusConflict,
+			header:     http.Header{"X-Request-Id": []string{"req_abc123"}},
+			body:       `{"error":{"code":"busy"}}`,
+			want: &ResponseError{
+				parent:    ErrConflict,
+				Code:      "busy",
+				RequestID: "req_abc123",
+			},
+			wantErr: ErrConflict,
+		},
+		{
+			name:       "specific error",
+			httpStatus: http.StatusForbidden,
+			body: `{"error":{"code":"scope_not_granted",` +
+				`"description":"Not granted","detail":{"scopes":["a"]}}}`,
+			want: &ScopeNotGrantedError{
+				CommonError: CommonError{
+					parent: ErrScopeNotGranted,
+					response: &ResponseError{
+						parent:      ErrForbidden,
+						Code:        "scope_not_granted",
+						Description: "Not granted",
+						Detail:      json.RawMessage(`{"scopes":["a"]}`),
+					},
+					Code:        "scope_not_granted",
+					Description: "Not granted",
+				},
+				Detail: &ScopeNotGrantedErrorDetail{Scopes: []string{"a"}},
+			},
+			wantErr: ErrScopeNotGranted,
+		},
+		{
+			name:       "missing error code",
+			httpStatus: http.StatusBadRequest,
+			body:       `{"error":{"description":"Oops"}}`,
+			want:       ErrUnexpectedResponse,
+			wantErr:    ErrUnexpectedResponse,
+		},
+		{
+			name:       "invalid JSON",
+			httpStatus: http.StatusBadGateway,
+			body:       `<html>Bad Gateway</html>`,
+			want:       ErrUnexpectedResponse,
+			wantErr:    ErrUnexpectedResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Response{
+				StatusCode: tt.httpStatus,
+				Header:     tt.header,
+			}
+			err := DecodeResponseError(r, strings.NewReader(tt.body))
+
+			assert.Equal(t, tt.want, err)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestResponseError_Error(t *testing.T) {
 	type fields struct {
 		code        string
 		description string
 		detail      json.RawMessage
+		requestID   string
 	}
 	tests := []struct {
 		name   string
@@ -308,6 +442,14 @@ func TestResponseError_Error(t *testing.T) {
 			},
 			want: "disk_not_found",
 		},
+		{
+			name: "code and request ID",
+			fields: fields{
+				code:      "disk_not_found",
+				requestID: "req_abc123",
+			},
+			want: "disk_not_found (request_id: req_abc123)",
+		},
 		{
 			name: "code and description",
 			fields: fields{
@@ -329,6 +471,21 @@ func TestResponseError_Error(t *testing.T) {
 				}`,
 			),
 		},
+		{
+			name: "code, description, detail and request ID",
+			fields: fields{
+				code:        "disk_not_found",
+				description: "Specified disk could not be found",
+				detail:      json.RawMessage(`{"id":"disk_oVsxmtVXYQSmmk8g"}`),
+				requestID:   "req_abc123",
+			},
+			want: undent.String(`
+				disk_not_found: Specified disk could not be found ` +
+				`(request_id: req_abc123) -- {
+				  "id": "disk_oVsxmtVXYQSmmk8g"
+				}`,
+			),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -336,6 +493,7 @@ func TestResponseError_Error(t *testing.T) {
 				Code:        tt.fields.code,
 				Description: tt.fields.description,
 				Detail:      tt.fields.detail,
+				RequestID:   tt.fields.requestID,
 			}
 
 			got := respErr.Error()
@@ -528,6 +686,7 @@ func TestCommonError_Error(t *testing.T) {
 		parent      error
 		code        string
 		description string
+		requestID   string
 	}
 	tests := []struct {
 		name   string
@@ -569,6 +728,17 @@ func TestCommonError_Error(t *testing.T) {
 			},
 			want: ErrUnauthorized.Error() + ": Invalid API key",
 		},
+		{
+			name: "parent, code, description and request ID",
+			fields: fields{
+				parent:      ErrUnauthorized,
+				code:        "invalid_api_key",
+				description: "Invalid API key",
+				requestID:   "req_abc123",
+			},
+			want: ErrUnauthorized.Error() + ": Invalid API key " +
+				"(request_id: req_abc123)",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -577,6 +747,11 @@ func TestCommonError_Error(t *testing.T) {
 				Code:        tt.fields.code,
 				Description: tt.fields.description,
 			}
+			if tt.fields.requestID != "" {
+				comErr.response = &ResponseError{
+					RequestID: tt.fields.requestID,
+				}
+			}
 
 			got := comErr.Error()
 
@@ -585,6 +760,18 @@ func TestCommonError_Error(t *testing.T) {
 	}
 }
 
+func TestCommonError_RequestID(t *testing.T) {
+	comErr := &CommonError{}
+	assert.Equal(t, "", comErr.RequestID())
+	assert.Equal(t, "oops", comErr.WithRequestID("oops"))
+
+	comErr = &CommonError{response: &ResponseError{RequestID: "req_abc123"}}
+	assert.Equal(t, "req_abc123", comErr.RequestID())
+	assert.Equal(t,
+		"oops (request_id: req_abc123)", comErr.WithRequestID("oops"),
+	)
+}
+
 func TestCommonError_Is(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -5,9 +5,11 @@ go 1.21.0
 toolchain go1.23.2
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/augurysys/timestamp v0.3.2
 	github.com/dave/jennifer v1.6.0
 	github.com/hashicorp/go-hclog v1.4.0
+	github.com/hashicorp/hcl/v2 v2.20.1
 	github.com/jimeh/go-golden v0.1.0
 	github.com/jimeh/rands v0.3.0
 	github.com/jimeh/undent v1.1.1
@@ -16,13 +18,17 @@ require (
 	github.com/oapi-codegen/oapi-codegen/v2 v2.4.1
 	github.com/oapi-codegen/runtime v1.1.1
 	github.com/stretchr/testify v1.9.0
+	github.com/zclconf/go-cty v1.13.2
 	golang.org/x/text v0.18.0
 	gopkg.in/yaml.v3 v3.0.1
 	mvdan.cc/gofumpt v0.4.0
 )
 
 require (
+	github.com/agext/levenshtein v1.2.1 // indirect
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/apparentlymart/go-textseg/v13 v13.0.0 // indirect
+	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/fatih/color v1.14.1 // indirect
 	github.com/globalsign/mgo v0.0.0-20181015135952-eeefdecb41b8 // indirect
@@ -30,6 +36,7 @@ require (
 	github.com/google/uuid v1.5.0 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mitchellh/go-wordwrap v0.0.0-20150314170334-ad45545899c7 // indirect
 	github.com/mitchellh/reflectwalk v1.0.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.mongodb.org/mongo-driver v1.11.2 // indirect
@@ -0,0 +1,27 @@
+// Package apierror decodes the error responses returned to the generated
+// clients of the next package into the same error types the katapult and core
+// packages return.
+package apierror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+)
+
+// Decode returns an error for a failed response, decoded from body and cast
+// to a core error type by core.CastResponseError. If body does not contain a
+// valid error, an error wrapping both the HTTP status-based error and
+// katapult.ErrUnexpectedResponse is returned.
+func Decode(rsp *http.Response, body []byte) error {
+	err := katapult.DecodeResponseError(rsp, bytes.NewReader(body))
+	if errors.Is(err, katapult.ErrUnexpectedResponse) {
+		return fmt.Errorf("%w: %w", katapult.StatusError(rsp.StatusCode), err)
+	}
+
+	return core.CastResponseError(err)
+}
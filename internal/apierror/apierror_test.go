@@ -0,0 +1,63 @@
+package apierror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		errStr string
+		errIs  []error
+	}{
+		{
+			name:   "core error",
+			status: http.StatusTooManyRequests,
+			body: `{"error":{"code":"rate_limit_reached",` +
+				`"description":"You have reached the rate limit",` +
+				`"detail":{"total_permitted":60}}}`,
+			errStr: "katapult: too_many_requests: rate_limit_reached: " +
+				"max requests per minute: 60",
+			errIs: []error{
+				core.ErrRateLimitReached,
+				katapult.ErrTooManyRequests,
+			},
+		},
+		{
+			name:   "unknown code",
+			status: http.StatusConflict,
+			body:   `{"error":{"code":"busy","description":"Try later"}}`,
+			errStr: "busy: Try later",
+			errIs:  []error{katapult.ErrConflict},
+		},
+		{
+			name:   "unexpected body",
+			status: http.StatusBadGateway,
+			body:   `Bad Gateway`,
+			errStr: "katapult: bad_gateway: katapult: unexpected_response",
+			errIs: []error{
+				katapult.ErrBadGateway,
+				katapult.ErrUnexpectedResponse,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rsp := &http.Response{StatusCode: tt.status}
+
+			err := Decode(rsp, []byte(tt.body))
+
+			assert.EqualError(t, err, tt.errStr)
+			for _, target := range tt.errIs {
+				assert.ErrorIs(t, err, target)
+			}
+		})
+	}
+}
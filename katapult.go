@@ -60,6 +60,36 @@ func WithAPIKey(key string) Option {
 	}
 }
 
+// WithTokenSource authenticates requests with OAuth2 tokens from ts instead
+// of a static API key. Tokens are reused until they expire, and requests
+// rejected with a 401 Unauthorized status or an invalid_api_token error are
+// retried once with a new token.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) error {
+		if ts == nil {
+			return fmt.Errorf("katapult: token source cannot be nil")
+		}
+
+		c.TokenSource = ReuseTokenSource(nil, ts)
+
+		return nil
+	}
+}
+
+// WithCache caches GET responses for catalog endpoints, such as data centers
+// and virtual machine packages, with cache. See Cache for details.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) error {
+		if cache == nil {
+			return fmt.Errorf("katapult: cache cannot be nil")
+		}
+
+		c.Cache = cache
+
+		return nil
+	}
+}
+
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -70,6 +100,16 @@ type Client struct {
 	APIKey    string
 	UserAgent string
 	BaseURL   *url.URL
+
+	// TokenSource provides OAuth2 tokens used to authenticate requests. When
+	// set, it takes precedence over APIKey, but not over API keys given for a
+	// single request or through its context. Set it with WithTokenSource to
+	// ensure tokens are reused between requests.
+	TokenSource TokenSource
+
+	// Cache optionally caches GET responses, and can be used to explicitly
+	// invalidate cached responses.
+	Cache *Cache
 }
 
 func New(opts ...Option) (*Client, error) {
@@ -96,12 +136,13 @@ func (c *Client) Do(
 	request *Request,
 	v interface{},
 ) (*Response, error) {
+	start := time.Now()
 	contentType, bodyReader, err := request.bodyContent()
 	if err != nil {
 		return nil, err
 	}
 
-	u := c.BaseURL.ResolveReference(request.URL)
+	u := c.baseURL(ctx, request).ResolveReference(request.URL)
 	req, err := http.NewRequestWithContext(
 		ctx, request.Method, u.String(), bodyReader,
 	)
@@ -117,17 +158,27 @@ func (c *Client) Do(
 		}
 	}
 
+	counter := &attemptCounter{client: c.HTTPClient}
+	var hc HTTPClient = counter
+	if c.Cache != nil {
+		hc = c.Cache.HTTPClient(counter)
+	}
 	if !request.NoAuth {
-		if c.APIKey == "" {
+		apiKey := c.apiKey(ctx, request)
+		switch {
+		case apiKey != "":
+			req.Header.Set(
+				"Authorization",
+				fmt.Sprintf("Bearer %s", apiKey),
+			)
+		case c.TokenSource != nil:
+			hc = NewTokenSourceHTTPClient(c.TokenSource, hc)
+		default:
 			return nil, fmt.Errorf(
 				"%w: no API key available for authenticated request: %s %s",
 				ErrRequest, request.Method, request.URL.Path,
 			)
 		}
-		req.Header.Set(
-			"Authorization",
-			fmt.Sprintf("Bearer %s", c.APIKey),
-		)
 	}
 	req.Header.Set("User-Agent", c.UserAgent)
 	req.Header.Set("Accept", "application/json")
@@ -135,13 +186,16 @@ func (c *Client) Do(
 		req.Header.Set("Content-Type", contentType)
 	}
 
-	r, err := c.HTTPClient.Do(req)
+	r, err := hc.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer r.Body.Close()
 
 	resp := NewResponse(r)
+	resp.Attempts = counter.attempts
+	defer func() { resp.Latency = time.Since(start) }()
+
 	if resp.StatusCode/100 != 2 {
 		return c.handleResponseError(resp)
 	}
@@ -157,23 +211,56 @@ func (c *Client) Do(
 	return resp, err
 }
 
+// attemptCounter counts the number of requests sent through it.
+type attemptCounter struct {
+	client   HTTPClient
+	attempts int
+}
+
+func (c *attemptCounter) Do(req *http.Request) (*http.Response, error) {
+	c.attempts++
+
+	return c.client.Do(req)
+}
+
+// apiKey returns the API key to use for request, preferring the request's own
+// APIKey, then one set on ctx, and finally the Client's APIKey unless a
+// TokenSource is set.
+func (c *Client) apiKey(ctx context.Context, request *Request) string {
+	if request.APIKey != "" {
+		return request.APIKey
+	}
+	if key, ok := APIKeyFromContext(ctx); ok {
+		return key
+	}
+	if c.TokenSource != nil {
+		return ""
+	}
+
+	return c.APIKey
+}
+
+// baseURL returns the base URL to resolve request against, preferring the
+// request's own BaseURL, then one set on ctx, and finally the Client's BaseURL.
+func (c *Client) baseURL(ctx context.Context, request *Request) *url.URL {
+	if request.BaseURL != nil {
+		return request.BaseURL
+	}
+	if u, ok := BaseURLFromContext(ctx); ok {
+		return u
+	}
+
+	return c.BaseURL
+}
+
 func (c *Client) handleResponseError(resp *Response) (*Response, error) {
-	var body responseErrorBody
-	err := json.NewDecoder(resp.Body).Decode(&body)
+	bodyErr, err := decodeResponseErrorBody(resp.Body)
 	if err != nil {
-		return resp, ErrUnexpectedResponse
+		return resp, err
 	}
+	resp.Error = bodyErr
 
-	if body.Error == nil || body.Error.Code == "" {
-		return resp, ErrUnexpectedResponse
-	}
-	resp.Error = body.Error
-	respErr := NewResponseError(
-		resp.StatusCode,
-		body.Error.Code,
-		body.Error.Description,
-		body.Error.Detail,
+	return resp, castResponseError(
+		newResponseErrorFromHTTP(resp.Response, bodyErr),
 	)
-
-	return resp, castResponseError(respErr)
 }
@@ -722,6 +722,98 @@ func TestClient_Do(t *testing.T) {
 	}
 }
 
+func TestClient_Do_overrides(t *testing.T) {
+	newServer := func(t *testing.T, name string) (*url.URL, *string) {
+		var auth string
+		server := httptest.NewServer(http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				fmt.Fprintf(w, `{"server":%q}`, name)
+			},
+		))
+		t.Cleanup(server.Close)
+
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		return u, &auth
+	}
+
+	type respBody struct {
+		Server string `json:"server"`
+	}
+
+	defaultURL, defaultAuth := newServer(t, "default")
+	ctxURL, ctxAuth := newServer(t, "context")
+	reqURL, reqAuth := newServer(t, "request")
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		opts       []RequestOption
+		wantServer string
+		wantAuth   *string
+		wantKey    string
+	}{
+		{
+			name:       "client defaults",
+			ctx:        context.Background(),
+			wantServer: "default",
+			wantAuth:   defaultAuth,
+			wantKey:    "client-key",
+		},
+		{
+			name: "context overrides client",
+			ctx: ContextWithBaseURL(
+				ContextWithAPIKey(context.Background(), "context-key"),
+				ctxURL,
+			),
+			wantServer: "context",
+			wantAuth:   ctxAuth,
+			wantKey:    "context-key",
+		},
+		{
+			name: "request overrides context",
+			ctx: ContextWithBaseURL(
+				ContextWithAPIKey(context.Background(), "context-key"),
+				ctxURL,
+			),
+			opts: []RequestOption{
+				RequestAPIKey("request-key"),
+				RequestBaseURL(reqURL),
+			},
+			wantServer: "request",
+			wantAuth:   reqAuth,
+			wantKey:    "request-key",
+		},
+		{
+			name:       "API key only",
+			ctx:        ContextWithAPIKey(context.Background(), "context-key"),
+			wantServer: "default",
+			wantAuth:   defaultAuth,
+			wantKey:    "context-key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(WithBaseURL(defaultURL), WithAPIKey("client-key"))
+			require.NoError(t, err)
+
+			req := NewRequest(
+				"GET", &url.URL{Path: "/core/v1/data_centers"}, nil, tt.opts...,
+			)
+			body := &respBody{}
+			_, err = c.Do(tt.ctx, req, body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantServer, body.Server)
+			assert.Equal(t, "Bearer "+tt.wantKey, *tt.wantAuth)
+			assert.Equal(t, "client-key", c.APIKey)
+			assert.Equal(t, defaultURL, c.BaseURL)
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	t.Parallel()
 
@@ -809,6 +901,69 @@ func TestWithAPIKey(t *testing.T) {
 	assert.Equal(t, key, c.APIKey)
 }
 
+func TestWithTokenSource(t *testing.T) {
+	c := &Client{}
+	ts := &countingTokenSource{}
+
+	err := WithTokenSource(ts)(c)
+	require.NoError(t, err)
+	require.IsType(t, &reuseTokenSource{}, c.TokenSource)
+	assert.Same(t, ts, c.TokenSource.(*reuseTokenSource).src)
+
+	err = WithTokenSource(nil)(c)
+	assert.EqualError(t, err, "katapult: token source cannot be nil")
+}
+
+func TestClient_Do_tokenSource(t *testing.T) {
+	var auths []string
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			auth := r.Header.Get("Authorization")
+			auths = append(auths, auth)
+			if auth == "Bearer token-1" {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"error":{"code":"invalid_api_token"}}`)
+
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		},
+	))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	src := &countingTokenSource{}
+	c, err := New(
+		WithBaseURL(u),
+		WithAPIKey("client-key"),
+		WithTokenSource(src),
+		WithHTTPClient(server.Client()),
+	)
+	require.NoError(t, err)
+
+	req := NewRequest("POST", &url.URL{Path: "/core/v1/x"}, map[string]int{})
+	firstResp, err := c.Do(context.Background(), req, nil)
+	require.NoError(t, err)
+	_, err = c.Do(context.Background(), req, nil)
+	require.NoError(t, err)
+
+	_, err = c.Do(
+		ContextWithAPIKey(context.Background(), "context-key"), req, nil,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"Bearer token-1",
+		"Bearer token-2",
+		"Bearer token-2",
+		"Bearer context-key",
+	}, auths)
+	assert.Equal(t, 2, firstResp.Attempts)
+	assert.Equal(t, 2, src.calls)
+}
+
 func TestWithBaseURL(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -847,3 +1002,32 @@ func TestWithBaseURL(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Do_responseMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("X-Request-ID", "req_abc123")
+			w.Header().Set("X-RateLimit-Permitted", "100")
+			w.Header().Set("X-RateLimit-Remaining", "42")
+			w.Header().Set("Server-Timing", "total;dur=12")
+			time.Sleep(time.Millisecond)
+			fmt.Fprint(w, `{}`)
+		},
+	))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	c, err := New(WithBaseURL(u), WithAPIKey("key"))
+	require.NoError(t, err)
+
+	req := NewRequest("GET", &url.URL{Path: "/core/v1/x"}, nil)
+	resp, err := c.Do(context.Background(), req, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "req_abc123", resp.RequestID)
+	assert.Equal(t, &RateLimit{Permitted: 100, Remaining: 42}, resp.RateLimit)
+	assert.Equal(t, 12*time.Millisecond, resp.ServerDuration())
+	assert.Equal(t, 1, resp.Attempts)
+	assert.GreaterOrEqual(t, resp.Latency, time.Millisecond)
+}
@@ -0,0 +1,241 @@
+package katapulttest
+
+import (
+	"strings"
+
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/namegenerator"
+)
+
+type buildRecord struct {
+	orgID string
+	build *core.VirtualMachineBuild
+	task  *taskRecord
+	vm    *core.VirtualMachine
+}
+
+// buildParams holds the resolved objects a virtual machine build is created
+// with.
+type buildParams struct {
+	org      *core.Organization
+	zone     *core.Zone
+	pkg      *core.VirtualMachinePackage
+	network  *core.Network
+	hostname string
+	specXML  string
+}
+
+func (s *Server) createBuild(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	params := &buildParams{org: org}
+	if _, err := rq.decodeBody("hostname", &params.hostname); err != nil {
+		return nil, err
+	}
+
+	params.pkg, err = s.findPackage(rq.lookup("package"))
+	if err != nil {
+		return nil, err
+	}
+
+	params.zone, err = s.resolveLocation(
+		rq.lookup("zone"), rq.lookup("data_center"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if attrs := rq.lookup("disk_template"); len(attrs) > 0 {
+		if _, err := s.findDiskTemplate(attrs); err != nil {
+			return nil, err
+		}
+	}
+
+	if attrs := rq.lookup("network"); len(attrs) > 0 {
+		params.network, err = s.findNetwork(attrs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.queueBuild(params)
+}
+
+func (s *Server) createBuildFromSpec(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	var specXML string
+	if _, err := rq.decodeBody("xml", &specXML); err != nil {
+		return nil, err
+	}
+
+	spec, err := buildspec.FromXML(strings.NewReader(specXML))
+	if err != nil {
+		return nil, errInvalidSpecXML(err.Error())
+	}
+
+	params := &buildParams{
+		org:      org,
+		hostname: spec.Hostname,
+		specXML:  specXML,
+	}
+
+	pkgAttrs := map[string]string{}
+	if spec.Resources != nil && spec.Resources.Package != nil {
+		pkgAttrs["id"] = spec.Resources.Package.ID
+		pkgAttrs["permalink"] = spec.Resources.Package.Permalink
+	}
+	params.pkg, err = s.findPackage(nonEmpty(pkgAttrs))
+	if err != nil {
+		return nil, err
+	}
+
+	zoneAttrs := map[string]string{}
+	if spec.Zone != nil {
+		zoneAttrs["id"] = spec.Zone.ID
+		zoneAttrs["permalink"] = spec.Zone.Permalink
+	}
+	dcAttrs := map[string]string{}
+	if spec.DataCenter != nil {
+		dcAttrs["id"] = spec.DataCenter.ID
+		dcAttrs["permalink"] = spec.DataCenter.Permalink
+	}
+	params.zone, err = s.resolveLocation(
+		nonEmpty(zoneAttrs), nonEmpty(dcAttrs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queueBuild(params)
+}
+
+// resolveLocation returns the zone matching zoneAttrs, or if none are given,
+// the first zone in the data center matching dcAttrs.
+func (s *Server) resolveLocation(
+	zoneAttrs map[string]string,
+	dcAttrs map[string]string,
+) (*core.Zone, error) {
+	if len(zoneAttrs) > 0 {
+		return s.findZone(zoneAttrs)
+	}
+	if len(dcAttrs) == 0 {
+		return nil, errLocationRequired()
+	}
+
+	dc, err := s.findDataCenter(dcAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range s.zones {
+		if zone.DataCenter != nil && zone.DataCenter.ID == dc.ID {
+			return zone, nil
+		}
+	}
+
+	return nil, errNotFound("zone_not_found", "zone")
+}
+
+func (s *Server) queueBuild(params *buildParams) (interface{}, error) {
+	if params.hostname == "" {
+		params.hostname = namegenerator.RandomHostname()
+		for s.hostnameTaken(params.org.ID, params.hostname) {
+			params.hostname = namegenerator.RandomHostname()
+		}
+	} else if s.hostnameTaken(params.org.ID, params.hostname) {
+		return nil, errValidation("Hostname has already been taken")
+	}
+	if params.network == nil && params.zone.DataCenter != nil {
+		params.network = s.defaultNetwork(params.zone.DataCenter)
+	}
+
+	rec := &buildRecord{
+		orgID: params.org.ID,
+		build: &core.VirtualMachineBuild{
+			ID:        s.newID("vmbuild"),
+			SpecXML:   params.specXML,
+			State:     core.VirtualMachineBuildPending,
+			CreatedAt: timestamp.Now(),
+		},
+		vm: &core.VirtualMachine{
+			ID:       s.newID("vm"),
+			Hostname: params.hostname,
+			State:    core.VirtualMachineStarted,
+			Zone:     params.zone,
+			Package:  params.pkg,
+		},
+	}
+	s.builds = append(s.builds, rec)
+
+	task := s.newTask("Build virtual machine", func() {
+		s.completeBuild(rec, params)
+	})
+	rec.task = s.tasks[len(s.tasks)-1]
+
+	return map[string]interface{}{
+		"virtual_machine_build": rec.build,
+		"build":                 rec.build,
+		"task":                  task,
+		"hostname":              params.hostname,
+	}, nil
+}
+
+// advanceBuild moves the build, and the task backing it, one step towards
+// completion.
+func (s *Server) advanceBuild(rec *buildRecord) {
+	s.advanceTask(rec.task)
+
+	if rec.task.task.Status == core.TaskRunning {
+		rec.build.State = core.VirtualMachineBuildBuilding
+	}
+}
+
+func (s *Server) completeBuild(rec *buildRecord, params *buildParams) {
+	vm := s.addVirtualMachine(params.org, rec.vm)
+	if params.network != nil {
+		ip := s.addIPAddress(
+			params.org.ID, params.network, core.IPv4, vm.ID,
+		)
+		vm.IPAddresses = []*core.IPAddress{ip}
+	}
+
+	rec.build.State = core.VirtualMachineBuildComplete
+	rec.build.VirtualMachine = vm
+}
+
+func (s *Server) getBuild(rq *request) (interface{}, error) {
+	attrs := rq.lookup("virtual_machine_build")
+
+	for _, rec := range s.builds {
+		if matchLookup(attrs, "id", rec.build.ID) {
+			s.advanceBuild(rec)
+
+			return map[string]interface{}{
+				"virtual_machine_build": rec.build,
+			}, nil
+		}
+	}
+
+	return nil, errNotFound("build_not_found", "build")
+}
+
+// nonEmpty returns a copy of attrs without any empty values.
+func nonEmpty(attrs map[string]string) map[string]string {
+	r := map[string]string{}
+	for k, v := range attrs {
+		if v != "" {
+			r[k] = v
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,188 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/krystal/go-katapult/core"
+	nextcore "github.com/krystal/go-katapult/next/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_buildVirtualMachine(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+
+	build, resp, err := c.VirtualMachineBuilds.Create(
+		ctx, defaultOrg, &core.VirtualMachineBuildArguments{
+			DataCenter: &core.DataCenterRef{
+				Permalink: DefaultDataCenterPermalink,
+			},
+			Package: core.VirtualMachinePackageRef{
+				Permalink: DefaultPackagePermalink,
+			},
+			DiskTemplate: &core.DiskTemplateRef{
+				Permalink: DefaultDiskTemplatePermalink,
+			},
+			Hostname: "web-1",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, core.VirtualMachineBuildPending, build.State)
+
+	build, _, err = c.VirtualMachineBuilds.Get(ctx, build.Ref())
+	require.NoError(t, err)
+	assert.Equal(t, core.VirtualMachineBuildBuilding, build.State)
+	assert.Nil(t, build.VirtualMachine)
+
+	build, _, err = c.VirtualMachineBuilds.Get(ctx, build.Ref())
+	require.NoError(t, err)
+	assert.Equal(t, core.VirtualMachineBuildComplete, build.State)
+	require.NotNil(t, build.VirtualMachine)
+
+	vm, _, err := c.VirtualMachines.GetByFQDN(ctx, "web-1.acme.kpult.io")
+	require.NoError(t, err)
+	assert.Equal(t, build.VirtualMachine.ID, vm.ID)
+	assert.Equal(t, core.VirtualMachineStarted, vm.State)
+	assert.Equal(t, DefaultZonePermalink, vm.Zone.Permalink)
+	assert.Equal(t, DefaultPackagePermalink, vm.Package.Permalink)
+	require.Len(t, vm.IPAddresses, 1)
+
+	ip, _, err := c.IPAddresses.GetByAddress(ctx, vm.IPAddresses[0].Address)
+	require.NoError(t, err)
+	assert.Equal(t, vm.ID, ip.AllocationID)
+}
+
+func TestServer_buildVirtualMachineErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *core.VirtualMachineBuildArguments
+		wantErr error
+	}{
+		{
+			name: "missing location",
+			args: &core.VirtualMachineBuildArguments{
+				Package: core.VirtualMachinePackageRef{
+					Permalink: DefaultPackagePermalink,
+				},
+			},
+			wantErr: core.ErrLocationRequired,
+		},
+		{
+			name: "unknown package",
+			args: &core.VirtualMachineBuildArguments{
+				Zone:    &core.ZoneRef{Permalink: DefaultZonePermalink},
+				Package: core.VirtualMachinePackageRef{Permalink: "nope"},
+			},
+			wantErr: core.ErrVirtualMachinePackageNotFound,
+		},
+		{
+			name: "unknown zone",
+			args: &core.VirtualMachineBuildArguments{
+				Zone: &core.ZoneRef{Permalink: "nope"},
+				Package: core.VirtualMachinePackageRef{
+					Permalink: DefaultPackagePermalink,
+				},
+			},
+			wantErr: core.ErrZoneNotFound,
+		},
+		{
+			name: "hostname taken",
+			args: &core.VirtualMachineBuildArguments{
+				Zone: &core.ZoneRef{Permalink: DefaultZonePermalink},
+				Package: core.VirtualMachinePackageRef{
+					Permalink: DefaultPackagePermalink,
+				},
+				Hostname: "taken",
+			},
+			wantErr: core.ErrValidationError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newCoreClient(t)
+			_, err := s.AddVirtualMachine(
+				defaultOrg, &core.VirtualMachine{Hostname: "taken"},
+			)
+			require.NoError(t, err)
+
+			_, _, err = c.VirtualMachineBuilds.Create(
+				context.Background(), defaultOrg, tt.args,
+			)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestServer_buildVirtualMachineFromSpec(t *testing.T) {
+	_, c := newCoreClient(t, WithTaskSteps(0))
+	ctx := context.Background()
+
+	spec := &buildspec.VirtualMachineSpec{
+		DataCenter: &buildspec.DataCenter{
+			Permalink: DefaultDataCenterPermalink,
+		},
+		Resources: &buildspec.Resources{
+			Package: &buildspec.Package{Permalink: "rock-6"},
+		},
+		Hostname: "db-1",
+	}
+
+	build, _, err := c.VirtualMachineBuilds.CreateFromSpec(
+		ctx, defaultOrg, spec,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, core.VirtualMachineBuildComplete, build.State)
+	assert.NotEmpty(t, build.SpecXML)
+
+	vm, _, err := c.VirtualMachines.GetByFQDN(ctx, "db-1.acme.kpult.io")
+	require.NoError(t, err)
+	assert.Equal(t, "rock-6", vm.Package.Permalink)
+
+	_, _, err = c.VirtualMachineBuilds.CreateFromSpecXML(
+		ctx, defaultOrg, "<VirtualMachineSpec",
+	)
+	assert.ErrorIs(t, err, core.ErrInvalidSpecXML)
+}
+
+func TestServer_buildVirtualMachineNextClient(t *testing.T) {
+	_, c := newNextCoreClient(t)
+	ctx := context.Background()
+
+	subDomain := DefaultOrganizationSubDomain
+	zone := DefaultZonePermalink
+	pkg := DefaultPackagePermalink
+	res, err := c.PostOrganizationVirtualMachinesBuildWithResponse(
+		ctx, nextcore.PostOrganizationVirtualMachinesBuildJSONRequestBody{
+			Organization: nextcore.OrganizationLookup{SubDomain: &subDomain},
+			Zone:         &nextcore.ZoneLookup{Permalink: &zone},
+			Package:      nextcore.VirtualMachinePackageLookup{Permalink: &pkg},
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, res.JSON201)
+	assert.NotEmpty(t, res.JSON201.Hostname)
+
+	params := &nextcore.GetVirtualMachinesBuildsVirtualMachineBuildParams{
+		VirtualMachineBuildId: res.JSON201.VirtualMachineBuild.Id,
+	}
+	for i := 0; i < DefaultTaskSteps; i++ {
+		_, err = c.GetVirtualMachinesBuildsVirtualMachineBuildWithResponse(
+			ctx, params,
+		)
+		require.NoError(t, err)
+	}
+
+	taskRes, err := c.GetTaskWithResponse(
+		ctx, &nextcore.GetTaskParams{TaskId: res.JSON201.Task.Id},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, taskRes.JSON200)
+	assert.Equal(
+		t, nextcore.TaskStatusEnumCompleted, *taskRes.JSON200.Task.Status,
+	)
+}
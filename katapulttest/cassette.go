@@ -0,0 +1,503 @@
+package katapulttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jimeh/go-golden"
+	"github.com/krystal/go-katapult"
+)
+
+// Redacted is the value which secrets are replaced with in recorded cassettes.
+const Redacted = "[REDACTED]"
+
+var (
+	// ErrCassette is the parent of all cassette related errors.
+	ErrCassette = errors.New("katapulttest: cassette")
+
+	// ErrUnmatchedRequest is returned by a replaying Recorder when a request
+	// does not match any unused interaction in the cassette.
+	ErrUnmatchedRequest = fmt.Errorf("%w: unmatched request", ErrCassette)
+)
+
+// DefaultScrubKeys is the list of header, query parameter and JSON object key
+// fragments which identify secrets that are redacted from recorded
+// cassettes. Matching is case-insensitive, and a key matches if it contains
+// any of the fragments.
+var DefaultScrubKeys = []string{
+	"authorization",
+	"api_key",
+	"api-key",
+	"apikey",
+	"token",
+	"secret",
+	"password",
+	"private_key",
+	"cookie",
+}
+
+// RecorderMode determines whether a Recorder records or replays interactions.
+type RecorderMode int
+
+const (
+	// ModeReplay serves responses from a cassette, without sending requests
+	// over the network.
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord sends requests over the network, and records the
+	// interactions into a cassette.
+	ModeRecord
+)
+
+// Cassette is a recorded list of HTTP interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded HTTP request and response pair.
+type Interaction struct {
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the recorded form of a *http.Request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the recorded form of a *http.Response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// RecorderOption configures a Recorder created with NewRecorder or
+// UseCassette.
+type RecorderOption func(r *Recorder)
+
+// WithRecorderMode sets the mode of the Recorder. Defaults to ModeReplay.
+func WithRecorderMode(mode RecorderMode) RecorderOption {
+	return func(r *Recorder) {
+		r.mode = mode
+	}
+}
+
+// WithRecorderHTTPClient sets the HTTP client used to send requests in
+// record mode. Defaults to a *http.Client with katapult.DefaultTimeout.
+func WithRecorderHTTPClient(hc katapult.HTTPClient) RecorderOption {
+	return func(r *Recorder) {
+		r.httpClient = hc
+	}
+}
+
+// WithScrubKeys sets the key fragments which identify secrets to redact,
+// replacing DefaultScrubKeys.
+func WithScrubKeys(keys ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubKeys = keys
+	}
+}
+
+// WithScrubber adds a function which is called with each interaction before
+// it is saved in record mode, and with each incoming request's recorded form
+// before it is matched in replay mode. It allows scrubbing secrets which
+// cannot be identified by key.
+func WithScrubber(fn func(i *Interaction)) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, fn)
+	}
+}
+
+// Recorder is a katapult.HTTPClient which records HTTP interactions into a
+// cassette file, or replays them from one. Use it with katapult.WithHTTPClient.
+//
+// Secrets are redacted from headers, query parameters and JSON bodies of
+// both requests and responses before they are saved. In replay mode requests
+// are matched against unused interactions in order, on method, path, query
+// and body, ignoring the scheme and host.
+//
+// All methods are safe for concurrent use.
+type Recorder struct {
+	mode       RecorderMode
+	path       string
+	httpClient katapult.HTTPClient
+	scrubKeys  []string
+	scrubbers  []func(i *Interaction)
+
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewRecorder returns a new Recorder for the cassette file at path. In replay
+// mode the cassette file is read immediately, returning an error if it cannot
+// be read.
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:       path,
+		httpClient: &http.Client{Timeout: katapult.DefaultTimeout},
+		scrubKeys:  DefaultScrubKeys,
+		cassette:   &Cassette{},
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrCassette, err)
+		}
+
+		err = json.Unmarshal(b, r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrCassette, path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// UseCassette returns a Recorder for the golden cassette file of the test,
+// as determined by github.com/jimeh/go-golden. When the GOLDEN_UPDATE
+// environment variable is set the Recorder records, and the cassette is
+// written when the test completes. Otherwise it replays, failing the test if
+// any interaction in the cassette is left unused.
+func UseCassette(t *testing.T, opts ...RecorderOption) *Recorder {
+	t.Helper()
+
+	mode := ModeReplay
+	if golden.Update() {
+		mode = ModeRecord
+	}
+
+	r, err := NewRecorder(
+		golden.File(t),
+		append([]RecorderOption{WithRecorderMode(mode)}, opts...)...,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+		if n := r.Unused(); mode == ModeReplay && n > 0 {
+			t.Errorf("katapulttest: %d unused cassette interactions", n)
+		}
+	})
+
+	return r
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Cassette returns the interactions recorded or loaded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Cassette{
+		Interactions: append([]*Interaction{}, r.cassette.Interactions...),
+	}
+}
+
+// Unused returns the number of interactions in the cassette which have not
+// been replayed. It always returns zero in record mode.
+func (r *Recorder) Unused() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, used := range r.used {
+		if !used {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Do implements katapult.HTTPClient.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := &RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   string(reqBody),
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, rr)
+	}
+
+	return r.replay(req, rr)
+}
+
+func (r *Recorder) record(
+	req *http.Request,
+	rr *RecordedRequest,
+) (*http.Response, error) {
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Interaction{
+		Request: rr,
+		Response: &RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(respBody),
+		},
+	}
+	r.scrub(i)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(
+	req *http.Request,
+	rr *RecordedRequest,
+) (*http.Response, error) {
+	in := &Interaction{Request: rr}
+	r.scrub(in)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, i := range r.cassette.Interactions {
+		if r.used[idx] || !requestsMatch(in.Request, i.Request) {
+			continue
+		}
+		r.used[idx] = true
+
+		header := i.Response.Header.Clone()
+		if header != nil && header.Get("Content-Length") != "" {
+			header.Set("Content-Length", strconv.Itoa(len(i.Response.Body)))
+		}
+
+		return &http.Response{
+			Status: fmt.Sprintf(
+				"%d %s",
+				i.Response.StatusCode,
+				http.StatusText(i.Response.StatusCode),
+			),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"%w: %s %s", ErrUnmatchedRequest, rr.Method, rr.URL,
+	)
+}
+
+// Stop writes the cassette file in record mode, creating any missing parent
+// directories. It does nothing in replay mode.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCassette, err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o755)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCassette, err)
+	}
+
+	err = os.WriteFile(r.path, append(b, '\n'), 0o644) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCassette, err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) scrub(i *Interaction) {
+	if i.Request != nil {
+		r.scrubHeader(i.Request.Header)
+		i.Request.URL = r.scrubURL(i.Request.URL)
+		i.Request.Body = r.scrubBody(i.Request.Body)
+	}
+	if i.Response != nil {
+		r.scrubHeader(i.Response.Header)
+		i.Response.Body = r.scrubBody(i.Response.Body)
+	}
+
+	for _, fn := range r.scrubbers {
+		fn(i)
+	}
+}
+
+func (r *Recorder) isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range r.scrubKeys {
+		if strings.Contains(key, strings.ToLower(k)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Recorder) scrubHeader(h http.Header) {
+	for k, vals := range h {
+		if !r.isSecret(k) {
+			continue
+		}
+		for idx := range vals {
+			vals[idx] = Redacted
+		}
+	}
+}
+
+func (r *Recorder) scrubURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+
+	q := u.Query()
+	changed := false
+	for k := range q {
+		if r.isSecret(k) {
+			q.Set(k, Redacted)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = q.Encode()
+	}
+
+	return u.String()
+}
+
+// scrubBody redacts secrets from a JSON body. Bodies which are not JSON are
+// returned unchanged.
+func (r *Recorder) scrubBody(body string) string {
+	if strings.TrimSpace(body) == "" {
+		return body
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+
+	b, err := json.Marshal(r.scrubValue(v))
+	if err != nil {
+		return body
+	}
+
+	return string(b)
+}
+
+func (r *Recorder) scrubValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, item := range val {
+			if _, isString := item.(string); isString && r.isSecret(k) {
+				val[k] = Redacted
+			} else {
+				val[k] = r.scrubValue(item)
+			}
+		}
+	case []interface{}:
+		for idx, item := range val {
+			val[idx] = r.scrubValue(item)
+		}
+	}
+
+	return v
+}
+
+// requestsMatch returns true if the incoming request matches the recorded
+// request on method, path, query and body.
+func requestsMatch(in, recorded *RecordedRequest) bool {
+	if in.Method != recorded.Method {
+		return false
+	}
+
+	inURL, err := url.Parse(in.URL)
+	if err != nil {
+		return false
+	}
+	recURL, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	if inURL.Path != recURL.Path ||
+		inURL.Query().Encode() != recURL.Query().Encode() {
+		return false
+	}
+
+	return in.Body == recorded.Body
+}
+
+// readBody reads and returns the content of body, replacing it with a new
+// reader of the same content.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(b))
+
+	return b, nil
+}
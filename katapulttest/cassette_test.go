@@ -0,0 +1,264 @@
+package katapulttest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jimeh/go-golden"
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecordingClient(
+	t *testing.T,
+	baseURL *url.URL,
+	rec *Recorder,
+) *core.Client {
+	t.Helper()
+
+	c, err := katapult.New(
+		katapult.WithBaseURL(baseURL),
+		katapult.WithAPIKey(DefaultAPIKey),
+		katapult.WithHTTPClient(rec),
+	)
+	require.NoError(t, err)
+
+	return core.New(c)
+}
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	ctx := context.Background()
+	s := NewServer()
+	defer s.Close()
+
+	rec, err := NewRecorder(
+		path,
+		WithRecorderMode(ModeRecord),
+		WithRecorderHTTPClient(s.Server.Client()),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, ModeRecord, rec.Mode())
+
+	c := newRecordingClient(t, s.BaseURL(), rec)
+	org, _, err := c.Organizations.GetBySubDomain(
+		ctx, DefaultOrganizationSubDomain,
+	)
+	require.NoError(t, err)
+	zone, _, err := c.DNSZones.Create(
+		ctx, defaultOrg, &core.DNSZoneCreateArguments{Name: "example.com"},
+	)
+	require.NoError(t, err)
+	_, _, err = c.DNSZones.GetByName(ctx, "missing.com")
+	require.ErrorIs(t, err, core.ErrDNSZoneNotFound)
+
+	require.NoError(t, rec.Stop())
+	assert.Len(t, rec.Cassette().Interactions, 3)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), DefaultAPIKey)
+	assert.Contains(t, string(b), Redacted)
+
+	replay, err := NewRecorder(path)
+	require.NoError(t, err)
+	assert.Equal(t, ModeReplay, replay.Mode())
+	assert.Equal(t, 3, replay.Unused())
+
+	s.Close()
+	c = newRecordingClient(
+		t, &url.URL{Scheme: "https", Host: "api.example.com"}, replay,
+	)
+
+	gotOrg, _, err := c.Organizations.GetBySubDomain(
+		ctx, DefaultOrganizationSubDomain,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, org, gotOrg)
+
+	gotZone, resp, err := c.DNSZones.Create(
+		ctx, defaultOrg, &core.DNSZoneCreateArguments{Name: "example.com"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, zone, gotZone)
+
+	_, _, err = c.DNSZones.GetByName(ctx, "missing.com")
+	assert.ErrorIs(t, err, core.ErrDNSZoneNotFound)
+	assert.Equal(t, 0, replay.Unused())
+
+	_, _, err = c.Organizations.GetBySubDomain(
+		ctx, DefaultOrganizationSubDomain,
+	)
+	assert.ErrorIs(t, err, ErrUnmatchedRequest)
+}
+
+func TestRecorder_scrubbing(t *testing.T) {
+	rec := &Recorder{scrubKeys: DefaultScrubKeys}
+
+	tests := []struct {
+		name string
+		in   *Interaction
+		want *Interaction
+	}{
+		{
+			name: "headers",
+			in: &Interaction{
+				Request: &RecordedRequest{
+					Header: http.Header{
+						"Authorization": {"Bearer abc"},
+						"X-Api-Key":     {"abc"},
+						"Accept":        {"application/json"},
+					},
+				},
+				Response: &RecordedResponse{
+					Header: http.Header{"Set-Cookie": {"session=abc"}},
+				},
+			},
+			want: &Interaction{
+				Request: &RecordedRequest{
+					Header: http.Header{
+						"Authorization": {Redacted},
+						"X-Api-Key":     {Redacted},
+						"Accept":        {"application/json"},
+					},
+				},
+				Response: &RecordedResponse{
+					Header: http.Header{"Set-Cookie": {Redacted}},
+				},
+			},
+		},
+		{
+			name: "query",
+			in: &Interaction{
+				Request: &RecordedRequest{
+					URL: "https://api.katapult.io/core/v1/x?api_key=abc&id=1",
+				},
+			},
+			want: &Interaction{
+				Request: &RecordedRequest{
+					URL: "https://api.katapult.io/core/v1/x?" +
+						"api_key=%5BREDACTED%5D&id=1",
+				},
+			},
+		},
+		{
+			name: "JSON bodies",
+			in: &Interaction{
+				Request: &RecordedRequest{
+					Body: `{"properties":{"password":"abc","name":"x"}}`,
+				},
+				Response: &RecordedResponse{
+					Body: `{"virtual_machine":{"id":"vm_1",` +
+						`"initial_root_password":"abc"},` +
+						`"api_tokens":[{"secret":"abc","expires":1}]}`,
+				},
+			},
+			want: &Interaction{
+				Request: &RecordedRequest{
+					Body: `{"properties":{"name":"x",` +
+						`"password":"[REDACTED]"}}`,
+				},
+				Response: &RecordedResponse{
+					Body: `{"api_tokens":[{"expires":1,` +
+						`"secret":"[REDACTED]"}],` +
+						`"virtual_machine":{"id":"vm_1",` +
+						`"initial_root_password":"[REDACTED]"}}`,
+				},
+			},
+		},
+		{
+			name: "non-JSON bodies",
+			in: &Interaction{
+				Request:  &RecordedRequest{Body: "password=abc"},
+				Response: &RecordedResponse{Body: "<html></html>"},
+			},
+			want: &Interaction{
+				Request:  &RecordedRequest{Body: "password=abc"},
+				Response: &RecordedResponse{Body: "<html></html>"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec.scrub(tt.in)
+
+			assert.Equal(t, tt.want, tt.in)
+		})
+	}
+}
+
+func TestRecorder_WithScrubber(t *testing.T) {
+	rec, err := NewRecorder("", WithRecorderMode(ModeRecord),
+		WithScrubKeys("x-custom"),
+		WithScrubber(func(i *Interaction) {
+			i.Response.Body = strings.ReplaceAll(
+				i.Response.Body, "acme", "example",
+			)
+		}),
+	)
+	require.NoError(t, err)
+
+	i := &Interaction{
+		Request: &RecordedRequest{
+			Header: http.Header{
+				"Authorization": {"Bearer abc"},
+				"X-Custom":      {"abc"},
+			},
+		},
+		Response: &RecordedResponse{Body: `"acme"`},
+	}
+	rec.scrub(i)
+
+	assert.Equal(t, http.Header{
+		"Authorization": {"Bearer abc"},
+		"X-Custom":      {Redacted},
+	}, i.Request.Header)
+	assert.Equal(t, `"example"`, i.Response.Body)
+}
+
+func TestNewRecorder_missingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.ErrorIs(t, err, ErrCassette)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUseCassette(t *testing.T) {
+	baseURL := katapult.DefaultURL
+	var opts []RecorderOption
+	if golden.Update() {
+		s := NewServer(WithTaskSteps(1))
+		t.Cleanup(s.Close)
+		baseURL = s.BaseURL()
+		opts = append(opts, WithRecorderHTTPClient(s.Server.Client()))
+	}
+
+	c := newRecordingClient(t, baseURL, UseCassette(t, opts...))
+	ctx := context.Background()
+
+	build, _, err := c.VirtualMachineBuilds.Create(
+		ctx, defaultOrg, &core.VirtualMachineBuildArguments{
+			Zone: &core.ZoneRef{Permalink: DefaultZonePermalink},
+			Package: core.VirtualMachinePackageRef{
+				Permalink: DefaultPackagePermalink,
+			},
+			Hostname: "web-1",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, core.VirtualMachineBuildPending, build.State)
+
+	build, _, err = c.VirtualMachineBuilds.Get(ctx, build.Ref())
+	require.NoError(t, err)
+	assert.Equal(t, core.VirtualMachineBuildComplete, build.State)
+	require.NotNil(t, build.VirtualMachine)
+	assert.Equal(t, "web-1.acme.kpult.io", build.VirtualMachine.FQDN)
+}
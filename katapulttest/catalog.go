@@ -0,0 +1,408 @@
+package katapulttest
+
+import (
+	"net/http"
+
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult/core"
+)
+
+// Permalinks and identifiers of the objects a Server is seeded with, unless
+// WithoutDefaults is used.
+const (
+	DefaultOrganizationSubDomain = "acme"
+	DefaultDataCenterPermalink   = "uk-lon-01"
+	DefaultZonePermalink         = "uk-lon-01-a"
+	DefaultNetworkPermalink      = "uk-lon-01-public"
+	DefaultPackagePermalink      = "rock-3"
+	DefaultDiskTemplatePermalink = "templates/ubuntu-22-04"
+)
+
+func (s *Server) seedDefaults() {
+	s.addOrganization(&core.Organization{
+		Name:                 "Acme Inc",
+		SubDomain:            DefaultOrganizationSubDomain,
+		InfrastructureDomain: DefaultOrganizationSubDomain + ".kpult.io",
+	})
+	dc := s.addDataCenter(&core.DataCenter{
+		Name:      "London",
+		Permalink: DefaultDataCenterPermalink,
+		Country:   &core.Country{Name: "United Kingdom", ISOCode2: "GB"},
+	})
+	s.addZone(&core.Zone{
+		Name:       "London Zone A",
+		Permalink:  DefaultZonePermalink,
+		DataCenter: dc,
+	})
+	s.addNetwork(&core.Network{
+		Name:       "Public Network",
+		Permalink:  DefaultNetworkPermalink,
+		DataCenter: dc,
+	})
+	s.addPackage(&core.VirtualMachinePackage{
+		Name:          "Rock 3",
+		Permalink:     DefaultPackagePermalink,
+		CPUCores:      1,
+		IPv4Addresses: 1,
+		MemoryInGB:    3,
+		StorageInGB:   30,
+		Privacy:       "public",
+	})
+	s.addPackage(&core.VirtualMachinePackage{
+		Name:          "Rock 6",
+		Permalink:     "rock-6",
+		CPUCores:      2,
+		IPv4Addresses: 1,
+		MemoryInGB:    6,
+		StorageInGB:   60,
+		Privacy:       "public",
+	})
+	s.addDiskTemplate(&core.DiskTemplate{
+		Name:      "Ubuntu 22.04",
+		Permalink: DefaultDiskTemplatePermalink,
+		Universal: true,
+		OperatingSystem: &core.OperatingSystem{
+			Name: "Ubuntu",
+		},
+	})
+}
+
+// AddOrganization adds the given organization to the Server, assigning it an
+// ID if it does not have one.
+func (s *Server) AddOrganization(org *core.Organization) *core.Organization {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addOrganization(org)
+}
+
+func (s *Server) addOrganization(org *core.Organization) *core.Organization {
+	if org.ID == "" {
+		org.ID = s.newID("org")
+	}
+	if org.CreatedAt == nil {
+		org.CreatedAt = timestamp.Now()
+	}
+	s.organizations = append(s.organizations, org)
+
+	return org
+}
+
+// AddDataCenter adds the given data center to the Server, assigning it an ID
+// if it does not have one.
+func (s *Server) AddDataCenter(dc *core.DataCenter) *core.DataCenter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addDataCenter(dc)
+}
+
+func (s *Server) addDataCenter(dc *core.DataCenter) *core.DataCenter {
+	if dc.ID == "" {
+		dc.ID = s.newID("dc")
+	}
+	s.dataCenters = append(s.dataCenters, dc)
+
+	return dc
+}
+
+// AddZone adds the given zone to the Server, assigning it an ID if it does not
+// have one.
+func (s *Server) AddZone(zone *core.Zone) *core.Zone {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addZone(zone)
+}
+
+func (s *Server) addZone(zone *core.Zone) *core.Zone {
+	if zone.ID == "" {
+		zone.ID = s.newID("zone")
+	}
+	s.zones = append(s.zones, zone)
+
+	return zone
+}
+
+// AddNetwork adds the given network to the Server, assigning it an ID if it
+// does not have one.
+func (s *Server) AddNetwork(network *core.Network) *core.Network {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addNetwork(network)
+}
+
+func (s *Server) addNetwork(network *core.Network) *core.Network {
+	if network.ID == "" {
+		network.ID = s.newID("netw")
+	}
+	s.networks = append(s.networks, network)
+
+	return network
+}
+
+// AddVirtualMachinePackage adds the given package to the Server, assigning it
+// an ID if it does not have one.
+func (s *Server) AddVirtualMachinePackage(
+	pkg *core.VirtualMachinePackage,
+) *core.VirtualMachinePackage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addPackage(pkg)
+}
+
+func (s *Server) addPackage(
+	pkg *core.VirtualMachinePackage,
+) *core.VirtualMachinePackage {
+	if pkg.ID == "" {
+		pkg.ID = s.newID("vmpkg")
+	}
+	s.packages = append(s.packages, pkg)
+
+	return pkg
+}
+
+// AddDiskTemplate adds the given disk template to the Server, assigning it an
+// ID if it does not have one.
+func (s *Server) AddDiskTemplate(tpl *core.DiskTemplate) *core.DiskTemplate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addDiskTemplate(tpl)
+}
+
+func (s *Server) addDiskTemplate(tpl *core.DiskTemplate) *core.DiskTemplate {
+	if tpl.ID == "" {
+		tpl.ID = s.newID("dtpl")
+	}
+	s.diskTemplates = append(s.diskTemplates, tpl)
+
+	return tpl
+}
+
+func (s *Server) findOrganization(
+	attrs map[string]string,
+) (*core.Organization, error) {
+	for _, org := range s.organizations {
+		if matchLookup(attrs, "id", org.ID, "sub_domain", org.SubDomain) {
+			if org.Suspended {
+				return nil, newAPIError(
+					http.StatusForbidden,
+					"organization_suspended",
+					"An organization was found from the arguments provided "+
+						"but it was suspended",
+					nil,
+				)
+			}
+
+			return org, nil
+		}
+	}
+
+	return nil, errNotFound("organization_not_found", "organization")
+}
+
+func (s *Server) findDataCenter(
+	attrs map[string]string,
+) (*core.DataCenter, error) {
+	for _, dc := range s.dataCenters {
+		if matchLookup(attrs, "id", dc.ID, "permalink", dc.Permalink) {
+			return dc, nil
+		}
+	}
+
+	return nil, errNotFound("data_center_not_found", "data center")
+}
+
+func (s *Server) findZone(attrs map[string]string) (*core.Zone, error) {
+	for _, zone := range s.zones {
+		if matchLookup(attrs, "id", zone.ID, "permalink", zone.Permalink) {
+			return zone, nil
+		}
+	}
+
+	return nil, errNotFound("zone_not_found", "zone")
+}
+
+func (s *Server) findNetwork(attrs map[string]string) (*core.Network, error) {
+	for _, n := range s.networks {
+		if matchLookup(attrs, "id", n.ID, "permalink", n.Permalink) {
+			return n, nil
+		}
+	}
+
+	return nil, errNotFound("network_not_found", "network")
+}
+
+func (s *Server) findPackage(
+	attrs map[string]string,
+) (*core.VirtualMachinePackage, error) {
+	for _, pkg := range s.packages {
+		if matchLookup(attrs, "id", pkg.ID, "permalink", pkg.Permalink) {
+			return pkg, nil
+		}
+	}
+
+	return nil, errNotFound("package_not_found", "package")
+}
+
+func (s *Server) findDiskTemplate(
+	attrs map[string]string,
+) (*core.DiskTemplate, error) {
+	for _, tpl := range s.diskTemplates {
+		if matchLookup(attrs, "id", tpl.ID, "permalink", tpl.Permalink) {
+			return tpl, nil
+		}
+	}
+
+	return nil, errNotFound("disk_template_not_found", "disk template")
+}
+
+// defaultNetwork returns the first network in the given data center.
+func (s *Server) defaultNetwork(dc *core.DataCenter) *core.Network {
+	for _, n := range s.networks {
+		if n.DataCenter != nil && n.DataCenter.ID == dc.ID {
+			return n
+		}
+	}
+
+	return nil
+}
+
+// matchLookup returns true if attrs contains at least one of the given
+// key/value pairs, with all given keys present in attrs matching their
+// values. Empty values never match.
+func matchLookup(attrs map[string]string, pairs ...string) bool {
+	matched := false
+	for i := 0; i+1 < len(pairs); i += 2 {
+		v, ok := attrs[pairs[i]]
+		if !ok {
+			continue
+		}
+		if v == "" || v != pairs[i+1] {
+			return false
+		}
+		matched = true
+	}
+
+	return matched
+}
+
+func (s *Server) listOrganizations(_ *request) (interface{}, error) {
+	return map[string]interface{}{
+		"organizations": s.organizations,
+	}, nil
+}
+
+func (s *Server) getOrganization(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"organization": org}, nil
+}
+
+func (s *Server) listDataCenters(_ *request) (interface{}, error) {
+	return map[string]interface{}{"data_centers": s.dataCenters}, nil
+}
+
+func (s *Server) getDataCenter(rq *request) (interface{}, error) {
+	dc, err := s.findDataCenter(rq.lookup("data_center"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"data_center": dc}, nil
+}
+
+func (s *Server) getDataCenterDefaultNetwork(
+	rq *request,
+) (interface{}, error) {
+	dc, err := s.findDataCenter(rq.lookup("data_center"))
+	if err != nil {
+		return nil, err
+	}
+
+	network := s.defaultNetwork(dc)
+	if network == nil {
+		return nil, errNotFound("network_not_found", "network")
+	}
+
+	return map[string]interface{}{"network": network}, nil
+}
+
+func (s *Server) getNetwork(rq *request) (interface{}, error) {
+	network, err := s.findNetwork(rq.lookup("network"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"network": network}, nil
+}
+
+func (s *Server) listPackages(rq *request) (interface{}, error) {
+	start, end, pagination := rq.paginate(len(s.packages))
+
+	return map[string]interface{}{
+		"pagination":               pagination,
+		"virtual_machine_packages": s.packages[start:end],
+	}, nil
+}
+
+func (s *Server) getPackage(rq *request) (interface{}, error) {
+	pkg, err := s.findPackage(rq.lookup("virtual_machine_package"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"virtual_machine_package": pkg}, nil
+}
+
+func (s *Server) listDiskTemplates(rq *request) (interface{}, error) {
+	if _, err := s.findOrganization(rq.lookup("organization")); err != nil {
+		return nil, err
+	}
+
+	start, end, pagination := rq.paginate(len(s.diskTemplates))
+
+	return map[string]interface{}{
+		"pagination":     pagination,
+		"disk_templates": s.diskTemplates[start:end],
+	}, nil
+}
+
+func (s *Server) getDiskTemplate(rq *request) (interface{}, error) {
+	tpl, err := s.findDiskTemplate(rq.lookup("disk_template"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"disk_template": tpl}, nil
+}
+
+func (s *Server) listZones(_ *request) (interface{}, error) {
+	return map[string]interface{}{"zones": s.zones}, nil
+}
+
+func (s *Server) getZone(rq *request) (interface{}, error) {
+	zone, err := s.findZone(rq.lookup("zone"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"zone": zone}, nil
+}
+
+func (s *Server) listAvailableNetworks(rq *request) (interface{}, error) {
+	if _, err := s.findOrganization(rq.lookup("organization")); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"networks":         s.networks,
+		"virtual_networks": []interface{}{},
+	}, nil
+}
@@ -0,0 +1,189 @@
+package katapulttest
+
+import (
+	"strings"
+
+	"github.com/krystal/go-katapult/core"
+)
+
+// Nameservers is the list of nameservers returned by the DNS zone nameservers
+// endpoint.
+var Nameservers = []string{"ns1.katapult.io", "ns2.katapult.io"}
+
+type dnsZoneRecord struct {
+	orgID string
+	zone  *core.DNSZone
+}
+
+// AddDNSZone adds the given DNS zone to the organization, assigning it an ID
+// if it does not have one.
+func (s *Server) AddDNSZone(
+	org core.OrganizationRef,
+	zone *core.DNSZone,
+) (*core.DNSZone, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.findOrganization(orgLookup(org))
+	if err != nil {
+		return nil, err
+	}
+
+	if zone.ID == "" {
+		zone.ID = s.newID("dnszone")
+	}
+	s.dnsZones = append(s.dnsZones, &dnsZoneRecord{orgID: o.ID, zone: zone})
+
+	return zone, nil
+}
+
+func (s *Server) findDNSZone(attrs map[string]string) (*dnsZoneRecord, error) {
+	for _, rec := range s.dnsZones {
+		if matchLookup(attrs, "id", rec.zone.ID, "name", rec.zone.Name) {
+			return rec, nil
+		}
+	}
+
+	return nil, errNotFound("dns_zone_not_found", "DNS zone")
+}
+
+type dnsZoneProperties struct {
+	Name       *string `json:"name"`
+	DefaultTTL *int    `json:"default_ttl"`
+}
+
+func (s *Server) listDNSZones(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	zones := []*core.DNSZone{}
+	for _, rec := range s.dnsZones {
+		if rec.orgID == org.ID {
+			zones = append(zones, rec.zone)
+		}
+	}
+
+	start, end, pagination := rq.paginate(len(zones))
+
+	return map[string]interface{}{
+		"pagination": pagination,
+		"dns_zones":  zones[start:end],
+	}, nil
+}
+
+func (s *Server) getDNSZoneNameservers(rq *request) (interface{}, error) {
+	if _, err := s.findOrganization(rq.lookup("organization")); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"nameservers": Nameservers}, nil
+}
+
+func (s *Server) getDNSZone(rq *request) (interface{}, error) {
+	rec, err := s.findDNSZone(rq.lookup("dns_zone"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"dns_zone": rec.zone}, nil
+}
+
+func (s *Server) createDNSZone(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	props := &dnsZoneProperties{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+	if props.Name == nil || strings.TrimSpace(*props.Name) == "" {
+		return nil, errValidation("Name can't be blank")
+	}
+	if s.dnsZoneNameTaken(*props.Name) {
+		return nil, errValidation("Name has already been taken")
+	}
+
+	zone := &core.DNSZone{
+		ID:         s.newID("dnszone"),
+		Name:       *props.Name,
+		DefaultTTL: 3600,
+	}
+	if props.DefaultTTL != nil {
+		zone.DefaultTTL = *props.DefaultTTL
+	}
+	s.dnsZones = append(s.dnsZones, &dnsZoneRecord{orgID: org.ID, zone: zone})
+
+	return map[string]interface{}{"dns_zone": zone}, nil
+}
+
+func (s *Server) dnsZoneNameTaken(name string) bool {
+	for _, rec := range s.dnsZones {
+		if strings.EqualFold(rec.zone.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) updateDNSZone(rq *request) (interface{}, error) {
+	rec, err := s.findDNSZone(rq.lookup("dns_zone"))
+	if err != nil {
+		return nil, err
+	}
+
+	props := &dnsZoneProperties{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+
+	if props.Name != nil && *props.Name != rec.zone.Name {
+		if s.dnsZoneNameTaken(*props.Name) {
+			return nil, errValidation("Name has already been taken")
+		}
+		rec.zone.Name = *props.Name
+	}
+	if props.DefaultTTL != nil {
+		rec.zone.DefaultTTL = *props.DefaultTTL
+	}
+
+	return map[string]interface{}{"dns_zone": rec.zone}, nil
+}
+
+func (s *Server) deleteDNSZone(rq *request) (interface{}, error) {
+	rec, err := s.findDNSZone(rq.lookup("dns_zone"))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range s.dnsZones {
+		if r == rec {
+			s.dnsZones = append(s.dnsZones[:i], s.dnsZones[i+1:]...)
+
+			break
+		}
+	}
+
+	return map[string]interface{}{"deleted": true}, nil
+}
+
+// verifyDNSZone marks the DNS zone as verified. Zones with a name ending in
+// ".invalid" can never be verified, allowing failed verification to be
+// tested.
+func (s *Server) verifyDNSZone(rq *request) (interface{}, error) {
+	rec, err := s.findDNSZone(rq.lookup("dns_zone"))
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(rec.zone.Name, ".invalid") {
+		return nil, errDNSZoneNotVerified()
+	}
+	rec.zone.Verified = true
+
+	return map[string]interface{}{"dns_zone": rec.zone}, nil
+}
@@ -0,0 +1,74 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_dnsZones(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+
+	zone, resp, err := c.DNSZones.Create(
+		ctx, defaultOrg, &core.DNSZoneCreateArguments{Name: "example.com"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, 3600, zone.DefaultTTL)
+	assert.False(t, zone.Verified)
+
+	_, _, err = c.DNSZones.Create(
+		ctx, defaultOrg, &core.DNSZoneCreateArguments{Name: "example.com"},
+	)
+	assert.ErrorIs(t, err, core.ErrValidationError)
+
+	zone, _, err = c.DNSZones.Verify(ctx, zone.Ref())
+	require.NoError(t, err)
+	assert.True(t, zone.Verified)
+
+	zone, _, err = c.DNSZones.Update(
+		ctx, zone.Ref(), &core.DNSZoneUpdateArguments{
+			Name:       "example.com",
+			DefaultTTL: 60,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 60, zone.DefaultTTL)
+
+	got, _, err := c.DNSZones.GetByName(ctx, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, zone, got)
+
+	zones, _, err := c.DNSZones.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*core.DNSZone{zone}, zones)
+
+	nameservers, _, err := c.DNSZones.Nameservers(ctx, defaultOrg)
+	require.NoError(t, err)
+	assert.Equal(t, Nameservers, nameservers)
+
+	deleted, _, err := c.DNSZones.Delete(ctx, zone.Ref())
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+	assert.True(t, *deleted)
+
+	_, _, err = c.DNSZones.GetByID(ctx, zone.ID)
+	assert.ErrorIs(t, err, core.ErrDNSZoneNotFound)
+}
+
+func TestServer_verifyDNSZoneFailure(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+
+	zone, _, err := c.DNSZones.Create(
+		ctx, defaultOrg, &core.DNSZoneCreateArguments{Name: "example.invalid"},
+	)
+	require.NoError(t, err)
+
+	_, _, err = c.DNSZones.Verify(ctx, zone.Ref())
+	assert.ErrorIs(t, err, core.ErrDNSZoneNotVerified)
+}
@@ -0,0 +1,163 @@
+package katapulttest
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// apiError is an error response returned by the Server, rendered in the same
+// format as errors from the Katapult API.
+type apiError struct {
+	status      int
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	Detail      interface{} `json:"detail"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, e.Code, e.Description)
+}
+
+func newAPIError(
+	status int,
+	code string,
+	description string,
+	detail interface{},
+) *apiError {
+	if detail == nil {
+		detail = map[string]interface{}{}
+	}
+
+	return &apiError{
+		status:      status,
+		Code:        code,
+		Description: description,
+		Detail:      detail,
+	}
+}
+
+func errorResponse(err error) (int, interface{}) {
+	apiErr, ok := err.(*apiError) //nolint:errorlint
+	if !ok {
+		apiErr = newAPIError(
+			http.StatusInternalServerError,
+			"internal_server_error",
+			err.Error(),
+			nil,
+		)
+	}
+
+	return apiErr.status, map[string]interface{}{"error": apiErr}
+}
+
+func errRouteNotFound(r *http.Request) *apiError {
+	return newAPIError(
+		http.StatusNotFound,
+		"route_not_found",
+		fmt.Sprintf(
+			"No route matches '%s' for %s", r.URL.Path, r.Method,
+		),
+		map[string]string{"path": r.URL.Path, "method": r.Method},
+	)
+}
+
+func errMissingAPIToken() *apiError {
+	return newAPIError(
+		http.StatusBadRequest,
+		"missing_api_token",
+		"No API token was provided in the Authorization header. "+
+			"Ensure a token is provided prefixed with Bearer.",
+		nil,
+	)
+}
+
+func errInvalidAPIToken() *apiError {
+	return newAPIError(
+		http.StatusForbidden,
+		"invalid_api_token",
+		"The API token provided was not valid "+
+			"(it may not exist or have expired)",
+		nil,
+	)
+}
+
+func errValidation(errs ...string) *apiError {
+	return newAPIError(
+		http.StatusUnprocessableEntity,
+		"validation_error",
+		"A validation error occurred with the object that was being "+
+			"created/updated/deleted",
+		map[string]interface{}{"errors": errs},
+	)
+}
+
+func errNotFound(code, object string) *apiError {
+	return newAPIError(
+		http.StatusNotFound,
+		code,
+		fmt.Sprintf(
+			"No %s was found matching any of the criteria provided in "+
+				"the arguments",
+			object,
+		),
+		nil,
+	)
+}
+
+func errObjectInTrash(trash *trashObjectRecord) *apiError {
+	return newAPIError(
+		http.StatusNotAcceptable,
+		"object_in_trash",
+		"The object found is in the trash and therefore cannot be "+
+			"manipulated through the API. It should be restored in order "+
+			"to run this operation.",
+		map[string]interface{}{"trash_object": trash.obj},
+	)
+}
+
+func errLocationRequired() *apiError {
+	return newAPIError(
+		http.StatusUnprocessableEntity,
+		"location_required",
+		"A zone or a data_center argument must be provided",
+		nil,
+	)
+}
+
+func errInvalidSpecXML(msg string) *apiError {
+	return newAPIError(
+		http.StatusBadRequest,
+		"invalid_spec_xml",
+		"The spec XML provided is invalid",
+		map[string]interface{}{"errors": msg},
+	)
+}
+
+func errNoAllocation() *apiError {
+	return newAPIError(
+		http.StatusUnprocessableEntity,
+		"no_allocation",
+		"This IP address is not currently allocated to any object, and "+
+			"cannot be unallocated.",
+		nil,
+	)
+}
+
+func errDNSZoneNotVerified() *apiError {
+	return newAPIError(
+		http.StatusUnprocessableEntity,
+		"dns_zone_not_verified",
+		"The DNS zone could not be verified, check the nameservers are "+
+			"set correctly",
+		nil,
+	)
+}
+
+func errDeletionRestricted(msg string) *apiError {
+	return newAPIError(
+		http.StatusConflict,
+		"deletion_restricted",
+		"Object cannot be deleted",
+		map[string]interface{}{"errors": []string{msg}},
+	)
+}
@@ -0,0 +1,210 @@
+package katapulttest
+
+import (
+	"fmt"
+
+	"github.com/krystal/go-katapult/core"
+)
+
+type ipAddressRecord struct {
+	orgID string
+	ip    *core.IPAddress
+}
+
+// addIPAddress creates a new IP address in the given network, allocated to
+// the virtual machine with the given ID if it is not empty. IPv4 addresses
+// are assigned from 192.0.2.0/24 and IPv6 addresses from 2001:db8::/64.
+func (s *Server) addIPAddress(
+	orgID string,
+	network *core.Network,
+	version core.IPVersion,
+	vmID string,
+) *core.IPAddress {
+	s.ipCounter++
+
+	ip := &core.IPAddress{
+		ID:      s.newID("ip"),
+		Network: network,
+	}
+	if version == core.IPv6 {
+		ip.Address = fmt.Sprintf("2001:db8::%x", s.ipCounter)
+		ip.AddressWithMask = ip.Address + "/64"
+	} else {
+		ip.Address = fmt.Sprintf("192.0.2.%d", s.ipCounter%254+1)
+		ip.AddressWithMask = ip.Address + "/24"
+	}
+	if vmID != "" {
+		ip.AllocationID = vmID
+		ip.AllocationType = "VirtualMachine"
+	}
+
+	s.ipAddresses = append(s.ipAddresses, &ipAddressRecord{
+		orgID: orgID,
+		ip:    ip,
+	})
+
+	return ip
+}
+
+func (s *Server) findIPAddress(
+	attrs map[string]string,
+) (*ipAddressRecord, error) {
+	for _, rec := range s.ipAddresses {
+		if matchLookup(attrs, "id", rec.ip.ID, "address", rec.ip.Address) {
+			return rec, nil
+		}
+	}
+
+	return nil, errNotFound("ip_address_not_found", "IP address")
+}
+
+func (s *Server) listIPAddresses(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []*core.IPAddress{}
+	for _, rec := range s.ipAddresses {
+		if rec.orgID == org.ID {
+			ips = append(ips, rec.ip)
+		}
+	}
+
+	start, end, pagination := rq.paginate(len(ips))
+
+	return map[string]interface{}{
+		"pagination":   pagination,
+		"ip_addresses": ips[start:end],
+	}, nil
+}
+
+func (s *Server) getIPAddress(rq *request) (interface{}, error) {
+	rec, err := s.findIPAddress(rq.lookup("ip_address"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"ip_address": rec.ip}, nil
+}
+
+func (s *Server) createIPAddress(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := rq.lookup("network")
+	if len(attrs) == 0 {
+		return nil, errValidation("Network must be provided")
+	}
+	network, err := s.findNetwork(attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &struct {
+		Version core.IPVersion `json:"version"`
+		VIP     bool           `json:"vip"`
+		Label   string         `json:"label"`
+	}{}
+	if _, err := rq.decodeBody("", args); err != nil {
+		return nil, err
+	}
+
+	switch args.Version {
+	case "":
+		args.Version = core.IPv4
+	case core.IPv4, core.IPv6:
+	default:
+		return nil, errValidation("Version is not included in the list")
+	}
+
+	ip := s.addIPAddress(org.ID, network, args.Version, "")
+	ip.VIP = args.VIP
+	ip.Label = args.Label
+
+	return map[string]interface{}{"ip_address": ip}, nil
+}
+
+func (s *Server) updateIPAddress(rq *request) (interface{}, error) {
+	rec, err := s.findIPAddress(rq.lookup("ip_address"))
+	if err != nil {
+		return nil, err
+	}
+
+	args := &struct {
+		VIP        *bool   `json:"vip"`
+		Label      *string `json:"label"`
+		ReverseDNS *string `json:"reverse_dns"`
+	}{}
+	if _, err := rq.decodeBody("", args); err != nil {
+		return nil, err
+	}
+
+	if args.VIP != nil {
+		rec.ip.VIP = *args.VIP
+	}
+	if args.Label != nil {
+		rec.ip.Label = *args.Label
+	}
+	if args.ReverseDNS != nil {
+		rec.ip.ReverseDNS = *args.ReverseDNS
+	}
+
+	return map[string]interface{}{"ip_address": rec.ip}, nil
+}
+
+func (s *Server) deleteIPAddress(rq *request) (interface{}, error) {
+	rec, err := s.findIPAddress(rq.lookup("ip_address"))
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.ip.AllocationID != "" {
+		return nil, errDeletionRestricted(
+			"IP address is allocated and cannot be deleted",
+		)
+	}
+
+	for i, r := range s.ipAddresses {
+		if r == rec {
+			s.ipAddresses = append(s.ipAddresses[:i], s.ipAddresses[i+1:]...)
+
+			break
+		}
+	}
+
+	return map[string]interface{}{}, nil
+}
+
+func (s *Server) unallocateIPAddress(rq *request) (interface{}, error) {
+	rec, err := s.findIPAddress(rq.lookup("ip_address"))
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.ip.AllocationID == "" {
+		return nil, errNoAllocation()
+	}
+
+	for _, vmRec := range s.virtualMachines {
+		if vmRec.vm.ID != rec.ip.AllocationID {
+			continue
+		}
+		for i, ip := range vmRec.vm.IPAddresses {
+			if ip == rec.ip {
+				vmRec.vm.IPAddresses = append(
+					vmRec.vm.IPAddresses[:i], vmRec.vm.IPAddresses[i+1:]...,
+				)
+
+				break
+			}
+		}
+	}
+
+	rec.ip.AllocationID = ""
+	rec.ip.AllocationType = ""
+
+	return map[string]interface{}{"ip_address": rec.ip}, nil
+}
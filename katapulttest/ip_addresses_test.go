@@ -0,0 +1,94 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ipAddresses(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+	network := core.NetworkRef{Permalink: DefaultNetworkPermalink}
+
+	ip4, _, err := c.IPAddresses.Create(
+		ctx, defaultOrg, &core.IPAddressCreateArguments{
+			Network: network,
+			Label:   "web",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.2", ip4.Address)
+	assert.Equal(t, "web", ip4.Label)
+
+	ip6, _, err := c.IPAddresses.Create(
+		ctx, defaultOrg, &core.IPAddressCreateArguments{
+			Network: network,
+			Version: core.IPv6,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "2001:db8::2", ip6.Address)
+
+	ips, _, err := c.IPAddresses.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*core.IPAddress{ip4, ip6}, ips)
+
+	got, _, err := c.IPAddresses.Update(
+		ctx, ip4.Ref(), &core.IPAddressUpdateArguments{
+			ReverseDNS: "web.example.com",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "web.example.com", got.ReverseDNS)
+
+	_, err = c.IPAddresses.Unallocate(ctx, ip4.Ref())
+	assert.ErrorIs(t, err, core.ErrNoAllocation)
+
+	_, err = c.IPAddresses.Delete(ctx, ip4.Ref())
+	require.NoError(t, err)
+
+	_, _, err = c.IPAddresses.GetByID(ctx, ip4.ID)
+	assert.ErrorIs(t, err, core.ErrIPAddressNotFound)
+
+	_, _, err = c.IPAddresses.Create(
+		ctx, defaultOrg, &core.IPAddressCreateArguments{
+			Network: core.NetworkRef{Permalink: "nope"},
+		},
+	)
+	assert.ErrorIs(t, err, core.ErrNetworkNotFound)
+}
+
+func TestServer_unallocateIPAddress(t *testing.T) {
+	_, c := newCoreClient(t, WithTaskSteps(0))
+	ctx := context.Background()
+
+	_, _, err := c.VirtualMachineBuilds.Create(
+		ctx, defaultOrg, &core.VirtualMachineBuildArguments{
+			Zone: &core.ZoneRef{Permalink: DefaultZonePermalink},
+			Package: core.VirtualMachinePackageRef{
+				Permalink: DefaultPackagePermalink,
+			},
+			Hostname: "web",
+		},
+	)
+	require.NoError(t, err)
+
+	vm, _, err := c.VirtualMachines.GetByFQDN(ctx, "web.acme.kpult.io")
+	require.NoError(t, err)
+	require.Len(t, vm.IPAddresses, 1)
+	ip := vm.IPAddresses[0]
+
+	_, err = c.IPAddresses.Delete(ctx, ip.Ref())
+	assert.ErrorIs(t, err, core.ErrDeletionRestricted)
+
+	_, err = c.IPAddresses.Unallocate(ctx, ip.Ref())
+	require.NoError(t, err)
+
+	vm, _, err = c.VirtualMachines.GetByID(ctx, vm.ID)
+	require.NoError(t, err)
+	assert.Empty(t, vm.IPAddresses)
+}
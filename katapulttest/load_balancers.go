@@ -0,0 +1,159 @@
+package katapulttest
+
+import (
+	"github.com/krystal/go-katapult/core"
+)
+
+type loadBalancerRecord struct {
+	orgID string
+	lb    *core.LoadBalancer
+}
+
+type loadBalancerProperties struct {
+	Name          *string            `json:"name"`
+	ResourceType  *core.ResourceType `json:"resource_type"`
+	ResourceIDs   *[]string          `json:"resource_ids"`
+	HTTPSRedirect *bool              `json:"https_redirect"`
+}
+
+func (p *loadBalancerProperties) apply(lb *core.LoadBalancer) {
+	if p.Name != nil {
+		lb.Name = *p.Name
+	}
+	if p.ResourceType != nil {
+		lb.ResourceType = *p.ResourceType
+	}
+	if p.ResourceIDs != nil {
+		lb.ResourceIDs = *p.ResourceIDs
+	}
+	if p.HTTPSRedirect != nil {
+		lb.HTTPSRedirect = *p.HTTPSRedirect
+	}
+}
+
+func (s *Server) findLoadBalancer(
+	attrs map[string]string,
+) (*loadBalancerRecord, error) {
+	for _, rec := range s.loadBalancers {
+		if matchLookup(attrs, "id", rec.lb.ID) {
+			return rec, nil
+		}
+	}
+
+	return nil, errNotFound("load_balancer_not_found", "load balancer")
+}
+
+func (s *Server) listLoadBalancers(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	lbs := []*core.LoadBalancer{}
+	for _, rec := range s.loadBalancers {
+		if rec.orgID == org.ID {
+			lbs = append(lbs, rec.lb)
+		}
+	}
+
+	start, end, pagination := rq.paginate(len(lbs))
+
+	return map[string]interface{}{
+		"pagination":     pagination,
+		"load_balancers": lbs[start:end],
+	}, nil
+}
+
+func (s *Server) getLoadBalancer(rq *request) (interface{}, error) {
+	rec, err := s.findLoadBalancer(rq.lookup("load_balancer"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"load_balancer": rec.lb}, nil
+}
+
+func (s *Server) createLoadBalancer(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	props := &struct {
+		loadBalancerProperties
+		DataCenter map[string]string `json:"data_center"`
+	}{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+
+	dcAttrs := nonEmpty(props.DataCenter)
+	if len(dcAttrs) == 0 {
+		return nil, errValidation("Data center must be provided")
+	}
+	dc, err := s.findDataCenter(dcAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	lb := &core.LoadBalancer{
+		ID:           s.newID("lb"),
+		ResourceType: core.VirtualMachinesResourceType,
+		DataCenter:   dc,
+	}
+	props.apply(lb)
+	if lb.Name == "" {
+		lb.Name = "Load Balancer"
+	}
+	if network := s.defaultNetwork(dc); network != nil {
+		lb.IPAddress = s.addIPAddress(org.ID, network, core.IPv4, "")
+		lb.IPAddress.AllocationID = lb.ID
+		lb.IPAddress.AllocationType = "LoadBalancer"
+	}
+
+	s.loadBalancers = append(s.loadBalancers, &loadBalancerRecord{
+		orgID: org.ID,
+		lb:    lb,
+	})
+
+	return map[string]interface{}{"load_balancer": lb}, nil
+}
+
+func (s *Server) updateLoadBalancer(rq *request) (interface{}, error) {
+	rec, err := s.findLoadBalancer(rq.lookup("load_balancer"))
+	if err != nil {
+		return nil, err
+	}
+
+	props := &loadBalancerProperties{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+	props.apply(rec.lb)
+
+	return map[string]interface{}{"load_balancer": rec.lb}, nil
+}
+
+func (s *Server) deleteLoadBalancer(rq *request) (interface{}, error) {
+	rec, err := s.findLoadBalancer(rq.lookup("load_balancer"))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range s.loadBalancers {
+		if r == rec {
+			s.loadBalancers = append(
+				s.loadBalancers[:i], s.loadBalancers[i+1:]...,
+			)
+
+			break
+		}
+	}
+
+	if rec.lb.IPAddress != nil {
+		rec.lb.IPAddress.AllocationID = ""
+		rec.lb.IPAddress.AllocationType = ""
+	}
+
+	return map[string]interface{}{"load_balancer": rec.lb}, nil
+}
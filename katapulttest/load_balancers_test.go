@@ -0,0 +1,55 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_loadBalancers(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+
+	lb, resp, err := c.LoadBalancers.Create(
+		ctx, defaultOrg, &core.LoadBalancerCreateArguments{
+			DataCenter: core.DataCenterRef{
+				Permalink: DefaultDataCenterPermalink,
+			},
+			Name: "web",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, core.VirtualMachinesResourceType, lb.ResourceType)
+	require.NotNil(t, lb.IPAddress)
+	assert.Equal(t, lb.ID, lb.IPAddress.AllocationID)
+
+	_, _, err = c.LoadBalancers.Create(
+		ctx, defaultOrg, &core.LoadBalancerCreateArguments{
+			DataCenter: core.DataCenterRef{Permalink: "nope"},
+		},
+	)
+	assert.ErrorIs(t, err, core.ErrDataCenterNotFound)
+
+	lb, _, err = c.LoadBalancers.Update(
+		ctx, lb.Ref(), &core.LoadBalancerUpdateArguments{
+			ResourceIDs: &[]string{"vm_1"},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vm_1"}, lb.ResourceIDs)
+
+	lbs, _, err := c.LoadBalancers.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	require.Len(t, lbs, 1)
+	assert.Equal(t, lb.ID, lbs[0].ID)
+
+	_, _, err = c.LoadBalancers.Delete(ctx, lb.Ref())
+	require.NoError(t, err)
+
+	_, _, err = c.LoadBalancers.GetByID(ctx, lb.ID)
+	assert.ErrorIs(t, err, core.ErrLoadBalancerNotFound)
+}
@@ -0,0 +1,163 @@
+package katapulttest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/krystal/go-katapult"
+)
+
+const (
+	// TokenPath is the path of the Server's OAuth2 token endpoint.
+	TokenPath = "/oauth2/token"
+
+	// DefaultClientID is the OAuth2 client ID the Server accepts unless
+	// WithOAuth2Client is used.
+	DefaultClientID = "katapulttest"
+
+	// DefaultClientSecret is the OAuth2 client secret the Server accepts
+	// unless WithOAuth2Client is used.
+	DefaultClientSecret = "katapulttest-secret" //nolint:gosec
+
+	// DefaultTokenLifetime is how long access tokens issued by the Server are
+	// valid for, unless WithTokenLifetime is used.
+	DefaultTokenLifetime = time.Hour
+)
+
+// WithOAuth2Client sets the client credentials which the Server's OAuth2 token
+// endpoint accepts.
+func WithOAuth2Client(clientID, clientSecret string) Option {
+	return func(s *Server) {
+		s.clientID = clientID
+		s.clientSecret = clientSecret
+	}
+}
+
+// WithTokenLifetime sets how long access tokens issued by the Server's OAuth2
+// token endpoint are valid for.
+func WithTokenLifetime(d time.Duration) Option {
+	return func(s *Server) {
+		s.tokenLifetime = d
+	}
+}
+
+// TokenURL returns the URL of the Server's OAuth2 token endpoint, which issues
+// access tokens through the client credentials grant. Issued tokens are
+// accepted by the Server in place of its API key until they expire.
+func (s *Server) TokenURL() string {
+	return s.URL + TokenPath
+}
+
+// ClientCredentials returns a *katapult.ClientCredentialsConfig for the
+// Server's OAuth2 token endpoint.
+func (s *Server) ClientCredentials(
+	scopes ...string,
+) *katapult.ClientCredentialsConfig {
+	return &katapult.ClientCredentialsConfig{
+		ClientID:     s.clientID,
+		ClientSecret: s.clientSecret,
+		TokenURL:     s.TokenURL(),
+		Scopes:       scopes,
+		HTTPClient:   s.Server.Client(),
+	}
+}
+
+// TokenClient returns a new *katapult.Client configured to send requests to
+// the Server, authenticated with tokens from its OAuth2 token endpoint. Any
+// given options are applied after those configuring the base URL and token
+// source.
+func (s *Server) TokenClient(
+	opts ...katapult.Option,
+) (*katapult.Client, error) {
+	ts := s.ClientCredentials().TokenSource(context.Background())
+
+	return katapult.New(append(
+		[]katapult.Option{
+			katapult.WithBaseURL(s.BaseURL()),
+			katapult.WithTokenSource(ts),
+			katapult.WithHTTPClient(s.Server.Client()),
+		},
+		opts...,
+	)...)
+}
+
+// IssuedTokens returns the number of access tokens issued by the Server's
+// OAuth2 token endpoint.
+func (s *Server) IssuedTokens() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issuedTokens
+}
+
+// RevokeTokens revokes all access tokens issued by the Server's OAuth2 token
+// endpoint, causing requests authenticated with them to fail with an
+// invalid_api_token error.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = map[string]time.Time{}
+}
+
+// tokenValid reports whether token was issued by the token endpoint and has
+// not expired or been revoked.
+func (s *Server) tokenValid(token string) bool {
+	expiry, ok := s.tokens[token]
+
+	return ok && time.Now().Before(expiry)
+}
+
+// serveToken implements the client credentials grant of the OAuth2 token
+// endpoint, responding with errors as described by RFC 6749.
+func (s *Server) serveToken(r *http.Request) (int, interface{}) {
+	oauthError := func(status int, code, desc string) (int, interface{}) {
+		return status, map[string]string{
+			"error":             code,
+			"error_description": desc,
+		}
+	}
+
+	if r.Method != http.MethodPost {
+		return oauthError(
+			http.StatusMethodNotAllowed,
+			"invalid_request", "token requests must use POST",
+		)
+	}
+	if err := r.ParseForm(); err != nil {
+		return oauthError(http.StatusBadRequest, "invalid_request", err.Error())
+	}
+
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id = r.PostForm.Get("client_id")
+		secret = r.PostForm.Get("client_secret")
+	}
+	if id != s.clientID || secret != s.clientSecret {
+		return oauthError(
+			http.StatusUnauthorized,
+			"invalid_client", "client authentication failed",
+		)
+	}
+
+	if grant := r.PostForm.Get("grant_type"); grant != "client_credentials" {
+		return oauthError(
+			http.StatusBadRequest,
+			"unsupported_grant_type",
+			"grant type '"+grant+"' is not supported",
+		)
+	}
+
+	token := strings.ToLower(s.newID("tok"))
+	s.tokens[token] = time.Now().Add(s.tokenLifetime)
+	s.issuedTokens++
+
+	return http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int64(s.tokenLifetime / time.Second),
+		"scope":        r.PostForm.Get("scope"),
+	}
+}
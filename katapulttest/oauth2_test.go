@@ -0,0 +1,150 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/next"
+	nextcore "github.com/krystal/go-katapult/next/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_TokenClient(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	ctx := context.Background()
+
+	tc, err := s.TokenClient()
+	require.NoError(t, err)
+	c := core.New(tc)
+
+	org, _, err := c.Organizations.GetBySubDomain(
+		ctx, DefaultOrganizationSubDomain,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultOrganizationSubDomain, org.SubDomain)
+
+	_, _, err = c.DataCenters.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.IssuedTokens())
+
+	s.RevokeTokens()
+
+	_, _, err = c.DataCenters.List(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.IssuedTokens())
+}
+
+func TestServer_tokenEndpoint(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		config  func(s *Server) *katapult.ClientCredentialsConfig
+		wantErr string
+	}{
+		{
+			name: "default credentials",
+			config: func(s *Server) *katapult.ClientCredentialsConfig {
+				return s.ClientCredentials("vm.read")
+			},
+		},
+		{
+			name: "custom credentials",
+			opts: []Option{WithOAuth2Client("acme", "hunter2")},
+			config: func(s *Server) *katapult.ClientCredentialsConfig {
+				return s.ClientCredentials()
+			},
+		},
+		{
+			name: "invalid credentials",
+			config: func(s *Server) *katapult.ClientCredentialsConfig {
+				c := s.ClientCredentials()
+				c.ClientSecret = "wrong"
+
+				return c
+			},
+			wantErr: "katapult: token_source: invalid_client: " +
+				"client authentication failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(append(
+				[]Option{WithTokenLifetime(30 * time.Minute)}, tt.opts...,
+			)...)
+			defer s.Close()
+
+			tok, err := tt.config(s).Token(ctx)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, 0, s.IssuedTokens())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer", tok.TokenType)
+			assert.True(t, tok.Valid())
+			assert.WithinDuration(t,
+				time.Now().Add(30*time.Minute), tok.Expiry, time.Minute,
+			)
+			assert.Equal(t, 1, s.IssuedTokens())
+		})
+	}
+}
+
+func TestServer_expiredToken(t *testing.T) {
+	s := NewServer(WithTokenLifetime(time.Second))
+	defer s.Close()
+	ctx := context.Background()
+
+	tok, err := s.ClientCredentials().Token(ctx)
+	require.NoError(t, err)
+
+	s.mu.Lock()
+	s.tokens[tok.AccessToken] = time.Now().Add(-time.Second)
+	s.mu.Unlock()
+
+	c, err := s.Client(katapult.WithAPIKey(tok.AccessToken))
+	require.NoError(t, err)
+
+	_, _, err = core.New(c).DataCenters.List(ctx)
+	assert.ErrorIs(t, err, core.ErrInvalidAPIToken)
+}
+
+func TestServer_nextClientWithTokenSource(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	ctx := context.Background()
+
+	nc, err := next.NewClientWithTokenSource(
+		s.CoreURL(), s.ClientCredentials().TokenSource(ctx), s.Server.Client(),
+		s.URL+"/public/v1", nil, s.Server.Client(),
+	)
+	require.NoError(t, err)
+	c, ok := nc.Core.(*nextcore.ClientWithResponses)
+	require.True(t, ok)
+
+	subDomain := DefaultOrganizationSubDomain
+	params := &nextcore.GetOrganizationParams{
+		OrganizationSubDomain: &subDomain,
+	}
+
+	res, err := c.GetOrganizationWithResponse(ctx, params)
+	require.NoError(t, err)
+	require.NotNil(t, res.JSON200)
+
+	s.RevokeTokens()
+
+	res, err = c.GetOrganizationWithResponse(ctx, params)
+	require.NoError(t, err)
+	require.NotNil(t, res.JSON200)
+	assert.Equal(t, 2, s.IssuedTokens())
+}
@@ -0,0 +1,214 @@
+package katapulttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/krystal/go-katapult"
+)
+
+type handlerFunc func(rq *request) (interface{}, error)
+
+type route struct {
+	method   string
+	segments []string
+	handler  handlerFunc
+	created  bool
+}
+
+// newRoute returns a route for the given method and path pattern relative to
+// /core/v1/. Pattern segments starting with a colon are placeholders, which
+// match any path segment.
+func newRoute(method, pattern string, handler handlerFunc) *route {
+	return &route{
+		method:   method,
+		segments: strings.Split(pattern, "/"),
+		handler:  handler,
+	}
+}
+
+// newCreateRoute returns a route which responds with a 201 Created status.
+func newCreateRoute(method, pattern string, handler handlerFunc) *route {
+	rt := newRoute(method, pattern, handler)
+	rt.created = true
+
+	return rt
+}
+
+func (s *Server) match(method, path string) (*route, map[string]string) {
+	if !strings.HasPrefix(path, "/core/v1/") {
+		return nil, nil
+	}
+	segments := strings.Split(strings.TrimPrefix(path, "/core/v1/"), "/")
+
+	for _, rt := range s.routes {
+		if rt.method != method || len(rt.segments) != len(segments) {
+			continue
+		}
+
+		params := map[string]string{}
+		matched := true
+		for i, seg := range rt.segments {
+			if strings.HasPrefix(seg, ":") {
+				params[seg[1:]] = segments[i]
+
+				continue
+			}
+			if seg != segments[i] {
+				matched = false
+
+				break
+			}
+		}
+
+		if matched {
+			return rt, params
+		}
+	}
+
+	return nil, nil
+}
+
+// request wraps a *http.Request, providing access to object lookups given via
+// path, query string or JSON body.
+type request struct {
+	*http.Request
+
+	params map[string]string
+	body   map[string]json.RawMessage
+}
+
+func newRequest(r *http.Request, params map[string]string) (*request, error) {
+	rq := &request{
+		Request: r,
+		params:  params,
+		body:    map[string]json.RawMessage{},
+	}
+
+	if r.Body == nil {
+		return rq, nil
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(b)) > 0 {
+		err = json.Unmarshal(b, &rq.body)
+		if err != nil {
+			return nil, errValidation(
+				fmt.Sprintf("Request body is not valid JSON: %s", err),
+			)
+		}
+	}
+
+	return rq, nil
+}
+
+// lookup returns the attributes used to look up an object of the given kind,
+// for example "virtual_machine". The attributes are sourced from a path
+// placeholder of the same name containing an ID, query parameters such as
+// "virtual_machine[id]", or a "virtual_machine" object in the JSON body.
+func (rq *request) lookup(kind string) map[string]string {
+	attrs := map[string]string{}
+
+	if v := rq.params[kind]; v != "" && v != "_" && v != kind {
+		attrs["id"] = v
+	}
+
+	prefix := kind + "["
+	for k, vals := range rq.URL.Query() {
+		if strings.HasPrefix(k, prefix) && strings.HasSuffix(k, "]") {
+			attrs[k[len(prefix):len(k)-1]] = vals[0]
+		}
+	}
+
+	if raw, ok := rq.body[kind]; ok {
+		obj := map[string]interface{}{}
+		if json.Unmarshal(raw, &obj) == nil {
+			for k, v := range obj {
+				if str, ok := v.(string); ok && str != "" {
+					attrs[k] = str
+				}
+			}
+		}
+	}
+
+	return attrs
+}
+
+// decodeBody decodes the value of the given top-level key of the JSON body
+// into v. If key is empty, the whole body is decoded into v. It returns false
+// if the key is not present.
+func (rq *request) decodeBody(key string, v interface{}) (bool, error) {
+	var raw []byte
+	if key == "" {
+		b, err := json.Marshal(rq.body)
+		if err != nil {
+			return false, err
+		}
+		raw = b
+	} else {
+		r, ok := rq.body[key]
+		if !ok {
+			return false, nil
+		}
+		raw = r
+	}
+
+	err := json.Unmarshal(raw, v)
+	if err != nil {
+		return true, errValidation(
+			fmt.Sprintf("Invalid value for %s: %s", key, err),
+		)
+	}
+
+	return true, nil
+}
+
+// paginate returns the start and end index of the requested page of a list
+// with total items, along with the pagination details to include in the
+// response.
+func (rq *request) paginate(total int) (int, int, *katapult.Pagination) {
+	q := rq.URL.Query()
+
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	perPage, _ := strconv.Atoi(q.Get("per_page"))
+	switch {
+	case perPage < 1:
+		perPage = DefaultPerPage
+	case perPage > MaxPerPage:
+		perPage = MaxPerPage
+	}
+
+	totalPages := (total + perPage - 1) / perPage
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	return start, end, &katapult.Pagination{
+		CurrentPage: page,
+		TotalPages:  totalPages,
+		Total:       total,
+		PerPage:     perPage,
+	}
+}
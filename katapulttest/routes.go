@@ -0,0 +1,238 @@
+package katapulttest
+
+import (
+	"net/http"
+
+	"github.com/krystal/go-katapult/core"
+)
+
+// buildRoutes returns all routes supported by the Server. Placeholders are
+// named after the object they look up, so that both the "_" segments used by
+// the core package, and the object name segments used by the next package
+// are matched.
+//
+// More specific routes must be listed before less specific routes with the
+// same number of segments.
+func (s *Server) buildRoutes() []*route {
+	return []*route{
+		// Organizations
+		newRoute(http.MethodGet, "organizations", s.listOrganizations),
+		newRoute(
+			http.MethodGet, "organizations/:organization", s.getOrganization,
+		),
+
+		// Data centers, zones and networks
+		newRoute(http.MethodGet, "data_centers", s.listDataCenters),
+		newRoute(
+			http.MethodGet, "data_centers/:data_center", s.getDataCenter,
+		),
+		newRoute(
+			http.MethodGet, "data_centers/:data_center/default_network",
+			s.getDataCenterDefaultNetwork,
+		),
+		newRoute(http.MethodGet, "zones", s.listZones),
+		newRoute(http.MethodGet, "zones/:zone", s.getZone),
+		newRoute(
+			http.MethodGet, "organizations/:organization/available_networks",
+			s.listAvailableNetworks,
+		),
+		newRoute(http.MethodGet, "networks/:network", s.getNetwork),
+
+		// Packages and disk templates
+		newRoute(http.MethodGet, "virtual_machine_packages", s.listPackages),
+		newRoute(
+			http.MethodGet,
+			"virtual_machine_packages/:virtual_machine_package",
+			s.getPackage,
+		),
+		newRoute(
+			http.MethodGet, "organizations/:organization/disk_templates",
+			s.listDiskTemplates,
+		),
+		newRoute(
+			http.MethodGet, "disk_templates/:disk_template",
+			s.getDiskTemplate,
+		),
+
+		// Tasks
+		newRoute(http.MethodGet, "tasks/:task", s.getTask),
+
+		// Virtual machine builds
+		newCreateRoute(
+			http.MethodPost,
+			"organizations/:organization/virtual_machines/build",
+			s.createBuild,
+		),
+		newCreateRoute(
+			http.MethodPost,
+			"organizations/:organization/virtual_machines/build_from_spec",
+			s.createBuildFromSpec,
+		),
+		newRoute(
+			http.MethodGet, "virtual_machines/builds/:virtual_machine_build",
+			s.getBuild,
+		),
+
+		// Virtual machines
+		newRoute(
+			http.MethodGet, "organizations/:organization/virtual_machines",
+			s.listVirtualMachines,
+		),
+		newRoute(
+			http.MethodGet, "virtual_machines/:virtual_machine",
+			s.getVirtualMachine,
+		),
+		newRoute(
+			http.MethodPatch, "virtual_machines/:virtual_machine",
+			s.updateVirtualMachine,
+		),
+		newRoute(
+			http.MethodDelete, "virtual_machines/:virtual_machine",
+			s.deleteVirtualMachine,
+		),
+		newRoute(
+			http.MethodPut, "virtual_machines/:virtual_machine/package",
+			s.changeVirtualMachinePackage,
+		),
+		newRoute(
+			http.MethodPost, "virtual_machines/:virtual_machine/start",
+			s.virtualMachineAction(
+				"Start",
+				core.VirtualMachineStarting,
+				core.VirtualMachineStarted,
+			),
+		),
+		newRoute(
+			http.MethodPost, "virtual_machines/:virtual_machine/stop",
+			s.virtualMachineAction(
+				"Stop", "", core.VirtualMachineStopped,
+			),
+		),
+		newRoute(
+			http.MethodPost, "virtual_machines/:virtual_machine/shutdown",
+			s.virtualMachineAction(
+				"Shutdown",
+				core.VirtualMachineStopping,
+				core.VirtualMachineStopped,
+			),
+		),
+		newRoute(
+			http.MethodPost, "virtual_machines/:virtual_machine/reset",
+			s.virtualMachineAction(
+				"Reset",
+				core.VirtualMachineResetting,
+				core.VirtualMachineStarted,
+			),
+		),
+
+		// IP addresses
+		newRoute(
+			http.MethodGet, "organizations/:organization/ip_addresses",
+			s.listIPAddresses,
+		),
+		newRoute(
+			http.MethodPost, "organizations/:organization/ip_addresses",
+			s.createIPAddress,
+		),
+		newRoute(
+			http.MethodGet, "ip_addresses/:ip_address", s.getIPAddress,
+		),
+		newRoute(
+			http.MethodPatch, "ip_addresses/:ip_address", s.updateIPAddress,
+		),
+		newRoute(
+			http.MethodDelete, "ip_addresses/:ip_address", s.deleteIPAddress,
+		),
+		newRoute(
+			http.MethodPost, "ip_addresses/:ip_address/unallocate",
+			s.unallocateIPAddress,
+		),
+
+		// DNS zones
+		newRoute(
+			http.MethodGet, "organizations/:organization/dns_zones",
+			s.listDNSZones,
+		),
+		newCreateRoute(
+			http.MethodPost, "organizations/:organization/dns_zones",
+			s.createDNSZone,
+		),
+		newRoute(
+			http.MethodGet,
+			"organizations/:organization/dns_zones/nameservers",
+			s.getDNSZoneNameservers,
+		),
+		newRoute(http.MethodGet, "dns_zones/:dns_zone", s.getDNSZone),
+		newRoute(http.MethodPatch, "dns_zones/:dns_zone", s.updateDNSZone),
+		newRoute(http.MethodDelete, "dns_zones/:dns_zone", s.deleteDNSZone),
+		newRoute(
+			http.MethodPost, "dns_zones/:dns_zone/verify", s.verifyDNSZone,
+		),
+
+		// Security groups
+		newRoute(
+			http.MethodGet, "organizations/:organization/security_groups",
+			s.listSecurityGroups,
+		),
+		newRoute(
+			http.MethodPost, "organizations/:organization/security_groups",
+			s.createSecurityGroup,
+		),
+		newRoute(
+			http.MethodGet, "security_groups/:security_group",
+			s.getSecurityGroup,
+		),
+		newRoute(
+			http.MethodPatch, "security_groups/:security_group",
+			s.updateSecurityGroup,
+		),
+		newRoute(
+			http.MethodDelete, "security_groups/:security_group",
+			s.deleteSecurityGroup,
+		),
+
+		// Load balancers
+		newRoute(
+			http.MethodGet, "organizations/:organization/load_balancers",
+			s.listLoadBalancers,
+		),
+		newCreateRoute(
+			http.MethodPost, "organizations/:organization/load_balancers",
+			s.createLoadBalancer,
+		),
+		newRoute(
+			http.MethodGet, "load_balancers/:load_balancer",
+			s.getLoadBalancer,
+		),
+		newRoute(
+			http.MethodPatch, "load_balancers/:load_balancer",
+			s.updateLoadBalancer,
+		),
+		newRoute(
+			http.MethodDelete, "load_balancers/:load_balancer",
+			s.deleteLoadBalancer,
+		),
+
+		// Trash objects
+		newRoute(
+			http.MethodGet, "organizations/:organization/trash_objects",
+			s.listTrashObjects,
+		),
+		newRoute(
+			http.MethodPost,
+			"organizations/:organization/trash_objects/purge_all",
+			s.purgeAllTrashObjects,
+		),
+		newRoute(
+			http.MethodGet, "trash_objects/:trash_object", s.getTrashObject,
+		),
+		newRoute(
+			http.MethodDelete, "trash_objects/:trash_object",
+			s.purgeTrashObject,
+		),
+		newRoute(
+			http.MethodPost, "trash_objects/:trash_object/restore",
+			s.restoreTrashObject,
+		),
+	}
+}
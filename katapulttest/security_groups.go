@@ -0,0 +1,137 @@
+package katapulttest
+
+import (
+	"strings"
+
+	"github.com/krystal/go-katapult/core"
+)
+
+type securityGroupRecord struct {
+	orgID string
+	group *core.SecurityGroup
+}
+
+type securityGroupProperties struct {
+	Name             *string   `json:"name"`
+	Associations     *[]string `json:"associations"`
+	AllowAllInbound  *bool     `json:"allow_all_inbound"`
+	AllowAllOutbound *bool     `json:"allow_all_outbound"`
+}
+
+func (p *securityGroupProperties) apply(sg *core.SecurityGroup) {
+	if p.Name != nil {
+		sg.Name = *p.Name
+	}
+	if p.Associations != nil {
+		sg.Associations = *p.Associations
+	}
+	if p.AllowAllInbound != nil {
+		sg.AllowAllInbound = *p.AllowAllInbound
+	}
+	if p.AllowAllOutbound != nil {
+		sg.AllowAllOutbound = *p.AllowAllOutbound
+	}
+}
+
+func (s *Server) findSecurityGroup(
+	attrs map[string]string,
+) (*securityGroupRecord, error) {
+	for _, rec := range s.securityGroups {
+		if matchLookup(attrs, "id", rec.group.ID) {
+			return rec, nil
+		}
+	}
+
+	return nil, errNotFound("security_group_not_found", "security group")
+}
+
+func (s *Server) listSecurityGroups(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*core.SecurityGroup{}
+	for _, rec := range s.securityGroups {
+		if rec.orgID == org.ID {
+			groups = append(groups, rec.group)
+		}
+	}
+
+	start, end, pagination := rq.paginate(len(groups))
+
+	return map[string]interface{}{
+		"pagination":      pagination,
+		"security_groups": groups[start:end],
+	}, nil
+}
+
+func (s *Server) getSecurityGroup(rq *request) (interface{}, error) {
+	rec, err := s.findSecurityGroup(rq.lookup("security_group"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"security_group": rec.group}, nil
+}
+
+func (s *Server) createSecurityGroup(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	props := &securityGroupProperties{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+	if props.Name == nil || strings.TrimSpace(*props.Name) == "" {
+		return nil, errValidation("Name can't be blank")
+	}
+
+	sg := &core.SecurityGroup{ID: s.newID("sg")}
+	props.apply(sg)
+	s.securityGroups = append(s.securityGroups, &securityGroupRecord{
+		orgID: org.ID,
+		group: sg,
+	})
+
+	return map[string]interface{}{"security_group": sg}, nil
+}
+
+func (s *Server) updateSecurityGroup(rq *request) (interface{}, error) {
+	rec, err := s.findSecurityGroup(rq.lookup("security_group"))
+	if err != nil {
+		return nil, err
+	}
+
+	props := &securityGroupProperties{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+	if props.Name != nil && strings.TrimSpace(*props.Name) == "" {
+		return nil, errValidation("Name can't be blank")
+	}
+	props.apply(rec.group)
+
+	return map[string]interface{}{"security_group": rec.group}, nil
+}
+
+func (s *Server) deleteSecurityGroup(rq *request) (interface{}, error) {
+	rec, err := s.findSecurityGroup(rq.lookup("security_group"))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range s.securityGroups {
+		if r == rec {
+			s.securityGroups = append(
+				s.securityGroups[:i], s.securityGroups[i+1:]...,
+			)
+
+			break
+		}
+	}
+
+	return map[string]interface{}{"security_group": rec.group}, nil
+}
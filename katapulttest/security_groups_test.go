@@ -0,0 +1,48 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_securityGroups(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+	allow := true
+
+	sg, _, err := c.SecurityGroups.Create(
+		ctx, defaultOrg, &core.SecurityGroupCreateArguments{
+			Name:            "web",
+			AllowAllInbound: &allow,
+		},
+	)
+	require.NoError(t, err)
+	assert.True(t, sg.AllowAllInbound)
+
+	_, _, err = c.SecurityGroups.Create(
+		ctx, defaultOrg, &core.SecurityGroupCreateArguments{},
+	)
+	assert.ErrorIs(t, err, core.ErrValidationError)
+
+	sg, _, err = c.SecurityGroups.Update(
+		ctx, sg.Ref(), &core.SecurityGroupUpdateArguments{
+			Associations: &[]string{"vm_1"},
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vm_1"}, sg.Associations)
+
+	groups, _, err := c.SecurityGroups.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*core.SecurityGroup{sg}, groups)
+
+	_, _, err = c.SecurityGroups.Delete(ctx, sg.Ref())
+	require.NoError(t, err)
+
+	_, _, err = c.SecurityGroups.GetByID(ctx, sg.ID)
+	assert.ErrorIs(t, err, core.ErrSecurityGroupNotFound)
+}
@@ -0,0 +1,257 @@
+// Package katapulttest provides an in-memory, stateful fake of the Katapult
+// API for use in integration tests.
+//
+// A Server implements the most commonly used /core/v1 endpoints for
+// organizations, virtual machines, virtual machine builds, tasks, IP
+// addresses, DNS zones, security groups, load balancers and trash objects. It
+// responds with the same JSON structures and error codes as the real API,
+// supports pagination of list endpoints, and progresses tasks and builds each
+// time they are fetched, allowing multi-step flows to be tested without
+// network access.
+//
+// Both the "_" placeholder path segments used by the core package, and the
+// named placeholder segments used by the next package (such as
+// /virtual_machines/virtual_machine) are supported. Object lookups are read
+// from query parameters and JSON request bodies alike.
+//
+// Requests are authenticated with the Server's API key, or with access tokens
+// issued by its OAuth2 token endpoint through the client credentials grant.
+package katapulttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jimeh/rands"
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+	"github.com/krystal/go-katapult/next"
+)
+
+const (
+	// DefaultAPIKey is the API key the Server accepts unless WithAPIKey is
+	// used.
+	DefaultAPIKey = "katapulttest"
+
+	// DefaultTaskSteps is the number of times a task or build must be fetched
+	// before it completes, unless WithTaskSteps is used.
+	DefaultTaskSteps = 2
+
+	// DefaultPerPage is the number of items returned per page by list
+	// endpoints when the per_page query parameter is not given.
+	DefaultPerPage = 30
+
+	// MaxPerPage is the maximum number of items returned per page by list
+	// endpoints.
+	MaxPerPage = 200
+)
+
+// Option configures a Server created with NewServer.
+type Option func(s *Server)
+
+// WithAPIKey sets the API key which the Server requires requests to
+// authenticate with. An empty key disables authentication.
+func WithAPIKey(key string) Option {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithTaskSteps sets the number of times tasks and builds must be fetched
+// before they complete. When zero, tasks and builds complete as soon as they
+// are created.
+func WithTaskSteps(n int) Option {
+	return func(s *Server) {
+		if n < 0 {
+			n = 0
+		}
+		s.taskSteps = n
+	}
+}
+
+// WithoutDefaults prevents the Server from being seeded with the default
+// organization, data center, zone, network, package and disk template.
+func WithoutDefaults() Option {
+	return func(s *Server) {
+		s.noDefaults = true
+	}
+}
+
+// Server is a fake Katapult API server backed by in-memory state. It embeds a
+// *httptest.Server, which is started by NewServer and must be closed by
+// calling Close when no longer needed.
+//
+// All methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	apiKey        string
+	taskSteps     int
+	noDefaults    bool
+	routes        []*route
+	ipCounter     int
+	clientID      string
+	clientSecret  string
+	tokenLifetime time.Duration
+	tokens        map[string]time.Time
+	issuedTokens  int
+
+	organizations   []*core.Organization
+	dataCenters     []*core.DataCenter
+	zones           []*core.Zone
+	networks        []*core.Network
+	packages        []*core.VirtualMachinePackage
+	diskTemplates   []*core.DiskTemplate
+	virtualMachines []*virtualMachineRecord
+	builds          []*buildRecord
+	tasks           []*taskRecord
+	ipAddresses     []*ipAddressRecord
+	dnsZones        []*dnsZoneRecord
+	securityGroups  []*securityGroupRecord
+	loadBalancers   []*loadBalancerRecord
+	trashObjects    []*trashObjectRecord
+}
+
+// NewServer creates and starts a new Server.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:        DefaultAPIKey,
+		taskSteps:     DefaultTaskSteps,
+		clientID:      DefaultClientID,
+		clientSecret:  DefaultClientSecret,
+		tokenLifetime: DefaultTokenLifetime,
+		tokens:        map[string]time.Time{},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.routes = s.buildRoutes()
+	if !s.noDefaults {
+		s.seedDefaults()
+	}
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// BaseURL returns the URL of the server, suitable for use with
+// katapult.WithBaseURL.
+func (s *Server) BaseURL() *url.URL {
+	u, _ := url.Parse(s.URL)
+
+	return u
+}
+
+// CoreURL returns the URL of the /core/v1 API, suitable for use as the core
+// server URL of next.NewClient.
+func (s *Server) CoreURL() string {
+	return s.URL + "/core/v1"
+}
+
+// APIKey returns the API key the Server requires requests to authenticate
+// with.
+func (s *Server) APIKey() string {
+	return s.apiKey
+}
+
+// Client returns a new *katapult.Client configured to send requests to the
+// Server. Any given options are applied after those configuring the base URL
+// and API key.
+func (s *Server) Client(opts ...katapult.Option) (*katapult.Client, error) {
+	return katapult.New(append(
+		[]katapult.Option{
+			katapult.WithBaseURL(s.BaseURL()),
+			katapult.WithAPIKey(s.apiKey),
+			katapult.WithHTTPClient(s.Server.Client()),
+		},
+		opts...,
+	)...)
+}
+
+// NextClient returns a new *next.Client configured to send requests to the
+// Server.
+func (s *Server) NextClient() (*next.Client, error) {
+	return next.NewClient(
+		s.CoreURL(), s.apiKey, s.Server.Client(),
+		s.URL+"/public/v1", s.apiKey, s.Server.Client(),
+	)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, body := s.handle(r)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) handle(r *http.Request) (int, interface{}) {
+	if r.URL.Path == TokenPath {
+		return s.serveToken(r)
+	}
+
+	rt, params := s.match(r.Method, r.URL.Path)
+	if rt == nil {
+		return errorResponse(errRouteNotFound(r))
+	}
+
+	if err := s.authenticate(r); err != nil {
+		return errorResponse(err)
+	}
+
+	rq, err := newRequest(r, params)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	body, err := rt.handler(rq)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	status := http.StatusOK
+	if rt.created {
+		status = http.StatusCreated
+	}
+
+	return status, body
+}
+
+func (s *Server) authenticate(r *http.Request) error {
+	if s.apiKey == "" {
+		return nil
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	switch {
+	case token == "":
+		return errMissingAPIToken()
+	case token == s.apiKey, s.tokenValid(token):
+		return nil
+	default:
+		return errInvalidAPIToken()
+	}
+}
+
+func (s *Server) newID(prefix string) string {
+	id, err := rands.Alphanumeric(16)
+	if err != nil {
+		panic(fmt.Sprintf("katapulttest: failed to generate ID: %s", err))
+	}
+
+	return prefix + "_" + id
+}
@@ -0,0 +1,246 @@
+package katapulttest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/core"
+	nextcore "github.com/krystal/go-katapult/next/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCoreClient(t *testing.T, opts ...Option) (*Server, *core.Client) {
+	t.Helper()
+
+	s := NewServer(opts...)
+	t.Cleanup(s.Close)
+
+	c, err := s.Client()
+	require.NoError(t, err)
+
+	return s, core.New(c)
+}
+
+func newNextCoreClient(
+	t *testing.T,
+	opts ...Option,
+) (*Server, *nextcore.ClientWithResponses) {
+	t.Helper()
+
+	s := NewServer(opts...)
+	t.Cleanup(s.Close)
+
+	c, err := s.NextClient()
+	require.NoError(t, err)
+
+	nc, ok := c.Core.(*nextcore.ClientWithResponses)
+	require.True(t, ok)
+
+	return s, nc
+}
+
+var defaultOrg = core.OrganizationRef{SubDomain: DefaultOrganizationSubDomain}
+
+func TestNewServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantAPIKey string
+		wantOrgs   int
+	}{
+		{
+			name:       "defaults",
+			wantAPIKey: DefaultAPIKey,
+			wantOrgs:   1,
+		},
+		{
+			name:       "with API key",
+			opts:       []Option{WithAPIKey("secret")},
+			wantAPIKey: "secret",
+			wantOrgs:   1,
+		},
+		{
+			name:       "without defaults",
+			opts:       []Option{WithoutDefaults()},
+			wantAPIKey: DefaultAPIKey,
+			wantOrgs:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, c := newCoreClient(t, tt.opts...)
+
+			assert.Equal(t, tt.wantAPIKey, s.APIKey())
+			assert.Equal(t, s.URL, s.BaseURL().String())
+			assert.Equal(t, s.URL+"/core/v1", s.CoreURL())
+
+			orgs, _, err := c.Organizations.List(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, orgs, tt.wantOrgs)
+		})
+	}
+}
+
+func TestServer_authentication(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiKey     string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "valid API key",
+			apiKey:     DefaultAPIKey,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "missing API key",
+			apiKey:     "",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "missing_api_token",
+		},
+		{
+			name:       "invalid API key",
+			apiKey:     "nope",
+			wantStatus: http.StatusForbidden,
+			wantCode:   "invalid_api_token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer()
+			defer s.Close()
+
+			req, err := http.NewRequest(
+				http.MethodGet, s.CoreURL()+"/organizations", nil,
+			)
+			require.NoError(t, err)
+			if tt.apiKey != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.apiKey)
+			}
+
+			resp, err := s.Server.Client().Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantCode != "" {
+				err := katapult.DecodeResponseError(resp, resp.Body)
+				assert.Equal(t, tt.wantCode, katapult.ErrorCode(err))
+			}
+		})
+	}
+}
+
+func TestServer_WithAPIKeyEmpty(t *testing.T) {
+	s := NewServer(WithAPIKey(""))
+	defer s.Close()
+
+	resp, err := s.Server.Client().Get(s.CoreURL() + "/organizations")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServer_routeNotFound(t *testing.T) {
+	_, c := newCoreClient(t)
+
+	_, _, err := c.Certificates.List(
+		context.Background(), defaultOrg, nil,
+	)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, katapult.ErrNotFound)
+	assert.Equal(t, "route_not_found", katapult.ErrorCode(err))
+}
+
+func TestServer_organizations(t *testing.T) {
+	s, c := newCoreClient(t)
+	ctx := context.Background()
+
+	org, _, err := c.Organizations.GetBySubDomain(
+		ctx, DefaultOrganizationSubDomain,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Inc", org.Name)
+
+	got, _, err := c.Organizations.GetByID(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, org, got)
+
+	s.AddOrganization(&core.Organization{
+		Name:      "Suspended",
+		SubDomain: "suspended",
+		Suspended: true,
+	})
+	_, _, err = c.Organizations.GetBySubDomain(ctx, "suspended")
+	assert.ErrorIs(t, err, core.ErrOrganizationSuspended)
+
+	_, _, err = c.Organizations.GetBySubDomain(ctx, "missing")
+	assert.ErrorIs(t, err, core.ErrOrganizationNotFound)
+	assert.True(t, katapult.IsNotFound(err))
+}
+
+func TestServer_nextClient(t *testing.T) {
+	_, c := newNextCoreClient(t)
+	ctx := context.Background()
+
+	subDomain := DefaultOrganizationSubDomain
+	res, err := c.GetOrganizationWithResponse(
+		ctx, &nextcore.GetOrganizationParams{
+			OrganizationSubDomain: &subDomain,
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, res.JSON200)
+	assert.Equal(t, "Acme Inc", *res.JSON200.Organization.Name)
+
+	missing := "missing"
+	_, err = c.GetOrganizationWithResponse(
+		ctx, &nextcore.GetOrganizationParams{
+			OrganizationSubDomain: &missing,
+		},
+	)
+	assert.ErrorIs(t, err, core.ErrOrganizationNotFound)
+}
+
+func TestServer_catalog(t *testing.T) {
+	_, c := newCoreClient(t)
+	ctx := context.Background()
+
+	dc, _, err := c.DataCenters.GetByPermalink(ctx, DefaultDataCenterPermalink)
+	require.NoError(t, err)
+	assert.Equal(t, "GB", dc.Country.ISOCode2)
+
+	network, _, err := c.DataCenters.DefaultNetwork(ctx, dc.Ref())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultNetworkPermalink, network.Permalink)
+
+	networks, _, _, err := c.Networks.List(ctx, defaultOrg)
+	require.NoError(t, err)
+	assert.Equal(t, []*core.Network{network}, networks)
+
+	pkgs, resp, err := c.VirtualMachinePackages.List(
+		ctx, &core.ListOptions{PerPage: 1},
+	)
+	require.NoError(t, err)
+	assert.Len(t, pkgs, 1)
+	assert.Equal(t, 2, resp.Pagination.TotalPages)
+	assert.Equal(t, 2, resp.Pagination.Total)
+
+	pkg, _, err := c.VirtualMachinePackages.GetByPermalink(ctx, "rock-6")
+	require.NoError(t, err)
+	assert.Equal(t, 2, pkg.CPUCores)
+
+	tpls, _, err := c.DiskTemplates.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	require.Len(t, tpls, 1)
+	assert.Equal(t, DefaultDiskTemplatePermalink, tpls[0].Permalink)
+
+	_, _, err = c.DiskTemplates.GetByPermalink(ctx, "templates/missing")
+	assert.ErrorIs(t, err, core.ErrDiskTemplateNotFound)
+}
@@ -0,0 +1,74 @@
+package katapulttest
+
+import (
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult/core"
+)
+
+type taskRecord struct {
+	task       *core.Task
+	polls      int
+	onComplete func()
+}
+
+// newTask creates a pending task, which progresses each time it is fetched.
+// The onComplete function is called once the task completes, and may be nil.
+func (s *Server) newTask(name string, onComplete func()) *core.Task {
+	rec := &taskRecord{
+		task: &core.Task{
+			ID:        s.newID("task"),
+			Name:      name,
+			Status:    core.TaskPending,
+			CreatedAt: timestamp.Now(),
+		},
+		onComplete: onComplete,
+	}
+	s.tasks = append(s.tasks, rec)
+
+	if s.taskSteps == 0 {
+		s.advanceTask(rec)
+	}
+
+	return rec.task
+}
+
+// advanceTask moves the task one step towards completion.
+func (s *Server) advanceTask(rec *taskRecord) {
+	t := rec.task
+	if t.Status == core.TaskCompleted || t.Status == core.TaskFailed {
+		return
+	}
+
+	rec.polls++
+	if t.StartedAt == nil {
+		t.StartedAt = timestamp.Now()
+	}
+
+	if rec.polls < s.taskSteps {
+		t.Status = core.TaskRunning
+		t.Progress = rec.polls * 100 / s.taskSteps
+
+		return
+	}
+
+	t.Status = core.TaskCompleted
+	t.Progress = 100
+	t.FinishedAt = timestamp.Now()
+	if rec.onComplete != nil {
+		rec.onComplete()
+	}
+}
+
+func (s *Server) getTask(rq *request) (interface{}, error) {
+	attrs := rq.lookup("task")
+
+	for _, rec := range s.tasks {
+		if matchLookup(attrs, "id", rec.task.ID) {
+			s.advanceTask(rec)
+
+			return map[string]interface{}{"task": rec.task}, nil
+		}
+	}
+
+	return nil, errNotFound("task_not_found", "task")
+}
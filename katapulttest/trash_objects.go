@@ -0,0 +1,150 @@
+package katapulttest
+
+import (
+	"time"
+
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult/core"
+)
+
+// trashRetention is how long objects are kept in the trash for.
+const trashRetention = 7 * 24 * time.Hour
+
+type trashObjectRecord struct {
+	orgID   string
+	obj     *core.TrashObject
+	restore func()
+	purge   func()
+}
+
+// trash moves an object into the trash. The restore function is called when
+// the object is restored, and purge when it is permanently deleted.
+func (s *Server) trash(
+	orgID string,
+	objectID string,
+	objectType string,
+	restore func(),
+	purge func(),
+) *trashObjectRecord {
+	rec := &trashObjectRecord{
+		orgID: orgID,
+		obj: &core.TrashObject{
+			ID:         s.newID("trsh"),
+			KeepUntil:  timestamp.Time(time.Now().Add(trashRetention)),
+			ObjectID:   objectID,
+			ObjectType: objectType,
+		},
+		restore: restore,
+		purge:   purge,
+	}
+	s.trashObjects = append(s.trashObjects, rec)
+
+	return rec
+}
+
+func (s *Server) removeTrashObject(rec *trashObjectRecord) {
+	for i, r := range s.trashObjects {
+		if r == rec {
+			s.trashObjects = append(
+				s.trashObjects[:i], s.trashObjects[i+1:]...,
+			)
+
+			return
+		}
+	}
+}
+
+func (s *Server) findTrashObject(
+	attrs map[string]string,
+) (*trashObjectRecord, error) {
+	for _, rec := range s.trashObjects {
+		if matchLookup(
+			attrs, "id", rec.obj.ID, "object_id", rec.obj.ObjectID,
+		) {
+			return rec, nil
+		}
+	}
+
+	return nil, errNotFound("trash_object_not_found", "trash object")
+}
+
+func (s *Server) listTrashObjects(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	objs := []*core.TrashObject{}
+	for _, rec := range s.trashObjects {
+		if rec.orgID == org.ID {
+			objs = append(objs, rec.obj)
+		}
+	}
+
+	start, end, pagination := rq.paginate(len(objs))
+
+	return map[string]interface{}{
+		"pagination":    pagination,
+		"trash_objects": objs[start:end],
+	}, nil
+}
+
+func (s *Server) getTrashObject(rq *request) (interface{}, error) {
+	rec, err := s.findTrashObject(rq.lookup("trash_object"))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"trash_object": rec.obj}, nil
+}
+
+func (s *Server) purgeTrashObject(rq *request) (interface{}, error) {
+	rec, err := s.findTrashObject(rq.lookup("trash_object"))
+	if err != nil {
+		return nil, err
+	}
+
+	task := s.newTask("Purge item from trash", func() {
+		s.removeTrashObject(rec)
+		if rec.purge != nil {
+			rec.purge()
+		}
+	})
+
+	return map[string]interface{}{"task": task}, nil
+}
+
+func (s *Server) purgeAllTrashObjects(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	task := s.newTask("Purge all items from trash", func() {
+		for _, rec := range append([]*trashObjectRecord{}, s.trashObjects...) {
+			if rec.orgID != org.ID {
+				continue
+			}
+			s.removeTrashObject(rec)
+			if rec.purge != nil {
+				rec.purge()
+			}
+		}
+	})
+
+	return map[string]interface{}{"task": task}, nil
+}
+
+func (s *Server) restoreTrashObject(rq *request) (interface{}, error) {
+	rec, err := s.findTrashObject(rq.lookup("trash_object"))
+	if err != nil {
+		return nil, err
+	}
+
+	s.removeTrashObject(rec)
+	if rec.restore != nil {
+		rec.restore()
+	}
+
+	return map[string]interface{}{"trash_object": rec.obj}, nil
+}
@@ -0,0 +1,82 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_trashAndRestoreVirtualMachine(t *testing.T) {
+	s, c := newCoreClient(t)
+	ctx := context.Background()
+
+	vm, err := s.AddVirtualMachine(
+		defaultOrg, &core.VirtualMachine{Hostname: "web"},
+	)
+	require.NoError(t, err)
+
+	trash, _, err := c.VirtualMachines.Delete(ctx, vm.Ref())
+	require.NoError(t, err)
+	assert.Equal(t, vm.ID, trash.ObjectID)
+	assert.Equal(t, "VirtualMachine", trash.ObjectType)
+
+	_, _, err = c.VirtualMachines.GetByID(ctx, vm.ID)
+	assert.ErrorIs(t, err, core.ErrObjectInTrash)
+
+	vms, _, err := c.VirtualMachines.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	assert.Empty(t, vms)
+
+	objs, _, err := c.TrashObjects.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*core.TrashObject{trash}, objs)
+
+	_, _, err = c.TrashObjects.Restore(
+		ctx, core.TrashObjectRef{ObjectID: vm.ID},
+	)
+	require.NoError(t, err)
+
+	got, _, err := c.VirtualMachines.GetByID(ctx, vm.ID)
+	require.NoError(t, err)
+	assert.Equal(t, vm.ID, got.ID)
+
+	_, _, err = c.TrashObjects.GetByID(ctx, trash.ID)
+	assert.ErrorIs(t, err, core.ErrTrashObjectNotFound)
+}
+
+func TestServer_purgeTrashObjects(t *testing.T) {
+	s, c := newCoreClient(t, WithTaskSteps(0))
+	ctx := context.Background()
+
+	var trashed []*core.TrashObject
+	for _, hostname := range []string{"a", "b"} {
+		vm, err := s.AddVirtualMachine(
+			defaultOrg, &core.VirtualMachine{Hostname: hostname},
+		)
+		require.NoError(t, err)
+
+		trash, _, err := c.VirtualMachines.Delete(ctx, vm.Ref())
+		require.NoError(t, err)
+		trashed = append(trashed, trash)
+	}
+
+	task, _, err := c.TrashObjects.Purge(ctx, trashed[0].Ref())
+	require.NoError(t, err)
+	assert.Equal(t, core.TaskCompleted, task.Status)
+
+	_, _, err = c.VirtualMachines.GetByID(ctx, trashed[0].ObjectID)
+	assert.ErrorIs(t, err, core.ErrVirtualMachineNotFound)
+
+	_, _, err = c.TrashObjects.PurgeAll(ctx, defaultOrg)
+	require.NoError(t, err)
+
+	objs, _, err := c.TrashObjects.List(ctx, defaultOrg, nil)
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+
+	_, _, err = c.VirtualMachines.GetByID(ctx, trashed[1].ObjectID)
+	assert.ErrorIs(t, err, core.ErrVirtualMachineNotFound)
+}
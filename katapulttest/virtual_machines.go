@@ -0,0 +1,294 @@
+package katapulttest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/augurysys/timestamp"
+	"github.com/krystal/go-katapult/core"
+)
+
+type virtualMachineRecord struct {
+	orgID string
+	vm    *core.VirtualMachine
+	trash *trashObjectRecord
+}
+
+// AddVirtualMachine adds the given virtual machine to the organization,
+// assigning it an ID if it does not have one. The virtual machine's
+// Organization field is set to the organization, and its state defaults to
+// started.
+func (s *Server) AddVirtualMachine(
+	org core.OrganizationRef,
+	vm *core.VirtualMachine,
+) (*core.VirtualMachine, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, err := s.findOrganization(orgLookup(org))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.addVirtualMachine(o, vm), nil
+}
+
+func (s *Server) addVirtualMachine(
+	org *core.Organization,
+	vm *core.VirtualMachine,
+) *core.VirtualMachine {
+	if vm.ID == "" {
+		vm.ID = s.newID("vm")
+	}
+	if vm.Name == "" {
+		vm.Name = vm.Hostname
+	}
+	if vm.FQDN == "" && vm.Hostname != "" && org.InfrastructureDomain != "" {
+		vm.FQDN = vm.Hostname + "." + org.InfrastructureDomain
+	}
+	if vm.State == "" {
+		vm.State = core.VirtualMachineStarted
+	}
+	if vm.CreatedAt == nil {
+		vm.CreatedAt = timestamp.Now()
+	}
+	vm.Organization = &core.Organization{
+		ID:        org.ID,
+		Name:      org.Name,
+		SubDomain: org.SubDomain,
+	}
+
+	s.virtualMachines = append(s.virtualMachines, &virtualMachineRecord{
+		orgID: org.ID,
+		vm:    vm,
+	})
+
+	return vm
+}
+
+func orgLookup(ref core.OrganizationRef) map[string]string {
+	attrs := map[string]string{}
+	if ref.ID != "" {
+		attrs["id"] = ref.ID
+	}
+	if ref.SubDomain != "" {
+		attrs["sub_domain"] = ref.SubDomain
+	}
+
+	return attrs
+}
+
+// findVirtualMachine returns the virtual machine record matching attrs. Unless
+// allowTrashed is true, an object_in_trash error is returned for virtual
+// machines which are in the trash.
+func (s *Server) findVirtualMachine(
+	attrs map[string]string,
+	allowTrashed bool,
+) (*virtualMachineRecord, error) {
+	for _, rec := range s.virtualMachines {
+		if !matchLookup(attrs, "id", rec.vm.ID, "fqdn", rec.vm.FQDN) {
+			continue
+		}
+		if rec.trash != nil && !allowTrashed {
+			return nil, errObjectInTrash(rec.trash)
+		}
+
+		return rec, nil
+	}
+
+	return nil, errNotFound("virtual_machine_not_found", "virtual machine")
+}
+
+// hostnameTaken returns true if the hostname is in use by a virtual machine in
+// the organization, or reserved by one of its builds which is yet to complete.
+func (s *Server) hostnameTaken(orgID, hostname string) bool {
+	for _, rec := range s.virtualMachines {
+		if rec.orgID == orgID && strings.EqualFold(rec.vm.Hostname, hostname) {
+			return true
+		}
+	}
+	for _, rec := range s.builds {
+		if rec.orgID == orgID &&
+			rec.build.State != core.VirtualMachineBuildComplete &&
+			strings.EqualFold(rec.vm.Hostname, hostname) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) listVirtualMachines(rq *request) (interface{}, error) {
+	org, err := s.findOrganization(rq.lookup("organization"))
+	if err != nil {
+		return nil, err
+	}
+
+	vms := []*core.VirtualMachine{}
+	for _, rec := range s.virtualMachines {
+		if rec.orgID == org.ID && rec.trash == nil {
+			vms = append(vms, rec.vm)
+		}
+	}
+
+	start, end, pagination := rq.paginate(len(vms))
+
+	return map[string]interface{}{
+		"pagination":       pagination,
+		"virtual_machines": vms[start:end],
+	}, nil
+}
+
+func (s *Server) getVirtualMachine(rq *request) (interface{}, error) {
+	rec, err := s.findVirtualMachine(rq.lookup("virtual_machine"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"virtual_machine": rec.vm}, nil
+}
+
+func (s *Server) updateVirtualMachine(rq *request) (interface{}, error) {
+	rec, err := s.findVirtualMachine(rq.lookup("virtual_machine"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	props := &struct {
+		Name        *string   `json:"name"`
+		Hostname    *string   `json:"hostname"`
+		Description *string   `json:"description"`
+		TagNames    *[]string `json:"tag_names"`
+	}{}
+	if _, err := rq.decodeBody("properties", props); err != nil {
+		return nil, err
+	}
+
+	vm := rec.vm
+	if props.Hostname != nil && *props.Hostname != vm.Hostname {
+		if s.hostnameTaken(rec.orgID, *props.Hostname) {
+			return nil, errValidation("Hostname has already been taken")
+		}
+		vm.Hostname = *props.Hostname
+		if i := strings.Index(vm.FQDN, "."); i >= 0 {
+			vm.FQDN = vm.Hostname + vm.FQDN[i:]
+		}
+	}
+	if props.Name != nil {
+		vm.Name = *props.Name
+	}
+	if props.Description != nil {
+		vm.Description = *props.Description
+	}
+	if props.TagNames != nil {
+		vm.TagNames = *props.TagNames
+	}
+
+	return map[string]interface{}{"virtual_machine": vm}, nil
+}
+
+func (s *Server) deleteVirtualMachine(rq *request) (interface{}, error) {
+	rec, err := s.findVirtualMachine(rq.lookup("virtual_machine"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	trash := s.trash(rec.orgID, rec.vm.ID, "VirtualMachine", func() {
+		rec.trash = nil
+	}, func() {
+		s.purgeVirtualMachine(rec)
+	})
+	rec.trash = trash
+
+	return map[string]interface{}{
+		"trash_object":    trash.obj,
+		"virtual_machine": rec.vm,
+	}, nil
+}
+
+func (s *Server) purgeVirtualMachine(rec *virtualMachineRecord) {
+	for i, r := range s.virtualMachines {
+		if r == rec {
+			s.virtualMachines = append(
+				s.virtualMachines[:i], s.virtualMachines[i+1:]...,
+			)
+
+			break
+		}
+	}
+
+	for _, ip := range s.ipAddresses {
+		if ip.ip.AllocationID == rec.vm.ID {
+			ip.ip.AllocationID = ""
+			ip.ip.AllocationType = ""
+		}
+	}
+}
+
+func (s *Server) changeVirtualMachinePackage(
+	rq *request,
+) (interface{}, error) {
+	rec, err := s.findVirtualMachine(rq.lookup("virtual_machine"), false)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := s.findPackage(rq.lookup("virtual_machine_package"))
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.vm.State != core.VirtualMachineStopped {
+		return nil, newAPIError(
+			http.StatusNotAcceptable,
+			"task_queueing_error",
+			"This error means that a background task that was needed to "+
+				"complete your request could not be queued",
+			map[string]string{
+				"details": "The virtual machine must be stopped to " +
+					"change its package",
+			},
+		)
+	}
+
+	task := s.newTask("Change package", func() {
+		rec.vm.Package = pkg
+	})
+
+	return map[string]interface{}{"task": task}, nil
+}
+
+// virtualMachineAction returns a handler which queues a task transitioning a
+// virtual machine through the given intermediate state, to the final state
+// once the task completes.
+func (s *Server) virtualMachineAction(
+	name string,
+	intermediate core.VirtualMachineState,
+	final core.VirtualMachineState,
+) handlerFunc {
+	return func(rq *request) (interface{}, error) {
+		rec, err := s.findVirtualMachine(rq.lookup("virtual_machine"), false)
+		if err != nil {
+			return nil, err
+		}
+
+		if name == "Shutdown" && rec.vm.State != core.VirtualMachineStarted {
+			return nil, newAPIError(
+				http.StatusNotAcceptable,
+				"virtual_machine_must_be_started",
+				"Virtual machines must be in a started state to "+
+					"perform this action",
+				map[string]string{"current_state": string(rec.vm.State)},
+			)
+		}
+
+		if intermediate != "" {
+			rec.vm.State = intermediate
+		}
+		task := s.newTask(name+" virtual machine", func() {
+			rec.vm.State = final
+		})
+
+		return map[string]interface{}{"task": task}, nil
+	}
+}
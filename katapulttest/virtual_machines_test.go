@@ -0,0 +1,171 @@
+package katapulttest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_listVirtualMachines(t *testing.T) {
+	s, c := newCoreClient(t)
+	ctx := context.Background()
+
+	for _, hostname := range []string{"a", "b", "c"} {
+		_, err := s.AddVirtualMachine(
+			defaultOrg, &core.VirtualMachine{Hostname: hostname},
+		)
+		require.NoError(t, err)
+	}
+
+	vms, resp, err := c.VirtualMachines.List(
+		ctx, defaultOrg, &core.ListOptions{Page: 2, PerPage: 2},
+	)
+	require.NoError(t, err)
+	require.Len(t, vms, 1)
+	assert.Equal(t, "c", vms[0].Hostname)
+	assert.Equal(t, 2, resp.Pagination.CurrentPage)
+	assert.Equal(t, 2, resp.Pagination.TotalPages)
+	assert.Equal(t, 3, resp.Pagination.Total)
+}
+
+func TestServer_updateVirtualMachine(t *testing.T) {
+	s, c := newCoreClient(t)
+	ctx := context.Background()
+
+	vm, err := s.AddVirtualMachine(
+		defaultOrg, &core.VirtualMachine{Hostname: "web"},
+	)
+	require.NoError(t, err)
+	_, err = s.AddVirtualMachine(
+		defaultOrg, &core.VirtualMachine{Hostname: "taken"},
+	)
+	require.NoError(t, err)
+
+	got, _, err := c.VirtualMachines.Update(
+		ctx, vm.Ref(), &core.VirtualMachineUpdateArguments{
+			Name:     "Web",
+			Hostname: "web-2",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Web", got.Name)
+	assert.Equal(t, "web-2.acme.kpult.io", got.FQDN)
+
+	_, _, err = c.VirtualMachines.Update(
+		ctx, vm.Ref(), &core.VirtualMachineUpdateArguments{
+			Hostname: "taken",
+		},
+	)
+	assert.ErrorIs(t, err, core.ErrValidationError)
+}
+
+func TestServer_virtualMachinePowerActions(t *testing.T) {
+	s, c := newCoreClient(t, WithTaskSteps(1))
+	ctx := context.Background()
+
+	vm, err := s.AddVirtualMachine(
+		defaultOrg, &core.VirtualMachine{Hostname: "web"},
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		action    func() (*core.Task, error)
+		wantState core.VirtualMachineState
+		wantErr   error
+	}{
+		{
+			name: "shutdown",
+			action: func() (*core.Task, error) {
+				task, _, err := c.VirtualMachines.Shutdown(ctx, vm.Ref())
+
+				return task, err
+			},
+			wantState: core.VirtualMachineStopped,
+		},
+		{
+			name: "shutdown when stopped",
+			action: func() (*core.Task, error) {
+				task, _, err := c.VirtualMachines.Shutdown(ctx, vm.Ref())
+
+				return task, err
+			},
+			wantErr: core.ErrVirtualMachineMustBeStarted,
+		},
+		{
+			name: "start",
+			action: func() (*core.Task, error) {
+				task, _, err := c.VirtualMachines.Start(ctx, vm.Ref())
+
+				return task, err
+			},
+			wantState: core.VirtualMachineStarted,
+		},
+		{
+			name: "reset",
+			action: func() (*core.Task, error) {
+				task, _, err := c.VirtualMachines.Reset(ctx, vm.Ref())
+
+				return task, err
+			},
+			wantState: core.VirtualMachineStarted,
+		},
+		{
+			name: "stop",
+			action: func() (*core.Task, error) {
+				task, _, err := c.VirtualMachines.Stop(ctx, vm.Ref())
+
+				return task, err
+			},
+			wantState: core.VirtualMachineStopped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := tt.action()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, core.TaskPending, task.Status)
+
+			task, _, err = c.Tasks.Get(ctx, task.ID)
+			require.NoError(t, err)
+			assert.Equal(t, core.TaskCompleted, task.Status)
+
+			got, _, err := c.VirtualMachines.GetByID(ctx, vm.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantState, got.State)
+		})
+	}
+}
+
+func TestServer_changeVirtualMachinePackage(t *testing.T) {
+	s, c := newCoreClient(t, WithTaskSteps(0))
+	ctx := context.Background()
+
+	vm, err := s.AddVirtualMachine(
+		defaultOrg, &core.VirtualMachine{Hostname: "web"},
+	)
+	require.NoError(t, err)
+	pkg := core.VirtualMachinePackageRef{Permalink: "rock-6"}
+
+	_, _, err = c.VirtualMachines.ChangePackage(ctx, vm.Ref(), pkg)
+	assert.ErrorIs(t, err, core.ErrTaskQueueingError)
+
+	_, _, err = c.VirtualMachines.Stop(ctx, vm.Ref())
+	require.NoError(t, err)
+
+	task, _, err := c.VirtualMachines.ChangePackage(ctx, vm.Ref(), pkg)
+	require.NoError(t, err)
+	assert.Equal(t, core.TaskCompleted, task.Status)
+
+	got, _, err := c.VirtualMachines.GetByID(ctx, vm.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "rock-6", got.Package.Permalink)
+}
@@ -13,3 +13,14 @@ func RandomHostname() string {
 func RandomName(prefixes ...string) string {
 	return globalGenerator.RandomName(prefixes...)
 }
+
+// UniqueHostname returns a random hostname from the default word lists which
+// is not in taken, the set of hostnames already in use, and adds it to taken.
+// A nil taken set is treated as empty.
+//
+// Up to DefaultHostnameAttempts random hostnames are tried. When all of them
+// are taken, a numeric suffix such as "-2" is added to the last one, counting
+// up until the hostname is free, so a hostname is always returned.
+func UniqueHostname(taken HostnameSet) string {
+	return globalGenerator.UniqueHostname(taken)
+}
@@ -70,3 +70,16 @@ func TestRandomName(t *testing.T) {
 		})
 	}
 }
+
+func TestUniqueHostname(t *testing.T) {
+	taken := NewHostnameSet()
+
+	for i := 0; i < 10; i++ {
+		got := UniqueHostname(taken)
+
+		assert.Truef(t, IsValidHostname(got),
+			"generated hostname is not valid: %s", got,
+		)
+	}
+	assert.Len(t, taken, 10)
+}
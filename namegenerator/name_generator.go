@@ -4,6 +4,7 @@ package namegenerator
 
 import (
 	"math/rand"
+	"sort"
 	"strings"
 	"time"
 )
@@ -313,8 +314,18 @@ func New(
 	adjectives map[string][]string,
 	nouns map[string][]string,
 ) *NameGenerator {
-	source := rand.NewSource(time.Now().UnixNano())
-	r := rand.New(source) //nolint:gosec
+	return NewWithSeed(time.Now().UnixNano(), adjectives, nouns)
+}
+
+// NewWithSeed returns a NameGenerator which generates the same sequence of
+// names every time it is created with the same seed and word lists, for use
+// in tests.
+func NewWithSeed(
+	seed int64,
+	adjectives map[string][]string,
+	nouns map[string][]string,
+) *NameGenerator {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec
 
 	return &NameGenerator{
 		rand:       r,
@@ -346,8 +357,16 @@ type wordList struct {
 func newWordList(rand *rand.Rand, wordGroups map[string][]string) *wordList {
 	wl := &wordList{rand: rand}
 
-	for group, words := range wordGroups {
-		for _, w := range words {
+	// Groups are added in sorted order, so seeded generators are not affected
+	// by the random iteration order of maps.
+	groups := make([]string, 0, len(wordGroups))
+	for group := range wordGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		for _, w := range wordGroups[group] {
 			wl.words = append(wl.words, &word{w, group})
 		}
 	}
@@ -0,0 +1,144 @@
+package namegenerator
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultHostnameAttempts is how many random hostnames UniqueHostname
+	// tries before falling back to adding a numeric suffix.
+	DefaultHostnameAttempts = 10
+
+	// MaxHostnameLength is the maximum length of a hostname label, as
+	// defined by RFC 1123.
+	MaxHostnameLength = 63
+
+	// fallbackHostname is used when a hostname has no valid characters.
+	fallbackHostname = "host"
+)
+
+// HostnameSet is a set of hostnames which are already taken. Hostnames are
+// case-insensitive, and are stored in lowercase.
+type HostnameSet map[string]struct{}
+
+// NewHostnameSet returns a HostnameSet containing the given hostnames.
+func NewHostnameSet(hostnames ...string) HostnameSet {
+	s := make(HostnameSet, len(hostnames))
+	for _, h := range hostnames {
+		s.Add(h)
+	}
+
+	return s
+}
+
+// Add adds hostname to the set.
+func (s HostnameSet) Add(hostname string) {
+	s[strings.ToLower(hostname)] = struct{}{}
+}
+
+// Has returns true if hostname is in the set.
+func (s HostnameSet) Has(hostname string) bool {
+	_, ok := s[strings.ToLower(hostname)]
+
+	return ok
+}
+
+// UniqueHostname returns a random hostname which is not in taken, trying up
+// to DefaultHostnameAttempts random hostnames. See
+// UniqueHostnameWithAttempts for details.
+func (s *NameGenerator) UniqueHostname(taken HostnameSet) string {
+	return s.UniqueHostnameWithAttempts(taken, DefaultHostnameAttempts)
+}
+
+// UniqueHostnameWithAttempts returns a random hostname which is not in taken,
+// and adds it to taken, so that repeated calls with the same set never return
+// the same hostname.
+//
+// Up to attempts random hostnames are tried. When all of them are taken, a
+// numeric suffix is added to the last one, such as "-2", counting up until
+// the hostname is free. The returned hostname is always a valid RFC 1123
+// label, even when the generator's word lists contain invalid characters.
+// A nil taken set is treated as empty.
+func (s *NameGenerator) UniqueHostnameWithAttempts(
+	taken HostnameSet,
+	attempts int,
+) string {
+	if taken == nil {
+		taken = HostnameSet{}
+	}
+
+	var hostname string
+	for i := 0; i < attempts || hostname == ""; i++ {
+		hostname = SanitizeHostname(s.RandomHostname())
+		if !taken.Has(hostname) {
+			taken.Add(hostname)
+
+			return hostname
+		}
+	}
+
+	for n := 2; ; n++ {
+		suffix := "-" + strconv.Itoa(n)
+		base := hostname
+		if len(base)+len(suffix) > MaxHostnameLength {
+			base = strings.TrimRight(
+				base[:MaxHostnameLength-len(suffix)], "-",
+			)
+		}
+
+		candidate := base + suffix
+		if !taken.Has(candidate) {
+			taken.Add(candidate)
+
+			return candidate
+		}
+	}
+}
+
+// SanitizeHostname returns hostname as a valid RFC 1123 label. It is
+// lowercased, runs of characters other than letters and digits are replaced
+// with a single hyphen, leading and trailing hyphens are removed, and it is
+// truncated to MaxHostnameLength. If nothing remains, "host" is returned.
+func SanitizeHostname(hostname string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(hostname) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+
+	s := b.String()
+	if len(s) > MaxHostnameLength {
+		s = strings.TrimRight(s[:MaxHostnameLength], "-")
+	}
+	if s == "" {
+		return fallbackHostname
+	}
+
+	return s
+}
+
+// IsValidHostname returns true if hostname is a valid RFC 1123 label: 1 to
+// 63 lowercase letters, digits and hyphens, not starting or ending with a
+// hyphen.
+func IsValidHostname(hostname string) bool {
+	if hostname == "" || len(hostname) > MaxHostnameLength ||
+		hostname[0] == '-' || hostname[len(hostname)-1] == '-' {
+		return false
+	}
+	for _, r := range hostname {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,210 @@
+package namegenerator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tinyAdjectives and tinyNouns can only produce "big-red-fox" and
+// "red-big-fox", so tests can take every possible hostname.
+var (
+	tinyAdjectives = map[string][]string{
+		"size":  {"big"},
+		"color": {"red"},
+	}
+	tinyNouns = map[string][]string{
+		"animals": {"fox"},
+	}
+)
+
+func TestNewWithSeed(t *testing.T) {
+	g1 := NewWithSeed(42, DefaultAdjectives, DefaultNouns)
+	g2 := NewWithSeed(42, DefaultAdjectives, DefaultNouns)
+
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, g1.RandomHostname(), g2.RandomHostname())
+		assert.Equal(t, g1.RandomName("tf"), g2.RandomName("tf"))
+	}
+}
+
+func TestHostnameSet(t *testing.T) {
+	s := NewHostnameSet("web-1", "DB-1")
+	s.Add("Cache-1")
+
+	assert.True(t, s.Has("web-1"))
+	assert.True(t, s.Has("WEB-1"))
+	assert.True(t, s.Has("db-1"))
+	assert.True(t, s.Has("cache-1"))
+	assert.False(t, s.Has("web-2"))
+	assert.Len(t, s, 3)
+}
+
+func TestNameGenerator_UniqueHostname(t *testing.T) {
+	g := NewWithSeed(1, DefaultAdjectives, DefaultNouns)
+	taken := NewHostnameSet()
+
+	for i := 0; i < 100; i++ {
+		got := g.UniqueHostname(taken)
+
+		assert.Truef(t, IsValidHostname(got),
+			"generated hostname is not valid: %s", got,
+		)
+	}
+	assert.Len(t, taken, 100)
+}
+
+func TestNameGenerator_UniqueHostname_seeded(t *testing.T) {
+	// The first hostnames of a seeded generator are taken, so a generator
+	// with the same seed has to retry past them.
+	first := NewWithSeed(7, DefaultAdjectives, DefaultNouns)
+	taken := NewHostnameSet(first.RandomHostname(), first.RandomHostname())
+	want := first.RandomHostname()
+
+	g := NewWithSeed(7, DefaultAdjectives, DefaultNouns)
+	got := g.UniqueHostname(taken)
+
+	assert.Equal(t, want, got)
+	assert.True(t, taken.Has(got))
+}
+
+func TestNameGenerator_UniqueHostnameWithAttempts(t *testing.T) {
+	tests := []struct {
+		name     string
+		taken    []string
+		attempts int
+		calls    int
+		want     *regexp.Regexp
+	}{
+		{
+			name:     "free",
+			attempts: 10,
+			calls:    2,
+			want:     regexp.MustCompile(`^(big-red|red-big)-fox$`),
+		},
+		{
+			name:     "suffixed",
+			taken:    []string{"big-red-fox", "red-big-fox"},
+			attempts: 10,
+			calls:    5,
+			want:     regexp.MustCompile(`^(big-red|red-big)-fox-[2-6]$`),
+		},
+		{
+			name: "suffix taken",
+			taken: []string{
+				"big-red-fox", "red-big-fox",
+				"big-red-fox-2", "red-big-fox-2",
+			},
+			attempts: 10,
+			calls:    2,
+			want:     regexp.MustCompile(`^(big-red|red-big)-fox-[34]$`),
+		},
+		{
+			name:     "no attempts",
+			attempts: 0,
+			calls:    2,
+			want:     regexp.MustCompile(`^(big-red|red-big)-fox(-2)?$`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithSeed(3, tinyAdjectives, tinyNouns)
+			taken := NewHostnameSet(tt.taken...)
+
+			seen := map[string]bool{}
+			for i := 0; i < tt.calls; i++ {
+				got := g.UniqueHostnameWithAttempts(taken, tt.attempts)
+
+				assert.Regexp(t, tt.want, got)
+				assert.Falsef(t, seen[got], "duplicate hostname: %s", got)
+				seen[got] = true
+			}
+			assert.Len(t, taken, len(tt.taken)+tt.calls)
+		})
+	}
+}
+
+func TestNameGenerator_UniqueHostname_invalidWords(t *testing.T) {
+	long := strings.Repeat("x", 70)
+	g := NewWithSeed(1,
+		map[string][]string{"a": {"Über"}, "b": {"--"}},
+		map[string][]string{"n": {long}},
+	)
+	taken := NewHostnameSet()
+
+	for i := 0; i < 3; i++ {
+		got := g.UniqueHostnameWithAttempts(taken, 1)
+
+		assert.Truef(t, IsValidHostname(got),
+			"generated hostname is not valid: %s", got,
+		)
+	}
+	assert.Len(t, taken, 3)
+}
+
+func TestNameGenerator_UniqueHostname_nilSet(t *testing.T) {
+	g := NewWithSeed(1, tinyAdjectives, tinyNouns)
+
+	got := g.UniqueHostname(nil)
+
+	assert.Contains(t, []string{"big-red-fox", "red-big-fox"}, got)
+}
+
+func TestSanitizeHostname(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		want     string
+	}{
+		{name: "valid", hostname: "web-1", want: "web-1"},
+		{name: "uppercase", hostname: "Web-1", want: "web-1"},
+		{
+			name:     "invalid characters",
+			hostname: "web_1.lon",
+			want:     "web-1-lon",
+		},
+		{name: "repeated hyphens", hostname: "web--_1", want: "web-1"},
+		{name: "edge hyphens", hostname: "-web-1-", want: "web-1"},
+		{name: "non-ASCII", hostname: "café-1", want: "caf-1"},
+		{
+			name:     "too long",
+			hostname: strings.Repeat("a", 62) + "-b",
+			want:     strings.Repeat("a", 62),
+		},
+		{name: "empty", hostname: "", want: "host"},
+		{name: "no valid characters", hostname: "---", want: "host"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SanitizeHostname(tt.hostname)
+
+			assert.Equal(t, tt.want, got)
+			assert.True(t, IsValidHostname(got))
+		})
+	}
+}
+
+func TestIsValidHostname(t *testing.T) {
+	tests := []struct {
+		hostname string
+		want     bool
+	}{
+		{hostname: "web-1", want: true},
+		{hostname: "1", want: true},
+		{hostname: strings.Repeat("a", 63), want: true},
+		{hostname: strings.Repeat("a", 64), want: false},
+		{hostname: "", want: false},
+		{hostname: "-web", want: false},
+		{hostname: "web-", want: false},
+		{hostname: "Web", want: false},
+		{hostname: "web_1", want: false},
+		{hostname: "web.lon", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidHostname(tt.hostname))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package next
 import (
 	"fmt"
 
+	"github.com/krystal/go-katapult"
 	"github.com/krystal/go-katapult/next/core"
 	"github.com/krystal/go-katapult/next/public"
 )
@@ -45,3 +46,33 @@ func NewClient(
 		Public: publicClient,
 	}, nil
 }
+
+// NewClientWithTokenSource creates a Client which authenticates requests with
+// OAuth2 tokens from the given token sources instead of static API tokens.
+// Tokens are reused until they expire, and requests rejected with a 401
+// Unauthorized status or an invalid_api_token error are retried once with a
+// new token. A nil publicTokens leaves requests to the public API
+// unauthenticated.
+func NewClientWithTokenSource(
+	coreURL string,
+	coreTokens katapult.TokenSource,
+	coreHTTPClient core.HttpRequestDoer,
+	publicURL string,
+	publicTokens katapult.TokenSource,
+	publicHTTPClient public.HttpRequestDoer,
+) (*Client, error) {
+	if coreTokens == nil {
+		return nil, fmt.Errorf("core token source cannot be nil")
+	}
+
+	coreDoer := katapult.NewTokenSourceHTTPClient(coreTokens, coreHTTPClient)
+
+	var publicDoer public.HttpRequestDoer = publicHTTPClient
+	if publicTokens != nil {
+		publicDoer = katapult.NewTokenSourceHTTPClient(
+			publicTokens, publicHTTPClient,
+		)
+	}
+
+	return NewClient(coreURL, "", coreDoer, publicURL, "", publicDoer)
+}
@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11399,11 +11398,6 @@ const (
 	clientVersion = "0.2.0" // x-release-please-version
 )
 
-var (
-	ErrRequestFailed = errors.New("request was not successful")
-	ErrNotFound      = errors.New("resource not found")
-)
-
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -33068,12 +33062,8 @@ func (c *ClientWithResponses) DeleteAddressListEntryWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33090,12 +33080,8 @@ func (c *ClientWithResponses) DeleteAddressListEntryWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33113,12 +33099,8 @@ func (c *ClientWithResponses) GetAddressListEntryWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33136,12 +33118,8 @@ func (c *ClientWithResponses) PatchAddressListEntryWithBodyWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33158,12 +33136,8 @@ func (c *ClientWithResponses) PatchAddressListEntryWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33181,12 +33155,8 @@ func (c *ClientWithResponses) GetAddressListsWithResponse(ctx context.Context, p
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33204,12 +33174,8 @@ func (c *ClientWithResponses) DeleteAddressListWithBodyWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33226,12 +33192,8 @@ func (c *ClientWithResponses) DeleteAddressListWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33249,12 +33211,8 @@ func (c *ClientWithResponses) GetAddressListWithResponse(ctx context.Context, pa
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33272,12 +33230,8 @@ func (c *ClientWithResponses) PatchAddressListWithBodyWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33294,12 +33248,8 @@ func (c *ClientWithResponses) PatchAddressListWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33317,12 +33267,8 @@ func (c *ClientWithResponses) GetAddressListEntriesWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33340,12 +33286,8 @@ func (c *ClientWithResponses) PostAddressListEntriesWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33362,12 +33304,8 @@ func (c *ClientWithResponses) PostAddressListEntriesWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33385,12 +33323,8 @@ func (c *ClientWithResponses) PostAddressListEntriesBulkWithBodyWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33407,12 +33341,8 @@ func (c *ClientWithResponses) PostAddressListEntriesBulkWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33430,12 +33360,8 @@ func (c *ClientWithResponses) DeleteApiTokenWithBodyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33452,12 +33378,8 @@ func (c *ClientWithResponses) DeleteApiTokenWithResponse(ctx context.Context, bo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33475,12 +33397,8 @@ func (c *ClientWithResponses) PatchApiTokenWithBodyWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33497,12 +33415,8 @@ func (c *ClientWithResponses) PatchApiTokenWithResponse(ctx context.Context, bod
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33520,12 +33434,8 @@ func (c *ClientWithResponses) PostApiTokenRegenerateSecretWithBodyWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33542,12 +33452,8 @@ func (c *ClientWithResponses) PostApiTokenRegenerateSecretWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33565,12 +33471,8 @@ func (c *ClientWithResponses) GetCertificateWithResponse(ctx context.Context, pa
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33588,12 +33490,8 @@ func (c *ClientWithResponses) GetCountriesWithResponse(ctx context.Context, para
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33611,12 +33509,8 @@ func (c *ClientWithResponses) GetCountryWithResponse(ctx context.Context, params
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33634,12 +33528,8 @@ func (c *ClientWithResponses) GetCountryCountryStatesWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33657,12 +33547,8 @@ func (c *ClientWithResponses) GetCountryStateWithResponse(ctx context.Context, p
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33680,12 +33566,8 @@ func (c *ClientWithResponses) GetCurrenciesWithResponse(ctx context.Context, par
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33703,12 +33585,8 @@ func (c *ClientWithResponses) GetCurrencyWithResponse(ctx context.Context, param
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33726,12 +33604,8 @@ func (c *ClientWithResponses) GetDataCentersWithResponse(ctx context.Context, re
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33749,12 +33623,8 @@ func (c *ClientWithResponses) GetDataCenterWithResponse(ctx context.Context, par
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33772,12 +33642,8 @@ func (c *ClientWithResponses) GetDataCenterDefaultNetworkWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33795,12 +33661,8 @@ func (c *ClientWithResponses) GetDataCenterGpuTypesWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33818,12 +33680,8 @@ func (c *ClientWithResponses) DeleteDiskBackupPolicyWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33840,12 +33698,8 @@ func (c *ClientWithResponses) DeleteDiskBackupPolicyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33863,12 +33717,8 @@ func (c *ClientWithResponses) GetDiskBackupPolicyWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33886,12 +33736,8 @@ func (c *ClientWithResponses) PatchDiskBackupPolicyWithBodyWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33908,12 +33754,8 @@ func (c *ClientWithResponses) PatchDiskBackupPolicyWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33931,12 +33773,8 @@ func (c *ClientWithResponses) DeleteDiskBackupPolicyScheduleWithBodyWithResponse
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33953,12 +33791,8 @@ func (c *ClientWithResponses) DeleteDiskBackupPolicyScheduleWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33976,12 +33810,8 @@ func (c *ClientWithResponses) GetDiskTemplateVersionWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -33999,12 +33829,8 @@ func (c *ClientWithResponses) GetDiskTemplateVersionSpecWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34022,12 +33848,8 @@ func (c *ClientWithResponses) GetDiskTemplateWithResponse(ctx context.Context, p
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34045,12 +33867,8 @@ func (c *ClientWithResponses) GetDiskTemplateVersionsWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34068,12 +33886,8 @@ func (c *ClientWithResponses) DeleteDiskWithBodyWithResponse(ctx context.Context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34090,12 +33904,8 @@ func (c *ClientWithResponses) DeleteDiskWithResponse(ctx context.Context, body D
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34113,12 +33923,8 @@ func (c *ClientWithResponses) GetDiskWithResponse(ctx context.Context, params *G
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34136,12 +33942,8 @@ func (c *ClientWithResponses) PatchDiskWithBodyWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34158,12 +33960,8 @@ func (c *ClientWithResponses) PatchDiskWithResponse(ctx context.Context, body Pa
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34181,12 +33979,8 @@ func (c *ClientWithResponses) PostDiskAssignWithBodyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34203,12 +33997,8 @@ func (c *ClientWithResponses) PostDiskAssignWithResponse(ctx context.Context, bo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34226,12 +34016,8 @@ func (c *ClientWithResponses) PostDiskAttachWithBodyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34248,12 +34034,8 @@ func (c *ClientWithResponses) PostDiskAttachWithResponse(ctx context.Context, bo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34271,12 +34053,8 @@ func (c *ClientWithResponses) PostDiskDetachWithBodyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34293,12 +34071,8 @@ func (c *ClientWithResponses) PostDiskDetachWithResponse(ctx context.Context, bo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34316,12 +34090,8 @@ func (c *ClientWithResponses) GetDiskDiskBackupPoliciesWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34339,12 +34109,8 @@ func (c *ClientWithResponses) PostDiskDiskBackupPoliciesWithBodyWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34361,12 +34127,8 @@ func (c *ClientWithResponses) PostDiskDiskBackupPoliciesWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34384,12 +34146,8 @@ func (c *ClientWithResponses) PutDiskIoProfileWithBodyWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34406,12 +34164,8 @@ func (c *ClientWithResponses) PutDiskIoProfileWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34429,12 +34183,8 @@ func (c *ClientWithResponses) PutDiskResizeWithBodyWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34451,12 +34201,8 @@ func (c *ClientWithResponses) PutDiskResizeWithResponse(ctx context.Context, bod
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34474,12 +34220,8 @@ func (c *ClientWithResponses) PostDiskUnassignWithBodyWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34496,12 +34238,8 @@ func (c *ClientWithResponses) PostDiskUnassignWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34519,12 +34257,8 @@ func (c *ClientWithResponses) DeleteDnsRecordWithBodyWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34541,12 +34275,8 @@ func (c *ClientWithResponses) DeleteDnsRecordWithResponse(ctx context.Context, b
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34564,12 +34294,8 @@ func (c *ClientWithResponses) GetDnsRecordWithResponse(ctx context.Context, para
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34587,12 +34313,8 @@ func (c *ClientWithResponses) PatchDnsRecordWithBodyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34609,12 +34331,8 @@ func (c *ClientWithResponses) PatchDnsRecordWithResponse(ctx context.Context, bo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34632,12 +34350,8 @@ func (c *ClientWithResponses) DeleteDnsZoneWithBodyWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34654,12 +34368,8 @@ func (c *ClientWithResponses) DeleteDnsZoneWithResponse(ctx context.Context, bod
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34677,12 +34387,8 @@ func (c *ClientWithResponses) GetDnsZoneWithResponse(ctx context.Context, params
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34700,12 +34406,8 @@ func (c *ClientWithResponses) PatchDnsZoneWithBodyWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34722,12 +34424,8 @@ func (c *ClientWithResponses) PatchDnsZoneWithResponse(ctx context.Context, body
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34745,12 +34443,8 @@ func (c *ClientWithResponses) GetDnsZoneRecordsWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34768,12 +34462,8 @@ func (c *ClientWithResponses) PostDnsZoneRecordsWithBodyWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34790,12 +34480,8 @@ func (c *ClientWithResponses) PostDnsZoneRecordsWithResponse(ctx context.Context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34813,12 +34499,8 @@ func (c *ClientWithResponses) PostDnsZoneVerifyWithBodyWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34835,12 +34517,8 @@ func (c *ClientWithResponses) PostDnsZoneVerifyWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34858,12 +34536,8 @@ func (c *ClientWithResponses) DeleteFileStorageVolumeWithBodyWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34880,12 +34554,8 @@ func (c *ClientWithResponses) DeleteFileStorageVolumeWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34903,12 +34573,8 @@ func (c *ClientWithResponses) GetFileStorageVolumeWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34926,12 +34592,8 @@ func (c *ClientWithResponses) PatchFileStorageVolumeWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34948,12 +34610,8 @@ func (c *ClientWithResponses) PatchFileStorageVolumeWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34971,12 +34629,8 @@ func (c *ClientWithResponses) GetGpuTypesWithResponse(ctx context.Context, param
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -34994,12 +34648,8 @@ func (c *ClientWithResponses) GetGpuTypeWithResponse(ctx context.Context, params
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35017,12 +34667,8 @@ func (c *ClientWithResponses) PostInvalidateLinkedWebSessionWithBodyWithResponse
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35039,12 +34685,8 @@ func (c *ClientWithResponses) PostInvalidateLinkedWebSessionWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35062,12 +34704,8 @@ func (c *ClientWithResponses) DeleteIpAddressWithBodyWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35084,12 +34722,8 @@ func (c *ClientWithResponses) DeleteIpAddressWithResponse(ctx context.Context, b
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35107,12 +34741,8 @@ func (c *ClientWithResponses) GetIpAddressWithResponse(ctx context.Context, para
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35130,12 +34760,8 @@ func (c *ClientWithResponses) PatchIpAddressWithBodyWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35152,12 +34778,8 @@ func (c *ClientWithResponses) PatchIpAddressWithResponse(ctx context.Context, bo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35175,12 +34797,8 @@ func (c *ClientWithResponses) PostIpAddressUnallocateWithBodyWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35197,12 +34815,8 @@ func (c *ClientWithResponses) PostIpAddressUnallocateWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35220,12 +34834,8 @@ func (c *ClientWithResponses) DeleteLoadBalancerWithBodyWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35242,12 +34852,8 @@ func (c *ClientWithResponses) DeleteLoadBalancerWithResponse(ctx context.Context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35265,12 +34871,8 @@ func (c *ClientWithResponses) GetLoadBalancerWithResponse(ctx context.Context, p
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35288,12 +34890,8 @@ func (c *ClientWithResponses) PatchLoadBalancerWithBodyWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35310,12 +34908,8 @@ func (c *ClientWithResponses) PatchLoadBalancerWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35333,12 +34927,8 @@ func (c *ClientWithResponses) GetLoadBalancerRulesWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35356,12 +34946,8 @@ func (c *ClientWithResponses) PostLoadBalancerRulesWithBodyWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35378,12 +34964,8 @@ func (c *ClientWithResponses) PostLoadBalancerRulesWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35401,12 +34983,8 @@ func (c *ClientWithResponses) DeleteLoadBalancersRulesLoadBalancerRuleWithBodyWi
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35423,12 +35001,8 @@ func (c *ClientWithResponses) DeleteLoadBalancersRulesLoadBalancerRuleWithRespon
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35446,12 +35020,8 @@ func (c *ClientWithResponses) GetLoadBalancersRulesLoadBalancerRuleWithResponse(
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35469,12 +35039,8 @@ func (c *ClientWithResponses) PatchLoadBalancersRulesLoadBalancerRuleWithBodyWit
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35491,12 +35057,8 @@ func (c *ClientWithResponses) PatchLoadBalancersRulesLoadBalancerRuleWithRespons
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35514,12 +35076,8 @@ func (c *ClientWithResponses) DeleteOrganizationWithBodyWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35536,12 +35094,8 @@ func (c *ClientWithResponses) DeleteOrganizationWithResponse(ctx context.Context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35559,12 +35113,8 @@ func (c *ClientWithResponses) GetNetworkWithResponse(ctx context.Context, params
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35582,12 +35132,8 @@ func (c *ClientWithResponses) DeleteObjectStorageAccessKeyWithBodyWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35604,12 +35150,8 @@ func (c *ClientWithResponses) DeleteObjectStorageAccessKeyWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35627,12 +35169,8 @@ func (c *ClientWithResponses) GetObjectStorageAccessKeyWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35650,12 +35188,8 @@ func (c *ClientWithResponses) PatchObjectStorageAccessKeyWithBodyWithResponse(ct
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35672,12 +35206,8 @@ func (c *ClientWithResponses) PatchObjectStorageAccessKeyWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35695,12 +35225,8 @@ func (c *ClientWithResponses) PostObjectStorageAccessKeyGenerateCredentialsWithB
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35717,12 +35243,8 @@ func (c *ClientWithResponses) PostObjectStorageAccessKeyGenerateCredentialsWithR
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35740,12 +35262,8 @@ func (c *ClientWithResponses) DeleteObjectStorageObjectStorageClusterBucketWithB
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35762,12 +35280,8 @@ func (c *ClientWithResponses) DeleteObjectStorageObjectStorageClusterBucketWithR
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35785,12 +35299,8 @@ func (c *ClientWithResponses) GetObjectStorageObjectStorageClusterBucketWithResp
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35808,12 +35318,8 @@ func (c *ClientWithResponses) PatchObjectStorageObjectStorageClusterBucketWithBo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35830,12 +35336,8 @@ func (c *ClientWithResponses) PatchObjectStorageObjectStorageClusterBucketWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35853,12 +35355,8 @@ func (c *ClientWithResponses) GetObjectStorageObjectStorageClusterBucketObjectWi
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35876,12 +35374,8 @@ func (c *ClientWithResponses) PostObjectStorageObjectStorageClusterBucketPresign
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35898,12 +35392,8 @@ func (c *ClientWithResponses) PostObjectStorageObjectStorageClusterBucketPresign
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35921,12 +35411,8 @@ func (c *ClientWithResponses) PostObjectStorageObjectStorageClusterBucketRevokeP
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35943,12 +35429,8 @@ func (c *ClientWithResponses) PostObjectStorageObjectStorageClusterBucketRevokeP
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35966,12 +35448,8 @@ func (c *ClientWithResponses) GetOperatingSystemsWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -35989,12 +35467,8 @@ func (c *ClientWithResponses) GetOperatingSystemWithResponse(ctx context.Context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36012,12 +35486,8 @@ func (c *ClientWithResponses) GetOrganizationsWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36035,12 +35505,8 @@ func (c *ClientWithResponses) GetOrganizationWithResponse(ctx context.Context, p
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36058,12 +35524,8 @@ func (c *ClientWithResponses) GetOrganizationAddressListsWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36081,12 +35543,8 @@ func (c *ClientWithResponses) PostOrganizationAddressListsWithBodyWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36103,12 +35561,8 @@ func (c *ClientWithResponses) PostOrganizationAddressListsWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36126,12 +35580,8 @@ func (c *ClientWithResponses) GetOrganizationApiTokensWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36149,12 +35599,8 @@ func (c *ClientWithResponses) PostOrganizationApiTokensWithBodyWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36171,12 +35617,8 @@ func (c *ClientWithResponses) PostOrganizationApiTokensWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36194,12 +35636,8 @@ func (c *ClientWithResponses) GetOrganizationAvailableNetworksWithResponse(ctx c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36217,12 +35655,8 @@ func (c *ClientWithResponses) GetOrganizationCertificatesWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36240,12 +35674,8 @@ func (c *ClientWithResponses) GetOrganizationDeletionStepsWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36263,12 +35693,8 @@ func (c *ClientWithResponses) GetOrganizationDiskBackupPoliciesWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36286,12 +35712,8 @@ func (c *ClientWithResponses) GetOrganizationDiskIoProfilesWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36309,12 +35731,8 @@ func (c *ClientWithResponses) GetOrganizationDiskTemplatesWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36332,12 +35750,8 @@ func (c *ClientWithResponses) GetOrganizationDisksWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36355,12 +35769,8 @@ func (c *ClientWithResponses) PostOrganizationDisksWithBodyWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36377,12 +35787,8 @@ func (c *ClientWithResponses) PostOrganizationDisksWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36400,12 +35806,8 @@ func (c *ClientWithResponses) GetOrganizationDnsZonesWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36423,12 +35825,8 @@ func (c *ClientWithResponses) PostOrganizationDnsZonesWithBodyWithResponse(ctx c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36445,12 +35843,8 @@ func (c *ClientWithResponses) PostOrganizationDnsZonesWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36468,12 +35862,8 @@ func (c *ClientWithResponses) GetOrganizationDnsZonesNameserversWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36491,12 +35881,8 @@ func (c *ClientWithResponses) GetOrganizationFileStorageVolumesWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36514,12 +35900,8 @@ func (c *ClientWithResponses) PostOrganizationFileStorageVolumesWithBodyWithResp
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36536,12 +35918,8 @@ func (c *ClientWithResponses) PostOrganizationFileStorageVolumesWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36559,12 +35937,8 @@ func (c *ClientWithResponses) GetOrganizationIpAddressesWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36582,12 +35956,8 @@ func (c *ClientWithResponses) PostOrganizationIpAddressesWithBodyWithResponse(ct
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36604,12 +35974,8 @@ func (c *ClientWithResponses) PostOrganizationIpAddressesWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36627,12 +35993,8 @@ func (c *ClientWithResponses) GetOrganizationLoadBalancersWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36650,12 +36012,8 @@ func (c *ClientWithResponses) PostOrganizationLoadBalancersWithBodyWithResponse(
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36672,12 +36030,8 @@ func (c *ClientWithResponses) PostOrganizationLoadBalancersWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36695,12 +36049,8 @@ func (c *ClientWithResponses) GetOrganizationManagedWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36718,12 +36068,8 @@ func (c *ClientWithResponses) PostOrganizationManagedWithBodyWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36740,12 +36086,8 @@ func (c *ClientWithResponses) PostOrganizationManagedWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36763,12 +36105,8 @@ func (c *ClientWithResponses) GetOrganizationNetworkSpeedProfilesWithResponse(ct
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36786,12 +36124,8 @@ func (c *ClientWithResponses) GetOrganizationObjectStorageAccessKeysWithResponse
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36809,12 +36143,8 @@ func (c *ClientWithResponses) DeleteOrganizationObjectStorageObjectStorageCluste
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36831,12 +36161,8 @@ func (c *ClientWithResponses) DeleteOrganizationObjectStorageObjectStorageCluste
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36854,12 +36180,8 @@ func (c *ClientWithResponses) GetOrganizationObjectStorageObjectStorageClusterWi
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36877,12 +36199,8 @@ func (c *ClientWithResponses) PostOrganizationObjectStorageObjectStorageClusterW
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36899,12 +36217,8 @@ func (c *ClientWithResponses) PostOrganizationObjectStorageObjectStorageClusterW
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36922,12 +36236,8 @@ func (c *ClientWithResponses) PostOrganizationObjectStorageObjectStorageClusterA
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36944,12 +36254,8 @@ func (c *ClientWithResponses) PostOrganizationObjectStorageObjectStorageClusterA
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36967,12 +36273,8 @@ func (c *ClientWithResponses) PostOrganizationObjectStorageObjectStorageClusterB
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -36989,12 +36291,8 @@ func (c *ClientWithResponses) PostOrganizationObjectStorageObjectStorageClusterB
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37012,12 +36310,8 @@ func (c *ClientWithResponses) GetOrganizationPolicyWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37035,12 +36329,8 @@ func (c *ClientWithResponses) GetOrganizationPolicyLimitsWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37058,12 +36348,8 @@ func (c *ClientWithResponses) GetOrganizationPricesWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37081,12 +36367,8 @@ func (c *ClientWithResponses) GetOrganizationSecurityGroupsWithResponse(ctx cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37104,12 +36386,8 @@ func (c *ClientWithResponses) PostOrganizationSecurityGroupsWithBodyWithResponse
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37126,12 +36404,8 @@ func (c *ClientWithResponses) PostOrganizationSecurityGroupsWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37149,12 +36423,8 @@ func (c *ClientWithResponses) GetOrganizationSshKeysWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37172,12 +36442,8 @@ func (c *ClientWithResponses) PostOrganizationSshKeysWithBodyWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37194,12 +36460,8 @@ func (c *ClientWithResponses) PostOrganizationSshKeysWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37217,12 +36479,8 @@ func (c *ClientWithResponses) GetOrganizationTagsWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37240,12 +36498,8 @@ func (c *ClientWithResponses) PostOrganizationTagsWithBodyWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37262,12 +36516,8 @@ func (c *ClientWithResponses) PostOrganizationTagsWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37285,12 +36535,8 @@ func (c *ClientWithResponses) GetOrganizationTrashObjectsWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37308,12 +36554,8 @@ func (c *ClientWithResponses) PostOrganizationTrashObjectsPurgeAllWithBodyWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37330,12 +36572,8 @@ func (c *ClientWithResponses) PostOrganizationTrashObjectsPurgeAllWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37353,12 +36591,8 @@ func (c *ClientWithResponses) GetOrganizationUsersWithAccessWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37376,12 +36610,8 @@ func (c *ClientWithResponses) GetOrganizationVirtualMachineGroupsWithResponse(ct
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37399,12 +36629,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualMachineGroupsWithBodyWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37421,12 +36647,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualMachineGroupsWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37444,12 +36666,8 @@ func (c *ClientWithResponses) GetOrganizationVirtualMachinesWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37467,12 +36685,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualMachinesBuildWithBodyWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37489,12 +36703,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualMachinesBuildWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37512,12 +36722,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualMachinesBuildFromSpecWithBo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37534,12 +36740,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualMachinesBuildFromSpecWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37557,12 +36759,8 @@ func (c *ClientWithResponses) GetOrganizationVirtualNetworksWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37580,12 +36778,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualNetworksWithBodyWithRespons
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37602,12 +36796,8 @@ func (c *ClientWithResponses) PostOrganizationVirtualNetworksWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37625,12 +36815,8 @@ func (c *ClientWithResponses) DeleteSecurityGroupsRulesSecurityGroupRuleWithBody
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37647,12 +36833,8 @@ func (c *ClientWithResponses) DeleteSecurityGroupsRulesSecurityGroupRuleWithResp
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37670,12 +36852,8 @@ func (c *ClientWithResponses) GetSecurityGroupsRulesSecurityGroupRuleWithRespons
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37693,12 +36871,8 @@ func (c *ClientWithResponses) PatchSecurityGroupsRulesSecurityGroupRuleWithBodyW
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37715,12 +36889,8 @@ func (c *ClientWithResponses) PatchSecurityGroupsRulesSecurityGroupRuleWithRespo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37738,12 +36908,8 @@ func (c *ClientWithResponses) DeleteSecurityGroupWithBodyWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37760,12 +36926,8 @@ func (c *ClientWithResponses) DeleteSecurityGroupWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37783,12 +36945,8 @@ func (c *ClientWithResponses) GetSecurityGroupWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37806,12 +36964,8 @@ func (c *ClientWithResponses) PatchSecurityGroupWithBodyWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37828,12 +36982,8 @@ func (c *ClientWithResponses) PatchSecurityGroupWithResponse(ctx context.Context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37851,12 +37001,8 @@ func (c *ClientWithResponses) GetSecurityGroupRulesWithResponse(ctx context.Cont
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37874,12 +37020,8 @@ func (c *ClientWithResponses) PostSecurityGroupRulesWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37896,12 +37038,8 @@ func (c *ClientWithResponses) PostSecurityGroupRulesWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37919,12 +37057,8 @@ func (c *ClientWithResponses) DeleteSshKeyWithBodyWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37941,12 +37075,8 @@ func (c *ClientWithResponses) DeleteSshKeyWithResponse(ctx context.Context, body
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37964,12 +37094,8 @@ func (c *ClientWithResponses) DeleteTagWithBodyWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -37986,12 +37112,8 @@ func (c *ClientWithResponses) DeleteTagWithResponse(ctx context.Context, body De
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38009,12 +37131,8 @@ func (c *ClientWithResponses) GetTagWithResponse(ctx context.Context, params *Ge
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38032,12 +37150,8 @@ func (c *ClientWithResponses) PatchTagWithBodyWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38054,12 +37168,8 @@ func (c *ClientWithResponses) PatchTagWithResponse(ctx context.Context, body Pat
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38077,12 +37187,8 @@ func (c *ClientWithResponses) GetTaskWithResponse(ctx context.Context, params *G
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38100,12 +37206,8 @@ func (c *ClientWithResponses) DeleteTrashObjectWithBodyWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38122,12 +37224,8 @@ func (c *ClientWithResponses) DeleteTrashObjectWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38145,12 +37243,8 @@ func (c *ClientWithResponses) GetTrashObjectWithResponse(ctx context.Context, pa
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38168,12 +37262,8 @@ func (c *ClientWithResponses) PostTrashObjectRestoreWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38190,12 +37280,8 @@ func (c *ClientWithResponses) PostTrashObjectRestoreWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38213,12 +37299,8 @@ func (c *ClientWithResponses) GetUsersCurrentWithResponse(ctx context.Context, r
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38236,12 +37318,8 @@ func (c *ClientWithResponses) GetVirtualMachineAuthorizedKeysWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38259,12 +37337,8 @@ func (c *ClientWithResponses) DeleteVirtualMachineGroupWithBodyWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38281,12 +37355,8 @@ func (c *ClientWithResponses) DeleteVirtualMachineGroupWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38304,12 +37374,8 @@ func (c *ClientWithResponses) GetVirtualMachineGroupWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38327,12 +37393,8 @@ func (c *ClientWithResponses) PatchVirtualMachineGroupWithBodyWithResponse(ctx c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38349,12 +37411,8 @@ func (c *ClientWithResponses) PatchVirtualMachineGroupWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38372,12 +37430,8 @@ func (c *ClientWithResponses) DeleteVirtualMachineNetworkInterfaceWithBodyWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38394,12 +37448,8 @@ func (c *ClientWithResponses) DeleteVirtualMachineNetworkInterfaceWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38417,12 +37467,8 @@ func (c *ClientWithResponses) GetVMNIVMNIWithResponse(ctx context.Context, param
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38440,12 +37486,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceAllocateIpWithBo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38462,12 +37504,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceAllocateIpWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38485,12 +37523,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceAllocateNewIpWit
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38507,12 +37541,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceAllocateNewIpWit
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38530,12 +37560,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceAttachWithBodyWi
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38552,12 +37578,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceAttachWithRespon
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38575,12 +37597,8 @@ func (c *ClientWithResponses) GetVirtualMachineNetworkInterfaceAvailableIpsAddre
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38598,12 +37616,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceDetachWithBodyWi
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38620,12 +37634,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfaceDetachWithRespon
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38643,12 +37653,8 @@ func (c *ClientWithResponses) PatchVirtualMachineNetworkInterfaceUpdateSpeedProf
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38665,12 +37671,8 @@ func (c *ClientWithResponses) PatchVirtualMachineNetworkInterfaceUpdateSpeedProf
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38688,12 +37690,8 @@ func (c *ClientWithResponses) GetVirtualMachinePackagesWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38711,12 +37709,8 @@ func (c *ClientWithResponses) GetVirtualMachinePackageWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38734,12 +37728,8 @@ func (c *ClientWithResponses) GetVirtualMachinesBuildsVirtualMachineBuildWithRes
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38757,12 +37747,8 @@ func (c *ClientWithResponses) DeleteVirtualMachineWithBodyWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38779,12 +37765,8 @@ func (c *ClientWithResponses) DeleteVirtualMachineWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38802,12 +37784,8 @@ func (c *ClientWithResponses) GetVirtualMachineWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38825,12 +37803,8 @@ func (c *ClientWithResponses) PatchVirtualMachineWithBodyWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38847,12 +37821,8 @@ func (c *ClientWithResponses) PatchVirtualMachineWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38870,12 +37840,8 @@ func (c *ClientWithResponses) PostVirtualMachineAllocateIpWithBodyWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38892,12 +37858,8 @@ func (c *ClientWithResponses) PostVirtualMachineAllocateIpWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38915,12 +37877,8 @@ func (c *ClientWithResponses) PostVirtualMachineConsoleSessionsWithBodyWithRespo
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38937,12 +37895,8 @@ func (c *ClientWithResponses) PostVirtualMachineConsoleSessionsWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38960,12 +37914,8 @@ func (c *ClientWithResponses) GetVirtualMachineDiskBackupPoliciesWithResponse(ct
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -38983,12 +37933,8 @@ func (c *ClientWithResponses) PostVirtualMachineDiskBackupPoliciesWithBodyWithRe
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39005,12 +37951,8 @@ func (c *ClientWithResponses) PostVirtualMachineDiskBackupPoliciesWithResponse(c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39028,12 +37970,8 @@ func (c *ClientWithResponses) GetVirtualMachineDisksWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39051,12 +37989,8 @@ func (c *ClientWithResponses) PutVirtualMachineFlexibleResourcesWithBodyWithResp
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39073,12 +38007,8 @@ func (c *ClientWithResponses) PutVirtualMachineFlexibleResourcesWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39096,12 +38026,8 @@ func (c *ClientWithResponses) GetVirtualMachineNetworkInterfacesWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39119,12 +38045,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfacesWithBodyWithRes
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39141,12 +38063,8 @@ func (c *ClientWithResponses) PostVirtualMachineNetworkInterfacesWithResponse(ct
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39164,12 +38082,8 @@ func (c *ClientWithResponses) GetVirtualMachineNetworkInterfaceWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39187,12 +38101,8 @@ func (c *ClientWithResponses) PutVirtualMachinePackageWithBodyWithResponse(ctx c
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39209,12 +38119,8 @@ func (c *ClientWithResponses) PutVirtualMachinePackageWithResponse(ctx context.C
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39232,12 +38138,8 @@ func (c *ClientWithResponses) PostVirtualMachineResetWithBodyWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39254,12 +38156,8 @@ func (c *ClientWithResponses) PostVirtualMachineResetWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39277,12 +38175,8 @@ func (c *ClientWithResponses) PostVirtualMachineShutdownWithBodyWithResponse(ctx
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39299,12 +38193,8 @@ func (c *ClientWithResponses) PostVirtualMachineShutdownWithResponse(ctx context
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39322,12 +38212,8 @@ func (c *ClientWithResponses) PostVirtualMachineStartWithBodyWithResponse(ctx co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39344,12 +38230,8 @@ func (c *ClientWithResponses) PostVirtualMachineStartWithResponse(ctx context.Co
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39367,12 +38249,8 @@ func (c *ClientWithResponses) PostVirtualMachineStopWithBodyWithResponse(ctx con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39389,12 +38267,8 @@ func (c *ClientWithResponses) PostVirtualMachineStopWithResponse(ctx context.Con
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39412,12 +38286,8 @@ func (c *ClientWithResponses) DeleteVirtualNetworkWithBodyWithResponse(ctx conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39434,12 +38304,8 @@ func (c *ClientWithResponses) DeleteVirtualNetworkWithResponse(ctx context.Conte
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39457,12 +38323,8 @@ func (c *ClientWithResponses) GetVirtualNetworkWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39480,12 +38342,8 @@ func (c *ClientWithResponses) PatchVirtualNetworkWithBodyWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39502,12 +38360,8 @@ func (c *ClientWithResponses) PatchVirtualNetworkWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39525,12 +38379,8 @@ func (c *ClientWithResponses) GetZonesWithResponse(ctx context.Context, reqEdito
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -39548,12 +38398,8 @@ func (c *ClientWithResponses) GetZoneWithResponse(ctx context.Context, params *G
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
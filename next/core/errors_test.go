@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/krystal/go-katapult"
+	katapultcore "github.com/krystal/go-katapult/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithResponses_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		errStr      string
+		errIs       []error
+		errAs       interface{}
+	}{
+		{
+			name:        "core error",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body: `{"error":{"code":"virtual_machine_not_found",` +
+				`"description":"No virtual machine was found matching ` +
+				`any of the criteria provided in the arguments",` +
+				`"detail":{}}}`,
+			errStr: "katapult: not_found: virtual_machine_not_found: " +
+				"No virtual machine was found matching any of the " +
+				"criteria provided in the arguments",
+			errIs: []error{
+				katapultcore.ErrVirtualMachineNotFound,
+				katapult.ErrResourceNotFound,
+				ErrNotFound,
+				ErrRequestFailed,
+			},
+			errAs: new(*katapultcore.VirtualMachineNotFoundError),
+		},
+		{
+			name:        "core error with detail",
+			status:      http.StatusTooManyRequests,
+			contentType: "application/json",
+			body: `{"error":{"code":"rate_limit_reached",` +
+				`"description":"You have reached the rate limit",` +
+				`"detail":{"total_permitted":60}}}`,
+			errStr: "katapult: too_many_requests: rate_limit_reached: " +
+				"max requests per minute: 60",
+			errIs: []error{
+				katapultcore.ErrRateLimitReached,
+				katapult.ErrTooManyRequests,
+				ErrRequestFailed,
+			},
+			errAs: new(*katapultcore.RateLimitReachedError),
+		},
+		{
+			name:        "katapult error",
+			status:      http.StatusForbidden,
+			contentType: "application/json",
+			body: `{"error":{"code":"scope_not_granted",` +
+				`"description":"The scope is not granted",` +
+				`"detail":{"scopes":["vm:read"]}}}`,
+			errStr: "katapult: unauthorized: scope_not_granted: " +
+				"required scopes: vm:read",
+			errIs: []error{
+				katapult.ErrScopeNotGranted,
+				katapult.ErrForbidden,
+				ErrRequestFailed,
+			},
+			errAs: new(*katapult.ScopeNotGrantedError),
+		},
+		{
+			name:        "unknown error code",
+			status:      http.StatusConflict,
+			contentType: "application/json",
+			body:        `{"error":{"code":"new_code","description":"No"}}`,
+			errStr:      "new_code: No",
+			errIs:       []error{katapult.ErrConflict, ErrRequestFailed},
+			errAs:       new(*katapult.ResponseError),
+		},
+		{
+			name:        "unexpected body",
+			status:      http.StatusNotFound,
+			contentType: "text/html",
+			body:        `<html>Not Found</html>`,
+			errStr:      "katapult: not_found: katapult: unexpected_response",
+			errIs: []error{
+				katapult.ErrUnexpectedResponse,
+				ErrNotFound,
+				ErrRequestFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", tt.contentType)
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+				},
+			))
+			defer srv.Close()
+
+			c, err := NewClientWithResponses(srv.URL, "secret")
+			require.NoError(t, err)
+
+			id := "vm_abc123"
+			res, err := c.GetVirtualMachineWithResponse(
+				context.Background(),
+				&GetVirtualMachineParams{VirtualMachineId: &id},
+			)
+
+			require.NotNil(t, res)
+			assert.Equal(t, tt.status, res.StatusCode())
+			assert.EqualError(t, err, tt.errStr)
+			for _, target := range tt.errIs {
+				assert.ErrorIs(t, err, target)
+			}
+			if tt.errAs != nil {
+				assert.ErrorAs(t, err, tt.errAs)
+			}
+		})
+	}
+}
+
+func TestClientWithResponses_errorHelpers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "17")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(
+				`{"error":{"code":"rate_limit_reached",` +
+					`"description":"You have reached the rate limit",` +
+					`"detail":{"total_permitted":60}}}`,
+			))
+		},
+	))
+	defer srv.Close()
+
+	c, err := NewClientWithResponses(srv.URL, "secret")
+	require.NoError(t, err)
+
+	_, err = c.GetDataCentersWithResponse(context.Background())
+	require.Error(t, err)
+
+	retryAfter, ok := katapult.RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 17*time.Second, retryAfter)
+	assert.True(t, katapult.IsRetryable(err))
+	assert.False(t, katapult.IsNotFound(err))
+	assert.Equal(t, "rate_limit_reached", katapult.ErrorCode(err))
+}
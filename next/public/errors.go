@@ -0,0 +1,27 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/internal/apierror"
+)
+
+// This file is not generated, and provides the error handling used by the
+// generated ...WithResponse functions.
+
+var (
+	// ErrRequestFailed is the parent of all errors returned for responses with
+	// a non-2xx status code.
+	ErrRequestFailed = katapult.ErrResponse
+
+	// ErrNotFound is the parent of all errors returned for responses with a 404
+	// status code.
+	ErrNotFound = katapult.ErrNotFound
+)
+
+// handleResponseError returns an error for a failed response, decoded by
+// apierror.Decode.
+func handleResponseError(rsp *http.Response, body []byte) error {
+	return apierror.Decode(rsp, body)
+}
@@ -0,0 +1,74 @@
+package public
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientWithResponses_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		errStr      string
+		errIs       []error
+	}{
+		{
+			name:        "core error",
+			status:      http.StatusTooManyRequests,
+			contentType: "application/json",
+			body: `{"error":{"code":"rate_limit_reached",` +
+				`"description":"You have reached the rate limit",` +
+				`"detail":{"total_permitted":60}}}`,
+			errStr: "katapult: too_many_requests: rate_limit_reached: " +
+				"max requests per minute: 60",
+			errIs: []error{
+				katapult.ErrTooManyRequests,
+				ErrRequestFailed,
+			},
+		},
+		{
+			name:        "unexpected body",
+			status:      http.StatusBadGateway,
+			contentType: "text/plain",
+			body:        `Bad Gateway`,
+			errStr:      "katapult: bad_gateway: katapult: unexpected_response",
+			errIs: []error{
+				katapult.ErrBadGateway,
+				katapult.ErrUnexpectedResponse,
+				ErrRequestFailed,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.Header().Set("Content-Type", tt.contentType)
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+				},
+			))
+			defer srv.Close()
+
+			c, err := NewClientWithResponses(srv.URL, "")
+			require.NoError(t, err)
+
+			res, err := c.GetDataCentersWithResponse(context.Background())
+
+			require.NotNil(t, res)
+			assert.Equal(t, tt.status, res.StatusCode())
+			assert.EqualError(t, err, tt.errStr)
+			for _, target := range tt.errIs {
+				assert.ErrorIs(t, err, target)
+			}
+		})
+	}
+}
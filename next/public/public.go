@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -560,11 +559,6 @@ const (
 	clientVersion = "0.2.0" // x-release-please-version
 )
 
-var (
-	ErrRequestFailed = errors.New("request was not successful")
-	ErrNotFound      = errors.New("resource not found")
-)
-
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -1260,12 +1254,8 @@ func (c *ClientWithResponses) GetDataCentersWithResponse(ctx context.Context, re
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1283,12 +1273,8 @@ func (c *ClientWithResponses) PostPricingEstimateWithBodyWithResponse(ctx contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1305,12 +1291,8 @@ func (c *ClientWithResponses) PostPricingEstimateWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1328,12 +1310,8 @@ func (c *ClientWithResponses) GetPricingPricesWithResponse(ctx context.Context,
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1351,12 +1329,8 @@ func (c *ClientWithResponses) PostSignupsWithBodyWithResponse(ctx context.Contex
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1373,12 +1347,8 @@ func (c *ClientWithResponses) PostSignupsWithResponse(ctx context.Context, body
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1396,12 +1366,8 @@ func (c *ClientWithResponses) GetStatsWithResponse(ctx context.Context, reqEdito
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -1419,12 +1385,8 @@ func (c *ClientWithResponses) GetVirtualMachinePackagesWithResponse(ctx context.
 		return nil, err
 	}
 
-	if rsp.StatusCode == http.StatusNotFound {
-		return res, ErrNotFound
-	}
-
 	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
-		return res, ErrRequestFailed
+		return res, handleResponseError(rsp, res.Body)
 	}
 
 	return res, nil
@@ -0,0 +1,452 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+// This file is not generated, and estimates the cost of build specs using the
+// generated pricing functions.
+
+var (
+	// ErrUnknownPricingResource is returned by SpecEstimator.Estimate when a
+	// spec needs a pricing resource which is not set in its Resources, or
+	// when a resource set there is not listed by GetPricingPrices.
+	ErrUnknownPricingResource = fmt.Errorf(
+		"%w: unknown_pricing_resource", katapult.Err,
+	)
+
+	// ErrSpecNotPriced is returned by SpecEstimator.Estimate when no part of
+	// a spec could be priced.
+	ErrSpecNotPriced = fmt.Errorf("%w: spec_not_priced", katapult.Err)
+)
+
+// SpecPricingResources are the names of the pricing resources, as listed by
+// GetPricingPrices, which the parts of a build spec are priced as.
+//
+// The public API schema does not define these names, so they have to be
+// set for each part of the specs being estimated. Names which are left empty
+// cause specs using them to fail to be estimated.
+type SpecPricingResources struct {
+	// Package is priced once per virtual machine, using the variant whose
+	// ID is the ID or permalink of the spec's package.
+	Package string
+
+	// CPUCore and Memory are priced per CPU core and per GB of memory for
+	// virtual machines with flexible resources.
+	CPUCore string
+	Memory  string
+
+	// Storage is priced per GB of system disk, using the variant whose ID
+	// is the ID or permalink of the disk's IO profile, or the default variant
+	// for disks without one.
+	Storage string
+
+	// IPv4Address and IPv6Address are priced per newly allocated address.
+	IPv4Address string
+	IPv6Address string
+}
+
+// names returns the resource names which are set.
+func (r SpecPricingResources) names() []string {
+	var names []string
+	for _, name := range []string{
+		r.Package, r.CPUCore, r.Memory, r.Storage, r.IPv4Address, r.IPv6Address,
+	} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// SpecEstimator estimates the cost of the virtual machines described by
+// build specs, using the pricing endpoints of the public API.
+type SpecEstimator struct {
+	Client ClientWithResponsesInterface
+
+	// Currency, Country, CustomerName and CustomerType are sent with each
+	// estimate, and determine the currency and tax rate of its prices.
+	Currency     CurrencyLookup
+	Country      CountryLookup
+	CustomerName string
+	CustomerType CustomerTypeEnum
+
+	// Resources holds the names of the pricing resources specs are priced
+	// as.
+	Resources SpecPricingResources
+}
+
+// SpecCustomer describes the customer a SpecEstimator prices specs for.
+type SpecCustomer struct {
+	// Name is the billing name of the customer.
+	Name string
+
+	// Type is the type of customer, which defaults to Business.
+	Type CustomerTypeEnum
+
+	// Currency is the ISO 4217 code of the currency to price specs in.
+	Currency string
+
+	// Country is the ISO 3166 alpha-2 or alpha-3 code of the country the
+	// customer is taxed in.
+	Country string
+}
+
+// NewSpecEstimator returns a SpecEstimator which prices specs as the given
+// pricing resources, in the currency and country of customer.
+func NewSpecEstimator(
+	client ClientWithResponsesInterface,
+	customer SpecCustomer,
+	resources SpecPricingResources,
+) *SpecEstimator {
+	e := &SpecEstimator{
+		Client:       client,
+		CustomerName: customer.Name,
+		CustomerType: customer.Type,
+		Resources:    resources,
+	}
+	if e.CustomerType == "" {
+		e.CustomerType = Business
+	}
+
+	if customer.Currency != "" {
+		e.Currency.IsoCode = &customer.Currency
+	}
+	switch len(customer.Country) {
+	case 0:
+	case 3:
+		e.Country.IsoCode3 = &customer.Country
+	default:
+		e.Country.IsoCode2 = &customer.Country
+	}
+
+	return e
+}
+
+// SpecEstimate is the estimated cost of a build spec. Prices are in the
+// estimate's currency, and are per hour or per month of running the virtual
+// machine.
+type SpecEstimate struct {
+	Currency       Currency
+	PerHour        float64
+	PerHourIncTax  float64
+	PerMonth       float64
+	PerMonthIncTax float64
+	TaxRate        float64
+
+	// Items is the breakdown of the estimate by pricing resource.
+	Items []*SpecEstimateItem
+
+	// Unpriced lists the paths of spec fields which are not included in the
+	// estimate, either because they have no matching price, or because
+	// their size is not known until the virtual machine is built, such as
+	// "system_disks[1]" for a disk without a size.
+	Unpriced []string
+}
+
+// SpecEstimateItem is the cost of a single pricing resource in a
+// SpecEstimate.
+type SpecEstimateItem struct {
+	Resource       string
+	Description    string
+	Quantity       int
+	PerHourEach    float64
+	PerMonthEach   float64
+	PerHour        float64
+	PerHourIncTax  float64
+	PerMonth       float64
+	PerMonthIncTax float64
+}
+
+// Estimate returns the estimated cost of the virtual machine described by
+// spec.
+//
+// A package, or the spec's flexible CPU cores and memory, the total size of
+// its system disks grouped by IO profile, and the number of IPv4 and IPv6
+// addresses newly allocated to its network interfaces are priced. Existing
+// IP addresses are already being paid for, and are not included. Resources
+// with a variant are requested as "<resource>:<variant>".
+//
+// An error wrapping ErrUnknownPricingResource is returned when a resource
+// name set in e.Resources is not listed by GetPricingPrices, or when spec
+// needs one which is not set. When spec has parts to price but none of them
+// could be priced, an error wrapping ErrSpecNotPriced is returned. A spec
+// with nothing to price returns an empty estimate without requesting one
+// from the API.
+func (e *SpecEstimator) Estimate(
+	ctx context.Context,
+	spec *buildspec.VirtualMachineSpec,
+	reqEditors ...RequestEditorFn,
+) (*SpecEstimate, error) {
+	pricesRes, err := e.Client.GetPricingPricesWithResponse(
+		ctx, reqEditors...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if pricesRes.JSON200 == nil {
+		return nil, fmt.Errorf("%w: no prices", katapult.ErrUnexpectedResponse)
+	}
+
+	catalog := newPriceCatalog(pricesRes.JSON200.Prices)
+	for _, name := range e.Resources.names() {
+		if _, ok := catalog[name]; !ok {
+			return nil, fmt.Errorf(
+				"%w: %q is not listed by GetPricingPrices",
+				ErrUnknownPricingResource, name,
+			)
+		}
+	}
+
+	est := &specEstimation{names: e.Resources, catalog: catalog}
+	est.add(spec)
+	if est.err != nil {
+		return nil, est.err
+	}
+
+	estimate := &SpecEstimate{Unpriced: est.unpriced}
+	if len(est.resources) == 0 {
+		if len(est.unpriced) > 0 {
+			return nil, fmt.Errorf("%w: %s",
+				ErrSpecNotPriced, strings.Join(est.unpriced, ", "),
+			)
+		}
+
+		return estimate, nil
+	}
+
+	res, err := e.Client.PostPricingEstimateWithResponse(ctx,
+		PostPricingEstimateJSONRequestBody{
+			Currency:     e.Currency,
+			Country:      e.Country,
+			CustomerName: e.CustomerName,
+			CustomerType: e.CustomerType,
+			Resources:    est.arguments(),
+		},
+		reqEditors...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if res.JSON200 == nil {
+		return nil, fmt.Errorf(
+			"%w: no estimate", katapult.ErrUnexpectedResponse,
+		)
+	}
+
+	pe := res.JSON200.Estimate
+	if pe.Currency != nil {
+		estimate.Currency = *pe.Currency
+	}
+	estimate.PerHour = price(pe.PerHour)
+	estimate.PerHourIncTax = price(pe.PerHourIncTax)
+	estimate.PerMonth = price(pe.PerMonth)
+	estimate.PerMonthIncTax = price(pe.PerMonthIncTax)
+	estimate.TaxRate = price(pe.TaxRate)
+	if pe.Resources != nil {
+		for _, r := range *pe.Resources {
+			item := &SpecEstimateItem{
+				PerHourEach:    price(r.PerHourEach),
+				PerMonthEach:   price(r.PerMonthEach),
+				PerHour:        price(r.PerHour),
+				PerHourIncTax:  price(r.PerHourIncTax),
+				PerMonth:       price(r.PerMonth),
+				PerMonthIncTax: price(r.PerMonthIncTax),
+			}
+			if r.Resource != nil {
+				item.Resource = *r.Resource
+			}
+			if r.Description != nil {
+				item.Description = *r.Description
+			}
+			if r.Quantity != nil {
+				item.Quantity = *r.Quantity
+			}
+			estimate.Items = append(estimate.Items, item)
+		}
+	}
+
+	return estimate, nil
+}
+
+// price returns f as a float64 with the shortest decimal representation of
+// the float32, so 0.1 is not returned as 0.10000000149011612.
+func price(f *float32) float64 {
+	if f == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(
+		strconv.FormatFloat(float64(*f), 'g', -1, 32), 64,
+	)
+
+	return v
+}
+
+// priceCatalog holds the variants of each pricing resource.
+type priceCatalog map[string][]PriceVariant
+
+func newPriceCatalog(
+	prices []GetPricingPrices200ResponsePrices,
+) priceCatalog {
+	c := priceCatalog{}
+	for _, p := range prices {
+		if p.Resource == nil {
+			continue
+		}
+		var variants []PriceVariant
+		if p.Variants != nil {
+			variants = *p.Variants
+		}
+		c[*p.Resource] = append(c[*p.Resource], variants...)
+	}
+
+	return c
+}
+
+// lookup returns the name resource is requested as with the first of the
+// given variants it has. With no variants, the resource's default variant is
+// used. It returns false if the resource or none of the variants are listed.
+func (c priceCatalog) lookup(
+	resource string,
+	variants ...string,
+) (string, bool) {
+	vs, ok := c[resource]
+	if !ok {
+		return "", false
+	}
+	if len(variants) == 0 {
+		return resource, true
+	}
+
+	for _, want := range variants {
+		if want == "" {
+			continue
+		}
+		for _, v := range vs {
+			if id, err := v.Id.Get(); err == nil && id == want {
+				if v.Default != nil && *v.Default {
+					return resource, true
+				}
+
+				return resource + ":" + want, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// specEstimation maps the parts of a build spec to pricing resources.
+type specEstimation struct {
+	names     SpecPricingResources
+	catalog   priceCatalog
+	resources []string
+	quantity  map[string]int
+	unpriced  []string
+	err       error
+}
+
+// charge adds quantity of the named resource for the spec field, or marks
+// the field as unpriced when none of the variants has a price. A resource
+// which is not set is recorded as an error.
+func (s *specEstimation) charge(
+	field string,
+	quantity int,
+	resource string,
+	variants ...string,
+) {
+	if resource == "" {
+		if s.err == nil {
+			s.err = fmt.Errorf("%w: no pricing resource is set for %s",
+				ErrUnknownPricingResource, field,
+			)
+		}
+
+		return
+	}
+
+	name, ok := s.catalog.lookup(resource, variants...)
+	if !ok {
+		s.unpriced = append(s.unpriced, field)
+
+		return
+	}
+
+	if s.quantity == nil {
+		s.quantity = map[string]int{}
+	}
+	if _, ok := s.quantity[name]; !ok {
+		s.resources = append(s.resources, name)
+	}
+	s.quantity[name] += quantity
+}
+
+func (s *specEstimation) add(spec *buildspec.VirtualMachineSpec) {
+	if r := spec.Resources; r != nil {
+		if p := r.Package; p != nil {
+			s.charge("resources.package", 1,
+				s.names.Package, p.ID, p.Permalink,
+			)
+		}
+		if r.CPUCores > 0 {
+			s.charge("resources.cpu_cores", r.CPUCores, s.names.CPUCore)
+		}
+		if r.Memory > 0 {
+			s.charge("resources.memory", r.Memory, s.names.Memory)
+		}
+	}
+
+	for i, d := range spec.SystemDisks {
+		field := fmt.Sprintf("system_disks[%d]", i)
+		switch {
+		case d.Size <= 0:
+			s.unpriced = append(s.unpriced, field)
+		case d.IOProfile != nil:
+			s.charge(field, d.Size, s.names.Storage,
+				d.IOProfile.ID, d.IOProfile.Permalink,
+			)
+		default:
+			s.charge(field, d.Size, s.names.Storage)
+		}
+	}
+
+	for i, nic := range spec.NetworkInterfaces {
+		for j, a := range nic.IPAddressAllocations {
+			if a.Type == buildspec.ExistingIPAddressAllocation {
+				continue
+			}
+
+			field := fmt.Sprintf(
+				"network_interfaces[%d].ip_address_allocations[%d]", i, j,
+			)
+			switch a.Version {
+			case buildspec.IPv4:
+				s.charge(field, 1, s.names.IPv4Address)
+			case buildspec.IPv6:
+				s.charge(field, 1, s.names.IPv6Address)
+			default:
+				s.unpriced = append(s.unpriced, field)
+			}
+		}
+	}
+}
+
+func (s *specEstimation) arguments() []PricingEstimateResourceArguments {
+	args := make([]PricingEstimateResourceArguments, 0, len(s.resources))
+	for _, name := range s.resources {
+		resource, quantity := name, s.quantity[name]
+		args = append(args, PricingEstimateResourceArguments{
+			Resource: &resource,
+			Quantity: &quantity,
+		})
+	}
+
+	return args
+}
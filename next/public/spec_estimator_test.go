@@ -0,0 +1,428 @@
+package public
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/krystal/go-katapult"
+	"github.com/krystal/go-katapult/buildspec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pricingServer is a stand-in for the pricing endpoints of the public API,
+// pricing each resource in GBP using its monthly price and a 20% tax rate.
+type pricingServer struct {
+	*httptest.Server
+
+	monthly   map[string]float64
+	estimates []PostPricingEstimateJSONRequestBody
+}
+
+func newPricingServer(t *testing.T) *pricingServer {
+	t.Helper()
+
+	s := &pricingServer{
+		monthly: map[string]float64{
+			"virtual_machine_package:rock-3": 20,
+			"virtual_machine_cpu_core":       5,
+			"virtual_machine_memory":         2.5,
+			"disk_storage":                   0.1,
+			"disk_storage:nvme":              0.25,
+			"ipv4_address":                   3,
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *pricingServer) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.URL.Path {
+	case "/pricing/prices":
+		_, _ = w.Write([]byte(`{"prices":[
+			{"resource":"virtual_machine_package","variants":[
+				{"id":"rock-3"},{"id":"rock-6"}]},
+			{"resource":"virtual_machine_cpu_core","variants":[
+				{"id":null,"default":true}]},
+			{"resource":"virtual_machine_memory"},
+			{"resource":"disk_storage","variants":[
+				{"id":"ssd","default":true},{"id":"nvme"}]},
+			{"resource":"ipv4_address"}
+		]}`))
+	case "/pricing/estimate":
+		var body PostPricingEstimateJSONRequestBody
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.estimates = append(s.estimates, body)
+
+		code, symbol := "GBP", "£"
+		estimate := PricingEstimate{
+			Currency:  &Currency{IsoCode: &code, Symbol: &symbol},
+			TaxRate:   f32(0.2),
+			Resources: &[]PricingEstimateResource{},
+		}
+		var total float32
+		for _, arg := range body.Resources {
+			each := float32(s.monthly[*arg.Resource])
+			perMonth := each * float32(*arg.Quantity)
+			total += perMonth
+			*estimate.Resources = append(*estimate.Resources,
+				PricingEstimateResource{
+					Resource:       arg.Resource,
+					Description:    arg.Resource,
+					Quantity:       arg.Quantity,
+					PerMonthEach:   f32(each),
+					PerMonth:       f32(perMonth),
+					PerMonthIncTax: f32(perMonth * 1.2),
+				},
+			)
+		}
+		estimate.PerMonth = f32(total)
+		estimate.PerMonthIncTax = f32(total * 1.2)
+
+		_ = json.NewEncoder(w).Encode(
+			map[string]any{"estimate": estimate},
+		)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":"route_not_found",` +
+			`"description":"No route matches"}}`))
+	}
+}
+
+// testPricingResources are the names of the resources priced by
+// pricingServer.
+var testPricingResources = SpecPricingResources{
+	Package:     "virtual_machine_package",
+	CPUCore:     "virtual_machine_cpu_core",
+	Memory:      "virtual_machine_memory",
+	Storage:     "disk_storage",
+	IPv4Address: "ipv4_address",
+}
+
+func f32(f float32) *float32 {
+	return &f
+}
+
+func TestNewSpecEstimator(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer SpecCustomer
+		want     *SpecEstimator
+	}{
+		{
+			name: "business",
+			customer: SpecCustomer{
+				Name:     "Acme Inc",
+				Currency: "GBP",
+				Country:  "GB",
+			},
+			want: &SpecEstimator{
+				Currency:     CurrencyLookup{IsoCode: strPtr("GBP")},
+				Country:      CountryLookup{IsoCode2: strPtr("GB")},
+				CustomerName: "Acme Inc",
+				CustomerType: Business,
+				Resources:    testPricingResources,
+			},
+		},
+		{
+			name: "consumer",
+			customer: SpecCustomer{
+				Name:     "Jane",
+				Type:     Consumer,
+				Currency: "EUR",
+				Country:  "IRL",
+			},
+			want: &SpecEstimator{
+				Currency:     CurrencyLookup{IsoCode: strPtr("EUR")},
+				Country:      CountryLookup{IsoCode3: strPtr("IRL")},
+				CustomerName: "Jane",
+				CustomerType: Consumer,
+				Resources:    testPricingResources,
+			},
+		},
+		{
+			name:     "no currency or country",
+			customer: SpecCustomer{Name: "Acme"},
+			want: &SpecEstimator{
+				CustomerName: "Acme",
+				CustomerType: Business,
+				Resources:    testPricingResources,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSpecEstimator(nil, tt.customer, testPricingResources)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpecEstimator_Estimate(t *testing.T) {
+	tests := []struct {
+		name          string
+		resources     *SpecPricingResources
+		spec          *buildspec.VirtualMachineSpec
+		wantResources map[string]int
+		wantPerMonth  float64
+		wantUnpriced  []string
+		errStr        string
+		errIs         error
+	}{
+		{
+			name: "package",
+			spec: &buildspec.VirtualMachineSpec{
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{Permalink: "rock-3"},
+				},
+				SystemDisks: []*buildspec.SystemDisk{{Size: 20}},
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						IPAddressAllocations: []*buildspec.IPAddressAllocation{
+							{
+								Type:    buildspec.NewIPAddressAllocation,
+								Version: buildspec.IPv4,
+							},
+						},
+					},
+				},
+			},
+			wantResources: map[string]int{
+				"virtual_machine_package:rock-3": 1,
+				"disk_storage":                   20,
+				"ipv4_address":                   1,
+			},
+			wantPerMonth: 25,
+		},
+		{
+			name: "flexible resources",
+			spec: &buildspec.VirtualMachineSpec{
+				Resources: &buildspec.Resources{Memory: 4, CPUCores: 2},
+				SystemDisks: []*buildspec.SystemDisk{
+					{Size: 10},
+					{
+						Size: 40,
+						IOProfile: &buildspec.DiskIOProfile{
+							Permalink: "nvme",
+						},
+					},
+					{
+						Size: 30,
+						IOProfile: &buildspec.DiskIOProfile{
+							Permalink: "ssd",
+						},
+					},
+				},
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						IPAddressAllocations: []*buildspec.IPAddressAllocation{
+							{Version: buildspec.IPv4},
+							{
+								Type: buildspec.ExistingIPAddressAllocation,
+								IPAddress: &buildspec.IPAddress{
+									Address: "192.0.2.1",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantResources: map[string]int{
+				"virtual_machine_cpu_core": 2,
+				"virtual_machine_memory":   4,
+				"disk_storage":             40,
+				"disk_storage:nvme":        40,
+				"ipv4_address":             1,
+			},
+			wantPerMonth: 37,
+		},
+		{
+			name: "unpriced",
+			spec: &buildspec.VirtualMachineSpec{
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{Permalink: "rock-3"},
+				},
+				SystemDisks: []*buildspec.SystemDisk{
+					{Size: 10},
+					{Name: "default size"},
+					{
+						Size: 10,
+						IOProfile: &buildspec.DiskIOProfile{
+							ID: "dio_unknown",
+						},
+					},
+				},
+			},
+			wantResources: map[string]int{
+				"virtual_machine_package:rock-3": 1,
+				"disk_storage":                   10,
+			},
+			wantPerMonth: 21,
+			wantUnpriced: []string{
+				"system_disks[1]",
+				"system_disks[2]",
+			},
+		},
+		{
+			name: "nothing priced",
+			spec: &buildspec.VirtualMachineSpec{
+				Resources: &buildspec.Resources{
+					Package: &buildspec.Package{Permalink: "rock-12"},
+				},
+				SystemDisks: []*buildspec.SystemDisk{{Name: "default size"}},
+			},
+			errStr: "katapult: spec_not_priced: " +
+				"resources.package, system_disks[0]",
+			errIs: ErrSpecNotPriced,
+		},
+		{
+			name:      "unlisted resource",
+			resources: &SpecPricingResources{Package: "package"},
+			spec:      rock3Spec(),
+			errStr: "katapult: unknown_pricing_resource: " +
+				`"package" is not listed by GetPricingPrices`,
+			errIs: ErrUnknownPricingResource,
+		},
+		{
+			name: "unset resource",
+			spec: &buildspec.VirtualMachineSpec{
+				NetworkInterfaces: []*buildspec.NetworkInterface{
+					{
+						IPAddressAllocations: []*buildspec.IPAddressAllocation{
+							{Version: buildspec.IPv6},
+						},
+					},
+				},
+			},
+			errStr: "katapult: unknown_pricing_resource: " +
+				"no pricing resource is set for " +
+				"network_interfaces[0].ip_address_allocations[0]",
+			errIs: ErrUnknownPricingResource,
+		},
+		{
+			name: "empty",
+			spec: &buildspec.VirtualMachineSpec{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newPricingServer(t)
+			c, err := NewClientWithResponses(srv.URL, "")
+			require.NoError(t, err)
+
+			resources := testPricingResources
+			if tt.resources != nil {
+				resources = *tt.resources
+			}
+			e := NewSpecEstimator(c, SpecCustomer{
+				Name:     "Acme",
+				Currency: "GBP",
+				Country:  "GB",
+			}, resources)
+
+			got, err := e.Estimate(context.Background(), tt.spec)
+
+			if tt.errIs != nil {
+				assert.Nil(t, got)
+				assert.EqualError(t, err, tt.errStr)
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Empty(t, srv.estimates)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantUnpriced, got.Unpriced)
+			assert.InDelta(t, tt.wantPerMonth, got.PerMonth, 0.001)
+
+			if tt.wantResources == nil {
+				assert.Empty(t, srv.estimates)
+				assert.Empty(t, got.Items)
+
+				return
+			}
+
+			require.Len(t, srv.estimates, 1)
+			req := srv.estimates[0]
+			assert.Equal(t, "GBP", *req.Currency.IsoCode)
+			assert.Equal(t, "GB", *req.Country.IsoCode2)
+			assert.Equal(t, "Acme", req.CustomerName)
+			assert.Equal(t, Business, req.CustomerType)
+
+			gotResources := map[string]int{}
+			for _, item := range got.Items {
+				gotResources[item.Resource] = item.Quantity
+			}
+			assert.Equal(t, tt.wantResources, gotResources)
+			assert.Equal(t, "GBP", *got.Currency.IsoCode)
+			assert.Equal(t, 0.2, got.TaxRate)
+			assert.InDelta(t, tt.wantPerMonth*1.2, got.PerMonthIncTax, 0.001)
+		})
+	}
+}
+
+func TestSpecEstimator_Estimate_items(t *testing.T) {
+	srv := newPricingServer(t)
+	c, err := NewClientWithResponses(srv.URL, "")
+	require.NoError(t, err)
+
+	e := &SpecEstimator{Client: c, Resources: testPricingResources}
+	got, err := e.Estimate(context.Background(), &buildspec.VirtualMachineSpec{
+		SystemDisks: []*buildspec.SystemDisk{{Size: 10}, {Size: 15}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*SpecEstimateItem{
+		{
+			Resource:       "disk_storage",
+			Description:    "disk_storage",
+			Quantity:       25,
+			PerMonthEach:   0.1,
+			PerMonth:       2.5,
+			PerMonthIncTax: 3,
+		},
+	}, got.Items)
+}
+
+func TestSpecEstimator_Estimate_error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"code":"invalid_api_token",` +
+				`"description":"The API token provided was not valid",` +
+				`"detail":{"details":"No token"}}}`))
+		},
+	))
+	defer srv.Close()
+
+	c, err := NewClientWithResponses(srv.URL, "")
+	require.NoError(t, err)
+
+	e := &SpecEstimator{Client: c}
+	got, err := e.Estimate(context.Background(), rock3Spec())
+
+	assert.Nil(t, got)
+	assert.ErrorIs(t, err, katapult.ErrForbidden)
+	assert.ErrorIs(t, err, ErrRequestFailed)
+}
+
+func rock3Spec() *buildspec.VirtualMachineSpec {
+	return &buildspec.VirtualMachineSpec{
+		Resources: &buildspec.Resources{
+			Package: &buildspec.Package{Permalink: "rock-3"},
+		},
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -26,6 +26,16 @@ type Request struct {
 	// authentication.
 	NoAuth bool
 
+	// APIKey overrides the Client's APIKey for this request only. When empty,
+	// an API key set on the request context with ContextWithAPIKey is used,
+	// falling back to the Client's APIKey.
+	APIKey string
+
+	// BaseURL overrides the Client's BaseURL for this request only. When nil,
+	// a base URL set on the request context with ContextWithBaseURL is used,
+	// falling back to the Client's BaseURL.
+	BaseURL *url.URL
+
 	// Header holds request-specific HTTP headers. Client.Do() will set a number
 	// of essential headers itself which cannot be customized through
 	// Request.Headers.
@@ -56,6 +66,28 @@ func RequestSetHeader(key, value string) RequestOption {
 	}
 }
 
+// RequestAPIKey sets the API key used to authenticate the outgoing request,
+// overriding the Client's APIKey.
+func RequestAPIKey(key string) RequestOption {
+	return func(r *Request) {
+		r.APIKey = key
+	}
+}
+
+// RequestBaseURL sets the base URL the outgoing request is resolved against,
+// overriding the Client's BaseURL.
+func RequestBaseURL(u *url.URL) RequestOption {
+	return func(r *Request) {
+		r.BaseURL = u
+	}
+}
+
+// RequestNoCache bypasses any response cache for the outgoing request, always
+// fetching a fresh response, which is then cached as usual.
+func RequestNoCache() RequestOption {
+	return RequestSetHeader("Cache-Control", "no-cache")
+}
+
 func NewRequest(
 	method string,
 	u *url.URL,
@@ -73,6 +73,26 @@ func TestNewRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with API key and base URL overrides",
+			args: args{
+				method: "GET",
+				u:      &url.URL{Path: "/foo/bar"},
+				opts: []RequestOption{
+					RequestAPIKey("tenant-key"),
+					RequestBaseURL(
+						&url.URL{Scheme: "https", Host: "api.example.com"},
+					),
+				},
+			},
+			want: &Request{
+				Method:  "GET",
+				URL:     &url.URL{Path: "/foo/bar"},
+				APIKey:  "tenant-key",
+				BaseURL: &url.URL{Scheme: "https", Host: "api.example.com"},
+				Header:  map[string][]string{},
+			},
+		},
 		{
 			name: "with struct body",
 			args: args{
@@ -2,6 +2,19 @@ package katapult
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Response headers from which response metadata is read.
+const (
+	HeaderRequestID          = "X-Request-ID"
+	HeaderRateLimitPermitted = "X-RateLimit-Permitted"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderServerTiming       = "Server-Timing"
+	HeaderRuntime            = "X-Runtime"
 )
 
 type Response struct {
@@ -9,6 +22,31 @@ type Response struct {
 
 	Pagination *Pagination
 	Error      *ResponseError
+
+	// RequestID is the ID Katapult assigned to the request, useful to
+	// correlate logs and support tickets with Katapult's side. It is empty
+	// if the response did not include one.
+	RequestID string
+
+	// RateLimit holds the rate limit state reported by the response, or nil
+	// if it was not reported.
+	RateLimit *RateLimit
+
+	// ServerTiming holds the server-side timing metrics reported by the
+	// response.
+	ServerTiming []*ServerTiming
+
+	// Attempts is the number of HTTP requests made to obtain the response,
+	// including any retries. It is zero for responses served from a Cache.
+	Attempts int
+
+	// CacheStatus is CacheHit or CacheRevalidated when the response was
+	// served from a Cache, and empty otherwise.
+	CacheStatus string
+
+	// Latency is the total time taken to obtain the response, including any
+	// retries and decoding of the response body.
+	Latency time.Duration
 }
 
 func NewResponse(r *http.Response) *Response {
@@ -16,7 +54,29 @@ func NewResponse(r *http.Response) *Response {
 		r = &http.Response{}
 	}
 
-	return &Response{Response: r}
+	resp := &Response{Response: r}
+	if r.Header != nil {
+		resp.RequestID = r.Header.Get(HeaderRequestID)
+		resp.RateLimit = parseRateLimit(r.Header)
+		resp.ServerTiming = parseServerTiming(r.Header)
+		resp.CacheStatus = r.Header.Get(HeaderCache)
+	}
+
+	return resp
+}
+
+// ServerDuration returns the total time the server reported spending on the
+// request, or zero if it was not reported.
+func (r *Response) ServerDuration() time.Duration {
+	var total time.Duration
+	for _, st := range r.ServerTiming {
+		if st.Name == "total" {
+			return st.Duration
+		}
+		total += st.Duration
+	}
+
+	return total
 }
 
 type Pagination struct {
@@ -26,3 +86,89 @@ type Pagination struct {
 	PerPage     int  `json:"per_page,omitempty"`
 	LargeSet    bool `json:"large_set,omitempty"`
 }
+
+// RateLimit describes the rate limit state reported by a response.
+type RateLimit struct {
+	// Permitted is the total number of requests permitted per period.
+	Permitted int
+
+	// Remaining is the number of requests remaining in the current period.
+	Remaining int
+
+	// Reset is when the current period ends, or zero if it was not reported.
+	Reset time.Time
+}
+
+func parseRateLimit(h http.Header) *RateLimit {
+	permitted, permittedErr := strconv.Atoi(h.Get(HeaderRateLimitPermitted))
+	remaining, remainingErr := strconv.Atoi(h.Get(HeaderRateLimitRemaining))
+	if permittedErr != nil && remainingErr != nil {
+		return nil
+	}
+
+	rl := &RateLimit{Permitted: permitted, Remaining: remaining}
+
+	// The reset header may either be a Unix timestamp, or a number of seconds
+	// until the reset.
+	reset, err := strconv.ParseInt(h.Get(HeaderRateLimitReset), 10, 64)
+	if err == nil && reset > 1e9 {
+		rl.Reset = time.Unix(reset, 0)
+	} else if err == nil && reset >= 0 {
+		rl.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+	}
+
+	return rl
+}
+
+// ServerTiming is a single server-side timing metric reported by a response.
+type ServerTiming struct {
+	Name        string
+	Description string
+	Duration    time.Duration
+}
+
+// parseServerTiming parses the Server-Timing headers of a response, falling
+// back to the X-Runtime header, a number of seconds, when none are present.
+func parseServerTiming(h http.Header) []*ServerTiming {
+	var timings []*ServerTiming
+
+	for _, value := range h.Values(HeaderServerTiming) {
+		for _, metric := range strings.Split(value, ",") {
+			params := strings.Split(metric, ";")
+			name := strings.TrimSpace(params[0])
+			if name == "" {
+				continue
+			}
+
+			st := &ServerTiming{Name: name}
+			for _, param := range params[1:] {
+				k, v, _ := strings.Cut(strings.TrimSpace(param), "=")
+				v = strings.Trim(v, `"`)
+				switch strings.ToLower(k) {
+				case "dur":
+					ms, err := strconv.ParseFloat(v, 64)
+					if err == nil {
+						st.Duration = time.Duration(
+							ms * float64(time.Millisecond),
+						)
+					}
+				case "desc":
+					st.Description = v
+				}
+			}
+			timings = append(timings, st)
+		}
+	}
+
+	if len(timings) == 0 {
+		secs, err := strconv.ParseFloat(h.Get(HeaderRuntime), 64)
+		if err == nil {
+			timings = append(timings, &ServerTiming{
+				Name:     "total",
+				Duration: time.Duration(secs * float64(time.Second)),
+			})
+		}
+	}
+
+	return timings
+}
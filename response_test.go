@@ -3,9 +3,11 @@ package katapult
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/krystal/go-katapult/internal/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPagination_JSONMarshaling(t *testing.T) {
@@ -53,6 +55,66 @@ func TestNewResponse(t *testing.T) {
 				Response: &http.Response{StatusCode: http.StatusEarlyHints},
 			},
 		},
+		{
+			name: "given http.Response with metadata headers",
+			r: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"X-Request-Id":          {"req_abc123"},
+					"X-Ratelimit-Permitted": {"100"},
+					"X-Ratelimit-Remaining": {"99"},
+					"X-Ratelimit-Reset":     {"1700000000"},
+					"Server-Timing": {
+						`db;dur=12.5;desc="Database", app;dur=40`,
+						"cache;desc=hit",
+					},
+				},
+			},
+			want: &Response{
+				Response: &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"X-Request-Id":          {"req_abc123"},
+						"X-Ratelimit-Permitted": {"100"},
+						"X-Ratelimit-Remaining": {"99"},
+						"X-Ratelimit-Reset":     {"1700000000"},
+						"Server-Timing": {
+							`db;dur=12.5;desc="Database", app;dur=40`,
+							"cache;desc=hit",
+						},
+					},
+				},
+				RequestID: "req_abc123",
+				RateLimit: &RateLimit{
+					Permitted: 100,
+					Remaining: 99,
+					Reset:     time.Unix(1700000000, 0),
+				},
+				ServerTiming: []*ServerTiming{
+					{
+						Name:        "db",
+						Description: "Database",
+						Duration:    12500 * time.Microsecond,
+					},
+					{Name: "app", Duration: 40 * time.Millisecond},
+					{Name: "cache", Description: "hit"},
+				},
+			},
+		},
+		{
+			name: "given http.Response with X-Runtime header",
+			r: &http.Response{
+				Header: http.Header{"X-Runtime": {"0.25"}},
+			},
+			want: &Response{
+				Response: &http.Response{
+					Header: http.Header{"X-Runtime": {"0.25"}},
+				},
+				ServerTiming: []*ServerTiming{
+					{Name: "total", Duration: 250 * time.Millisecond},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,3 +125,93 @@ func TestNewResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestResponse_ServerDuration(t *testing.T) {
+	tests := []struct {
+		name   string
+		timing []*ServerTiming
+		want   time.Duration
+	}{
+		{
+			name: "no timings",
+			want: 0,
+		},
+		{
+			name: "sum of timings",
+			timing: []*ServerTiming{
+				{Name: "db", Duration: 10 * time.Millisecond},
+				{Name: "app", Duration: 30 * time.Millisecond},
+			},
+			want: 40 * time.Millisecond,
+		},
+		{
+			name: "total timing",
+			timing: []*ServerTiming{
+				{Name: "db", Duration: 10 * time.Millisecond},
+				{Name: "total", Duration: 55 * time.Millisecond},
+			},
+			want: 55 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Response{ServerTiming: tt.timing}
+
+			assert.Equal(t, tt.want, r.ServerDuration())
+		})
+	}
+}
+
+func Test_parseRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    http.Header
+		want      *RateLimit
+		wantReset time.Duration
+	}{
+		{
+			name:   "no headers",
+			header: http.Header{},
+			want:   nil,
+		},
+		{
+			name: "remaining only",
+			header: http.Header{
+				"X-Ratelimit-Remaining": {"5"},
+			},
+			want: &RateLimit{Remaining: 5},
+		},
+		{
+			name: "reset as seconds",
+			header: http.Header{
+				"X-Ratelimit-Permitted": {"100"},
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {"30"},
+			},
+			want:      &RateLimit{Permitted: 100},
+			wantReset: 30 * time.Second,
+		},
+		{
+			name: "invalid reset",
+			header: http.Header{
+				"X-Ratelimit-Permitted": {"100"},
+				"X-Ratelimit-Reset":     {"soon"},
+			},
+			want: &RateLimit{Permitted: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRateLimit(tt.header)
+
+			if tt.wantReset != 0 {
+				require.NotNil(t, got)
+				assert.WithinDuration(t,
+					time.Now().Add(tt.wantReset), got.Reset, time.Second,
+				)
+				got.Reset = time.Time{}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
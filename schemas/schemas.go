@@ -1,3 +1,4 @@
 package schemas
 
 //go:generate go run github.com/krystal/go-katapult/tools/schemafetcher -n core -v v1
+//go:generate go run github.com/krystal/go-katapult/tools/buildspecschema -o buildspec/virtual_machine_spec.json
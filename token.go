@@ -0,0 +1,323 @@
+package katapult
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a token is considered
+// invalid, avoiding it expiring while a request is in flight.
+const tokenExpiryDelta = 10 * time.Second
+
+// Token is an OAuth2 access token used to authenticate requests.
+type Token struct {
+	// AccessToken is sent as a bearer token in the Authorization header.
+	AccessToken string `json:"access_token"`
+
+	// TokenType is the type of token, typically "Bearer".
+	TokenType string `json:"token_type,omitempty"`
+
+	// Expiry is when the token expires. A zero value means the token does not
+	// expire.
+	Expiry time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether t is non-nil, has an access token, and is not about
+// to expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	if t.Expiry.IsZero() {
+		return true
+	}
+
+	return time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource provides tokens used to authenticate requests. It mirrors the
+// interface of golang.org/x/oauth2's TokenSource, and one can be adapted with
+// TokenSourceFunc.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// TokenSourceFunc is an adapter allowing a function to be used as a
+// TokenSource.
+type TokenSourceFunc func() (*Token, error)
+
+// Token calls f().
+func (f TokenSourceFunc) Token() (*Token, error) {
+	return f()
+}
+
+// StaticTokenSource returns a TokenSource which always returns t.
+func StaticTokenSource(t *Token) TokenSource {
+	return TokenSourceFunc(func() (*Token, error) {
+		return t, nil
+	})
+}
+
+// ReuseTokenSource returns a TokenSource which returns t for as long as it is
+// valid, and otherwise fetches a new token from src. Tokens rejected by the API
+// are discarded by clients, causing a new token to be fetched from src.
+//
+// It is safe for concurrent use, as long as src is.
+func ReuseTokenSource(t *Token, src TokenSource) TokenSource {
+	return newReuseTokenSource(t, src)
+}
+
+func newReuseTokenSource(t *Token, src TokenSource) *reuseTokenSource {
+	if rts, ok := src.(*reuseTokenSource); ok {
+		if t == nil {
+			return rts
+		}
+		src = rts.src
+	}
+
+	return &reuseTokenSource{token: t, src: src}
+}
+
+type reuseTokenSource struct {
+	mu    sync.Mutex
+	token *Token
+	src   TokenSource
+}
+
+func (s *reuseTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	t, err := s.src.Token()
+	if err != nil {
+		if errors.Is(err, ErrTokenSource) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrTokenSource, err)
+	}
+	if t == nil || t.AccessToken == "" {
+		return nil, fmt.Errorf("%w: empty access token", ErrTokenSource)
+	}
+	s.token = t
+
+	return t, nil
+}
+
+// invalidate discards t if it is the current token, so the next call to Token
+// fetches a new one.
+func (s *reuseTokenSource) invalidate(t *Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == t {
+		s.token = nil
+	}
+}
+
+// NewTokenSourceHTTPClient returns a HTTPClient which authenticates requests
+// with a bearer token from ts, before sending them with hc. When a request is
+// rejected with a 401 Unauthorized status or an invalid_api_token error, the
+// token is discarded and the request is retried once with a fresh token, as
+// long as its body can be replayed. If a fresh token cannot be obtained, an
+// error wrapping ErrTokenSource is returned instead of the rejected response.
+//
+// Tokens are reused for as long as they are valid, so ts is only called when
+// a new token is needed.
+func NewTokenSourceHTTPClient(ts TokenSource, hc HTTPClient) HTTPClient {
+	if hc == nil {
+		hc = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	return &tokenSourceHTTPClient{
+		source: newReuseTokenSource(nil, ts),
+		client: hc,
+	}
+}
+
+type tokenSourceHTTPClient struct {
+	source *reuseTokenSource
+	client HTTPClient
+}
+
+func (c *tokenSourceHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	token, err := c.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(authorize(req, token))
+	if err != nil || !tokenRejected(resp) {
+		return resp, err
+	}
+
+	c.source.invalidate(token)
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: failed to replay request body: %w", ErrRequest, err,
+			)
+		}
+	}
+
+	// Token wraps all errors with ErrTokenSource.
+	token, err = c.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Do(authorize(retry, token))
+}
+
+// tokenRejected reports whether resp indicates the API rejected the token it
+// was authenticated with, either through a 401 Unauthorized status, or an
+// invalid_api_token error. The response body is left intact.
+func tokenRejected(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return false
+	}
+
+	respErr, err := decodeResponseErrorBody(bytes.NewReader(b))
+
+	return err == nil && respErr.Code == "invalid_api_token"
+}
+
+// authorize returns a copy of req with its Authorization header set to token.
+func authorize(req *http.Request, token *Token) *http.Request {
+	r := req.Clone(req.Context())
+	r.Body = req.Body
+
+	tokenType := token.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	r.Header.Set("Authorization", tokenType+" "+token.AccessToken)
+
+	return r
+}
+
+// ClientCredentialsConfig describes an OAuth2 client credentials flow, used
+// to obtain tokens from a token endpoint on behalf of a client application.
+type ClientCredentialsConfig struct {
+	// ClientID is the application's ID.
+	ClientID string
+
+	// ClientSecret is the application's secret.
+	ClientSecret string
+
+	// TokenURL is the token endpoint URL.
+	TokenURL string
+
+	// Scopes optionally specifies a list of requested permission scopes.
+	Scopes []string
+
+	// HTTPClient is used to make requests to TokenURL. Defaults to a
+	// *http.Client with DefaultTimeout when nil.
+	HTTPClient HTTPClient
+}
+
+// TokenSource returns a TokenSource which fetches tokens from the token
+// endpoint using ctx, reusing each one until it expires.
+func (c *ClientCredentialsConfig) TokenSource(
+	ctx context.Context,
+) TokenSource {
+	return ReuseTokenSource(nil, TokenSourceFunc(func() (*Token, error) {
+		return c.Token(ctx)
+	}))
+}
+
+// Token fetches a new token from the token endpoint.
+func (c *ClientCredentialsConfig) Token(ctx context.Context) (*Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(
+		url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret),
+	)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body := &struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(body)
+	if err != nil && resp.StatusCode/100 == 2 {
+		return nil, fmt.Errorf("%w: invalid token response: %w",
+			ErrTokenSource, err,
+		)
+	}
+
+	if resp.StatusCode/100 != 2 || body.Error != "" {
+		msg := body.Error
+		if msg == "" {
+			msg = resp.Status
+		}
+		if body.ErrorDescription != "" {
+			msg += ": " + body.ErrorDescription
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrTokenSource, msg)
+	}
+
+	t := &Token{AccessToken: body.AccessToken, TokenType: body.TokenType}
+	if body.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+
+	return t, nil
+}
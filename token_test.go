@@ -0,0 +1,492 @@
+package katapult
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTokenSource returns a new token, "token-N", each time it is called.
+type countingTokenSource struct {
+	mu     sync.Mutex
+	calls  int
+	expiry time.Duration
+	err    error
+}
+
+func (s *countingTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	s.calls++
+	t := &Token{AccessToken: fmt.Sprintf("token-%d", s.calls)}
+	if s.expiry != 0 {
+		t.Expiry = time.Now().Add(s.expiry)
+	}
+
+	return t, nil
+}
+
+func TestToken_Valid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *Token
+		want  bool
+	}{
+		{name: "nil", token: nil, want: false},
+		{name: "empty access token", token: &Token{}, want: false},
+		{
+			name:  "without expiry",
+			token: &Token{AccessToken: "abc"},
+			want:  true,
+		},
+		{
+			name: "expires in the future",
+			token: &Token{
+				AccessToken: "abc",
+				Expiry:      time.Now().Add(time.Hour),
+			},
+			want: true,
+		},
+		{
+			name: "expires within expiry delta",
+			token: &Token{
+				AccessToken: "abc",
+				Expiry:      time.Now().Add(tokenExpiryDelta / 2),
+			},
+			want: false,
+		},
+		{
+			name: "expired",
+			token: &Token{
+				AccessToken: "abc",
+				Expiry:      time.Now().Add(-time.Minute),
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.token.Valid())
+		})
+	}
+}
+
+func TestReuseTokenSource(t *testing.T) {
+	src := &countingTokenSource{expiry: time.Hour}
+	ts := ReuseTokenSource(nil, src)
+
+	for i := 0; i < 3; i++ {
+		tok, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", tok.AccessToken)
+	}
+	assert.Equal(t, 1, src.calls)
+
+	rts := ts.(*reuseTokenSource)
+	rts.invalidate(&Token{AccessToken: "token-1"})
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", tok.AccessToken, "other tokens are ignored")
+
+	rts.invalidate(tok)
+	tok, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", tok.AccessToken)
+
+	assert.Same(t, ts, ReuseTokenSource(nil, ts))
+}
+
+func TestReuseTokenSource_initialToken(t *testing.T) {
+	src := &countingTokenSource{}
+
+	ts := ReuseTokenSource(&Token{AccessToken: "initial"}, src)
+	tok, err := ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "initial", tok.AccessToken)
+
+	ts = ReuseTokenSource(
+		&Token{AccessToken: "expired", Expiry: time.Now().Add(-time.Hour)},
+		src,
+	)
+	tok, err = ts.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", tok.AccessToken)
+}
+
+func TestReuseTokenSource_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     TokenSource
+		wantErr string
+	}{
+		{
+			name: "source error",
+			src: &countingTokenSource{
+				err: errors.New("token endpoint unavailable"),
+			},
+			wantErr: "katapult: token_source: token endpoint unavailable",
+		},
+		{
+			name: "source error wrapping ErrTokenSource",
+			src: &countingTokenSource{
+				err: fmt.Errorf("%w: invalid_client", ErrTokenSource),
+			},
+			wantErr: "katapult: token_source: invalid_client",
+		},
+		{
+			name:    "nil token",
+			src:     StaticTokenSource(nil),
+			wantErr: "katapult: token_source: empty access token",
+		},
+		{
+			name:    "empty access token",
+			src:     StaticTokenSource(&Token{}),
+			wantErr: "katapult: token_source: empty access token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReuseTokenSource(nil, tt.src).Token()
+
+			assert.EqualError(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, ErrTokenSource)
+		})
+	}
+}
+
+func TestNewTokenSourceHTTPClient(t *testing.T) {
+	invalidTokenBody := `{"error":{"code":"invalid_api_token",` +
+		`"description":"The API token provided was not valid"}}`
+	forbiddenBody := `{"error":{"code":"permission_denied",` +
+		`"description":"Permission denied"}}`
+
+	tests := []struct {
+		name       string
+		reject     func(w http.ResponseWriter)
+		body       func() io.Reader
+		wantStatus int
+		wantAuths  []string
+		wantBody   string
+	}{
+		{
+			name:       "accepted token",
+			wantStatus: http.StatusOK,
+			wantAuths:  []string{"Bearer token-1"},
+			wantBody:   "ok",
+		},
+		{
+			name: "401 is retried with a new token",
+			reject: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+			wantStatus: http.StatusOK,
+			wantAuths:  []string{"Bearer token-1", "Bearer token-2"},
+			wantBody:   "ok",
+		},
+		{
+			name: "invalid_api_token is retried with a new token",
+			reject: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(invalidTokenBody))
+			},
+			body: func() io.Reader {
+				return strings.NewReader(`{"hello":"world"}`)
+			},
+			wantStatus: http.StatusOK,
+			wantAuths:  []string{"Bearer token-1", "Bearer token-2"},
+			wantBody:   `ok {"hello":"world"}`,
+		},
+		{
+			name: "other 403 errors are not retried",
+			reject: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(forbiddenBody))
+			},
+			wantStatus: http.StatusForbidden,
+			wantAuths:  []string{"Bearer token-1"},
+			wantBody:   forbiddenBody,
+		},
+		{
+			name: "requests with bodies which cannot be replayed",
+			reject: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte("unauthorized"))
+			},
+			body: func() io.Reader {
+				return io.MultiReader(strings.NewReader("hello"))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantAuths:  []string{"Bearer token-1"},
+			wantBody:   "unauthorized",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var auths []string
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					auths = append(auths, r.Header.Get("Authorization"))
+					if len(auths) == 1 && tt.reject != nil {
+						tt.reject(w)
+
+						return
+					}
+
+					b, _ := io.ReadAll(r.Body)
+					out := "ok"
+					if len(b) > 0 {
+						out += " " + string(b)
+					}
+					_, _ = w.Write([]byte(out))
+				},
+			))
+			defer server.Close()
+
+			var body io.Reader
+			if tt.body != nil {
+				body = tt.body()
+			}
+			req, err := http.NewRequest("POST", server.URL, body)
+			require.NoError(t, err)
+
+			hc := NewTokenSourceHTTPClient(
+				&countingTokenSource{}, server.Client(),
+			)
+			resp, err := hc.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantAuths, auths)
+			assert.Equal(t, tt.wantBody, string(b))
+			assert.Empty(t, req.Header.Get("Authorization"))
+		})
+	}
+}
+
+// failingRefreshTokenSource returns a token on its first call, and err on all
+// later calls.
+type failingRefreshTokenSource struct {
+	calls int
+	err   error
+}
+
+func (s *failingRefreshTokenSource) Token() (*Token, error) {
+	s.calls++
+	if s.calls > 1 {
+		return nil, s.err
+	}
+
+	return &Token{AccessToken: "token-1"}, nil
+}
+
+func TestNewTokenSourceHTTPClient_retryErrors(t *testing.T) {
+	errGetBody := errors.New("body gone")
+	errRefresh := errors.New("refresh failed")
+
+	tests := []struct {
+		name    string
+		source  TokenSource
+		getBody func() (io.ReadCloser, error)
+		errStr  string
+		errIs   []error
+	}{
+		{
+			name:   "token refresh fails",
+			source: &failingRefreshTokenSource{err: errRefresh},
+			errStr: "katapult: token_source: refresh failed",
+			errIs:  []error{ErrTokenSource, errRefresh},
+		},
+		{
+			name:   "request body cannot be replayed",
+			source: &countingTokenSource{},
+			getBody: func() (io.ReadCloser, error) {
+				return nil, errGetBody
+			},
+			errStr: "katapult: request: failed to replay request body: " +
+				"body gone",
+			errIs: []error{ErrRequest, errGetBody},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				},
+			))
+			defer server.Close()
+
+			req, err := http.NewRequest("GET", server.URL, nil)
+			require.NoError(t, err)
+			req.GetBody = tt.getBody
+
+			hc := NewTokenSourceHTTPClient(tt.source, server.Client())
+			resp, err := hc.Do(req) //nolint:bodyclose
+
+			assert.Nil(t, resp)
+			assert.EqualError(t, err, tt.errStr)
+			for _, target := range tt.errIs {
+				assert.ErrorIs(t, err, target)
+			}
+		})
+	}
+}
+
+func TestNewTokenSourceHTTPClient_tokenSourceError(t *testing.T) {
+	hc := NewTokenSourceHTTPClient(
+		&countingTokenSource{err: errors.New("nope")}, http.DefaultClient,
+	)
+	req, err := http.NewRequest("GET", "http://localhost/", nil)
+	require.NoError(t, err)
+
+	resp, err := hc.Do(req) //nolint:bodyclose
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, ErrTokenSource)
+}
+
+func TestClientCredentialsConfig_Token(t *testing.T) {
+	tests := []struct {
+		name       string
+		scopes     []string
+		status     int
+		body       string
+		want       *Token
+		wantExpiry bool
+		wantScope  string
+		wantErr    string
+	}{
+		{
+			name:   "success",
+			scopes: []string{"vm.read", "vm.write"},
+			status: http.StatusOK,
+			body: `{"access_token":"abc","token_type":"Bearer",` +
+				`"expires_in":3600}`,
+			want:       &Token{AccessToken: "abc", TokenType: "Bearer"},
+			wantExpiry: true,
+			wantScope:  "vm.read vm.write",
+		},
+		{
+			name:   "without expiry",
+			status: http.StatusOK,
+			body:   `{"access_token":"abc","token_type":"Bearer"}`,
+			want:   &Token{AccessToken: "abc", TokenType: "Bearer"},
+		},
+		{
+			name:   "error response",
+			status: http.StatusUnauthorized,
+			body: `{"error":"invalid_client",` +
+				`"error_description":"client authentication failed"}`,
+			wantErr: "katapult: token_source: invalid_client: " +
+				"client authentication failed",
+		},
+		{
+			name:    "error response without body",
+			status:  http.StatusInternalServerError,
+			wantErr: "katapult: token_source: 500 Internal Server Error",
+		},
+		{
+			name:   "invalid JSON",
+			status: http.StatusOK,
+			body:   `{`,
+			wantErr: "katapult: token_source: invalid token response: " +
+				"unexpected EOF",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "POST", r.Method)
+					assert.Equal(t,
+						"application/x-www-form-urlencoded",
+						r.Header.Get("Content-Type"),
+					)
+
+					id, secret, ok := r.BasicAuth()
+					assert.True(t, ok)
+					assert.Equal(t, "client%2F1", id)
+					assert.Equal(t, "s3cr3t", secret)
+
+					require.NoError(t, r.ParseForm())
+					wantForm := url.Values{"grant_type": {"client_credentials"}}
+					if tt.wantScope != "" {
+						wantForm.Set("scope", tt.wantScope)
+					}
+					assert.Equal(t, wantForm, r.PostForm)
+
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+				},
+			))
+			defer server.Close()
+
+			c := &ClientCredentialsConfig{
+				ClientID:     "client/1",
+				ClientSecret: "s3cr3t",
+				TokenURL:     server.URL,
+				Scopes:       tt.scopes,
+				HTTPClient:   server.Client(),
+			}
+			got, err := c.Token(context.Background())
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrTokenSource)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExpiry, !got.Expiry.IsZero())
+			got.Expiry = time.Time{}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClientCredentialsConfig_TokenSource(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			fmt.Fprintf(w,
+				`{"access_token":"token-%d","expires_in":3600}`, requests,
+			)
+		},
+	))
+	defer server.Close()
+
+	c := &ClientCredentialsConfig{
+		TokenURL:   server.URL,
+		HTTPClient: server.Client(),
+	}
+	ts := c.TokenSource(context.Background())
+
+	for i := 0; i < 3; i++ {
+		tok, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", tok.AccessToken)
+	}
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,43 @@
+// Command buildspecschema writes the JSON Schema for build spec documents,
+// as returned by buildspec.JSONSchema, to a file.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/krystal/go-katapult/buildspec"
+)
+
+func main() {
+	fs := flag.NewFlagSet("buildspecschema", flag.ExitOnError)
+	output := fs.String("o", "", "file to write schema to (required)")
+	_ = fs.Parse(os.Args[1:])
+
+	if *output == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(*output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(127)
+	}
+}
+
+func run(output string) error {
+	b, err := buildspec.JSONSchema()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(output), 0o755)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec
+	return os.WriteFile(output, b, 0o644)
+}
@@ -274,15 +274,16 @@ func (g *Generator) errNewStructFunc(f *jen.File, e *apischema.Error) error {
 	name := g.errStructName(e)
 	detailName := g.errStructDetailName(e)
 
+	newCommonErr := g.katapult("NewCommonErrorFromResponse").Call(
+		jen.Line().Id(g.errVarName(e)),
+		jen.Line().Lit(e.Code),
+		jen.Line().Id("theError").Id(",").Line(),
+	)
+
 	funcBody := []jen.Code{
 		jen.Return(
 			jen.Id("&" + name).Values(jen.Dict{
-				jen.Line().Id("CommonError"): g.katapult("NewCommonError").
-					Call(
-						jen.Line().Id(g.errVarName(e)),
-						jen.Line().Lit(e.Code),
-						jen.Line().Id("theError.Description").Id(",").Line(),
-					),
+				jen.Line().Id("CommonError"): newCommonErr,
 			}),
 		),
 	}
@@ -299,12 +300,8 @@ func (g *Generator) errNewStructFunc(f *jen.File, e *apischema.Error) error {
 			).Line(),
 			jen.Return(
 				jen.Id("&" + name).Values(jen.Dict{
-					jen.Id("CommonError"): g.katapult("NewCommonError").Call(
-						jen.Line().Id(g.errVarName(e)),
-						jen.Line().Lit(e.Code),
-						jen.Line().Id("theError.Description").Id(",").Line(),
-					),
-					jen.Id("Detail"): jen.Id("detail"),
+					jen.Id("CommonError"): newCommonErr,
+					jen.Id("Detail"):      jen.Id("detail"),
 				}),
 			),
 		}
@@ -0,0 +1,554 @@
+// Command fakegen generates per-resource interfaces for the API clients in a
+// package, along with in-memory fakes which satisfy them.
+//
+// Each exported *XxxClient struct with exported pointer-receiver methods
+// produces an XxxAPI interface, and an aggregate API struct is generated from
+// the fields of the package's Client struct. Fakes are written to a separate
+// package, one struct per resource, which record every call and delegate to
+// optional <Method>Func fields.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mvdan.cc/gofumpt/format"
+)
+
+const header = "// Code generated by " +
+	"github.com/krystal/go-katapult/tools/fakegen. DO NOT EDIT.\n\n"
+
+type param struct {
+	Name     string
+	Type     ast.Expr
+	Variadic bool
+}
+
+type method struct {
+	Name    string
+	Params  []*param
+	Results []ast.Expr
+}
+
+type resource struct {
+	Field   string
+	Client  string
+	Methods []*method
+}
+
+func (r *resource) Interface() string {
+	return r.Field + "API"
+}
+
+type configuration struct {
+	SrcDir     string
+	PkgName    string
+	PkgPath    string
+	FakeDir    string
+	FakePkg    string
+	ClientType string
+}
+
+func main() {
+	config := &configuration{}
+	fs := flag.NewFlagSet("fakegen", flag.ExitOnError)
+	fs.StringVar(&config.SrcDir, "s", ".", "source package directory")
+	fs.StringVar(&config.PkgPath, "i", "", "source package import path")
+	fs.StringVar(&config.FakeDir, "o", "", "output directory for fakes")
+	fs.StringVar(&config.FakePkg, "p", "", "package name for fakes")
+	fs.StringVar(
+		&config.ClientType, "c", "Client", "aggregate client struct name",
+	)
+	_ = fs.Parse(os.Args[1:])
+
+	if config.PkgPath == "" || config.FakeDir == "" || config.FakePkg == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(config); err != nil {
+		fmt.Fprintf(os.Stderr, "fakegen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(config *configuration) error {
+	fset := token.NewFileSet()
+	filter := func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") &&
+			!strings.HasSuffix(fi.Name(), "_generated.go")
+	}
+	pkgs, err := parser.ParseDir(fset, config.SrcDir, filter, 0)
+	if err != nil {
+		return err
+	}
+
+	var pkg *ast.Package
+	for name, p := range pkgs {
+		if !strings.HasSuffix(name, "_test") {
+			pkg = p
+			config.PkgName = name
+		}
+	}
+	if pkg == nil {
+		return fmt.Errorf("no package found in %s", config.SrcDir)
+	}
+
+	resources, imports, err := collect(pkg, config.ClientType)
+	if err != nil {
+		return err
+	}
+
+	g := &generator{fset: fset, config: config, imports: imports}
+
+	b, err := g.interfaces(resources)
+	if err != nil {
+		return err
+	}
+	err = writeFile(filepath.Join(config.SrcDir, "interfaces_generated.go"), b)
+	if err != nil {
+		return err
+	}
+
+	b, err = g.fakes(resources)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(config.FakeDir, "fakes_generated.go"), b)
+}
+
+func writeFile(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	//nolint:gosec
+	return os.WriteFile(path, b, 0o644)
+}
+
+// collect finds the aggregate client's fields, and the exported methods of
+// each resource client type they reference, in source order.
+//
+//nolint:gocyclo
+func collect(
+	pkg *ast.Package,
+	clientType string,
+) ([]*resource, map[string]string, error) {
+	var fields *ast.FieldList
+	methods := map[string][]*method{}
+	imports := map[string]string{}
+
+	files := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	for _, name := range files {
+		file := pkg.Files[name]
+		for _, imp := range file.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			alias := importName(path)
+			if imp.Name != nil {
+				alias = imp.Name.Name
+			}
+			imports[alias] = path
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || ts.Name.Name != clientType {
+						continue
+					}
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						fields = st.Fields
+					}
+				}
+			case *ast.FuncDecl:
+				recv := receiverType(d)
+				if recv == "" || !d.Name.IsExported() {
+					continue
+				}
+				methods[recv] = append(methods[recv], newMethod(d))
+			}
+		}
+	}
+
+	if fields == nil {
+		return nil, nil, fmt.Errorf("struct %s not found", clientType)
+	}
+
+	resources := []*resource{}
+	for _, f := range fields.List {
+		star, ok := f.Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+		ident, ok := star.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		for _, name := range f.Names {
+			resources = append(resources, &resource{
+				Field:   name.Name,
+				Client:  ident.Name,
+				Methods: methods[ident.Name],
+			})
+		}
+	}
+
+	return resources, imports, nil
+}
+
+// importName guesses the package name of an unaliased import from its path,
+// dropping any "go-" prefix (e.g. github.com/krystal/go-katapult).
+func importName(path string) string {
+	name := path[strings.LastIndex(path, "/")+1:]
+
+	return strings.TrimPrefix(name, "go-")
+}
+
+func receiverType(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) != 1 {
+		return ""
+	}
+	star, ok := d.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	ident, ok := star.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+
+	return ident.Name
+}
+
+func newMethod(d *ast.FuncDecl) *method {
+	m := &method{Name: d.Name.Name}
+	for i, f := range d.Type.Params.List {
+		typ := f.Type
+		variadic := false
+		if e, ok := typ.(*ast.Ellipsis); ok {
+			typ = e.Elt
+			variadic = true
+		}
+		if len(f.Names) == 0 {
+			m.Params = append(m.Params, &param{
+				Name: fmt.Sprintf("arg%d", i), Type: typ, Variadic: variadic,
+			})
+
+			continue
+		}
+		for _, n := range f.Names {
+			name := n.Name
+			if name == "_" {
+				name = fmt.Sprintf("arg%d", len(m.Params))
+			}
+			m.Params = append(m.Params, &param{
+				Name: name, Type: typ, Variadic: variadic,
+			})
+		}
+	}
+	if d.Type.Results != nil {
+		for _, f := range d.Type.Results.List {
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				m.Results = append(m.Results, f.Type)
+			}
+		}
+	}
+
+	return m
+}
+
+type generator struct {
+	fset    *token.FileSet
+	config  *configuration
+	imports map[string]string
+	used    map[string]bool
+}
+
+// expr renders a type expression. When qualify is set, identifiers declared
+// in the source package are prefixed with its name.
+func (g *generator) expr(e ast.Expr, qualify bool) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				g.used[x.Name] = true
+			}
+
+			return false
+		}
+
+		return true
+	})
+
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, g.fset, e)
+	if !qualify {
+		return buf.String()
+	}
+
+	return qualifyIdents(buf.String(), g.config.PkgName)
+}
+
+// qualifyIdents prefixes every unqualified exported identifier in a rendered
+// type expression with pkg.
+func qualifyIdents(s string, pkg string) string {
+	var out strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		if !isIdentByte(c) {
+			out.WriteByte(c)
+			i++
+
+			continue
+		}
+		j := i
+		for j < len(s) && isIdentByte(s[j]) {
+			j++
+		}
+		word := s[i:j]
+		qualified := i > 0 && s[i-1] == '.'
+		if !qualified && word[0] >= 'A' && word[0] <= 'Z' {
+			out.WriteString(pkg + ".")
+		}
+		out.WriteString(word)
+		i = j
+	}
+
+	return out.String()
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (g *generator) signature(m *method, qualify bool) (string, string) {
+	params := make([]string, 0, len(m.Params))
+	for _, p := range m.Params {
+		typ := g.expr(p.Type, qualify)
+		if p.Variadic {
+			typ = "..." + typ
+		}
+		params = append(params, p.Name+" "+typ)
+	}
+	results := make([]string, 0, len(m.Results))
+	for _, r := range m.Results {
+		results = append(results, g.expr(r, qualify))
+	}
+
+	return strings.Join(params, ", "), strings.Join(results, ", ")
+}
+
+func (g *generator) importBlock(extra ...string) string {
+	paths := append([]string{}, extra...)
+	for alias := range g.used {
+		if path, ok := g.imports[alias]; ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var buf strings.Builder
+	buf.WriteString("import (\n")
+	for _, p := range paths {
+		buf.WriteString(strconv.Quote(p) + "\n")
+	}
+	buf.WriteString(")\n\n")
+
+	return buf.String()
+}
+
+func (g *generator) interfaces(resources []*resource) ([]byte, error) {
+	g.used = map[string]bool{}
+	var body strings.Builder
+
+	for _, r := range resources {
+		fmt.Fprintf(&body,
+			"// %s is the interface satisfied by *%s.\n",
+			r.Interface(), r.Client,
+		)
+		fmt.Fprintf(&body, "type %s interface {\n", r.Interface())
+		for _, m := range r.Methods {
+			params, results := g.signature(m, false)
+			fmt.Fprintf(&body, "%s(%s) (%s)\n", m.Name, params, results)
+		}
+		body.WriteString("}\n\n")
+	}
+
+	body.WriteString("var (\n")
+	for _, r := range resources {
+		fmt.Fprintf(&body, "_ %s = (*%s)(nil)\n", r.Interface(), r.Client)
+	}
+	body.WriteString(")\n\n")
+
+	fmt.Fprintf(&body,
+		"// API is an interface-typed equivalent of %[1]s, allowing each "+
+			"resource\n// client to be replaced, for example with a fake "+
+			"in tests.\n", g.config.ClientType,
+	)
+	body.WriteString("type API struct {\n")
+	for _, r := range resources {
+		fmt.Fprintf(&body, "%s %s\n", r.Field, r.Interface())
+	}
+	body.WriteString("}\n\n")
+
+	body.WriteString(
+		"// NewAPI returns an API backed by the standard resource clients.\n" +
+			"func NewAPI(rm RequestMaker) *API {\n" +
+			"return New(rm).API()\n}\n\n",
+	)
+	fmt.Fprintf(&body,
+		"// API returns an API which uses the resource clients of c.\n"+
+			"func (c *%s) API() *API {\nreturn &API{\n",
+		g.config.ClientType,
+	)
+	for _, r := range resources {
+		fmt.Fprintf(&body, "%[1]s: c.%[1]s,\n", r.Field)
+	}
+	body.WriteString("}\n}\n")
+
+	src := "package " + g.config.PkgName + "\n\n" +
+		g.importBlock() + header + body.String()
+
+	return render(src)
+}
+
+func (g *generator) fakes(resources []*resource) ([]byte, error) {
+	g.used = map[string]bool{}
+	pkg := g.config.PkgName
+	var body strings.Builder
+
+	for _, r := range resources {
+		fmt.Fprintf(&body,
+			"// %[1]s is an in-memory fake of %[2]s.%[3]s.\n//\n"+
+				"// Each method records the call and then delegates to its "+
+				"<Method>Func\n// field, returning zero values when it is "+
+				"nil.\n",
+			r.Field, pkg, r.Interface(),
+		)
+		fmt.Fprintf(&body, "type %s struct {\n*Recorder\n\n", r.Field)
+		for _, m := range r.Methods {
+			params, results := g.signature(m, true)
+			fmt.Fprintf(&body, "%sFunc func(%s) (%s)\n",
+				m.Name, params, results,
+			)
+		}
+		body.WriteString("}\n\n")
+
+		for _, m := range r.Methods {
+			params, _ := g.signature(m, true)
+			named := make([]string, 0, len(m.Results))
+			for i, res := range m.Results {
+				named = append(named,
+					fmt.Sprintf("r%d %s", i, g.expr(res, true)),
+				)
+			}
+			args := make([]string, 0, len(m.Params))
+			call := make([]string, 0, len(m.Params))
+			for _, p := range m.Params {
+				args = append(args, p.Name)
+				if p.Variadic {
+					call = append(call, p.Name+"...")
+				} else {
+					call = append(call, p.Name)
+				}
+			}
+
+			fmt.Fprintf(&body,
+				"func (f *%s) %s(%s) (%s) {\n",
+				r.Field, m.Name, params, strings.Join(named, ", "),
+			)
+			fmt.Fprintf(&body,
+				"record(&f.Recorder, %q, %q, %s)\n",
+				r.Field, m.Name, strings.Join(args, ", "),
+			)
+			fmt.Fprintf(&body,
+				"if f.%[1]sFunc != nil {\nreturn f.%[1]sFunc(%[2]s)\n}\n\n"+
+					"return\n}\n\n",
+				m.Name, strings.Join(call, ", "),
+			)
+		}
+	}
+
+	fmt.Fprintf(&body,
+		"// Client holds a fake for every resource, all sharing a single "+
+			"Recorder.\ntype Client struct {\n*Recorder\n\n",
+	)
+	for _, r := range resources {
+		fmt.Fprintf(&body, "%[1]s *%[1]s\n", r.Field)
+	}
+	body.WriteString("}\n\n")
+
+	body.WriteString(
+		"// New returns a Client with a fake for every resource.\n" +
+			"func New() *Client {\nrec := &Recorder{}\n\n" +
+			"return &Client{\nRecorder: rec,\n",
+	)
+	for _, r := range resources {
+		fmt.Fprintf(&body, "%[1]s: &%[1]s{Recorder: rec},\n", r.Field)
+	}
+	body.WriteString("}\n}\n\n")
+
+	fmt.Fprintf(&body,
+		"// API returns a %[1]s.API backed by the fakes of c.\n"+
+			"func (c *Client) API() *%[1]s.API {\nreturn &%[1]s.API{\n",
+		pkg,
+	)
+	for _, r := range resources {
+		fmt.Fprintf(&body, "%[1]s: c.%[1]s,\n", r.Field)
+	}
+	body.WriteString("}\n}\n\n")
+
+	body.WriteString("var (\n")
+	for _, r := range resources {
+		fmt.Fprintf(&body,
+			"_ %s.%s = (*%s)(nil)\n", pkg, r.Interface(), r.Field,
+		)
+	}
+	body.WriteString(")\n")
+
+	src := "package " + g.config.FakePkg + "\n\n" +
+		g.importBlock(g.config.PkgPath) + header + body.String()
+
+	return render(src)
+}
+
+// render formats src with gofumpt, using the language version of the current
+// module.
+func render(src string) ([]byte, error) {
+	langVersion := ""
+	out, err := exec.Command(
+		"go", "list", "-m", "-f", "{{.GoVersion}}",
+	).Output()
+	out = bytes.TrimSpace(out)
+	if err == nil && len(out) > 0 {
+		langVersion = string(out)
+	}
+
+	return format.Source([]byte(src), format.Options{
+		LangVersion: langVersion,
+		ExtraRules:  true,
+	})
+}
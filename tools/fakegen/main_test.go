@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRun_coreUpToDate regenerates the core interfaces and fakes from a copy
+// of the core package, and checks them against the files checked in, so
+// changes to core clients without running go generate are caught.
+func TestRun_coreUpToDate(t *testing.T) {
+	srcDir := filepath.Join("..", "..", "core")
+	tmpDir := t.TempDir()
+
+	entries, err := os.ReadDir(srcDir)
+	require.NoError(t, err)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") ||
+			strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(srcDir, name))
+		require.NoError(t, err)
+		err = os.WriteFile(filepath.Join(tmpDir, name), b, 0o600)
+		require.NoError(t, err)
+	}
+
+	err = run(&configuration{
+		SrcDir:     tmpDir,
+		PkgPath:    "github.com/krystal/go-katapult/core",
+		FakeDir:    filepath.Join(tmpDir, "corefake"),
+		FakePkg:    "corefake",
+		ClientType: "Client",
+	})
+	require.NoError(t, err)
+
+	files := []string{
+		"interfaces_generated.go",
+		filepath.Join("corefake", "fakes_generated.go"),
+	}
+	for _, name := range files {
+		want, err := os.ReadFile(filepath.Join(srcDir, name))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(tmpDir, name))
+		require.NoError(t, err)
+
+		assert.Equalf(t, string(got), string(want),
+			"core/%s is out of date, run go generate ./core", name,
+		)
+	}
+}